@@ -56,32 +56,48 @@ func init() {
 }
 
 func fetch(src, dst string) error {
+	archive, err := fetchArchive(src)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive) // clean up
+
+	return archiver.Unarchive(archive, dst)
+}
+
+// fetchArchive downloads the archive at `src` to a temporary file, returning
+// its path.
+func fetchArchive(src string) (string, error) {
 	// Fetch the resource from the web:
 	resp, err := http.Get(src) //nolint:gosec,noctx
 
 	if err != nil {
-		return err
-	} else if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("could not fetch '%s' (status code '%d')", src, resp.StatusCode)
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not fetch '%s' (status code '%d')", src, resp.StatusCode)
 	}
 
 	// Create a temp file to represent the archive locally:
 	tmpfile, err := os.CreateTemp("", "temp.*.zip")
 	if err != nil {
-		return err
+		return "", err
 	}
-	defer os.Remove(tmpfile.Name()) // clean up
 
 	// Write to the  local archive:
 	_, err = io.Copy(tmpfile, resp.Body)
 	if err != nil {
-		return err
+		tmpfile.Close()
+		os.Remove(tmpfile.Name())
+		return "", err
 	} else if err = tmpfile.Close(); err != nil {
-		return err
+		os.Remove(tmpfile.Name())
+		return "", err
 	}
 
-	resp.Body.Close()
-	return archiver.Unarchive(tmpfile.Name(), dst)
+	return tmpfile.Name(), nil
 }
 
 func install(args []string, flags *core.CLIFlags) error {
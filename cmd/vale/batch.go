@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// batchResult is the response to a single document sent to `--batch`.
+type batchResult struct {
+	Path   string
+	Alerts []core.Alert
+	Error  string `json:",omitempty"`
+}
+
+// readBatchFrame reads a single document from a `--batch` stream.
+//
+// Each document is a set of headers -- `Path` and `Content-Length`, which is
+// the size of the content in bytes -- followed by a blank line and the
+// content itself:
+//
+//	Path: docs/README.md
+//	Content-Length: 12
+//
+//	Some text.
+//
+// `io.EOF` is returned once the stream has been closed between documents.
+func readBatchFrame(r *bufio.Reader) (string, string, error) {
+	headers, err := textproto.NewReader(r).ReadMIMEHeader()
+	if errors.Is(err, io.EOF) && len(headers) == 0 {
+		return "", "", io.EOF
+	} else if err != nil {
+		return "", "", fmt.Errorf("invalid headers: %w", err)
+	}
+
+	path := headers.Get("Path")
+	if path == "" {
+		return "", "", errors.New("missing 'Path' header")
+	}
+
+	size, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil || size < 0 {
+		return "", "", fmt.Errorf("invalid 'Content-Length' for '%s'", path)
+	}
+
+	content := make([]byte, size)
+	if _, err = io.ReadFull(r, content); err != nil {
+		return "", "", fmt.Errorf("truncated content for '%s': %w", path, err)
+	}
+
+	return path, string(content), nil
+}
+
+// writeBatchFrame writes `v` as JSON, preceded by its `Content-Length`.
+func writeBatchFrame(w io.Writer, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
+
+// lintBatch lints each document read from `r`, writing one framed JSON
+// result to `w` per document as soon as it's been linted.
+//
+// Errors specific to a document are reported in its result, while a
+// malformed stream stops the batch.
+func lintBatch(r io.Reader, w io.Writer, linter *lint.Linter) (bool, error) {
+	hasErrors := false
+
+	in := bufio.NewReader(r)
+	out := bufio.NewWriter(w)
+
+	for {
+		path, content, err := readBatchFrame(in)
+		if errors.Is(err, io.EOF) {
+			return hasErrors, nil
+		} else if err != nil {
+			return hasErrors, core.NewE100("--batch", err)
+		}
+
+		result := batchResult{Path: path, Alerts: []core.Alert{}}
+
+		f, err := linter.LintContent(path, content)
+		if err != nil {
+			result.Error = strings.TrimSpace(err.Error())
+		} else {
+			result.Alerts = append(result.Alerts, f.SortedAlerts()...)
+			limitAlerts(result.Alerts, linter.Manager.Config)
+		}
+
+		for _, a := range result.Alerts {
+			if a.Severity == "error" {
+				hasErrors = true
+			}
+		}
+
+		if err = writeBatchFrame(out, result); err != nil {
+			return hasErrors, core.NewE100("--batch", err)
+		} else if err = out.Flush(); err != nil {
+			return hasErrors, core.NewE100("--batch", err)
+		}
+	}
+}
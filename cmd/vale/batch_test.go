@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+func batchInput(docs ...string) string {
+	var b strings.Builder
+	for i := 0; i < len(docs); i += 2 {
+		fmt.Fprintf(&b, "Path: %s\r\nContent-Length: %d\r\n\r\n%s", docs[i], len(docs[i+1]), docs[i+1])
+	}
+	return b.String()
+}
+
+func TestLintBatch(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.GBaseStyles = []string{"Vale"}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := batchInput(
+		"docs/a.md", "# Title\n\nThis is is a test.\n",
+		"b.txt", "Ünïcode text.\n",
+		"c.md", "It is is done.\n")
+
+	var out bytes.Buffer
+	hasErrors, err := lintBatch(strings.NewReader(input), &out, linter)
+	if err != nil {
+		t.Fatal(err)
+	} else if !hasErrors {
+		t.Error("Expected the repetition to be reported as an error")
+	}
+
+	results := []batchResult{}
+
+	r := bufio.NewReader(&out)
+	for {
+		headers, headerErr := textproto.NewReader(r).ReadMIMEHeader()
+		if headerErr != nil {
+			break
+		}
+
+		size, _ := strconv.Atoi(headers.Get("Content-Length"))
+		content := make([]byte, size)
+		if _, err = io.ReadFull(r, content); err != nil {
+			t.Fatal(err)
+		}
+
+		var result batchResult
+		if err = json.Unmarshal(content, &result); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %+v", results)
+	}
+
+	for i, expected := range []struct {
+		path  string
+		line  int
+		count int
+	}{{"docs/a.md", 3, 1}, {"b.txt", 0, 0}, {"c.md", 1, 1}} {
+		result := results[i]
+		if result.Path != expected.path || len(result.Alerts) != expected.count {
+			t.Errorf("Unexpected result for '%s': %+v", expected.path, result)
+		} else if expected.count > 0 && result.Alerts[0].Line != expected.line {
+			t.Errorf("Expected line %d for '%s', got %d", expected.line, expected.path, result.Alerts[0].Line)
+		}
+	}
+}
+
+func TestLintBatchRedacted(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.GBaseStyles = []string{"Vale"}
+	cfg.RedactContext = true
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if _, err = lintBatch(strings.NewReader(batchInput("a.md", "It is is done.\n")), &out, linter); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(out.String(), "is is") {
+		t.Errorf("Expected the match to be redacted, got %s", out.String())
+	} else if !strings.Contains(out.String(), "[REDACTED]") {
+		t.Errorf("Expected a redacted match in %s", out.String())
+	}
+}
+
+func TestReadBatchFrameErrors(t *testing.T) {
+	for _, input := range []string{
+		"Content-Length: 4\r\n\r\ntext",
+		"Path: a.md\r\n\r\ntext",
+		"Path: a.md\r\nContent-Length: 10\r\n\r\ntext",
+	} {
+		if _, _, err := readBatchFrame(bufio.NewReader(strings.NewReader(input))); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+var levelToTeamCity = map[string]string{
+	"suggestion": "INFO",
+	"warning":    "WARNING",
+	"error":      "ERROR",
+}
+
+// Azure DevOps only supports two issue types.
+var levelToAzure = map[string]string{
+	"suggestion": "warning",
+	"warning":    "warning",
+	"error":      "error",
+}
+
+var levelToGitHub = map[string]string{
+	"suggestion": "notice",
+	"warning":    "warning",
+	"error":      "error",
+}
+
+var teamCityEscaper = strings.NewReplacer(
+	"|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+var azurePropertyEscaper = strings.NewReplacer(
+	"%", "%AZP25", ";", "%3B", "\r", "%0D", "\n", "%0A", "]", "%5D")
+
+var azureMessageEscaper = strings.NewReplacer(
+	"%", "%AZP25", "\r", "%0D", "\n", "%0A")
+
+var gitHubPropertyEscaper = strings.NewReplacer(
+	"%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+var gitHubMessageEscaper = strings.NewReplacer(
+	"%", "%25", "\r", "%0D", "\n", "%0A")
+
+// PrintTeamCityAlerts prints Alerts as TeamCity service messages, which are
+// shown on a build's "Inspections" tab.
+//
+// See https://www.jetbrains.com/help/teamcity/service-messages.html.
+func PrintTeamCityAlerts(linted []*core.File) bool {
+	alertCount := 0
+	defined := map[string]bool{}
+
+	for _, f := range linted {
+		for _, a := range f.SortedAlerts() {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			check := teamCityEscaper.Replace(a.Check)
+			if !defined[a.Check] {
+				// Each inspection type must be defined before it's used.
+				fmt.Printf("##teamcity[inspectionType id='%s' name='%s' description='%s' category='Vale']\n",
+					check, check, check)
+				defined[a.Check] = true
+			}
+
+			fmt.Printf("##teamcity[inspection typeId='%s' message='%s' file='%s' line='%d' SEVERITY='%s']\n",
+				check,
+				teamCityEscaper.Replace(a.Message),
+				teamCityEscaper.Replace(f.Path),
+				a.Line,
+				levelToTeamCity[a.Severity])
+		}
+	}
+
+	return alertCount != 0
+}
+
+// PrintAzureAlerts prints Alerts as Azure DevOps logging commands, which are
+// shown in a pipeline's summary and logs.
+//
+// See https://learn.microsoft.com/en-us/azure/devops/pipelines/scripts/logging-commands.
+func PrintAzureAlerts(linted []*core.File) bool {
+	alertCount := 0
+
+	for _, f := range linted {
+		for _, a := range f.SortedAlerts() {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			fmt.Printf("##vso[task.logissue type=%s;sourcepath=%s;linenumber=%d;columnnumber=%d;code=%s;]%s\n",
+				levelToAzure[a.Severity],
+				azurePropertyEscaper.Replace(f.Path),
+				a.Line,
+				a.Span[0],
+				azurePropertyEscaper.Replace(a.Check),
+				azureMessageEscaper.Replace(a.Message))
+		}
+	}
+
+	return alertCount != 0
+}
+
+// PrintGitHubAlerts prints Alerts as GitHub Actions workflow commands, which
+// are shown as annotations on a pull request's changed files.
+//
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions.
+func PrintGitHubAlerts(linted []*core.File) bool {
+	alertCount := 0
+
+	for _, f := range linted {
+		for _, a := range f.SortedAlerts() {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			fmt.Printf("::%s file=%s,line=%d,col=%d,endColumn=%d,title=%s::%s\n",
+				levelToGitHub[a.Severity],
+				gitHubPropertyEscaper.Replace(reportPath(f.Path)),
+				a.Line,
+				a.Span[0],
+				a.Span[1],
+				gitHubPropertyEscaper.Replace(a.Check),
+				gitHubMessageEscaper.Replace(a.Message))
+		}
+	}
+
+	return alertCount != 0
+}
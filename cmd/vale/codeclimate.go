@@ -0,0 +1,109 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// levelToCodeClimate maps Vale's severities to those of Code Climate (which
+// are also used by GitLab's Code Quality reports).
+var levelToCodeClimate = map[string]string{
+	"suggestion": "info",
+	"warning":    "minor",
+	"error":      "major",
+}
+
+// CodeClimateIssue is a single issue in a Code Climate (or GitLab Code
+// Quality) report.
+//
+// See https://docs.gitlab.com/ee/ci/testing/code_quality.html.
+type CodeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    CodeClimateLocation `json:"location"`
+}
+
+// CodeClimateLocation is the location of a `CodeClimateIssue`.
+type CodeClimateLocation struct {
+	Path      string `json:"path"`
+	Positions struct {
+		Begin CodeClimatePosition `json:"begin"`
+		End   CodeClimatePosition `json:"end"`
+	} `json:"positions"`
+}
+
+// CodeClimatePosition is a 1-based line and column.
+type CodeClimatePosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// PrintCodeClimateAlerts prints Alerts as a Code Climate report, which GitLab
+// displays in the diff view of a merge request.
+func PrintCodeClimateAlerts(linted []*core.File) bool {
+	alertCount := 0
+	issues := []CodeClimateIssue{}
+
+	for _, f := range linted {
+		path := reportPath(f.Path)
+		seen := map[string]int{}
+
+		for _, a := range f.SortedAlerts() {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			// The fingerprint identifies an issue across runs, so we avoid
+			// using its line: otherwise, any edit above an issue would make
+			// it appear to be new.
+			key := a.Check + "\x00" + a.Match + "\x00" + a.Message
+			seen[key]++
+
+			issue := CodeClimateIssue{
+				Type:        "issue",
+				CheckName:   a.Check,
+				Description: a.Message,
+				Categories:  []string{"Style"},
+				Fingerprint: codeClimateFingerprint(path, key, seen[key]),
+				Severity:    levelToCodeClimate[a.Severity],
+			}
+
+			issue.Location.Path = path
+			issue.Location.Positions.Begin = CodeClimatePosition{Line: a.Line, Column: a.Span[0]}
+			issue.Location.Positions.End = CodeClimatePosition{Line: a.Line, Column: a.Span[1]}
+
+			issues = append(issues, issue)
+		}
+	}
+
+	fmt.Println(getJSON(issues))
+	return alertCount != 0
+}
+
+// reportPath returns `path` relative to the current directory, since CI
+// reports (e.g., Code Climate or JUnit) are expected to use
+// repository-relative paths.
+func reportPath(path string) string {
+	if filepath.IsAbs(path) {
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, relErr := filepath.Rel(cwd, path); relErr == nil {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+func codeClimateFingerprint(path, key string, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d", path, key, occurrence)))
+	return hex.EncodeToString(sum[:16])
+}
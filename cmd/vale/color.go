@@ -11,14 +11,21 @@ import (
 	"github.com/errata-ai/vale/v3/internal/core"
 )
 
+// foldLimit is the number of times an alert with the same rule and message is
+// shown for a file before the rest are folded into a single line.
+const foldLimit = 3
+
 // PrintVerboseAlerts prints Alerts in verbose format.
-func PrintVerboseAlerts(linted []*core.File, wrap bool) bool {
+//
+// Unless `expand` is true, repeated alerts are folded (see `foldLimit`); the
+// summary still counts every alert.
+func PrintVerboseAlerts(linted []*core.File, wrap, expand bool) bool {
 	var errors, warnings, suggestions int
 	var e, w, s int
 	var symbol string
 
 	for _, f := range linted {
-		e, w, s = printVerboseAlert(f, wrap)
+		e, w, s = printVerboseAlert(f, wrap, expand)
 		errors += e
 		warnings += w
 		suggestions += s
@@ -28,28 +35,24 @@ func PrintVerboseAlerts(linted []*core.File, wrap bool) bool {
 	wtotal := fmt.Sprintf("%d %s", warnings, pluralize("warning", warnings))
 	stotal := fmt.Sprintf("%d %s", suggestions, pluralize("suggestion", suggestions))
 
-	if errors > 0 || warnings > 0 {
-		symbol = "\u2716"
-	} else {
-		symbol = "\u2714"
-	}
+	symbol = summarySymbol(errors > 0 || warnings > 0)
 
 	n := len(linted)
 	if n == 1 && strings.HasPrefix(linted[0].Path, "stdin") {
 		fmt.Printf("%s %s, %s and %s in %s.\n", symbol,
-			pterm.Red(etotal), pterm.Yellow(wtotal),
-			pterm.Blue(stotal), "stdin")
+			levelSprint("error", etotal), levelSprint("warning", wtotal),
+			levelSprint("suggestion", stotal), "stdin")
 	} else {
 		fmt.Printf("%s %s, %s and %s in %d %s.\n", symbol,
-			pterm.Red(etotal), pterm.Yellow(wtotal),
-			pterm.Blue(stotal), n, pluralize("file", n))
+			levelSprint("error", etotal), levelSprint("warning", wtotal),
+			levelSprint("suggestion", stotal), n, pluralize("file", n))
 	}
 
 	return errors != 0
 }
 
 // printVerboseAlert includes an alert's line, column, level, and message.
-func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
+func printVerboseAlert(f *core.File, wrap, expand bool) (int, int, int) {
 	var loc, level string
 	var errors, warnings, notifications int
 
@@ -64,21 +67,36 @@ func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
 	table.SetRowSeparator("")
 	table.SetAutoWrapText(!wrap)
 
+	total := map[string]int{}
+	for _, a := range alerts {
+		total[a.Check+"\x00"+a.Message]++
+	}
+	shown := map[string]int{}
+
 	fmt.Printf("\n %s", pterm.Underscore.Sprint(f.Path))
 	for _, a := range alerts {
 		switch a.Severity {
 		case "suggestion":
-			level = pterm.Blue(a.Severity)
 			notifications++
 		case "warning":
-			level = pterm.Yellow(a.Severity)
 			warnings++
 		case "error":
-			level = pterm.Red(a.Severity)
 			errors++
 		}
+
+		key := a.Check + "\x00" + a.Message
+		if shown[key]++; !expand && shown[key] > foldLimit {
+			continue
+		}
+
+		level = levelSprint(a.Severity, a.Severity)
 		loc = fmt.Sprintf("%d:%d", a.Line, a.Span[0])
 		table.Append([]string{loc, level, a.Message, a.Check})
+
+		if n := total[key] - foldLimit; !expand && shown[key] == foldLimit && n > 0 {
+			table.Append([]string{"", "", fmt.Sprintf(
+				"(repeated %d more %s; use --verbose to expand)", n, pluralize("time", n)), a.Check})
+		}
 	}
 	table.Render()
 	return errors, warnings, notifications
@@ -38,6 +38,18 @@ var commandInfo = map[string]string{
 	"fix":            "Attempt to automatically fix the given alert.",
 }
 
+// writeActions are the commands that can't run without writing to the
+// filesystem (see `--no-write`).
+var writeActions = map[string]bool{
+	"sync":           true,
+	"install":        true,
+	"init":           true,
+	"package":        true,
+	"daemon":         true,
+	"host-install":   true,
+	"host-uninstall": true,
+}
+
 // Actions are the available CLI commands.
 var Actions = map[string]func(args []string, flags *core.CLIFlags) error{
 	"ls-config":  printConfig,
@@ -89,7 +101,16 @@ func sync(_ []string, flags *core.CLIFlags) error {
 	cfg, err := core.ReadPipeline(flags, true)
 	if err != nil {
 		return err
-	} else if err = initPath(cfg); err != nil {
+	}
+
+	// Another run may be syncing (or reading) the same `StylesPath`.
+	lock, err := core.LockState(cfg.StylesPath())
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	if err = initPath(cfg); err != nil {
 		return err
 	}
 
@@ -103,6 +124,13 @@ func sync(_ []string, flags *core.CLIFlags) error {
 	if err != nil {
 		return err
 	}
+
+	pins, err := core.GetPackagePins(rootINI)
+	if err != nil {
+		return err
+	}
+	packagePolicy = pkgPolicy{pins: pins, strict: flags.StrictPackages}
+
 	stylesPath := cfg.StylesPath()
 
 	p, err := pterm.DefaultProgressbar.WithTotal(len(pkgs)).Start()
@@ -111,7 +139,8 @@ func sync(_ []string, flags *core.CLIFlags) error {
 	}
 
 	for idx, pkg := range pkgs {
-		name := fileNameWithoutExt(pkg)
+		name, _ := splitPkgVersion(pkg)
+		name = fileNameWithoutExt(name)
 
 		p.UpdateTitle("Syncing " + name)
 		p.Increment()
@@ -160,6 +189,8 @@ func printMetrics(args []string, _ *core.CLIFlags) error {
 	linted, err := linter.Lint([]string{args[0]}, "*")
 	if err != nil {
 		return err
+	} else if len(linted) == 0 {
+		return core.NewE100("ls-metrics", fmt.Errorf("'%s' wasn't linted", args[0]))
 	}
 
 	computed, _ := linted[0].ComputeMetrics()
@@ -2,23 +2,94 @@ package main
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
 )
 
 // PrintAlerts prints the given alerts in the user-specified format.
-func PrintAlerts(linted []*core.File, config *core.Config) (bool, error) {
+//
+// The statistics, if any, are only included in JSON output.
+func PrintAlerts(linted []*core.File, config *core.Config, stats *lint.RunStats) (bool, error) {
 	if config.Flags.Sorted {
 		sort.Sort(core.ByName(linted))
 	}
+
+	limitContext(linted, config)
+
 	switch config.Flags.Output {
 	case "JSON":
+		if config.Flags.Stats {
+			return PrintJSONStats(linted, stats), nil
+		}
 		return PrintJSONAlerts(linted), nil
 	case "line":
 		return PrintLineAlerts(linted, config.Flags.Relative), nil
+	case "codeclimate":
+		return PrintCodeClimateAlerts(linted), nil
+	case "teamcity":
+		return PrintTeamCityAlerts(linted), nil
+	case "azure":
+		return PrintAzureAlerts(linted), nil
+	case "github":
+		return PrintGitHubAlerts(linted), nil
+	case "junit":
+		return PrintJUnitAlerts(linted), nil
 	case "CLI":
-		return PrintVerboseAlerts(linted, config.Flags.Wrap), nil
+		return PrintVerboseAlerts(linted, config.Flags.Wrap, config.Flags.Verbose), nil
 	default:
+		if strings.HasPrefix(config.Flags.Output, sqlitePrefix) {
+			return PrintSQLiteAlerts(linted, config)
+		}
 		return PrintCustomAlerts(linted, config)
 	}
 }
+
+// suggest fills in the spelling suggestions of the alerts in `linted` if
+// the run's output includes them: JSON (whether printed or routed to an
+// `[outputs]` sink) and `--fix`.
+func suggest(linted []*core.File, linter *lint.Linter) {
+	config := linter.Manager.Config
+	if !showsSuggestions(config) {
+		return
+	}
+	for _, f := range linted {
+		linter.Manager.Suggest(f.Alerts)
+	}
+}
+
+func showsSuggestions(config *core.Config) bool {
+	if config.Flags.Output == "JSON" || config.Flags.Fix {
+		return true
+	}
+	for _, sinks := range config.Outputs {
+		for _, sink := range sinks {
+			if sink != terminalSink {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// limitContext applies `LimitContext` to every alert in `linted`.
+func limitContext(linted []*core.File, config *core.Config) {
+	for _, f := range linted {
+		limitAlerts(f.Alerts, config)
+	}
+}
+
+// limitAlerts applies `LimitContext` to each of the given alerts.
+//
+// Every output that includes alerts (including `--batch`, `serve`, `lsp`,
+// and the daemon) goes through here, so that none of them exposes more of a
+// document than the config allows.
+func limitAlerts(alerts []core.Alert, config *core.Config) {
+	if !config.RedactContext && config.ContextChars == 0 {
+		return
+	}
+	for i := range alerts {
+		core.LimitContext(&alerts[i], config)
+	}
+}
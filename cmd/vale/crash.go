@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// printCrashes summarizes (on stderr) the files that were skipped because
+// linting them panicked.
+func printCrashes(crashes []*lint.Crash) {
+	if len(crashes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Skipped %d %s that crashed:\n",
+		len(crashes), pluralize("file", len(crashes)))
+	for _, c := range crashes {
+		fmt.Fprintf(os.Stderr, "  %s\n", c.Error())
+	}
+
+	if Flags.CrashBundle == "" {
+		fmt.Fprintf(os.Stderr, "Use %s to save the details of each crash.\n",
+			toCodeStyle("--crash-bundle=<dir>"))
+	}
+}
+
+// writeCrashBundles writes a diagnostic bundle for each crash to `dir`: the
+// configuration, the definition of the rule that panicked, the offending
+// text, and the panic's stack trace.
+func writeCrashBundles(dir string, crashes []*lint.Crash, config *core.Config) error {
+	if err := core.CheckWritable("--crash-bundle"); err != nil {
+		return err
+	}
+
+	for i, c := range crashes {
+		bundle := filepath.Join(dir, fmt.Sprintf("crash-%d", i+1))
+		if err := os.MkdirAll(bundle, os.ModePerm); err != nil {
+			return core.NewE100("--crash-bundle", err)
+		}
+
+		files := map[string]string{
+			"config.json": config.String(),
+			"snippet.txt": c.Snippet,
+			"panic.txt": fmt.Sprintf("File: %s\nRule: %s\n\npanic: %s\n\n%s",
+				c.Path, c.Rule, c.Value, c.Stack),
+		}
+
+		if def := findRuleDefinition(c.Rule, config); def != "" {
+			if b, err := os.ReadFile(def); err == nil {
+				files["rule.yml"] = string(b)
+			}
+		}
+
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(bundle, name), []byte(content), 0o600); err != nil {
+				return core.NewE100("--crash-bundle", err)
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %d diagnostic %s to '%s'.\n",
+		len(crashes), pluralize("bundle", len(crashes)), dir)
+
+	return nil
+}
+
+// findRuleDefinition returns the path to the definition of the rule `name`
+// (e.g., `Style.Rule`), or an empty string if it's built in or can't be
+// found.
+func findRuleDefinition(name string, config *core.Config) string {
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	for _, p := range config.SearchPaths() {
+		path := filepath.Join(p, parts[0], parts[1]+".yml")
+		if core.FileExists(path) {
+			return path
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// maxSitemaps is the maximum number of sitemaps read from a sitemap index.
+const maxSitemaps = 50
+
+// crawlTimeout is how long the crawler waits for any one response.
+const crawlTimeout = 30 * time.Second
+
+// maxCrawlSize is the most that the crawler reads of any one response.
+const maxCrawlSize = 10 << 20
+
+func init() {
+	pflag.StringVar(&Flags.Sitemap, "sitemap", "",
+		fmt.Sprintf(`The sitemap listing the pages for 'crawl' to lint (%s).`,
+			toCodeStyle(`--sitemap=https://docs.example.com/sitemap.xml`)))
+	pflag.IntVar(&Flags.MaxPages, "max-pages", 100, "The maximum number of pages for 'crawl' to lint.")
+	pflag.DurationVar(&Flags.CrawlDelay, "crawl-delay", 500*time.Millisecond,
+		"The minimum time between the requests made by 'crawl'.")
+	pflag.IntVar(&Flags.CrawlWorkers, "crawl-workers", 4, "The number of pages for 'crawl' to fetch at once.")
+
+	commandInfo["crawl"] = "Lint the pages of a published site, as listed in its sitemap."
+	Actions["crawl"] = crawl
+}
+
+// sitemap is either a list of pages (`<urlset>`) or a list of other sitemaps
+// (`<sitemapindex>`).
+type sitemap struct {
+	XMLName xml.Name
+	URLs    []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// crawl lints the pages listed in a sitemap (and any given as arguments),
+// printing a single report for all of them.
+//
+// To avoid overloading the site, at most `--crawl-workers` pages are fetched
+// at once, requests are at least `--crawl-delay` apart, and any page
+// disallowed by the site's `robots.txt` file is skipped.
+func crawl(args []string, flags *core.CLIFlags) error {
+	if flags.Sitemap == "" && len(args) == 0 {
+		return core.NewE100("crawl", errors.New("'--sitemap' or at least one URL is required"))
+	}
+
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	pages := []string{}
+	for _, arg := range args {
+		if !lint.IsURL(arg) {
+			return core.NewE100("crawl", fmt.Errorf("'%s' isn't a URL", arg))
+		}
+		pages = append(pages, arg)
+	}
+
+	c := newCrawler(flags.CrawlDelay)
+	if flags.Sitemap != "" {
+		found, mapErr := c.readSitemap(flags.Sitemap, flags.MaxPages, 0)
+		if mapErr != nil {
+			return core.NewE100("crawl", mapErr)
+		}
+		pages = append(pages, found...)
+	}
+	pages = dedupe(pages)
+	if flags.MaxPages > 0 && len(pages) > flags.MaxPages {
+		pages = pages[:flags.MaxPages]
+	}
+
+	linted, failed := c.lintPages(linter, pages, flags)
+	for _, msg := range failed {
+		fmt.Fprintf(os.Stderr, "  %s\n", msg)
+	}
+	fmt.Fprintf(os.Stderr, "Crawled %d of %d %s.\n",
+		len(linted), len(pages), pluralize("page", len(pages)))
+
+	hasErrors, err := PrintAlerts(linted, cfg, nil)
+	if err != nil {
+		return err
+	} else if hasErrors && !flags.NoExit {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// A crawler fetches pages politely: its requests are spaced out and it
+// respects each site's `robots.txt` file.
+type crawler struct {
+	client  *http.Client
+	limiter <-chan time.Time
+	robots  map[string][]string // host -> disallowed path prefixes
+	mu      gosync.Mutex
+	lintMu  gosync.Mutex // a `Linter` isn't safe for concurrent use
+}
+
+func newCrawler(delay time.Duration) *crawler {
+	c := &crawler{client: &http.Client{Timeout: crawlTimeout}, robots: map[string][]string{}}
+	if delay > 0 {
+		c.limiter = time.NewTicker(delay).C
+	}
+	return c
+}
+
+// wait blocks until the crawler is allowed to make another request.
+func (c *crawler) wait() {
+	if c.limiter != nil {
+		<-c.limiter
+	}
+}
+
+// get fetches `u`, returning (at most `maxCrawlSize` bytes of) its body and
+// its content type.
+func (c *crawler) get(u string) ([]byte, string, error) {
+	c.wait()
+
+	req, err := http.NewRequest(http.MethodGet, u, nil) //nolint:noctx
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", lint.UserAgent())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("'%s' returned %s", u, resp.Status)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxCrawlSize))
+	return b, resp.Header.Get("Content-Type"), err
+}
+
+// readSitemap returns the pages listed in the sitemap at `u`, following any
+// nested sitemaps.
+func (c *crawler) readSitemap(u string, limit, depth int) ([]string, error) {
+	b, _, err := c.get(u)
+	if err != nil {
+		return nil, err
+	}
+
+	var sm sitemap
+	if err = xml.Unmarshal(b, &sm); err != nil {
+		return nil, fmt.Errorf("'%s' isn't a sitemap: %w", u, err)
+	}
+
+	pages := []string{}
+	for _, entry := range sm.URLs {
+		pages = append(pages, strings.TrimSpace(entry.Loc))
+	}
+
+	for i, entry := range sm.Sitemaps {
+		if depth > 0 || i >= maxSitemaps || (limit > 0 && len(pages) >= limit) {
+			break
+		}
+		nested, nestedErr := c.readSitemap(strings.TrimSpace(entry.Loc), limit, depth+1)
+		if nestedErr != nil {
+			return nil, nestedErr
+		}
+		pages = append(pages, nested...)
+	}
+
+	return pages, nil
+}
+
+// allowed reports whether the site's `robots.txt` file allows Vale to fetch
+// the page at `u`.
+func (c *crawler) allowed(u *url.URL) bool {
+	c.mu.Lock()
+	disallowed, found := c.robots[u.Host]
+	c.mu.Unlock()
+
+	if !found {
+		robots := url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/robots.txt"}
+		if b, _, err := c.get(robots.String()); err == nil {
+			disallowed = parseRobots(string(b))
+		}
+
+		c.mu.Lock()
+		c.robots[u.Host] = disallowed
+		c.mu.Unlock()
+	}
+
+	for _, prefix := range disallowed {
+		if strings.HasPrefix(u.Path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// lintPages lints `pages`, returning the linted pages (in order) and a
+// description of each page that couldn't be linted.
+func (c *crawler) lintPages(linter *lint.Linter, pages []string, flags *core.CLIFlags) ([]*core.File, []string) {
+	results := make([]*core.File, len(pages))
+	errs := make([]string, len(pages))
+
+	workers := flags.CrawlWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg gosync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				u, err := url.Parse(pages[i])
+				if err != nil {
+					errs[i] = fmt.Sprintf("%s (%v)", pages[i], err)
+					continue
+				} else if !c.allowed(u) {
+					errs[i] = fmt.Sprintf("%s (disallowed by robots.txt)", pages[i])
+					continue
+				}
+
+				f, err := c.lintPage(linter, pages[i], flags.Selector)
+				if err != nil {
+					errs[i] = fmt.Sprintf("%s (%s)", pages[i], errorMessage(err))
+					continue
+				}
+				results[i] = f
+			}
+		}()
+	}
+
+	for i := range pages {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var linted []*core.File
+	var failed []string
+	for i := range pages {
+		if results[i] != nil {
+			linted = append(linted, results[i])
+		} else {
+			failed = append(failed, errs[i])
+		}
+	}
+
+	return linted, failed
+}
+
+// lintPage fetches and lints the page at `u`.
+//
+// NOTE: Pages are fetched concurrently, but they share a `Linter` and so are
+// linted one at a time.
+func (c *crawler) lintPage(linter *lint.Linter, u, selector string) (*core.File, error) {
+	b, ct, err := c.get(u)
+	if err != nil {
+		return nil, err
+	} else if ct != "" && !strings.Contains(ct, "html") {
+		return nil, fmt.Errorf("'%s' isn't HTML (%s)", u, ct)
+	}
+
+	c.lintMu.Lock()
+	defer c.lintMu.Unlock()
+
+	return linter.LintPage(u, string(b), selector)
+}
+
+// parseRobots returns the paths disallowed for all user agents (`*`) by a
+// `robots.txt` file.
+func parseRobots(content string) []string {
+	var disallowed []string
+
+	applies := false
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.SplitN(scanner.Text(), "#", 2)[0])
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "user-agent":
+			applies = value == "*"
+		case "disallow":
+			if applies && value != "" {
+				disallowed = append(disallowed, value)
+			}
+		}
+	}
+
+	return disallowed
+}
+
+func dedupe(items []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// errorMessage returns the message of a (possibly formatted) error, without
+// its code and title.
+func errorMessage(err error) string {
+	parts := strings.Split(err.Error(), "\n\n")
+	if len(parts) >= 3 {
+		return strings.TrimSpace(parts[1])
+	}
+	return err.Error()
+}
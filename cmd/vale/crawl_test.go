@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	gosync "sync"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+func TestParseRobots(t *testing.T) {
+	robots := `
+User-agent: Googlebot
+Disallow: /google-only/
+
+User-agent: *
+Disallow: /private/ # not public
+Disallow:
+Allow: /private/ok.html
+`
+	got := parseRobots(robots)
+	if !reflect.DeepEqual(got, []string{"/private/"}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestReadSitemap(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>%[1]s/docs.xml</loc></sitemap>
+</sitemapindex>`, server.URL)
+	})
+	mux.HandleFunc("/docs.xml", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>%[1]s/a.html</loc></url>
+  <url><loc> %[1]s/b.html </loc></url>
+</urlset>`, server.URL)
+	})
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "User-agent: *\nDisallow: /b\n")
+	})
+
+	c := newCrawler(0)
+
+	pages, err := c.readSitemap(server.URL+"/sitemap.xml", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{server.URL + "/a.html", server.URL + "/b.html"}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Fatalf("expected %v, got %v", expected, pages)
+	}
+
+	for i, allowed := range []bool{true, false} {
+		u, _ := url.Parse(pages[i])
+		if c.allowed(u) != allowed {
+			t.Errorf("%s: expected allowed=%v", pages[i], allowed)
+		}
+	}
+
+	if _, err = c.readSitemap(server.URL+"/missing.xml", 0, 0); err == nil {
+		t.Error("expected an error for a missing sitemap")
+	}
+}
+
+func TestLintPages(t *testing.T) {
+	var mu gosync.Mutex
+	agents := map[string]bool{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agents[r.UserAgent()] = true
+		mu.Unlock()
+
+		if r.URL.Path == "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><body><main><p>This is is a test.</p></main></body></html>")
+	}))
+	defer server.Close()
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.GBaseStyles = []string{"Vale"}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages := []string{}
+	for i := 0; i < 8; i++ {
+		pages = append(pages, fmt.Sprintf("%s/page-%d.html", server.URL, i))
+	}
+
+	linted, failed := newCrawler(0).lintPages(linter, pages, &core.CLIFlags{CrawlWorkers: 4, Selector: "main"})
+	if len(failed) > 0 {
+		t.Fatalf("Expected no failures, got %v", failed)
+	} else if len(linted) != len(pages) {
+		t.Fatalf("Expected %d pages, got %d", len(pages), len(linted))
+	}
+
+	for i, f := range linted {
+		if f.Path != pages[i] || len(f.Alerts) != 1 {
+			t.Errorf("Unexpected result for '%s': %+v", pages[i], f.Alerts)
+		}
+	}
+
+	if len(agents) != 1 || !agents[lint.UserAgent()] {
+		t.Errorf("Expected every request to use '%s', got %v", lint.UserAgent(), agents)
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
@@ -10,6 +12,10 @@ import (
 	"github.com/errata-ai/vale/v3/internal/core"
 )
 
+// templatePrefix explicitly marks an `--output` value as a template file
+// (e.g., `--output=template=report.tmpl`).
+const templatePrefix = "template="
+
 // ProcessedFile represents a file that Vale has linted.
 type ProcessedFile struct {
 	Alerts []core.Alert
@@ -20,17 +26,24 @@ type ProcessedFile struct {
 type Data struct {
 	Files       []ProcessedFile
 	LintedTotal int
+	Summary     map[string]int // alert counts by severity
 }
 
-// PrintCustomAlerts formats the given alerts using a user-defined template.
+// PrintCustomAlerts formats the given alerts using a user-defined template,
+// given as either `--output=<path>` or `--output=template=<path>`.
 func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
 	var alertCount int
 
-	path := cfg.Flags.Output
+	path := strings.TrimPrefix(cfg.Flags.Output, templatePrefix)
 	if !core.FileExists(path) {
 		path = core.FindAsset(cfg, path)
 	}
 
+	if path == "" {
+		return false, core.NewE100("template", fmt.Errorf(
+			"'%s' isn't an output format or a template file", cfg.Flags.Output))
+	}
+
 	b, err := os.ReadFile(path)
 	if err != nil {
 		return false, core.NewE100("template", err)
@@ -42,6 +55,11 @@ func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
 		return false, core.NewE100("template", err)
 	}
 
+	summary := map[string]int{}
+	for _, level := range core.AlertLevels {
+		summary[level] = 0
+	}
+
 	formatted := []ProcessedFile{}
 	for _, f := range linted {
 		if len(f.Alerts) == 0 {
@@ -50,8 +68,8 @@ func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
 		for _, a := range f.SortedAlerts() {
 			if a.Severity == "error" {
 				alertCount++
-				break
 			}
+			summary[a.Severity]++
 		}
 		formatted = append(formatted, ProcessedFile{
 			Path:   f.Path,
@@ -62,5 +80,6 @@ func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
 	return alertCount != 0, t.Execute(os.Stdout, Data{
 		Files:       formatted,
 		LintedTotal: len(linted),
+		Summary:     summary,
 	})
 }
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/pterm/pterm"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// daemonIdle is how long the daemon waits for a new request before shutting
+// itself down.
+const daemonIdle = 15 * time.Minute
+
+// daemonTimeout is how long the daemon waits for a client to send its
+// request, or to read its response, before giving up on it.
+//
+// Since requests are handled one at a time, a client that never does either
+// would otherwise block the daemon indefinitely.
+var daemonTimeout = 10 * time.Second
+
+// daemonCacheSize is the maximum number of linters that the daemon keeps,
+// evicting the least recently used one when it's full.
+const daemonCacheSize = 8
+
+// daemonEnv are the environment variables that affect how a configuration is
+// loaded, which the CLI forwards to the daemon.
+var daemonEnv = []string{
+	"VALE_CONFIG_PATH", "VALE_CONFIG_CONTENT", "VALE_STYLES_PATH",
+	"VALE_CACHE_PATH", "VALE_STATE_PATH", "DICPATH",
+}
+
+// daemonRequest is sent from the CLI to the daemon.
+type daemonRequest struct {
+	Flags core.CLIFlags
+	Args  []string
+	Cwd   string
+	Env   map[string]string // the client's values of `daemonEnv`, if set
+	Stdin *string
+	Stop  bool
+}
+
+// daemonResponse is the daemon's reply to a `daemonRequest`.
+type daemonResponse struct {
+	Files []daemonFile
+	Error string
+}
+
+type daemonFile struct {
+	Path   string
+	Alerts []core.Alert
+}
+
+// daemonLinter is a cached linter for a particular project and set of
+// command-line flags.
+type daemonLinter struct {
+	linter *lint.Linter
+	stamp  time.Time
+	used   int // when the linter was last used, relative to the others
+}
+
+func init() {
+	commandInfo["daemon"] = "Start or stop the background linting daemon used by `--fast`."
+	Actions["daemon"] = daemon
+}
+
+func daemon(args []string, _ *core.CLIFlags) error {
+	if len(args) != 1 {
+		return core.NewE100("daemon", errors.New("one argument expected ('start' or 'stop')"))
+	}
+
+	switch args[0] {
+	case "start":
+		return runDaemon()
+	case "stop":
+		return stopDaemon()
+	default:
+		return core.NewE100("daemon", fmt.Errorf("unknown subcommand '%s'", args[0]))
+	}
+}
+
+func daemonSocket() (string, error) {
+	return xdg.RuntimeFile("vale/daemon.sock")
+}
+
+func dialDaemon() (net.Conn, error) {
+	sock, err := daemonSocket()
+	if err != nil {
+		return nil, err
+	}
+	return net.DialTimeout("unix", sock, time.Second)
+}
+
+// spawnDaemon starts a detached daemon process and waits for it to begin
+// accepting connections.
+func spawnDaemon() (net.Conn, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(exe, "daemon", "start")
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	} else if err = cmd.Process.Release(); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < 40; i++ {
+		conn, dialErr := dialDaemon()
+		if dialErr == nil {
+			return conn, nil
+		}
+		err = dialErr
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return nil, err
+}
+
+func sendRequest(conn net.Conn, req daemonRequest) (daemonResponse, error) {
+	var resp daemonResponse
+
+	defer conn.Close()
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return resp, err
+	}
+
+	err := json.NewDecoder(conn).Decode(&resp)
+	return resp, err
+}
+
+// lintWithDaemon sends the given arguments to the daemon, starting one if
+// necessary.
+//
+// The returned bool is false if we couldn't reach a daemon, in which case the
+// caller should lint in-process instead.
+func lintWithDaemon(args []string, flags *core.CLIFlags) ([]*core.File, bool, error) {
+	var linted []*core.File
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return linted, false, err
+	}
+	req := daemonRequest{Flags: *flags, Args: args, Cwd: cwd, Env: map[string]string{}}
+	for _, name := range daemonEnv {
+		if value, set := os.LookupEnv(name); set {
+			req.Env[name] = value
+		}
+	}
+
+	conn, err := dialDaemon()
+	if err != nil {
+		conn, err = spawnDaemon()
+		if err != nil {
+			return linted, false, err
+		}
+	}
+
+	// NOTE: Past this point, we can't fall back to linting in-process since
+	// we may have already consumed stdin.
+	if len(args) == 0 {
+		stdin, readErr := io.ReadAll(os.Stdin)
+		if readErr != nil {
+			conn.Close()
+			return linted, true, core.NewE100("daemon", readErr)
+		}
+		text := string(stdin)
+		req.Stdin = &text
+	}
+
+	resp, err := sendRequest(conn, req)
+	if err != nil {
+		return linted, true, core.NewE100("daemon", err)
+	} else if resp.Error != "" {
+		return linted, true, errors.New(resp.Error)
+	}
+
+	for _, f := range resp.Files {
+		linted = append(linted, &core.File{Path: f.Path, Alerts: f.Alerts})
+	}
+
+	return linted, true, nil
+}
+
+func stopDaemon() error {
+	conn, err := dialDaemon()
+	if err != nil {
+		pterm.Info.Println("No daemon is running.")
+		return nil
+	}
+
+	if _, err = sendRequest(conn, daemonRequest{Stop: true}); err != nil {
+		return core.NewE100("daemon", err)
+	}
+
+	pterm.Success.Println("Stopped the daemon.")
+	return nil
+}
+
+// runDaemon serves lint requests until it's stopped or has been idle for
+// `daemonIdle`.
+//
+// Requests are handled one at a time since a `Linter` isn't safe for
+// concurrent use.
+func runDaemon() error {
+	sock, err := daemonSocket()
+	if err != nil {
+		return core.NewE100("daemon", err)
+	}
+
+	// NOTE: Two clients may spawn a daemon at the same time, so the socket's
+	// lock (rather than a successful dial) decides which of them keeps
+	// running.
+	lock, err := core.TryLockState(sock)
+	if errors.Is(err, core.ErrLocked) {
+		return core.NewE100("daemon", errors.New("a daemon is already running"))
+	} else if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	if conn, dialErr := dialDaemon(); dialErr == nil {
+		conn.Close()
+		return core.NewE100("daemon", errors.New("a daemon is already running"))
+	}
+	// Remove a stale socket left behind by a previous daemon.
+	_ = os.Remove(sock)
+
+	listener, err := net.Listen("unix", sock)
+	if err != nil {
+		return core.NewE100("daemon", err)
+	}
+	defer listener.Close()
+
+	cache := map[string]*daemonLinter{}
+	for {
+		_ = listener.(*net.UnixListener).SetDeadline(time.Now().Add(daemonIdle))
+
+		conn, acceptErr := listener.Accept()
+		if acceptErr != nil {
+			var netErr net.Error
+			if errors.As(acceptErr, &netErr) && netErr.Timeout() {
+				return nil
+			}
+			return core.NewE100("daemon", acceptErr)
+		}
+
+		if stop := serveRequest(conn, cache); stop {
+			return nil
+		}
+	}
+}
+
+// serveRequest handles a single connection, reporting whether or not the
+// daemon should shut down.
+func serveRequest(conn net.Conn, cache map[string]*daemonLinter) bool {
+	var req daemonRequest
+
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(daemonTimeout)); err != nil {
+		return false
+	} else if err = json.NewDecoder(conn).Decode(&req); err != nil {
+		return false
+	}
+
+	resp := daemonResponse{}
+	if !req.Stop {
+		files, err := daemonLint(req, cache)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+		for _, f := range files {
+			resp.Files = append(resp.Files, daemonFile{Path: f.Path, Alerts: f.Alerts})
+		}
+	}
+
+	// The time we spent linting doesn't count against the client.
+	_ = conn.SetDeadline(time.Now().Add(daemonTimeout))
+	_ = json.NewEncoder(conn).Encode(resp)
+
+	return req.Stop
+}
+
+func daemonLint(req daemonRequest, cache map[string]*daemonLinter) ([]*core.File, error) {
+	if err := os.Chdir(req.Cwd); err != nil {
+		return nil, err
+	}
+
+	// NOTE: Requests are handled one at a time, so it's safe to adopt the
+	// client's environment for the duration of each one.
+	for _, name := range daemonEnv {
+		var err error
+		if value, set := req.Env[name]; set {
+			err = os.Setenv(name, value)
+		} else {
+			err = os.Unsetenv(name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	flags := req.Flags
+	cfg, err := core.ReadPipeline(&flags, false)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := json.Marshal([]interface{}{req.Flags, req.Env})
+	if err != nil {
+		return nil, err
+	}
+
+	linter, err := cachedLinter(cache, req.Cwd+string(key), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var linted []*core.File
+	if req.Stdin != nil {
+		linted, err = linter.LintString(*req.Stdin)
+	} else {
+		linted, err = doLint(req.Args, linter, flags.Glob)
+	}
+
+	suggest(linted, linter)
+	limitContext(linted, cfg)
+	return linted, err
+}
+
+// cachedLinter returns the linter cached under `id`, creating a new one if
+// there isn't one or if the configuration has changed since it was created.
+//
+// At most `daemonCacheSize` linters are kept.
+func cachedLinter(cache map[string]*daemonLinter, id string, cfg *core.Config) (*lint.Linter, error) {
+	stamp := lastModified(cfg)
+
+	entry, found := cache[id]
+	if !found || stamp.After(entry.stamp) {
+		linter, err := lint.NewLinter(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found && len(cache) >= daemonCacheSize {
+			evictLinter(cache)
+		}
+		entry = &daemonLinter{linter: linter, stamp: stamp}
+		cache[id] = entry
+	}
+	for _, other := range cache {
+		entry.used = max(entry.used, other.used+1)
+	}
+
+	return entry.linter, nil
+}
+
+// evictLinter removes the least recently used linter from the cache.
+func evictLinter(cache map[string]*daemonLinter) {
+	oldest := ""
+	for id, entry := range cache {
+		if oldest == "" || entry.used < cache[oldest].used {
+			oldest = id
+		}
+	}
+	delete(cache, oldest)
+}
+
+// lastModified returns the most recent modification time across all of the
+// given configuration's files and styles.
+//
+// We use this to decide when a cached linter is out-of-date.
+func lastModified(cfg *core.Config) time.Time {
+	var latest time.Time
+
+	update := func(path string) {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	for _, file := range cfg.ConfigFiles {
+		update(file)
+	}
+
+	for _, dir := range cfg.Paths {
+		_ = filepath.WalkDir(dir, func(path string, _ fs.DirEntry, err error) error {
+			if err == nil {
+				update(path)
+			}
+			return nil
+		})
+	}
+
+	return latest
+}
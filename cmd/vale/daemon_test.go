@@ -0,0 +1,238 @@
+package main
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestDaemonServeRequest(t *testing.T) {
+	dir := t.TempDir()
+
+	ini := filepath.Join(dir, ".vale.ini")
+	if err := os.WriteFile(ini, []byte("[*]\nBasedOnStyles = Vale\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd) //nolint:errcheck
+
+	text := "This is is a test."
+	req := daemonRequest{Flags: core.CLIFlags{Path: ini, InExt: ".txt"}, Cwd: dir, Stdin: &text}
+
+	cache := map[string]*daemonLinter{}
+	for i := 0; i < 2; i++ {
+		client, server := net.Pipe()
+
+		done := make(chan bool)
+		go func() { done <- serveRequest(server, cache) }()
+
+		resp, sendErr := sendRequest(client, req)
+		if sendErr != nil {
+			t.Fatal(sendErr)
+		} else if <-done {
+			t.Fatal("Expected the daemon to keep running")
+		} else if resp.Error != "" {
+			t.Fatal(resp.Error)
+		}
+
+		checks := []string{}
+		for _, f := range resp.Files {
+			for _, a := range f.Alerts {
+				checks = append(checks, a.Check)
+			}
+		}
+		if actual := strings.Join(checks, ", "); actual != "Vale.Repetition" {
+			t.Errorf("Expected 'Vale.Repetition', got '%s'", actual)
+		}
+	}
+
+	if len(cache) != 1 {
+		t.Errorf("Expected one cached linter, got %d", len(cache))
+	}
+}
+
+func TestDaemonEnv(t *testing.T) {
+	for _, name := range daemonEnv {
+		// Restore the test's environment afterward.
+		t.Setenv(name, "")
+	}
+
+	dir := t.TempDir()
+
+	vale := filepath.Join(dir, "vale.ini")
+	if err := os.WriteFile(vale, []byte("[*]\nBasedOnStyles = Vale\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	none := filepath.Join(dir, "none.ini")
+	if err := os.WriteFile(none, []byte("[*]\nBasedOnStyles =\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd) //nolint:errcheck
+
+	text := "This is is a test."
+	cache := map[string]*daemonLinter{}
+
+	for _, tc := range []struct {
+		config string
+		alerts int
+	}{
+		{vale, 1},
+		{none, 0},
+	} {
+		req := daemonRequest{
+			Flags: core.CLIFlags{InExt: ".txt"},
+			Cwd:   dir,
+			Env:   map[string]string{"VALE_CONFIG_PATH": tc.config},
+			Stdin: &text,
+		}
+
+		files, lintErr := daemonLint(req, cache)
+		if lintErr != nil {
+			t.Fatal(lintErr)
+		}
+
+		alerts := 0
+		for _, f := range files {
+			alerts += len(f.Alerts)
+		}
+		if alerts != tc.alerts {
+			t.Errorf("%s: expected %d alerts, got %d", filepath.Base(tc.config), tc.alerts, alerts)
+		}
+	}
+
+	if len(cache) != 2 {
+		t.Errorf("Expected a linter for each environment, got %d", len(cache))
+	}
+}
+
+func TestDaemonServeRequestTimeout(t *testing.T) {
+	timeout := daemonTimeout
+	daemonTimeout = 50 * time.Millisecond
+	defer func() { daemonTimeout = timeout }()
+
+	client, server := net.Pipe()
+	defer client.Close()
+
+	done := make(chan bool)
+	go func() { done <- serveRequest(server, map[string]*daemonLinter{}) }()
+
+	select {
+	case stop := <-done:
+		if stop {
+			t.Error("Expected the daemon to keep running")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a silent client to time out")
+	}
+}
+
+func TestCachedLinter(t *testing.T) {
+	ini := filepath.Join(t.TempDir(), ".vale.ini")
+	if err := os.WriteFile(ini, []byte("[*]\nBasedOnStyles = Vale\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ConfigFiles = []string{ini}
+
+	cache := map[string]*daemonLinter{}
+
+	first, err := cachedLinter(cache, "project", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := cachedLinter(cache, "project", cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if again != first {
+		t.Error("Expected an unchanged configuration to reuse the cached linter")
+	}
+
+	later := time.Now().Add(time.Hour)
+	if err = os.Chtimes(ini, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := cachedLinter(cache, "project", cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if changed == first {
+		t.Error("Expected a changed configuration to create a new linter")
+	}
+
+	for i := 0; i < daemonCacheSize; i++ {
+		if _, err = cachedLinter(cache, strconv.Itoa(i), cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(cache) != daemonCacheSize {
+		t.Errorf("Expected at most %d cached linters, got %d", daemonCacheSize, len(cache))
+	} else if _, found := cache["project"]; found {
+		t.Error("Expected the least recently used linter to be evicted")
+	}
+}
+
+func TestDaemonStop(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+	xdg.Reload()
+	defer xdg.Reload()
+
+	stopped := make(chan error)
+	go func() { stopped <- runDaemon() }()
+
+	var conn net.Conn
+	var err error
+	for i := 0; i < 40; i++ {
+		if conn, err = dialDaemon(); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := sendRequest(conn, daemonRequest{Stop: true})
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.Error != "" {
+		t.Fatal(resp.Error)
+	}
+
+	select {
+	case err = <-stopped:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the daemon to stop")
+	}
+
+	if _, err = dialDaemon(); err == nil {
+		t.Error("Expected the daemon to no longer accept connections")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// editorAlertLimit is the maximum number of alerts `--editor` reports for a
+// single file.
+const editorAlertLimit = 100
+
+// EditorResult is a single line of `--editor` output.
+//
+// Results are written as JSON Lines, one per file, as soon as each file has
+// been linted. Alert columns are counted in UTF-16 code units, as expected by
+// most editors (and the Language Server Protocol).
+type EditorResult struct {
+	Path    string
+	Alerts  []core.Alert
+	Omitted int // alerts left out to stay within the limit
+}
+
+func newEditorResult(f *core.File, config *core.Config) EditorResult {
+	alerts, omitted := sampleAlerts(f.SortedAlerts(), editorAlertLimit)
+	if alerts == nil {
+		alerts = []core.Alert{}
+	}
+
+	lines := f.Lines
+	if len(lines) == 0 && len(alerts) > 0 {
+		// Stored results (see `--checkpoint`) don't include the file's text.
+		if b, err := os.ReadFile(f.Path); err == nil {
+			lines = strings.SplitAfter(string(b), "\n")
+		}
+	}
+
+	for i := range alerts {
+		if a := &alerts[i]; a.Line > 0 && a.Line <= len(lines) {
+			a.Span = utf16Span(lines[a.Line-1], a.Span)
+		}
+	}
+	limitAlerts(alerts, config)
+
+	return EditorResult{Path: f.Path, Alerts: alerts, Omitted: omitted}
+}
+
+// sampleAlerts returns at most `limit` alerts, preferring the most severe, in
+// their original order. It also returns the number of alerts left out.
+func sampleAlerts(alerts []core.Alert, limit int) ([]core.Alert, int) {
+	if len(alerts) <= limit {
+		return alerts, 0
+	}
+
+	idx := make([]int, len(alerts))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		return core.LevelToInt[alerts[idx[i]].Severity] > core.LevelToInt[alerts[idx[j]].Severity]
+	})
+
+	kept := idx[:limit]
+	sort.Ints(kept)
+
+	sampled := make([]core.Alert, 0, limit)
+	for _, i := range kept {
+		sampled = append(sampled, alerts[i])
+	}
+
+	return sampled, len(alerts) - limit
+}
+
+// utf16Span converts a 1-based, inclusive span of rune columns on `line` to
+// UTF-16 code units.
+func utf16Span(line string, span []int) []int {
+	runes := []rune(line)
+	if len(span) != 2 || span[0] < 1 || span[1] > len(runes) {
+		return span
+	}
+
+	start := len(utf16.Encode(runes[:span[0]-1])) + 1
+	end := len(utf16.Encode(runes[:span[1]]))
+
+	return []int{start, end}
+}
+
+// printEditorResults finishes a `--editor` run, reporting whether or not
+// there were any errors.
+//
+// Results are usually streamed as each file is linted (see `OnLinted`), so we
+// only print them here if they came from a daemon (see `--fast`).
+func printEditorResults(linted []*core.File, config *core.Config, served bool) (bool, error) {
+	hasErrors := false
+	for _, f := range linted {
+		if served {
+			if err := printEditorResult(os.Stdout, f, config); err != nil {
+				return hasErrors, err
+			}
+		}
+		for _, a := range f.Alerts {
+			if a.Severity == "error" {
+				hasErrors = true
+			}
+		}
+	}
+	return hasErrors, nil
+}
+
+// printEditorResult writes the results for `f` as a single line of JSON.
+func printEditorResult(w io.Writer, f *core.File, config *core.Config) error {
+	b, err := json.Marshal(newEditorResult(f, config))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
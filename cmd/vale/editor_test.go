@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestUTF16Span(t *testing.T) {
+	cases := []struct {
+		line     string
+		span     []int
+		expected []int
+	}{
+		{"Some text.\n", []int{6, 9}, []int{6, 9}},
+		{"Ünïcode 😀 text.\n", []int{11, 14}, []int{12, 15}},
+		{"😀😀 text.\n", []int{1, 2}, []int{1, 4}},
+		{"Short.\n", []int{40, 45}, []int{40, 45}},
+	}
+
+	for _, tc := range cases {
+		if got := utf16Span(tc.line, tc.span); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%q %v: expected %v, got %v", tc.line, tc.span, tc.expected, got)
+		}
+	}
+}
+
+func TestSampleAlerts(t *testing.T) {
+	var alerts []core.Alert
+	for i, level := range []string{"suggestion", "error", "warning", "error", "suggestion"} {
+		alerts = append(alerts, core.Alert{Line: i + 1, Severity: level})
+	}
+
+	sampled, omitted := sampleAlerts(alerts, 3)
+	if omitted != 2 {
+		t.Errorf("expected 2 omitted alerts, got %d", omitted)
+	}
+
+	var lines []int
+	for _, a := range sampled {
+		lines = append(lines, a.Line)
+	}
+
+	if !reflect.DeepEqual(lines, []int{2, 3, 4}) {
+		t.Errorf("expected the most severe alerts in order, got lines %v", lines)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// fixableActions are the actions that `--fix` applies: each of them computes
+// its replacement from the alert alone (unlike, e.g., `suggest`, which offers
+// a list of spellings to choose from).
+var fixableActions = []string{"replace", "remove", "convert", "edit"}
+
+// fixEdit replaces the (0-based, exclusive) rune columns [start, end) of a
+// line.
+type fixEdit struct {
+	line  int
+	start int
+	end   int
+	text  string
+	match string
+}
+
+// fixResult summarizes the changes made by `--fix`.
+type fixResult struct {
+	Fixed    int      // the number of alerts fixed
+	Files    int      // the number of files changed
+	Reverted []string // files restored since their fixes caused new alerts
+}
+
+// alertEdit returns the edit that fixes `a`, if it has exactly one possible
+// replacement.
+//
+// Alerts from rules without an `action` may still be fixable if their rule
+// implies one (see `check.ImpliedAction`).
+func alertEdit(a core.Alert, mgr *check.Manager) (fixEdit, bool) {
+	if a.Hide || len(a.Span) != 2 {
+		return fixEdit{}, false
+	} else if a.Action.Name == "" {
+		if action, ok := check.ImpliedAction(a, mgr.Rules()[a.Check]); ok {
+			a.Action = action
+		}
+	}
+
+	if !core.StringInSlice(a.Action.Name, fixableActions) {
+		return fixEdit{}, false
+	}
+
+	suggestions, err := check.FixAlert(a, mgr.Config)
+	if err != nil || len(suggestions) != 1 || suggestions[0] == a.Match {
+		return fixEdit{}, false
+	}
+
+	return fixEdit{
+		line: a.Line, start: a.Span[0] - 1, end: a.Span[1],
+		text: suggestions[0], match: a.Match}, true
+}
+
+// fixContent applies the fixes for `alerts` to `content`, returning the new
+// content and the number of alerts that were fixed.
+//
+// An edit is skipped if the text at its location isn't the alert's match
+// (e.g., a match that spans inline markup) or if it overlaps another edit.
+func fixContent(content string, alerts []core.Alert, mgr *check.Manager) (string, int) {
+	var edits []fixEdit
+	for _, a := range alerts {
+		if e, ok := alertEdit(a, mgr); ok {
+			edits = append(edits, e)
+		}
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].line != edits[j].line {
+			return edits[i].line < edits[j].line
+		}
+		return edits[i].start < edits[j].start
+	})
+
+	lines := strings.Split(content, "\n")
+	byLine := map[int][]fixEdit{}
+
+	fixed := 0
+	for i, e := range edits {
+		if e.line < 1 || e.line > len(lines) {
+			continue
+		} else if i > 0 && edits[i-1].line == e.line && edits[i-1].end > e.start {
+			continue
+		}
+
+		runes := []rune(lines[e.line-1])
+		if e.start < 0 || e.end > len(runes) || e.start >= e.end {
+			continue
+		} else if string(runes[e.start:e.end]) != e.match {
+			continue
+		}
+
+		byLine[e.line] = append(byLine[e.line], e)
+		fixed++
+	}
+
+	for line, lineEdits := range byLine {
+		runes := []rune(lines[line-1])
+		// NOTE: We apply each line's edits from right to left so that their
+		// columns remain valid.
+		for i := len(lineEdits) - 1; i >= 0; i-- {
+			e := lineEdits[i]
+			runes = append(runes[:e.start], append([]rune(e.text), runes[e.end:]...)...)
+		}
+		lines[line-1] = string(runes)
+	}
+
+	return strings.Join(lines, "\n"), fixed
+}
+
+// applyFixes rewrites each of the linted files with its fixes applied (see
+// `--fix`), returning the files' alerts after re-linting them.
+//
+// If a file has more alerts for any rule after being fixed -- e.g., if one
+// rule's replacement is flagged by another rule -- we restore its original
+// content and keep its original alerts.
+func applyFixes(linted []*core.File, linter *lint.Linter) ([]*core.File, fixResult, error) {
+	result := fixResult{}
+
+	updated := make([]*core.File, 0, len(linted))
+	for _, f := range linted {
+		info, err := os.Stat(f.Path)
+		if err != nil || info.IsDir() {
+			// This was linted from stdin or a string.
+			updated = append(updated, f)
+			continue
+		}
+
+		original, err := os.ReadFile(f.Path)
+		if err != nil {
+			return linted, result, core.NewE100("--fix", err)
+		}
+
+		content, fixed := fixContent(string(original), f.Alerts, linter.Manager)
+		if fixed == 0 {
+			updated = append(updated, f)
+			continue
+		}
+
+		perm := info.Mode().Perm()
+		if err = os.WriteFile(f.Path, []byte(content), perm); err != nil {
+			return linted, result, core.NewE100("--fix", err)
+		}
+
+		relinted, err := linter.Lint([]string{f.Path}, "*")
+		if err != nil {
+			return linted, result, err
+		} else if len(relinted) != 1 || hasNewAlerts(f.Alerts, relinted[0].Alerts) {
+			if err = os.WriteFile(f.Path, original, perm); err != nil {
+				return linted, result, core.NewE100("--fix", err)
+			}
+			result.Reverted = append(result.Reverted, f.Path)
+			updated = append(updated, f)
+			continue
+		}
+
+		relinted[0].Path = f.Path
+		updated = append(updated, relinted[0])
+
+		result.Fixed += fixed
+		result.Files++
+	}
+
+	return updated, result, nil
+}
+
+// hasNewAlerts reports whether any rule has more alerts in `after` than it
+// did in `before`.
+func hasNewAlerts(before, after []core.Alert) bool {
+	counts := map[string]int{}
+	for _, a := range before {
+		counts[a.Check]++
+	}
+
+	for _, a := range after {
+		counts[a.Check]--
+		if counts[a.Check] < 0 {
+			return true
+		}
+	}
+
+	return false
+}
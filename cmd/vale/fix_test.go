@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestFixContent(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mgr, err := check.NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replace := func(params ...string) core.Action {
+		return core.Action{Name: "replace", Params: params}
+	}
+
+	content := "Send an e-mail to Müller.\r\nUtilize the data base.\n"
+	alerts := []core.Alert{
+		{Line: 1, Span: []int{9, 14}, Match: "e-mail", Action: replace("email")},
+		{Line: 2, Span: []int{1, 7}, Match: "Utilize", Action: replace("Use")},
+		// Ambiguous:
+		{Line: 2, Span: []int{13, 21}, Match: "data base", Action: replace("database", "databank")},
+		// Overlaps the first edit:
+		{Line: 1, Span: []int{9, 10}, Match: "e-", Action: core.Action{Name: "remove"}},
+		// Stale location:
+		{Line: 1, Span: []int{19, 24}, Match: "Mueller", Action: replace("Miller")},
+		// Not an automatic fix:
+		{Line: 1, Span: []int{1, 4}, Match: "Send", Action: core.Action{Name: "suggest", Params: []string{"spellings"}}},
+	}
+
+	fixed, count := fixContent(content, alerts, mgr)
+
+	expected := "Send an email to Müller.\r\nUse the data base.\n"
+	if fixed != expected {
+		t.Errorf("Expected %q, got %q", expected, fixed)
+	} else if count != 2 {
+		t.Errorf("Expected 2 fixes, got %d", count)
+	}
+}
+
+func TestFixContentImplied(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mgr, err := check.NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, rule := range map[string]string{
+		"Swap":  "extends: substitution\nmessage: Use '%s' instead of '%s'.\nswap:\n  leverage: use\n  utilize: use|employ\n",
+		"Title": "extends: capitalization\nmessage: \"'%s' should be in title case.\"\nmatch: $title\nscope: heading\n",
+	} {
+		path := filepath.Join(dir, name+".yml")
+		if err = os.WriteFile(path, []byte(rule), 0o600); err != nil {
+			t.Fatal(err)
+		} else if err = mgr.AddRuleFromFile("Test."+name, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content := "# An introduction to vale\n\nWe leverage and utilize it.\n"
+	alerts := []core.Alert{
+		{Check: "Test.Title", Line: 1, Span: []int{3, 25}, Match: "An introduction to vale"},
+		{Check: "Test.Swap", Line: 3, Span: []int{4, 11}, Match: "leverage"},
+		// Ambiguous:
+		{Check: "Test.Swap", Line: 3, Span: []int{17, 23}, Match: "utilize"},
+	}
+
+	fixed, count := fixContent(content, alerts, mgr)
+
+	expected := "# An Introduction to Vale\n\nWe use and utilize it.\n"
+	if fixed != expected {
+		t.Errorf("Expected %q, got %q", expected, fixed)
+	} else if count != 2 {
+		t.Errorf("Expected 2 fixes, got %d", count)
+	}
+}
+
+func TestHasNewAlerts(t *testing.T) {
+	before := []core.Alert{{Check: "A"}, {Check: "A"}, {Check: "B"}}
+
+	if hasNewAlerts(before, []core.Alert{{Check: "A"}, {Check: "B"}}) {
+		t.Error("Expected fewer alerts not to be a regression")
+	} else if !hasNewAlerts(before, []core.Alert{{Check: "C"}}) {
+		t.Error("Expected an alert from a new rule to be a regression")
+	}
+}
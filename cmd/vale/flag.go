@@ -22,14 +22,46 @@ func init() {
 	pflag.StringVar(&Flags.Glob, "glob", "*",
 		fmt.Sprintf(`A glob pattern (%s)`, toCodeStyle(`--glob='*.{md,txt}.'`)))
 	pflag.StringVar(&Flags.Path, "config", "",
-		fmt.Sprintf(`A file path (%s).`, toCodeStyle(`--config='some/file/path/.vale.ini'`)))
-	pflag.StringVar(&Flags.Output, "output", "CLI", `An output style ("line", "JSON", or a template file).`)
+		fmt.Sprintf(`A file path (%s) or %s to read from stdin.`,
+			toCodeStyle(`--config='some/file/path/.vale.ini'`), toCodeStyle(`-`)))
+	pflag.StringVar(&Flags.Output, "output", "CLI", `An output style ("line", "JSON", "codeclimate", "teamcity", "azure", "github", "junit", "sqlite:<file>", or "template=<file>").`)
 	pflag.StringVar(&Flags.InExt, "ext", ".txt",
 		fmt.Sprintf(`An extension to associate with stdin (%s).`, toCodeStyle(`--ext=.md`)))
+	pflag.StringVar(&Flags.Checkpoint, "checkpoint", "",
+		fmt.Sprintf(`A file in which to record progress, allowing an interrupted run to resume (%s).`,
+			toCodeStyle(`--checkpoint=state.db`)))
+	pflag.StringVar(&Flags.Shard, "shard", "",
+		fmt.Sprintf(`Only lint the given shard of the files, for parallel CI jobs (%s).`, toCodeStyle(`--shard=2/8`)))
+	pflag.StringVar(&Flags.Profile, "profile", "",
+		fmt.Sprintf(`A preset that selects rules by cost: 'quick' skips spelling and NLP-heavy rules (%s).`,
+			toCodeStyle(`--profile=quick`)))
+	pflag.StringVar(&Flags.Webhook, "webhook", "",
+		fmt.Sprintf(`A URL to POST the JSON results of each run to (%s).`,
+			toCodeStyle(`--webhook=https://example.com/vale`)))
+	pflag.StringVar(&Flags.Selector, "selector", "",
+		fmt.Sprintf(`A CSS selector for the content area of any URLs being linted (%s).`,
+			toCodeStyle(`--selector='main article'`)))
+	pflag.StringVar(&Flags.CrashBundle, "crash-bundle", "",
+		fmt.Sprintf(`A directory in which to save diagnostics for any file that crashed a rule (%s).`,
+			toCodeStyle(`--crash-bundle=crashes`)))
+	pflag.StringVar(&Flags.Why, "why", "",
+		fmt.Sprintf(`Explain how a rule evaluated a given line (%s).`,
+			toCodeStyle(`--why=Vale.Spelling:README.md:12`)))
+
+	pflag.DurationVar(&Flags.MaxDuration, "max-duration", 0,
+		fmt.Sprintf(`A time budget after which no new files are linted, reporting partial results (%s).`,
+			toCodeStyle(`--max-duration=10m`)))
+	pflag.IntVar(&Flags.Jobs, "jobs", 0,
+		fmt.Sprintf(`The number of files to lint at once, defaulting to the number of CPUs (%s).`,
+			toCodeStyle(`--jobs=4`)))
 
 	pflag.StringVar(&Flags.AlertLevel, "minAlertLevel", "",
 		fmt.Sprintf(`The minimum level to display (%s).`, toCodeStyle(`--minAlertLevel=error`)))
 
+	pflag.StringVar(&Flags.Color, "color", "auto",
+		`When to use colors ("auto", "always", or "never"); "auto" respects NO_COLOR.`)
+	pflag.StringVar(&Flags.Theme, "theme", "default", `The CLI output's colors ("default" or "high-contrast").`)
+	pflag.BoolVar(&Flags.NoColor, "no-color", false, `Don't use colors (same as --color=never).`)
 	pflag.BoolVar(&Flags.Wrap, "no-wrap", false, "Don't wrap CLI output.")
 	pflag.BoolVar(&Flags.NoExit, "no-exit", false, "Don't return a nonzero exit code on errors.")
 	pflag.BoolVar(&Flags.Simple, "ignore-syntax", false, "Lint all files line-by-line.")
@@ -41,4 +73,45 @@ func init() {
 	pflag.BoolVar(&Flags.Normalize, "normalize", false, "replace each path separator with a slash ('/')")
 	pflag.BoolVar(&Flags.Relative, "relative", false, "return relative paths")
 	pflag.BoolVar(&Flags.IgnoreGlobal, "no-global", false, "Don't load the global configuration.")
+	pflag.BoolVar(&Flags.Fast, "fast", false,
+		"Lint using a background daemon that keeps styles loaded between runs.")
+	pflag.BoolVar(&Flags.Compare, "compare", false,
+		fmt.Sprintf(`Compare the outlines of two files (%s).`, toCodeStyle(`outline`)))
+	pflag.BoolVar(&Flags.Check, "check", false,
+		fmt.Sprintf(`Report, rather than fix, unformatted rules (%s only).`, toCodeStyle(`fmt-styles`)))
+	pflag.StringVar(&Flags.Format, "format", "",
+		fmt.Sprintf(`Lint the input as a fragment rather than a file (%s).`, toCodeStyle(`--format=gfm-comment`)))
+	pflag.BoolVar(&Flags.Verbose, "verbose", false,
+		"Show every alert in CLI output, rather than folding repeated ones.")
+	pflag.BoolVar(&Flags.Editor, "editor", false,
+		"Stream capped, UTF-16-positioned JSON results for editor integrations.")
+	pflag.BoolVar(&Flags.PrintScopes, "print-scopes", false,
+		fmt.Sprintf(`Print each block of a file with its scope and the rules that see it (%s).`,
+			toCodeStyle(`--print-scopes README.md`)))
+	pflag.BoolVar(&Flags.Batch, "batch", false,
+		"Lint documents framed by 'Path' and 'Content-Length' headers on stdin, returning framed JSON.")
+	pflag.BoolVar(&Flags.Fix, "fix", false,
+		"Rewrite files in place, applying each alert's replacement when there's only one.")
+	pflag.BoolVar(&Flags.NoCache, "no-cache", false,
+		"Lint every file, rather than reusing the cached results of those that haven't changed.")
+	pflag.BoolVar(&Flags.AssignIDs, "assign-ids", false,
+		"Give each alert a stable ID and track its age in the project's '.vale-state.json' file.")
+	pflag.BoolVar(&Flags.Baseline, "baseline", false,
+		"Only report alerts that aren't recorded in the project's '.vale-baseline.json' file.")
+	pflag.BoolVar(&Flags.UpdateBaseline, "update-baseline", false,
+		"Record the current alerts in the project's '.vale-baseline.json' file.")
+	pflag.BoolVar(&Flags.RetryFailed, "retry-failed", false,
+		"Only lint the files (within the given paths, if any) that had alerts or errors when they were last linted.")
+	pflag.BoolVar(&Flags.NoWrite, "no-write", false,
+		"Never write to the filesystem, keeping the cache, alert history, and locks in memory.")
+	pflag.BoolVar(&Flags.StrictPackages, "strict-packages", false,
+		"Refuse to sync packages that aren't pinned to a checksum or signed (including local directories).")
+	pflag.BoolVar(&Flags.Stats, "stats", false,
+		fmt.Sprintf(`Include per-rule and per-scope statistics in JSON output (%s).`, toCodeStyle(`--output=JSON`)))
+	pflag.BoolVar(&Flags.Deterministic, "deterministic", false,
+		"Run rules, read vocabularies, and print files in a fixed order.")
+
+	pflag.Int64Var(&Flags.Shuffle, "shuffle", 0,
+		fmt.Sprintf(`Run rules in a random order, optionally seeded (%s).`, toCodeStyle(`--shuffle=42`)))
+	pflag.Lookup("shuffle").NoOptDefVal = "-1"
 }
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"golang.org/x/term"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// initPackages are the packaged styles offered by `vale init`.
+var initPackages = []string{
+	"Microsoft", "Google", "RedHat", "IBM", "proselint", "write-good",
+	"alex", "Readability", "Joblint",
+}
+
+// initCIs maps the CI services supported by `vale init` to the file (relative
+// to the project) that holds their configuration.
+var initCIs = map[string]string{
+	"GitHub Actions": filepath.Join(".github", "workflows", "vale.yml"),
+	"GitLab CI":      filepath.Join(".gitlab", "ci", "vale.yml"),
+}
+
+const githubWorkflow = `name: Vale
+on: [pull_request]
+
+jobs:
+  vale:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: errata-ai/vale-action@reviewdog
+`
+
+// gitlabJob reports alerts to GitLab's Code Quality widget; it needs to be
+// included from the project's `.gitlab-ci.yml` file.
+const gitlabJob = `vale:
+  image:
+    name: jdkato/vale
+    entrypoint: [""]
+  script:
+    - vale sync
+    - vale --output=codeclimate . > gl-code-quality-report.json || true
+  artifacts:
+    reports:
+      codequality: gl-code-quality-report.json
+`
+
+const stylesIgnore = `# Packages are installed by 'vale sync', so we only track our own files.
+*
+!.gitignore
+!config/
+!config/**
+`
+
+// initOptions are the choices made when scaffolding a new project.
+type initOptions struct {
+	StylesPath    string
+	MinAlertLevel string
+	Vocab         string
+	Packages      []string
+	CI            string // a key of `initCIs`, if any
+}
+
+func defaultInitOptions() initOptions {
+	return initOptions{
+		StylesPath:    "styles",
+		MinAlertLevel: "suggestion",
+		Vocab:         "Base",
+	}
+}
+
+func init() {
+	commandInfo["init"] = "Create a .vale.ini file, StylesPath, and Vocab for a new project."
+	Actions["init"] = initProject
+}
+
+// initProject scaffolds a Vale configuration in the current directory (or the
+// given one), asking the user for their choices if we're in a terminal.
+func initProject(args []string, _ *core.CLIFlags) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	ini := filepath.Join(dir, ".vale.ini")
+	if core.FileExists(ini) {
+		return core.NewE100("init", fmt.Errorf("'%s' already exists", ini))
+	}
+
+	opts := defaultInitOptions()
+
+	interactive := term.IsTerminal(int(os.Stdin.Fd()))
+	if interactive {
+		if err := askInitOptions(&opts); err != nil {
+			return core.NewE100("init", err)
+		}
+	}
+
+	created, err := writeProject(dir, opts)
+	if err != nil {
+		return core.NewE100("init", err)
+	}
+
+	for _, path := range created {
+		pterm.Success.Printfln("Created '%s'.", path)
+	}
+
+	if opts.CI == "GitLab CI" {
+		pterm.Info.Printfln("Include '%s' from your .gitlab-ci.yml file.", initCIs[opts.CI])
+	}
+
+	if len(opts.Packages) == 0 {
+		return nil
+	}
+
+	download := false
+	if interactive {
+		download, _ = pterm.DefaultInteractiveConfirm.
+			WithDefaultValue(true).
+			Show("Download the selected packages now?")
+	}
+
+	if !download {
+		pterm.Info.Println("Run 'vale sync' to download the selected packages.")
+		return nil
+	}
+
+	return sync(nil, &core.CLIFlags{Path: ini})
+}
+
+// askInitOptions prompts the user for each of the project's options.
+func askInitOptions(opts *initOptions) error {
+	var err error
+
+	opts.StylesPath, err = pterm.DefaultInteractiveTextInput.
+		WithDefaultValue(opts.StylesPath).
+		Show("Where should styles be stored (StylesPath)?")
+	if err != nil {
+		return err
+	}
+
+	opts.MinAlertLevel, err = pterm.DefaultInteractiveSelect.
+		WithOptions(core.AlertLevels).
+		WithDefaultOption(opts.MinAlertLevel).
+		Show("What's the minimum alert level to report?")
+	if err != nil {
+		return err
+	}
+
+	opts.Packages, err = pterm.DefaultInteractiveMultiselect.
+		WithOptions(initPackages).
+		Show("Which packaged styles would you like to use?")
+	if err != nil {
+		return err
+	}
+
+	opts.Vocab, err = pterm.DefaultInteractiveTextInput.
+		WithDefaultValue(opts.Vocab).
+		Show("What should your project's vocabulary be called?")
+	if err != nil {
+		return err
+	}
+
+	ci, err := pterm.DefaultInteractiveSelect.
+		WithOptions([]string{"None", "GitHub Actions", "GitLab CI"}).
+		WithDefaultOption("None").
+		Show("Would you like to run Vale in CI?")
+	if err != nil {
+		return err
+	} else if ci != "None" {
+		opts.CI = ci
+	}
+
+	return nil
+}
+
+// writeProject writes the configuration files for a new project, returning
+// the paths it created.
+func writeProject(dir string, opts initOptions) ([]string, error) {
+	var created []string
+
+	if strings.TrimSpace(opts.StylesPath) == "" || strings.TrimSpace(opts.Vocab) == "" {
+		return created, errors.New("StylesPath and Vocab can't be empty")
+	} else if !core.StringInSlice(opts.MinAlertLevel, core.AlertLevels) {
+		return created, fmt.Errorf("'%s' must be one of %v", opts.MinAlertLevel, core.AlertLevels)
+	}
+
+	files := map[string]string{
+		".vale.ini": makeINI(opts),
+		filepath.Join(opts.StylesPath, ".gitignore"):                            stylesIgnore,
+		filepath.Join(opts.StylesPath, core.VocabDir, opts.Vocab, "accept.txt"): "",
+		filepath.Join(opts.StylesPath, core.VocabDir, opts.Vocab, "reject.txt"): "",
+	}
+
+	switch opts.CI {
+	case "GitHub Actions":
+		files[initCIs[opts.CI]] = githubWorkflow
+	case "GitLab CI":
+		files[initCIs[opts.CI]] = gitlabJob
+	}
+
+	// We write the files in a fixed order so that our output is predictable.
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		full := filepath.Join(dir, path)
+		if core.FileExists(full) {
+			// We never overwrite anything (e.g., an existing vocabulary).
+			continue
+		} else if err := os.MkdirAll(filepath.Dir(full), os.ModePerm); err != nil {
+			return created, err
+		} else if err = os.WriteFile(full, []byte(files[path]), 0o600); err != nil {
+			return created, err
+		}
+		created = append(created, full)
+	}
+
+	return created, nil
+}
+
+// makeINI returns the contents of a new project's `.vale.ini` file.
+func makeINI(opts initOptions) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "StylesPath = %s\n\n", filepath.ToSlash(opts.StylesPath))
+	fmt.Fprintf(&b, "MinAlertLevel = %s\n\n", opts.MinAlertLevel)
+	fmt.Fprintf(&b, "Vocab = %s\n\n", opts.Vocab)
+
+	if len(opts.Packages) > 0 {
+		fmt.Fprintf(&b, "Packages = %s\n\n", strings.Join(opts.Packages, ", "))
+	}
+
+	styles := append([]string{"Vale"}, opts.Packages...)
+	fmt.Fprintf(&b, "[*]\nBasedOnStyles = %s\n", strings.Join(styles, ", "))
+
+	return b.String()
+}
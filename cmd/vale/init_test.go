@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteProject(t *testing.T) {
+	dir := t.TempDir()
+
+	opts := defaultInitOptions()
+	opts.Packages = []string{"Microsoft"}
+	opts.CI = "GitHub Actions"
+
+	created, err := writeProject(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(created) != 5 {
+		t.Fatalf("expected 5 files, got %v", created)
+	}
+
+	ini, err := os.ReadFile(filepath.Join(dir, ".vale.ini"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"Packages = Microsoft", "BasedOnStyles = Vale, Microsoft"} {
+		if !strings.Contains(string(ini), want) {
+			t.Errorf("expected '%s' in:\n%s", want, ini)
+		}
+	}
+
+	accept := filepath.Join(dir, "styles", "config", "vocabularies", "Base", "accept.txt")
+	if err = os.WriteFile(accept, []byte("Vale\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second run shouldn't overwrite anything.
+	created, err = writeProject(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(created) != 0 {
+		t.Errorf("expected no new files, got %v", created)
+	}
+
+	content, err := os.ReadFile(accept)
+	if err != nil {
+		t.Fatal(err)
+	} else if string(content) != "Vale\n" {
+		t.Errorf("'%s' was overwritten", accept)
+	}
+}
+
+func TestWriteProjectInvalid(t *testing.T) {
+	for _, opts := range []initOptions{
+		{StylesPath: "", MinAlertLevel: "warning", Vocab: "Base"},
+		{StylesPath: "styles", MinAlertLevel: "warning", Vocab: " "},
+		{StylesPath: "styles", MinAlertLevel: "fatal", Vocab: "Base"},
+	} {
+		if _, err := writeProject(t.TempDir(), opts); err == nil {
+			t.Errorf("expected an error for %+v", opts)
+		}
+	}
+}
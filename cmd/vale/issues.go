@@ -0,0 +1,450 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/spf13/pflag"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// issueLabel is the label applied to every exported issue; it's also used to
+// limit the search for existing issues.
+const issueLabel = "vale"
+
+// The environment variables holding tracker credentials.
+const (
+	gitHubTokenEnv = "GITHUB_TOKEN"
+	jiraUserEnv    = "JIRA_USER"
+	jiraTokenEnv   = "JIRA_TOKEN"
+)
+
+// reIssueFingerprint finds the fingerprint of an exported issue in its body.
+var reIssueFingerprint = regexp.MustCompile(`vale-fingerprint: ([0-9a-f]+)`)
+
+var defaultIssueTemplate = template.Must(template.New("issue").Funcs(sprig.TxtFuncMap()).Parse(
+	`Vale reported {{ len .Alerts }} {{ if eq (len .Alerts) 1 }}alert{{ else }}alerts{{ end }} ` +
+		`{{ if eq .GroupBy "rule" }}for ` + "`{{ .Key }}`" + `{{ else }}in ` + "`{{ .Key }}`" + `{{ end }}:
+
+{{ range .Alerts -}}
+- {{ if eq $.GroupBy "rule" }}{{ .Path }}{{ else }}{{ .Check }}{{ end }}:{{ .Line }}:{{ index .Span 0 }} ({{ .Severity }}): {{ .Message }}
+{{ end }}`))
+
+func init() {
+	pflag.StringVar(&Flags.Tracker, "tracker", "github",
+		fmt.Sprintf(`The issue tracker for %s ("github" or "jira").`, toCodeStyle(`export-issues`)))
+	pflag.StringVar(&Flags.TrackerURL, "tracker-url", "",
+		`The base URL of the issue tracker's API (e.g., "https://example.atlassian.net").`)
+	pflag.StringVar(&Flags.Project, "project", "",
+		`The repository ("owner/name") or project key to file issues in.`)
+	pflag.StringVar(&Flags.GroupBy, "group-by", "file",
+		`How to group alerts into issues ("file" or "rule").`)
+	pflag.StringVar(&Flags.IssueTemplate, "issue-template", "",
+		"A template file for the body of each issue.")
+	pflag.BoolVar(&Flags.DryRun, "dry-run", false,
+		"Print the issues that would be filed without filing them.")
+
+	commandInfo["export-issues"] = "File the alerts in the given files as GitHub or Jira issues."
+	Actions["export-issues"] = exportIssues
+}
+
+// IssueAlert is an alert and the file it was reported in.
+type IssueAlert struct {
+	core.Alert
+	Path string
+}
+
+// IssueGroup is a set of alerts that's filed as a single issue; it's the data
+// available to `--issue-template`.
+type IssueGroup struct {
+	Key     string // the file path or rule name
+	GroupBy string // "file" or "rule"
+	Alerts  []IssueAlert
+}
+
+// Issue is a tracker-agnostic issue.
+type Issue struct {
+	Title       string
+	Body        string
+	Labels      []string
+	Fingerprint string
+	Exists      bool   `json:",omitempty"` // an open issue already has this fingerprint
+	URL         string `json:",omitempty"`
+}
+
+// issueTracker files issues in a specific tracker.
+type issueTracker interface {
+	// fingerprints returns the fingerprints of the tracker's open issues.
+	fingerprints() (map[string]bool, error)
+	// create files an issue, returning its URL.
+	create(issue Issue) (string, error)
+}
+
+// exportIssues files an issue for each group of alerts (see `--group-by`),
+// skipping any group that already has an open issue.
+//
+// Each issue's body includes a fingerprint of its group (its file or rule),
+// which is how we recognize issues filed by earlier runs.
+func exportIssues(args []string, flags *core.CLIFlags) error {
+	if len(args) == 0 {
+		return core.NewE100("export-issues", errors.New("at least one argument expected"))
+	} else if flags.GroupBy != "file" && flags.GroupBy != "rule" {
+		return core.NewE100("export-issues", fmt.Errorf(
+			"'--group-by' must be 'file' or 'rule', not '%s'", flags.GroupBy))
+	}
+
+	var tracker issueTracker
+	if flags.Project != "" || !flags.DryRun {
+		t, err := newIssueTracker(flags)
+		if err != nil {
+			return core.NewE100("export-issues", err)
+		}
+		tracker = t
+	}
+
+	tmpl := defaultIssueTemplate
+	if flags.IssueTemplate != "" {
+		b, err := os.ReadFile(flags.IssueTemplate)
+		if err != nil {
+			return core.NewE100("export-issues", err)
+		}
+		tmpl, err = template.New(filepath.Base(flags.IssueTemplate)).Funcs(
+			sprig.TxtFuncMap()).Funcs(funcs).Parse(string(b))
+		if err != nil {
+			return core.NewE100("export-issues", err)
+		}
+	}
+
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	linted, err := linter.Lint(args, flags.Glob)
+	if err != nil {
+		return err
+	}
+
+	issues, err := buildIssues(groupAlerts(linted, flags.GroupBy), flags, tmpl)
+	if err != nil {
+		return core.NewE100("export-issues", err)
+	}
+
+	return fileIssues(issues, tracker, flags.DryRun)
+}
+
+// fileIssues files each issue that doesn't already exist, or -- in a dry
+// run -- prints them all.
+func fileIssues(issues []Issue, tracker issueTracker, dryRun bool) error {
+	existing := map[string]bool{}
+	if tracker != nil {
+		found, err := tracker.fingerprints()
+		if err != nil {
+			return core.NewE100("export-issues", err)
+		}
+		existing = found
+	}
+
+	for i := range issues {
+		issues[i].Exists = existing[issues[i].Fingerprint]
+	}
+
+	if dryRun {
+		return printJSON(issues)
+	}
+
+	created := 0
+	for _, issue := range issues {
+		if issue.Exists {
+			continue
+		}
+		u, err := tracker.create(issue)
+		if err != nil {
+			return core.NewE100("export-issues", err)
+		}
+		fmt.Printf("Created %s (%s)\n", u, issue.Title)
+		created++
+	}
+
+	fmt.Printf("Filed %d new %s; %d already open.\n",
+		created, pluralize("issue", created), len(issues)-created)
+	return nil
+}
+
+// groupAlerts groups the alerts in `linted` by file or by rule, sorted by
+// key.
+func groupAlerts(linted []*core.File, groupBy string) []IssueGroup {
+	byKey := map[string]*IssueGroup{}
+	for _, f := range linted {
+		path := reportPath(f.Path)
+		for _, a := range f.SortedAlerts() {
+			key := path
+			if groupBy == "rule" {
+				key = a.Check
+			}
+			if _, found := byKey[key]; !found {
+				byKey[key] = &IssueGroup{Key: key, GroupBy: groupBy}
+			}
+			byKey[key].Alerts = append(byKey[key].Alerts, IssueAlert{Alert: a, Path: path})
+		}
+	}
+
+	groups := []IssueGroup{}
+	for _, group := range byKey {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Key < groups[j].Key
+	})
+
+	return groups
+}
+
+// buildIssues renders an issue for each group.
+func buildIssues(groups []IssueGroup, flags *core.CLIFlags, tmpl *template.Template) ([]Issue, error) {
+	issues := []Issue{}
+	for _, group := range groups {
+		var body bytes.Buffer
+		if err := tmpl.Execute(&body, group); err != nil {
+			return nil, err
+		}
+
+		fingerprint := issueFingerprint(flags.Project, group)
+		marker := "<!-- vale-fingerprint: " + fingerprint + " -->"
+		if flags.Tracker == "jira" {
+			// Jira's markup doesn't have comments.
+			marker = "{color:gray}vale-fingerprint: " + fingerprint + "{color}"
+		}
+
+		preposition := "in"
+		if group.GroupBy == "rule" {
+			preposition = "for"
+		}
+
+		issues = append(issues, Issue{
+			Title: fmt.Sprintf("Vale: %d %s %s %s",
+				len(group.Alerts), pluralize("alert", len(group.Alerts)), preposition, group.Key),
+			Body:        strings.TrimRight(body.String(), "\n") + "\n\n" + marker + "\n",
+			Labels:      []string{issueLabel},
+			Fingerprint: fingerprint,
+		})
+	}
+	return issues, nil
+}
+
+// issueFingerprint identifies a group across runs. It depends on the group's
+// key, but not on its alerts, so that a file (or rule) only ever has one
+// open issue.
+func issueFingerprint(project string, group IssueGroup) string {
+	sum := sha256.Sum256([]byte(project + "\x00" + group.GroupBy + "\x00" + group.Key))
+	return hex.EncodeToString(sum[:8])
+}
+
+func newIssueTracker(flags *core.CLIFlags) (issueTracker, error) {
+	if flags.Project == "" {
+		return nil, errors.New("'--project' is required")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	switch flags.Tracker {
+	case "github":
+		base := flags.TrackerURL
+		if base == "" {
+			base = "https://api.github.com"
+		}
+		return &gitHubTracker{
+			client: client, base: strings.TrimRight(base, "/"), repo: flags.Project,
+			token: os.Getenv(gitHubTokenEnv),
+		}, nil
+	case "jira":
+		if flags.TrackerURL == "" {
+			return nil, errors.New("'--tracker-url' is required for Jira")
+		}
+		return &jiraTracker{
+			client: client, base: strings.TrimRight(flags.TrackerURL, "/"), project: flags.Project,
+			user: os.Getenv(jiraUserEnv), token: os.Getenv(jiraTokenEnv),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown tracker '%s'", flags.Tracker)
+	}
+}
+
+// gitHubTracker files GitHub issues, authenticating with `GITHUB_TOKEN`.
+type gitHubTracker struct {
+	client *http.Client
+	base   string
+	repo   string
+	token  string
+}
+
+func (t *gitHubTracker) fingerprints() (map[string]bool, error) {
+	found := map[string]bool{}
+	for page := 1; ; page++ {
+		var issues []struct {
+			Body string `json:"body"`
+		}
+
+		query := url.Values{
+			"state": {"open"}, "labels": {issueLabel},
+			"per_page": {"100"}, "page": {fmt.Sprint(page)}}
+		err := t.do(http.MethodGet, "/repos/"+t.repo+"/issues?"+query.Encode(), nil, &issues)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range issues {
+			for _, m := range reIssueFingerprint.FindAllStringSubmatch(issue.Body, -1) {
+				found[m[1]] = true
+			}
+		}
+
+		if len(issues) < 100 {
+			return found, nil
+		}
+	}
+}
+
+func (t *gitHubTracker) create(issue Issue) (string, error) {
+	var created struct {
+		URL string `json:"html_url"`
+	}
+	err := t.do(http.MethodPost, "/repos/"+t.repo+"/issues", map[string]interface{}{
+		"title": issue.Title, "body": issue.Body, "labels": issue.Labels,
+	}, &created)
+	return created.URL, err
+}
+
+func (t *gitHubTracker) do(method, path string, body, result interface{}) error {
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+	if t.token != "" {
+		headers["Authorization"] = "Bearer " + t.token
+	}
+	return doJSON(t.client, method, t.base+path, headers, body, result)
+}
+
+// jiraTracker files Jira issues (of type "Task"), authenticating with
+// `JIRA_USER` and `JIRA_TOKEN`.
+type jiraTracker struct {
+	client  *http.Client
+	base    string
+	project string
+	user    string
+	token   string
+}
+
+func (t *jiraTracker) fingerprints() (map[string]bool, error) {
+	found := map[string]bool{}
+
+	jql := fmt.Sprintf(`project = "%s" AND labels = %s AND statusCategory != Done`, t.project, issueLabel)
+	for start := 0; ; {
+		var result struct {
+			Total  int `json:"total"`
+			Issues []struct {
+				Fields struct {
+					Description string `json:"description"`
+				} `json:"fields"`
+			} `json:"issues"`
+		}
+
+		query := url.Values{
+			"jql": {jql}, "fields": {"description"},
+			"maxResults": {"100"}, "startAt": {fmt.Sprint(start)}}
+		if err := t.do(http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &result); err != nil {
+			return nil, err
+		}
+
+		for _, issue := range result.Issues {
+			for _, m := range reIssueFingerprint.FindAllStringSubmatch(issue.Fields.Description, -1) {
+				found[m[1]] = true
+			}
+		}
+
+		start += len(result.Issues)
+		if len(result.Issues) == 0 || start >= result.Total {
+			return found, nil
+		}
+	}
+}
+
+func (t *jiraTracker) create(issue Issue) (string, error) {
+	var created struct {
+		Key string `json:"key"`
+	}
+	err := t.do(http.MethodPost, "/rest/api/2/issue", map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": t.project},
+			"issuetype":   map[string]string{"name": "Task"},
+			"summary":     issue.Title,
+			"description": issue.Body,
+			"labels":      issue.Labels,
+		},
+	}, &created)
+	return t.base + "/browse/" + created.Key, err
+}
+
+func (t *jiraTracker) do(method, path string, body, result interface{}) error {
+	headers := map[string]string{}
+	if t.user != "" || t.token != "" {
+		creds := base64.StdEncoding.EncodeToString([]byte(t.user + ":" + t.token))
+		headers["Authorization"] = "Basic " + creds
+	}
+	return doJSON(t.client, method, t.base+path, headers, body, result)
+}
+
+// doJSON makes a JSON request, decoding the response into `result`.
+func doJSON(client *http.Client, method, u string, headers map[string]string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, u, reader) //nolint:noctx
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vale/"+version)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("'%s %s' responded with '%s'", method, u, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
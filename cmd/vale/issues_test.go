@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestExportIssues(t *testing.T) {
+	linted := []*core.File{
+		{Path: "a.md", Alerts: []core.Alert{
+			{Check: "Vale.Spelling", Line: 2, Span: []int{1, 3}, Severity: "error", Message: "Did you really mean 'Teh'?"},
+			{Check: "Vale.Repetition", Line: 1, Span: []int{5, 11}, Severity: "error", Message: "'the' is repeated!"},
+		}},
+		{Path: "b.md", Alerts: []core.Alert{
+			{Check: "Vale.Spelling", Line: 4, Span: []int{2, 4}, Severity: "error", Message: "Did you really mean 'tset'?"},
+		}},
+	}
+	flags := &core.CLIFlags{Tracker: "github", Project: "octo/docs"}
+
+	groups := groupAlerts(linted, "rule")
+	if len(groups) != 2 || groups[0].Key != "Vale.Repetition" || len(groups[1].Alerts) != 2 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+
+	issues, err := buildIssues(groupAlerts(linted, "file"), flags, defaultIssueTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Vale reported 2 alerts in `a.md`:\n\n" +
+		"- Vale.Repetition:1:5 (error): 'the' is repeated!\n" +
+		"- Vale.Spelling:2:1 (error): Did you really mean 'Teh'?\n\n" +
+		"<!-- vale-fingerprint: " + issues[0].Fingerprint + " -->\n"
+	if issues[0].Body != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, issues[0].Body)
+	}
+
+	created := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/docs/issues" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			// `a.md` already has an open issue.
+			fmt.Fprintf(w, `[{"body": "Old.\n\n<!-- vale-fingerprint: %s -->"}]`, issues[0].Fingerprint)
+			return
+		}
+
+		var payload struct{ Title string }
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		created = append(created, payload.Title)
+		fmt.Fprint(w, `{"html_url": "https://github.com/octo/docs/issues/2"}`)
+	}))
+	defer server.Close()
+
+	flags.TrackerURL = server.URL
+	tracker, err := newIssueTracker(flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = fileIssues(issues, tracker, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(created, ", ") != "Vale: 1 alert in b.md" {
+		t.Errorf("unexpected issues created: %v", created)
+	}
+}
@@ -4,10 +4,48 @@ import (
 	"fmt"
 
 	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
 )
 
+// JSONStatsResults is the JSON output when `--stats` is given: the usual
+// alerts, keyed by file path, along with per-rule statistics and the density
+// of alerts by kind of content and by section.
+type JSONStatsResults struct {
+	Alerts   map[string][]core.Alert
+	Stats    map[string]lint.RuleStats
+	Scopes   map[string]lint.ScopeStats
+	Sections map[string]lint.ScopeStats
+}
+
 // PrintJSONAlerts prints Alerts in map[file.path][]Alert form.
 func PrintJSONAlerts(linted []*core.File) bool {
+	formatted, hasErrors := formatJSONAlerts(linted)
+	fmt.Println(getJSON(formatted))
+	return hasErrors
+}
+
+// PrintJSONStats prints Alerts in map[file.path][]Alert form along with the
+// given statistics.
+func PrintJSONStats(linted []*core.File, stats *lint.RunStats) bool {
+	formatted, hasErrors := formatJSONAlerts(linted)
+	if stats == nil {
+		// The results were served by a daemon (see `--fast`).
+		stats = &lint.RunStats{
+			Rules:    map[string]lint.RuleStats{},
+			Scopes:   map[string]lint.ScopeStats{},
+			Sections: map[string]lint.ScopeStats{},
+		}
+	}
+	fmt.Println(getJSON(JSONStatsResults{
+		Alerts:   formatted,
+		Stats:    stats.Rules,
+		Scopes:   stats.Scopes,
+		Sections: stats.Sections,
+	}))
+	return hasErrors
+}
+
+func formatJSONAlerts(linted []*core.File) (map[string][]core.Alert, bool) {
 	alertCount := 0
 	formatted := map[string][]core.Alert{}
 	for _, f := range linted {
@@ -18,6 +56,5 @@ func PrintJSONAlerts(linted []*core.File) bool {
 			formatted[f.Path] = append(formatted[f.Path], a)
 		}
 	}
-	fmt.Println(getJSON(formatted))
-	return alertCount != 0
+	return formatted, alertCount != 0
 }
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// JUnitTestSuites is the root of a JUnit XML report.
+//
+// See https://github.com/testmoapp/junitxml.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite holds the results for a single file.
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase holds the alerts for a single rule in a file; a file without
+// any alerts has a single, passing, test case.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+// JUnitFailure lists a rule's alerts, one per line; its type is the highest
+// severity among them.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// PrintJUnitAlerts prints Alerts as a JUnit XML report, with a test suite for
+// each file and a failing test case for each rule that it violates.
+func PrintJUnitAlerts(linted []*core.File) bool {
+	alertCount := 0
+	report := JUnitTestSuites{Name: "Vale"}
+
+	for _, f := range linted {
+		path := reportPath(f.Path)
+		suite := JUnitTestSuite{Name: path}
+
+		byRule := map[string][]core.Alert{}
+		for _, a := range f.SortedAlerts() {
+			if a.Severity == "error" {
+				alertCount++
+			}
+			byRule[a.Check] = append(byRule[a.Check], a)
+		}
+
+		rules := make([]string, 0, len(byRule))
+		for rule := range byRule {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+
+		for _, rule := range rules {
+			alerts := byRule[rule]
+
+			var lines []string
+			for _, a := range alerts {
+				lines = append(lines, fmt.Sprintf("%s:%d:%d: [%s] %s",
+					path, a.Line, a.Span[0], a.Severity, a.Message))
+			}
+
+			suite.Cases = append(suite.Cases, JUnitTestCase{
+				Name:      rule,
+				ClassName: path,
+				File:      path,
+				Line:      alerts[0].Line,
+				Failure: &JUnitFailure{
+					Message: fmt.Sprintf("%d %s", len(alerts), pluralize("alert", len(alerts))),
+					Type:    highestSeverity(alerts),
+					Text:    strings.Join(lines, "\n"),
+				},
+			})
+		}
+
+		if len(suite.Cases) == 0 {
+			suite.Cases = []JUnitTestCase{{Name: "Vale", ClassName: path, File: path}}
+		} else {
+			suite.Failures = len(suite.Cases)
+		}
+		suite.Tests = len(suite.Cases)
+
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Println(err.Error())
+	} else {
+		fmt.Println(xml.Header + string(b))
+	}
+
+	return alertCount != 0
+}
+
+func highestSeverity(alerts []core.Alert) string {
+	highest := "suggestion"
+	for _, a := range alerts {
+		if core.LevelToInt[a.Severity] > core.LevelToInt[highest] {
+			highest = a.Severity
+		}
+	}
+	return highest
+}
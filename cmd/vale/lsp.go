@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// JSON-RPC error codes used by the Language Server Protocol.
+const (
+	lspParseError     = -32700
+	lspMethodNotFound = -32601
+	lspInvalidParams  = -32602
+)
+
+// lspSeverity maps our alert levels to LSP's `DiagnosticSeverity`.
+var lspSeverity = map[string]int{
+	"error":      1,
+	"warning":    2,
+	"suggestion": 3,
+}
+
+// lspMessage is a JSON-RPC request, response, or notification.
+type lspMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *lspError        `json:"error,omitempty"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+
+	CodeDescription *lspCodeDescription `json:"codeDescription,omitempty"`
+}
+
+type lspCodeDescription struct {
+	Href string `json:"href"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspCodeAction struct {
+	Title       string          `json:"title"`
+	Kind        string          `json:"kind"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+	Edit        struct {
+		Changes map[string][]lspTextEdit `json:"changes"`
+	} `json:"edit"`
+}
+
+type lspTextDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type lspDocumentParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspCodeActionParams struct {
+	TextDocument lspTextDocument `json:"textDocument"`
+	Range        lspRange        `json:"range"`
+}
+
+// lspDocument is an open document and the diagnostics last published for
+// it.
+type lspDocument struct {
+	lines       []string
+	alerts      []core.Alert
+	diagnostics []lspDiagnostic
+}
+
+// lspServer is a Language Server Protocol server that lints each document
+// as it's opened or changed.
+//
+// Documents are synced in full (`TextDocumentSyncKind.Full`) and linted
+// using the configuration found from the server's working directory.
+type lspServer struct {
+	linter *lint.Linter
+	config *core.Config
+	docs   map[string]*lspDocument
+	out    *bufio.Writer
+}
+
+func init() {
+	commandInfo["lsp"] = "Run a Language Server Protocol server over stdin and stdout."
+	Actions["lsp"] = runLSP
+}
+
+func runLSP(_ []string, flags *core.CLIFlags) error {
+	config, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(config)
+	if err != nil {
+		return err
+	}
+
+	return newLSPServer(linter, os.Stdout).serve(os.Stdin)
+}
+
+func newLSPServer(linter *lint.Linter, w io.Writer) *lspServer {
+	return &lspServer{
+		linter: linter,
+		config: linter.Manager.Config,
+		docs:   map[string]*lspDocument{},
+		out:    bufio.NewWriter(w),
+	}
+}
+
+// readLSPMessage reads a single message, which is framed by the same
+// `Content-Length` header as a `--batch` document (see `readBatchFrame`).
+func readLSPMessage(r *bufio.Reader) ([]byte, error) {
+	headers, err := textproto.NewReader(r).ReadMIMEHeader()
+	if errors.Is(err, io.EOF) && len(headers) == 0 {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid headers: %w", err)
+	}
+
+	size, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil || size < 0 {
+		return nil, errors.New("invalid 'Content-Length'")
+	}
+
+	body := make([]byte, size)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("truncated message: %w", err)
+	}
+
+	return body, nil
+}
+
+// serve handles messages from `r` until the client sends `exit` (or closes
+// the stream).
+func (s *lspServer) serve(r io.Reader) error {
+	in := bufio.NewReader(r)
+	for {
+		body, err := readLSPMessage(in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return core.NewE100("lsp", err)
+		}
+
+		var msg lspMessage
+		if err = json.Unmarshal(body, &msg); err != nil {
+			if err = s.reply(nil, nil, &lspError{lspParseError, err.Error()}); err != nil {
+				return core.NewE100("lsp", err)
+			}
+			continue
+		} else if msg.Method == "exit" {
+			return nil
+		}
+
+		if err = s.handle(msg); err != nil {
+			return core.NewE100("lsp", err)
+		}
+	}
+}
+
+// handle responds to a single message; requests we don't support get a
+// `MethodNotFound` error, while unknown notifications are ignored.
+func (s *lspServer) handle(msg lspMessage) error {
+	switch msg.Method {
+	case "initialize":
+		return s.reply(msg.ID, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": map[string]interface{}{
+					"openClose": true,
+					"change":    1,
+				},
+				"codeActionProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "vale", "version": version},
+		}, nil)
+	case "shutdown":
+		return s.reply(msg.ID, json.RawMessage("null"), nil)
+	case "textDocument/didOpen", "textDocument/didChange", "textDocument/didClose":
+		var params lspDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		return s.update(msg.Method, params)
+	case "textDocument/codeAction":
+		var params lspCodeActionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.reply(msg.ID, nil, &lspError{lspInvalidParams, err.Error()})
+		}
+		return s.reply(msg.ID, s.codeActions(params), nil)
+	}
+
+	if msg.ID != nil {
+		return s.reply(msg.ID, nil, &lspError{lspMethodNotFound, "unsupported method: " + msg.Method})
+	}
+	return nil
+}
+
+// update lints a document that's been opened or changed, publishing its
+// diagnostics; closing a document clears them.
+func (s *lspServer) update(method string, params lspDocumentParams) error {
+	uri := params.TextDocument.URI
+
+	text := params.TextDocument.Text
+	switch method {
+	case "textDocument/didClose":
+		delete(s.docs, uri)
+		return s.publish(uri, []lspDiagnostic{})
+	case "textDocument/didChange":
+		if len(params.ContentChanges) == 0 {
+			return nil
+		}
+		// With full syncing, the last change is the document's content.
+		text = params.ContentChanges[len(params.ContentChanges)-1].Text
+	}
+
+	doc := &lspDocument{lines: strings.SplitAfter(text, "\n"), diagnostics: []lspDiagnostic{}}
+	s.docs[uri] = doc
+
+	f, err := s.linter.LintContent(uriToPath(uri), text)
+	if err != nil {
+		return s.notify("window/logMessage", map[string]interface{}{
+			"type": 1, "message": core.StripANSI(err.Error())})
+	}
+
+	for _, a := range f.SortedAlerts() {
+		// NOTE: We keep the full alert for code actions, but only publish
+		// as much of the document as the config allows.
+		doc.alerts = append(doc.alerts, a)
+
+		shown := []core.Alert{a}
+		limitAlerts(shown, s.config)
+		doc.diagnostics = append(doc.diagnostics, doc.diagnostic(shown[0]))
+	}
+
+	return s.publish(uri, doc.diagnostics)
+}
+
+// codeActions offers a fix for each suggestion of the alerts in the given
+// range.
+func (s *lspServer) codeActions(params lspCodeActionParams) []lspCodeAction {
+	actions := []lspCodeAction{}
+
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return actions
+	}
+
+	for i, a := range doc.alerts {
+		diag := doc.diagnostics[i]
+		if a.Action.Name == "" || !overlaps(diag.Range, params.Range) {
+			continue
+		}
+
+		suggestions, err := check.FixAlert(a, s.config)
+		if err != nil {
+			continue
+		}
+
+		for _, fix := range suggestions {
+			title := fmt.Sprintf("Replace with '%s'", fix)
+			if fix == "" {
+				title = fmt.Sprintf("Remove '%s'", a.Match)
+			}
+
+			action := lspCodeAction{Title: title, Kind: "quickfix", Diagnostics: []lspDiagnostic{diag}}
+			action.Edit.Changes = map[string][]lspTextEdit{
+				params.TextDocument.URI: {{Range: diag.Range, NewText: fix}},
+			}
+			actions = append(actions, action)
+		}
+	}
+
+	return actions
+}
+
+// diagnostic converts `a` to an LSP diagnostic, whose positions are 0-based
+// and counted in UTF-16 code units.
+func (doc *lspDocument) diagnostic(a core.Alert) lspDiagnostic {
+	line := a.Line - 1
+	if line < 0 {
+		line = 0
+	}
+
+	span := a.Span
+	if a.Line > 0 && a.Line <= len(doc.lines) {
+		span = utf16Span(doc.lines[a.Line-1], a.Span)
+	}
+
+	diag := lspDiagnostic{
+		Range: lspRange{
+			Start: lspPosition{Line: line, Character: max(span[0]-1, 0)},
+			End:   lspPosition{Line: line, Character: span[1]},
+		},
+		Severity: lspSeverity[a.Severity],
+		Code:     a.Check,
+		Source:   "vale",
+		Message:  a.Message,
+	}
+	if a.Link != "" {
+		diag.CodeDescription = &lspCodeDescription{Href: a.Link}
+	}
+
+	return diag
+}
+
+func (s *lspServer) publish(uri string, diagnostics []lspDiagnostic) error {
+	return s.notify("textDocument/publishDiagnostics", map[string]interface{}{
+		"uri": uri, "diagnostics": diagnostics})
+}
+
+func (s *lspServer) notify(method string, params interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.write(lspMessage{JSONRPC: "2.0", Method: method, Params: body})
+}
+
+func (s *lspServer) reply(id *json.RawMessage, result interface{}, rpcErr *lspError) error {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	return s.write(lspMessage{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr})
+}
+
+func (s *lspServer) write(msg lspMessage) error {
+	if err := writeBatchFrame(s.out, msg); err != nil {
+		return err
+	}
+	return s.out.Flush()
+}
+
+// overlaps reports whether two ranges share at least one position.
+func overlaps(a, b lspRange) bool {
+	before := func(p, q lspPosition) bool {
+		return p.Line < q.Line || (p.Line == q.Line && p.Character < q.Character)
+	}
+	return !before(a.End, b.Start) && !before(b.End, a.Start)
+}
+
+// uriToPath converts a `file://` URI to a local path; other URIs (such as
+// `untitled:`) are returned as is, which is enough to find their format.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		// `file:///C:/docs/a.md` -> `C:/docs/a.md`
+		path = strings.TrimPrefix(path, "/")
+	}
+
+	return filepath.FromSlash(path)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+func lspInput(msgs ...string) string {
+	var b strings.Builder
+	for _, m := range msgs {
+		fmt.Fprintf(&b, "Content-Length: %d\r\n\r\n%s", len(m), m)
+	}
+	return b.String()
+}
+
+func newTestLSPServer(t *testing.T, out *bytes.Buffer) *lspServer {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.GBaseStyles = []string{"Vale"}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newLSPServer(linter, out)
+}
+
+func readLSPOutput(t *testing.T, out *bytes.Buffer) []lspMessage {
+	var msgs []lspMessage
+
+	r := bufio.NewReader(out)
+	for {
+		body, err := readLSPMessage(r)
+		if err != nil {
+			break
+		}
+
+		var msg lspMessage
+		if err = json.Unmarshal(body, &msg); err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs
+}
+
+func TestLSPDiagnostics(t *testing.T) {
+	var out bytes.Buffer
+	server := newTestLSPServer(t, &out)
+
+	input := lspInput(
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///tmp/a.md","text":"# Tïtle\n\nÜñï is is a test.\n"}}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":"file:///tmp/a.md"},"contentChanges":[{"text":"Fixed.\n"}]}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/hover","params":{}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"shutdown"}`)
+
+	if err := server.serve(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := readLSPOutput(t, &out)
+	if len(msgs) != 5 {
+		t.Fatalf("Expected 5 messages, got %d", len(msgs))
+	}
+
+	var opened struct {
+		URI         string
+		Diagnostics []lspDiagnostic
+	}
+	if err := json.Unmarshal(msgs[1].Params, &opened); err != nil {
+		t.Fatal(err)
+	} else if len(opened.Diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %v", opened.Diagnostics)
+	}
+
+	diag := opened.Diagnostics[0]
+	expected := lspRange{Start: lspPosition{2, 4}, End: lspPosition{2, 9}}
+	if diag.Range != expected || diag.Code != "Vale.Repetition" || diag.Severity != 1 {
+		t.Errorf("Unexpected diagnostic: %+v", diag)
+	}
+
+	if !strings.Contains(string(msgs[2].Params), `"diagnostics":[]`) {
+		t.Errorf("Expected the change to clear the diagnostics, got %s", msgs[2].Params)
+	}
+
+	if msgs[3].Error == nil || msgs[3].Error.Code != lspMethodNotFound {
+		t.Errorf("Expected 'hover' to be unsupported, got %+v", msgs[3])
+	}
+}
+
+func TestLSPCodeActions(t *testing.T) {
+	var out bytes.Buffer
+	server := newTestLSPServer(t, &out)
+
+	uri := "file:///tmp/b.md"
+	doc := &lspDocument{lines: []string{"Use utilize here.\n"}}
+	doc.alerts = []core.Alert{{
+		Check: "Test.Simple", Match: "utilize", Line: 1, Span: []int{5, 11}, Severity: "warning",
+		Action: core.Action{Name: "replace", Params: []string{"use", "apply"}}}}
+	doc.diagnostics = []lspDiagnostic{doc.diagnostic(doc.alerts[0])}
+	server.docs[uri] = doc
+
+	params := lspCodeActionParams{TextDocument: lspTextDocument{URI: uri}}
+	params.Range = lspRange{Start: lspPosition{0, 6}, End: lspPosition{0, 6}}
+
+	actions := server.codeActions(params)
+	if len(actions) != 2 {
+		t.Fatalf("Expected 2 actions, got %+v", actions)
+	}
+
+	edit := actions[0].Edit.Changes[uri][0]
+	if actions[0].Title != "Replace with 'use'" || edit.NewText != "use" || edit.Range.Start.Character != 4 {
+		t.Errorf("Unexpected action: %+v", actions[0])
+	}
+
+	params.Range = lspRange{Start: lspPosition{0, 12}, End: lspPosition{0, 14}}
+	if actions = server.codeActions(params); len(actions) != 0 {
+		t.Errorf("Expected no actions outside of the alert, got %+v", actions)
+	}
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 
@@ -36,8 +40,27 @@ func doLint(args []string, l *lint.Linter, glob string) ([]*core.File, error) {
 	var linted []*core.File
 	var err error
 
+	if l.Manager.Config.Flags.Format == "gfm-comment" {
+		return lintComment(args, l)
+	}
+
+	// URLs are fetched and linted on their own; any other arguments are
+	// paths.
+	args, urls := splitURLs(args)
+
+	var pages []*core.File
+	for _, u := range urls {
+		page, lintErr := l.LintURL(u, l.Manager.Config.Flags.Selector)
+		if lintErr != nil {
+			return pages, lintErr
+		}
+		pages = append(pages, page)
+	}
+
 	length := len(args)
-	if length == 1 && looksLikeStdin(args[0]) == 1 { //nolint:gocritic
+	if len(urls) > 0 && length == 0 { //nolint:gocritic
+		return pages, nil
+	} else if length == 1 && len(urls) == 0 && looksLikeStdin(args[0]) == 1 {
 		// Case 1:
 		//
 		// $ vale "some text in a string"
@@ -73,7 +96,98 @@ func doLint(args []string, l *lint.Linter, glob string) ([]*core.File, error) {
 		}
 	}
 
-	return linted, err
+	return append(pages, linted...), err
+}
+
+// lintComment lints the given text -- or, if there isn't any, stdin -- as a
+// single GFM fragment (see `--format=gfm-comment`).
+//
+// Unlike the default input handling, arguments are never treated as paths.
+func lintComment(args []string, l *lint.Linter) ([]*core.File, error) {
+	text := strings.Join(args, " ")
+	if len(args) == 0 {
+		stdin, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, core.NewE100("--format", err)
+		}
+		text = string(stdin)
+	}
+
+	f, err := l.LintComment(text)
+	if err != nil {
+		return nil, err
+	}
+	return []*core.File{f}, nil
+}
+
+// splitURLs separates the URLs in `args` (see `lint.LintURL`) from the
+// other arguments.
+func splitURLs(args []string) ([]string, []string) {
+	var rest, urls []string
+	for _, arg := range args {
+		if lint.IsURL(arg) {
+			urls = append(urls, arg)
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+	return rest, urls
+}
+
+// printSkipped summarizes (on stderr, so as not to interfere with
+// machine-readable output) the files that weren't linted because they don't
+// appear to be text.
+func printSkipped(skipped map[string]string) {
+	if len(skipped) == 0 {
+		return
+	}
+
+	paths := make([]string, 0, len(skipped))
+	for path := range skipped {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	fmt.Fprintf(os.Stderr, "Skipped %d non-text %s:\n",
+		len(paths), pluralize("file", len(paths)))
+	for _, path := range paths {
+		fmt.Fprintf(os.Stderr, "  %s (%s)\n", path, skipped[path])
+	}
+}
+
+// printUnlinted marks (on stderr) a run's results as partial, listing the
+// files that weren't linted because the run exceeded `--max-duration`.
+func printUnlinted(unlinted []string, budget time.Duration) {
+	if len(unlinted) == 0 {
+		return
+	}
+
+	paths := append([]string{}, unlinted...)
+	sort.Strings(paths)
+
+	fmt.Fprintf(os.Stderr, "Partial results: the run exceeded %s (%s), so %d %s weren't linted:\n",
+		toCodeStyle("--max-duration"), budget, len(paths), pluralize("file", len(paths)))
+	for _, path := range paths {
+		fmt.Fprintf(os.Stderr, "  %s\n", path)
+	}
+}
+
+// printFixes summarizes (on stderr) the changes made by `--fix`.
+func printFixes(fixes fixResult) {
+	fmt.Fprintf(os.Stderr, "Fixed %d %s in %d %s.\n",
+		fixes.Fixed, pluralize("alert", fixes.Fixed), fixes.Files, pluralize("file", fixes.Files))
+	for _, path := range fixes.Reverted {
+		fmt.Fprintf(os.Stderr, "  %s (reverted: its fixes introduced new alerts)\n", path)
+	}
+}
+
+// useResultCache reports whether a run should reuse the cached results of
+// unchanged files (see `--no-cache`).
+//
+// Runs that need each file to be linted -- e.g., to fix it or to collect
+// per-rule statistics -- don't use the cache.
+func useResultCache(flags *core.CLIFlags) bool {
+	return !flags.NoCache && flags.Checkpoint == "" && !flags.Fix && !flags.Stats && flags.Format == ""
 }
 
 func handleError(err error) {
@@ -83,6 +197,12 @@ func handleError(err error) {
 
 func main() {
 	pflag.Parse()
+	core.ValeVersion = version
+	core.ReadOnly = Flags.NoWrite
+
+	if err := configureTerminal(&Flags); err != nil {
+		handleError(err)
+	}
 
 	args := pflag.Args()
 	argc := len(args)
@@ -92,13 +212,22 @@ func main() {
 		os.Exit(0)
 	} else if Flags.Help {
 		pflag.Usage()
-	} else if argc == 0 && !stat() {
+	} else if argc == 0 && !stat() && Flags.Why == "" && !Flags.Batch && !Flags.RetryFailed {
 		PrintIntro()
 	}
 
+	if Flags.Check && (argc == 0 || args[0] != "fmt-styles") {
+		handleError(core.NewE100("--check", errors.New("'--check' is only supported by 'fmt-styles'")))
+	}
+
 	if argc > 0 {
 		cmd, exists := Actions[args[0]]
 		if exists {
+			if writeActions[args[0]] {
+				if err := core.CheckWritable(args[0]); err != nil {
+					handleError(err)
+				}
+			}
 			if err := cmd(args[1:], &Flags); err != nil {
 				handleError(err)
 			}
@@ -106,25 +235,216 @@ func main() {
 		}
 	}
 
-	config, err := core.ReadPipeline(&Flags, false)
-	if err != nil {
+	if Flags.Format != "" && Flags.Format != "gfm-comment" {
+		handleError(core.NewE100("--format", fmt.Errorf(
+			"unknown format '%s' (expected 'gfm-comment')", Flags.Format)))
+	} else if Flags.Format != "" && Flags.Fix {
+		handleError(core.NewE100("--format", errors.New("'--fix' requires files to lint")))
+	}
+
+	if Flags.Fix && argc == 0 {
+		handleError(core.NewE100("--fix", errors.New("'--fix' requires files to lint")))
+	} else if Flags.Fix {
+		if err := core.CheckWritable("--fix"); err != nil {
+			handleError(err)
+		}
+	}
+
+	if err := checkSQLite(Flags.Output, "--output"); err != nil {
 		handleError(err)
 	}
 
-	linter, err := lint.NewLinter(config)
+	if Flags.Path == "-" && (Flags.Batch || (argc == 0 && Flags.Why == "")) {
+		handleError(core.NewE100("--config", errors.New(
+			"stdin can't be used for both the configuration and the content to lint")))
+	}
+
+	config, err := core.ReadPipeline(&Flags, false)
 	if err != nil {
 		handleError(err)
 	}
 
-	linted, err := doLint(args, linter, Flags.Glob)
-	if err != nil {
+	if Flags.Why != "" {
+		if err = explainRule(Flags.Why, config); err != nil {
+			handleError(err)
+		}
+		os.Exit(0)
+	} else if Flags.PrintScopes {
+		if err = printScopes(args, config); err != nil {
+			handleError(err)
+		}
+		os.Exit(0)
+	} else if Flags.Batch {
+		linter, lintErr := lint.NewLinter(config)
+		if lintErr != nil {
+			handleError(lintErr)
+		}
+
+		hasErrors, batchErr := lintBatch(os.Stdin, os.Stdout, linter)
+		if batchErr != nil {
+			handleError(batchErr)
+		} else if hasErrors && !Flags.NoExit {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	for _, sinks := range config.Outputs {
+		for _, sink := range sinks {
+			if err = checkSQLite(sink, "[outputs]"); err != nil {
+				handleError(err)
+			}
+		}
+	}
+
+	if Flags.Shuffle < 0 {
+		// We report the seed so that an order-dependent result can be
+		// reproduced using `--shuffle=<seed>`.
+		Flags.Shuffle = time.Now().UnixNano()
+		fmt.Fprintf(os.Stderr, "Shuffling rules with seed %d.\n", Flags.Shuffle)
+	}
+
+	if Flags.RetryFailed {
+		if args, err = lint.FailedFiles(args, config); err != nil {
+			handleError(err)
+		} else if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "There are no failed files to retry.")
+			os.Exit(0)
+		}
+	}
+
+	var linted []*core.File
+	var skipped map[string]string
+	var crashes []*lint.Crash
+	var unlinted []string
+	var stats *lint.RunStats
+
+	served := false
+	// NOTE: The daemon can't see an inline configuration, so we lint
+	// in-process instead -- as we do when writes are disabled, since the
+	// daemon needs a socket.
+	if Flags.Fast && !Flags.Fix && !Flags.NoWrite && Flags.Format == "" && !core.UsesInlineConfig(&Flags) {
+		linted, served, err = lintWithDaemon(args, &Flags)
+		if served && err != nil {
+			handleError(err)
+		}
+	}
+
+	if !served {
+		if useResultCache(&Flags) {
+			// The cache is an implicit `--checkpoint`: unchanged files reuse
+			// the results of a previous run with the same configuration.
+			if Flags.Checkpoint, err = core.ResultCachePath(config); err != nil {
+				handleError(err)
+			}
+		}
+
+		linter, lintErr := lint.NewLinter(config)
+		if lintErr != nil {
+			handleError(lintErr)
+		}
+
+		if Flags.Editor {
+			linter.OnLinted = func(f *core.File) {
+				if printErr := printEditorResult(os.Stdout, f, config); printErr != nil {
+					handleError(printErr)
+				}
+			}
+		}
+
+		linted, err = doLint(args, linter, Flags.Glob)
+		if err != nil {
+			handleError(err)
+		}
+		suggest(linted, linter)
+		skipped = linter.Skipped
+		crashes = linter.Crashes
+		unlinted = linter.Unlinted
+		stats = linter.Stats()
+
+		if Flags.Fix {
+			var fixes fixResult
+			if linted, fixes, err = applyFixes(linted, linter); err != nil {
+				handleError(err)
+			}
+			printFixes(fixes)
+		}
+	}
+
+	baselined := 0
+	if Flags.UpdateBaseline {
+		recorded, baseErr := lint.UpdateBaseline(linted, config)
+		if baseErr != nil {
+			handleError(baseErr)
+		}
+		fmt.Fprintf(os.Stderr, "Recorded %d %s in the baseline.\n", recorded, pluralize("alert", recorded))
+		os.Exit(0)
+	} else if Flags.Baseline {
+		if baselined, err = lint.ApplyBaseline(linted, config); err != nil {
+			handleError(err)
+		}
+	}
+
+	// NOTE: This comes after the baseline so that baselined alerts don't
+	// count as failures.
+	if err = lint.RecordFailed(linted, crashes, unlinted, config); err != nil {
 		handleError(err)
 	}
 
-	hasErrors, err := PrintAlerts(linted, config)
+	if Flags.AssignIDs || len(config.Policy) > 0 {
+		// A `[policy]` needs to know how old each alert is, so it implies
+		// `--assign-ids`.
+		if err = lint.TrackAlerts(linted, config, time.Now()); err != nil {
+			handleError(err)
+		}
+	}
+
+	var hasErrors bool
+	if Flags.Editor {
+		hasErrors, err = printEditorResults(linted, config, served)
+	} else if len(config.Outputs) > 0 {
+		terminal, routed := routeAlerts(linted, config.Outputs)
+		if hasErrors, err = PrintAlerts(terminal, config, stats); err == nil {
+			var routedErrors bool
+			routedErrors, err = writeOutputs(routed, config)
+			hasErrors = hasErrors || routedErrors
+		}
+	} else {
+		hasErrors, err = PrintAlerts(linted, config, stats)
+	}
 	if err != nil {
 		handleError(err)
-	} else if hasErrors && !Flags.NoExit {
+	}
+	printSkipped(skipped)
+	printCrashes(crashes)
+	printUnlinted(unlinted, Flags.MaxDuration)
+
+	if baselined > 0 {
+		fmt.Fprintf(os.Stderr, "Hid %d baselined %s.\n", baselined, pluralize("alert", baselined))
+	}
+
+	if len(unlinted) > 0 {
+		// As with crashes, the files we didn't get to may have had errors.
+		hasErrors = true
+	}
+
+	if len(crashes) > 0 {
+		// A crashed file may have had errors of its own.
+		hasErrors = true
+		if Flags.CrashBundle != "" {
+			if err = writeCrashBundles(Flags.CrashBundle, crashes, config); err != nil {
+				ShowError(err, Flags.Output, os.Stderr)
+			}
+		}
+	}
+
+	if config.Webhook != "" {
+		if err = sendWebhook(config.Webhook, linted); err != nil {
+			ShowError(err, Flags.Output, os.Stderr)
+		}
+	}
+
+	if hasErrors && !Flags.NoExit {
 		os.Exit(1)
 	}
 
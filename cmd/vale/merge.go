@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func init() {
+	commandInfo["merge-results"] = "Combine the JSON results of sharded runs (see `--shard`) into one report."
+	Actions["merge-results"] = mergeResults
+}
+
+// readResults reads a report written by `--output=JSON`, with or without
+// `--stats`.
+func readResults(path string) (map[string][]core.Alert, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var withStats JSONStatsResults
+	if err = json.Unmarshal(b, &withStats); err == nil && withStats.Alerts != nil {
+		return withStats.Alerts, nil
+	}
+
+	results := map[string][]core.Alert{}
+	if err = json.Unmarshal(b, &results); err != nil {
+		return nil, fmt.Errorf("'%s' isn't a JSON report: %w", path, err)
+	}
+
+	return results, nil
+}
+
+// mergeReports combines the results of several shards.
+//
+// A file reported by more than one shard -- e.g., if the shards overlapped
+// -- must have the same results in each of them; otherwise, the shards were
+// likely run with different configurations.
+func mergeReports(paths []string) ([]*core.File, error) {
+	merged := map[string][]core.Alert{}
+	source := map[string]string{}
+
+	for _, path := range paths {
+		results, err := readResults(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for file, alerts := range results {
+			if previous, found := merged[file]; found {
+				if !reflect.DeepEqual(previous, alerts) {
+					return nil, fmt.Errorf(
+						"'%s' has different results in '%s' and '%s'", file, source[file], path)
+				}
+				continue
+			}
+			merged[file] = alerts
+			source[file] = path
+		}
+	}
+
+	files := make([]string, 0, len(merged))
+	for file := range merged {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	linted := make([]*core.File, 0, len(files))
+	for _, file := range files {
+		linted = append(linted, &core.File{Path: file, Alerts: merged[file]})
+	}
+
+	return linted, nil
+}
+
+// mergeResults prints the combined results of sharded runs in the format
+// given by `--output`, exiting with an error if any of them are errors.
+//
+// `--minAlertLevel` is applied again, so that a report can be produced from
+// shards that were run with a lower level.
+func mergeResults(args []string, flags *core.CLIFlags) error {
+	if len(args) == 0 {
+		return core.NewE100("merge-results", errors.New("at least one JSON report expected"))
+	}
+
+	linted, err := mergeReports(args)
+	if err != nil {
+		return core.NewE100("merge-results", err)
+	}
+
+	config, err := core.NewConfig(flags)
+	if err != nil {
+		return err
+	}
+
+	if flags.AlertLevel != "" {
+		minLevel, ok := core.LevelToInt[flags.AlertLevel]
+		if !ok {
+			return core.NewE100("merge-results", fmt.Errorf("unknown level '%s'", flags.AlertLevel))
+		}
+
+		for _, f := range linted {
+			kept := []core.Alert{}
+			for _, a := range f.Alerts {
+				if core.LevelToInt[a.Severity] >= minLevel {
+					kept = append(kept, a)
+				}
+			}
+			f.Alerts = kept
+		}
+	}
+
+	hasErrors, err := PrintAlerts(linted, config, nil)
+	if err != nil {
+		return err
+	} else if hasErrors && !flags.NoExit {
+		os.Exit(1)
+	}
+
+	return nil
+}
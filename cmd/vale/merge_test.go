@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeReports(t *testing.T) {
+	dir := t.TempDir()
+
+	reports := map[string]string{
+		"1.json": `{"a.md": [{"Check": "Vale.Repetition", "Line": 1, "Span": [1, 5], "Severity": "error"}]}`,
+		"2.json": `{"Alerts": {"b.md": [{"Check": "Vale.Spelling", "Line": 2, "Span": [3, 4], "Severity": "error"}]}, "Stats": {}}`,
+		"3.json": `{"a.md": [{"Check": "Vale.Repetition", "Line": 1, "Span": [1, 5], "Severity": "error"}]}`,
+		"4.json": `{"a.md": []}`,
+	}
+	for name, content := range reports {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths := func(names ...string) []string {
+		for i, name := range names {
+			names[i] = filepath.Join(dir, name)
+		}
+		return names
+	}
+
+	linted, err := mergeReports(paths("2.json", "1.json", "3.json"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted) != 2 || linted[0].Path != "a.md" || linted[1].Path != "b.md" {
+		t.Fatalf("Unexpected files: %v", linted)
+	} else if len(linted[0].Alerts) != 1 || len(linted[1].Alerts) != 1 {
+		t.Errorf("Expected one alert per file, got %v and %v", linted[0].Alerts, linted[1].Alerts)
+	}
+
+	_, err = mergeReports(paths("1.json", "4.json"))
+	if err == nil || !strings.Contains(err.Error(), "different results") {
+		t.Errorf("Expected conflicting results to be an error, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func init() {
+	pflag.StringVar(&Flags.Extends, "extends", "",
+		fmt.Sprintf(`The extension point of a new rule (%s).`, toCodeStyle(`new-rule --extends=existence`)))
+	pflag.StringVar(&Flags.Level, "level", "",
+		fmt.Sprintf(`The level of a new rule (%s).`, toCodeStyle(`new-rule --level=error`)))
+	pflag.StringVar(&Flags.Message, "message", "",
+		fmt.Sprintf(`The message of a new rule (%s).`, toCodeStyle(`new-rule --message="Avoid '%s'."`)))
+
+	commandInfo["new-rule"] = "Create a rule (e.g., `MyStyle.Avoid`) in StylesPath from a template."
+	Actions["new-rule"] = newRule
+}
+
+// ruleOptions are the choices made when scaffolding a new rule.
+type ruleOptions struct {
+	Extends string
+	Level   string
+	Message string
+}
+
+// newRule writes a skeleton of the given rule (`Style.Name`) to the current
+// StylesPath, asking the user for any options that weren't given as flags
+// if we're in a terminal.
+func newRule(args []string, flags *core.CLIFlags) error {
+	if len(args) != 1 {
+		return core.NewE100("new-rule", errors.New("one argument (e.g., 'MyStyle.Avoid') expected"))
+	}
+
+	style, name, found := strings.Cut(args[0], ".")
+	if !found || style == "" || name == "" || strings.ContainsAny(args[0], `/\`) {
+		return core.NewE100("new-rule", fmt.Errorf("'%s' must be of the form 'Style.Name'", args[0]))
+	} else if err := core.CheckWritable("new-rule"); err != nil {
+		return err
+	}
+
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(cfg.StylesPath(), style, name+".yml")
+	if core.FileExists(path) {
+		return core.NewE100("new-rule", fmt.Errorf("'%s' already exists", path))
+	}
+
+	opts := ruleOptions{Extends: flags.Extends, Level: flags.Level, Message: flags.Message}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		if err = askRuleOptions(&opts); err != nil {
+			return core.NewE100("new-rule", err)
+		}
+	}
+
+	if opts.Extends == "" {
+		opts.Extends = "existence"
+	}
+	if opts.Level == "" {
+		opts.Level = "warning"
+	}
+
+	src, err := check.RuleTemplate(opts.Extends, opts.Message, opts.Level)
+	if err != nil {
+		return core.NewE100("new-rule", err)
+	} else if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return core.NewE100("new-rule", err)
+	} else if err = os.WriteFile(path, src, 0o600); err != nil {
+		return core.NewE100("new-rule", err)
+	}
+
+	pterm.Success.Printfln("Created '%s'.", path)
+	if !usesStyle(cfg, style) {
+		pterm.Info.Printfln("Add '%s' to BasedOnStyles to use it.", style)
+	}
+
+	return nil
+}
+
+// usesStyle reports whether any section of the config is based on `style`.
+func usesStyle(cfg *core.Config, style string) bool {
+	if core.StringInSlice(style, cfg.GBaseStyles) {
+		return true
+	}
+	for _, styles := range cfg.SBaseStyles {
+		if core.StringInSlice(style, styles) {
+			return true
+		}
+	}
+	return false
+}
+
+// askRuleOptions prompts the user for each of the rule's options that
+// haven't already been set.
+func askRuleOptions(opts *ruleOptions) error {
+	var err error
+
+	if opts.Extends == "" {
+		opts.Extends, err = pterm.DefaultInteractiveSelect.
+			WithOptions(check.ExtensionPoints()).
+			WithDefaultOption("existence").
+			Show("Which extension point should the rule extend?")
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Level == "" {
+		opts.Level, err = pterm.DefaultInteractiveSelect.
+			WithOptions(core.AlertLevels).
+			WithDefaultOption("warning").
+			Show("What level should the rule's alerts have?")
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Message == "" {
+		opts.Message, err = pterm.DefaultInteractiveTextInput.
+			Show("What should the rule's message be (leave empty for the default)?")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// Section is a heading and the sections nested beneath it.
+type Section struct {
+	core.Heading
+	Sections []*Section `json:",omitempty"`
+}
+
+// OutlineChange is a single difference between two outlines.
+type OutlineChange struct {
+	Change string        // "added", "removed", "moved", or "unchanged"
+	Old    *core.Heading `json:",omitempty"`
+	New    *core.Heading `json:",omitempty"`
+}
+
+func init() {
+	commandInfo["outline"] = "Print the given file's heading outline (or, with `--compare`, diff two outlines)."
+	Actions["outline"] = outline
+}
+
+func outline(args []string, flags *core.CLIFlags) error {
+	if flags.Compare {
+		if len(args) != 2 {
+			return core.NewE100("outline", errors.New("two arguments expected"))
+		}
+		return compareOutlines(args[0], args[1], flags)
+	} else if len(args) != 1 {
+		return core.NewE100("outline", errors.New("one argument expected"))
+	}
+
+	headings, err := readOutline(args[0], flags)
+	if err != nil {
+		return err
+	}
+
+	if flags.Output == "JSON" {
+		return printJSON(buildSections(headings))
+	}
+
+	for _, h := range headings {
+		fmt.Printf("%s- %s (line %d)\n", strings.Repeat("  ", h.Level-1), h.Text, h.Line)
+	}
+	return nil
+}
+
+// readOutline returns the headings of the markup file at `path`, as parsed
+// according to the project's configuration (e.g., its `[formats]`), if any.
+func readOutline(path string, flags *core.CLIFlags) ([]core.Heading, error) {
+	if !core.FileExists(path) {
+		return nil, core.NewE100("outline", fmt.Errorf("file '%s' not found", path))
+	}
+
+	cfg, err := core.ReadPipeline(flags, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: We only need the file to be parsed, so we don't care which rules
+	// run -- just that some do.
+	cfg.GBaseStyles = []string{"Vale"}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	linted, err := linter.Lint([]string{path}, "*")
+	if err != nil {
+		return nil, err
+	} else if len(linted) == 0 {
+		return nil, core.NewE100("outline", fmt.Errorf("'%s' wasn't linted", path))
+	} else if linted[0].Format != "markup" {
+		return nil, core.NewE100("outline", fmt.Errorf("'%s' is not a markup file", path))
+	}
+
+	return linted[0].Outline, nil
+}
+
+// buildSections nests a flat list of headings by level.
+func buildSections(headings []core.Heading) []*Section {
+	var root []*Section
+	var stack []*Section
+
+	for _, h := range headings {
+		section := &Section{Heading: h}
+		for len(stack) > 0 && stack[len(stack)-1].Level >= h.Level {
+			stack = stack[:len(stack)-1]
+		}
+
+		if len(stack) == 0 {
+			root = append(root, section)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Sections = append(parent.Sections, section)
+		}
+		stack = append(stack, section)
+	}
+
+	return root
+}
+
+func compareOutlines(oldPath, newPath string, flags *core.CLIFlags) error {
+	before, err := readOutline(oldPath, flags)
+	if err != nil {
+		return err
+	}
+
+	after, err := readOutline(newPath, flags)
+	if err != nil {
+		return err
+	}
+
+	changes := diffOutlines(before, after)
+	if flags.Output == "JSON" {
+		return printJSON(changes)
+	}
+
+	markers := map[string]string{
+		"added": "+", "removed": "-", "moved": "~", "unchanged": " "}
+
+	for _, c := range changes {
+		h := c.New
+		if h == nil {
+			h = c.Old
+		}
+
+		line := fmt.Sprintf("%s %s%s", markers[c.Change], strings.Repeat("  ", h.Level-1), h.Text)
+		if c.Change == "moved" && c.Old.Level != c.New.Level {
+			line += fmt.Sprintf(" (h%d -> h%d)", c.Old.Level, c.New.Level)
+		}
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+// diffOutlines computes the changes needed to turn `before` into `after`,
+// based on their longest common subsequence of headings.
+//
+// A heading that was removed in one place and added in another (or at a
+// different level, e.g., `h2` -> `h3`) is reported as "moved".
+func diffOutlines(before, after []core.Heading) []OutlineChange {
+	same := func(a, b core.Heading) bool {
+		return a.Level == b.Level && a.Text == b.Text
+	}
+
+	n, m := len(before), len(after)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if same(before[i], after[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var changes []OutlineChange
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && same(before[i], after[j]):
+			changes = append(changes, OutlineChange{"unchanged", &before[i], &after[j]})
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] >= lcs[i+1][j]):
+			changes = append(changes, OutlineChange{"added", nil, &after[j]})
+			j++
+		default:
+			changes = append(changes, OutlineChange{"removed", &before[i], nil})
+			i++
+		}
+	}
+
+	// Pair up headings that were removed and re-added at a different level.
+	for a := range changes {
+		if changes[a].Change != "added" {
+			continue
+		}
+		for r := range changes {
+			if changes[r].Change == "removed" && changes[r].Old.Text == changes[a].New.Text {
+				changes[a].Change = "moved"
+				changes[a].Old = changes[r].Old
+				changes[r].Change = ""
+				break
+			}
+		}
+	}
+
+	diff := []OutlineChange{}
+	for _, c := range changes {
+		if c.Change != "" {
+			diff = append(diff, c)
+		}
+	}
+
+	return diff
+}
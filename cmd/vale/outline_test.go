@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// parseOutline parses headings written as, e.g., "# A, ## B".
+func parseOutline(spec string) []core.Heading {
+	headings := []core.Heading{}
+	for i, h := range strings.Split(spec, ", ") {
+		if h == "" {
+			continue
+		}
+		marks, text, _ := strings.Cut(h, " ")
+		headings = append(headings, core.Heading{Text: text, Level: len(marks), Line: i + 1})
+	}
+	return headings
+}
+
+func TestDiffOutlines(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		before string
+		after  string
+		diff   string
+	}{
+		{"unchanged", "# A, ## B", "# A, ## B", "unchanged A, unchanged B"},
+		{"added", "# A, ## B", "# A, ## B, ## C", "unchanged A, unchanged B, added C"},
+		{"removed", "# A, ## B, ## C", "# A, ## C", "unchanged A, removed B, unchanged C"},
+		{"moved", "# A, ## B, ## C", "# A, ## C, ## B", "unchanged A, moved C, unchanged B"},
+		{"level changed", "# A, ## B", "# A, ### B", "unchanged A, moved B (h2 -> h3)"},
+		{"empty", "", "# A", "added A"},
+	} {
+		changes := []string{}
+		for _, c := range diffOutlines(parseOutline(tc.before), parseOutline(tc.after)) {
+			h := c.New
+			if h == nil {
+				h = c.Old
+			}
+
+			change := c.Change + " " + h.Text
+			if c.Change == "moved" && c.Old.Level != c.New.Level {
+				change += fmt.Sprintf(" (h%d -> h%d)", c.Old.Level, c.New.Level)
+			}
+			changes = append(changes, change)
+		}
+
+		if actual := strings.Join(changes, ", "); actual != tc.diff {
+			t.Errorf("%s: expected '%s', got '%s'", tc.name, tc.diff, actual)
+		}
+	}
+}
+
+func TestBuildSections(t *testing.T) {
+	var format func(sections []*Section) string
+	format = func(sections []*Section) string {
+		parts := []string{}
+		for _, s := range sections {
+			part := s.Text
+			if len(s.Sections) > 0 {
+				part += "(" + format(s.Sections) + ")"
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	for _, tc := range []struct {
+		headings string
+		sections string
+	}{
+		{"", ""},
+		{"# A, # B", "A B"},
+		{"# A, ## B, ### C, ## D", "A(B(C) D)"},
+		{"# A, ### B, ## C", "A(B C)"},
+		{"## A, # B, ## C", "A B(C)"},
+	} {
+		if actual := format(buildSections(parseOutline(tc.headings))); actual != tc.sections {
+			t.Errorf("'%s': expected '%s', got '%s'", tc.headings, tc.sections, actual)
+		}
+	}
+}
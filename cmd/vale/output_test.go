@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pterm/pterm"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// SnapshotData holds the expected output of each formatter.
+var SnapshotData = "../../testdata/snapshots"
+
+var record = flag.Bool("record", false, "Overwrite the formatter snapshots with the current output.")
+var verify = flag.Bool("verify", true, "Compare formatter output against the recorded snapshots.")
+
+// formatters maps a snapshot name to the output it's compared against.
+//
+// New output formats should add an entry here (and run `go test -record`).
+var formatters = map[string]func(linted []*core.File) error{
+	"JSON": func(linted []*core.File) error {
+		PrintJSONAlerts(linted)
+		return nil
+	},
+	"codeclimate": func(linted []*core.File) error {
+		PrintCodeClimateAlerts(linted)
+		return nil
+	},
+	"teamcity": func(linted []*core.File) error {
+		PrintTeamCityAlerts(linted)
+		return nil
+	},
+	"azure": func(linted []*core.File) error {
+		PrintAzureAlerts(linted)
+		return nil
+	},
+	"github": func(linted []*core.File) error {
+		PrintGitHubAlerts(linted)
+		return nil
+	},
+	"junit": func(linted []*core.File) error {
+		PrintJUnitAlerts(linted)
+		return nil
+	},
+	"line": func(linted []*core.File) error {
+		PrintLineAlerts(linted, false)
+		return nil
+	},
+	"CLI": func(linted []*core.File) error {
+		PrintVerboseAlerts(linted, false, false)
+		return nil
+	},
+	"custom": func(linted []*core.File) error {
+		cfg, err := core.NewConfig(&core.CLIFlags{
+			Output: filepath.Join(SnapshotData, "custom.tmpl"),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = PrintCustomAlerts(linted, cfg)
+		return err
+	},
+	"template": func(linted []*core.File) error {
+		cfg, err := core.NewConfig(&core.CLIFlags{
+			Output: templatePrefix + filepath.Join(SnapshotData, "slack.tmpl"),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = PrintCustomAlerts(linted, cfg)
+		return err
+	},
+}
+
+// snapshotFiles returns a fixed set of linted files covering each severity,
+// multiple files, and a file without any alerts.
+func snapshotFiles() []*core.File {
+	return []*core.File{
+		{
+			Path: "docs/README.md",
+			Alerts: []core.Alert{
+				{
+					Check: "Vale.Spelling", Severity: "error", Line: 3, Span: []int{5, 8},
+					Match: "tset", Message: "Did you really mean 'tset'?",
+					Action: core.Action{Name: "suggest", Params: []string{"spellings"}},
+				},
+				{
+					Check: "Style.Passive", Severity: "suggestion", Line: 1, Span: []int{10, 17},
+					Match: "was made", Message: "'was made' may be passive voice.",
+					Link: "https://example.com/passive",
+				},
+			},
+		},
+		{
+			Path: "docs/guide.txt",
+			Alerts: []core.Alert{
+				{
+					Check: "Style.Terms", Severity: "warning", Line: 12, Span: []int{1, 10},
+					Match: "Javascript", Message: "Use 'JavaScript' instead of 'Javascript'.",
+					Action: core.Action{Name: "replace", Params: []string{"JavaScript"}},
+				},
+			},
+		},
+		{Path: "docs/clean.md"},
+	}
+}
+
+// captureStdout returns everything written to stdout by `fn`.
+func captureStdout(fn func() error) ([]byte, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	done := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- b
+	}()
+
+	err = fn()
+
+	os.Stdout = stdout
+	w.Close()
+
+	return <-done, err
+}
+
+func TestOutputSnapshots(t *testing.T) {
+	pterm.DisableStyling()
+	defer pterm.EnableStyling()
+
+	for name, format := range formatters {
+		t.Run(name, func(t *testing.T) {
+			out, err := captureStdout(func() error {
+				return format(snapshotFiles())
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join(SnapshotData, name+".golden")
+			if *record {
+				if err = os.WriteFile(golden, out, 0o600); err != nil {
+					t.Fatal(err)
+				}
+				return
+			} else if !*verify {
+				return
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("missing snapshot (run with `-record`): %s", err)
+			}
+
+			if !bytes.Equal(expected, out) {
+				t.Errorf("output of '%s' changed (run with `-record` if intended):\n--- expected\n%s\n--- actual\n%s",
+					name, expected, out)
+			}
+		})
+	}
+}
+
+func TestFoldRepeatedAlerts(t *testing.T) {
+	pterm.DisableStyling()
+	defer pterm.EnableStyling()
+
+	f := &core.File{Path: "repeated.md"}
+	for line := 1; line <= foldLimit+2; line++ {
+		f.Alerts = append(f.Alerts, core.Alert{
+			Check: "Vale.Spelling", Severity: "error", Line: line, Span: []int{1, 3},
+			Message: "Did you really mean 'Teh'?"})
+	}
+
+	for _, expand := range []bool{false, true} {
+		out, err := captureStdout(func() error {
+			PrintVerboseAlerts([]*core.File{f}, true, expand)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		shown := bytes.Count(out, []byte("Did you really mean"))
+		folded := bytes.Contains(out, []byte("repeated 2 more times"))
+		if expand && (shown != foldLimit+2 || folded) {
+			t.Errorf("Expected every alert with --verbose, got:\n%s", out)
+		} else if !expand && (shown != foldLimit || !folded) {
+			t.Errorf("Expected %d alerts and a folded line, got:\n%s", foldLimit, out)
+		} else if !bytes.Contains(out, []byte("5 errors")) {
+			t.Errorf("Expected the summary to count every alert, got:\n%s", out)
+		}
+	}
+}
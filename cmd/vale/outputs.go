@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// terminalSink is the `[outputs]` sink that represents the terminal (in the
+// format given by `--output`).
+const terminalSink = "CLI"
+
+// routeAlerts splits the alerts in `linted` between the sinks of the
+// config's `[outputs]` section, such as:
+//
+//	[outputs]
+//	error = CLI
+//	suggestion = reports/suggestions.json
+//	Microsoft = CLI, https://example.com/vale
+//
+// Each key is a rule, a style, or a level and each alert is sent to every
+// sink that one of its keys is routed to. Alerts that aren't routed anywhere
+// are printed to the terminal, as usual.
+func routeAlerts(linted []*core.File, outputs map[string][]string) ([]*core.File, map[string][]*core.File) {
+	terminal := []*core.File{}
+	routed := map[string][]*core.File{}
+
+	// Every sink gets a report, even if it's empty, so that we never leave
+	// a stale one behind.
+	for _, sinks := range outputs {
+		for _, sink := range sinks {
+			if sink != terminalSink {
+				routed[sink] = []*core.File{}
+			}
+		}
+	}
+
+	for _, f := range linted {
+		files := map[string]*core.File{}
+
+		for _, a := range f.Alerts {
+			sinks := alertSinks(a, outputs)
+			if len(sinks) == 0 {
+				sinks = []string{terminalSink}
+			}
+
+			for _, sink := range sinks {
+				if _, ok := files[sink]; !ok {
+					files[sink] = &core.File{Path: f.Path}
+					if sink != terminalSink {
+						routed[sink] = append(routed[sink], files[sink])
+					}
+				}
+				files[sink].Alerts = append(files[sink].Alerts, a)
+			}
+		}
+
+		// We keep every file in the terminal's output, since some formats
+		// report the number of files linted.
+		if shown, ok := files[terminalSink]; ok {
+			terminal = append(terminal, shown)
+		} else {
+			terminal = append(terminal, &core.File{Path: f.Path})
+		}
+	}
+
+	return terminal, routed
+}
+
+// alertSinks returns the sinks that the given alert is routed to.
+func alertSinks(a core.Alert, outputs map[string][]string) []string {
+	style, _, _ := strings.Cut(a.Check, ".")
+
+	sinks := []string{}
+	for _, key := range []string{a.Check, style, a.Severity} {
+		for _, sink := range outputs[key] {
+			if !core.StringInSlice(sink, sinks) {
+				sinks = append(sinks, sink)
+			}
+		}
+	}
+
+	return sinks
+}
+
+// writeOutputs sends the alerts routed by `routeAlerts` to their sinks: URLs
+// receive a webhook payload and `sqlite:<path>` sinks are added to a SQLite
+// database, while any other sink is a path to write a JSON report to.
+//
+// Paths are relative to the project's `.vale.ini` file.
+//
+// The returned bool reports whether any of the routed alerts are errors.
+func writeOutputs(routed map[string][]*core.File, config *core.Config) (bool, error) {
+	hasErrors := false
+
+	sinks := make([]string, 0, len(routed))
+	for sink := range routed {
+		sinks = append(sinks, sink)
+	}
+	sort.Strings(sinks)
+
+	var errs []error
+	for _, sink := range sinks {
+		// Each sink has its own copy of its alerts (see `routeAlerts`).
+		limitContext(routed[sink], config)
+
+		formatted, sinkErrors := formatJSONAlerts(routed[sink])
+		hasErrors = hasErrors || sinkErrors
+
+		if strings.HasPrefix(sink, "http://") || strings.HasPrefix(sink, "https://") {
+			errs = append(errs, sendWebhook(sink, routed[sink]))
+			continue
+		}
+
+		if err := core.CheckWritable("[outputs]"); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		path := strings.TrimPrefix(sink, sqlitePrefix)
+		if !filepath.IsAbs(path) && config.RootINI != "" {
+			path = filepath.Join(filepath.Dir(config.RootINI), path)
+		}
+
+		if strings.HasPrefix(sink, sqlitePrefix) {
+			if _, err := writeSQLite(path, routed[sink], config); err != nil {
+				errs = append(errs, core.NewE100("[outputs]", err))
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			errs = append(errs, core.NewE100("[outputs]", err))
+		} else if err = os.WriteFile(path, []byte(getJSON(formatted)+"\n"), 0o600); err != nil {
+			errs = append(errs, core.NewE100("[outputs]", err))
+		}
+	}
+
+	return hasErrors, errors.Join(errs...)
+}
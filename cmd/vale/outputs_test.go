@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestRouteAlerts(t *testing.T) {
+	outputs := map[string][]string{
+		"error":        {"CLI"},
+		"suggestion":   {"report.json"},
+		"Style.Terms":  {"terms.json", "CLI"},
+		"Vale":         {"https://example.com/vale"},
+		"Unused.Check": {"unused.json"},
+	}
+
+	terminal, routed := routeAlerts(snapshotFiles(), outputs)
+
+	shown := map[string]int{}
+	for _, f := range terminal {
+		shown[f.Path] = len(f.Alerts)
+	}
+
+	// `Vale.Spelling` is an error (CLI) in the `Vale` style (webhook), while
+	// `Style.Terms` is routed to both the CLI and its own report.
+	expected := map[string]int{"docs/README.md": 1, "docs/guide.txt": 1, "docs/clean.md": 0}
+	for path, count := range expected {
+		if shown[path] != count {
+			t.Errorf("Expected %d alerts on the terminal for '%s', got %v", count, path, shown)
+		}
+	}
+
+	for sink, checks := range map[string][]string{
+		"report.json":              {"Style.Passive"},
+		"terms.json":               {"Style.Terms"},
+		"https://example.com/vale": {"Vale.Spelling"},
+		"unused.json":              {},
+	} {
+		found := []string{}
+		for _, f := range routed[sink] {
+			for _, a := range f.Alerts {
+				found = append(found, a.Check)
+			}
+		}
+		if strings.Join(found, ",") != strings.Join(checks, ",") {
+			t.Errorf("Expected %v to be routed to '%s', got %v", checks, sink, found)
+		}
+	}
+}
+
+func TestWriteOutputs(t *testing.T) {
+	dir := t.TempDir()
+
+	config, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.RootINI = filepath.Join(dir, ".vale.ini")
+
+	_, routed := routeAlerts(snapshotFiles(), map[string][]string{
+		"suggestion":  {"reports/suggestions.json"},
+		"Other.Rule":  {"reports/empty.json"},
+		"Style.Terms": {"CLI"},
+	})
+
+	hasErrors, err := writeOutputs(routed, config)
+	if err != nil {
+		t.Fatal(err)
+	} else if hasErrors {
+		t.Error("Expected no routed errors")
+	}
+
+	report, err := os.ReadFile(filepath.Join(dir, "reports", "suggestions.json"))
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(string(report), "Style.Passive") {
+		t.Errorf("Expected the suggestion in the report, got:\n%s", report)
+	}
+
+	empty, err := os.ReadFile(filepath.Join(dir, "reports", "empty.json"))
+	if err != nil {
+		t.Fatal(err)
+	} else if strings.TrimSpace(string(empty)) != "{}" {
+		t.Errorf("Expected an empty report, got:\n%s", empty)
+	}
+}
+
+func TestWriteOutputsRedacted(t *testing.T) {
+	dir := t.TempDir()
+
+	config, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.RootINI = filepath.Join(dir, ".vale.ini")
+	config.RedactContext = true
+
+	_, routed := routeAlerts(snapshotFiles(), map[string][]string{
+		"warning": {"reports/warnings.json"},
+	})
+
+	if _, err = writeOutputs(routed, config); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := os.ReadFile(filepath.Join(dir, "reports", "warnings.json"))
+	if err != nil {
+		t.Fatal(err)
+	} else if strings.Contains(string(report), "'Javascript'") || !strings.Contains(string(report), "[REDACTED]") {
+		t.Errorf("Expected the match to be redacted, got:\n%s", report)
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mholt/archiver/v3"
+	cp "github.com/otiai10/copy"
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// styleTestsDir is the (optional) directory, within a style, that holds
+// test cases for its rules; it isn't included in the packaged style.
+//
+// Each test case is named after the rule it tests and whether it should
+// pass or fail: for example, `Headings.valid.md` must not trigger the
+// `Headings` rule, while `Headings.invalid.md` must trigger it at least once.
+const styleTestsDir = "tests"
+
+var reStyleVersion = regexp.MustCompile(`^v?\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)?$`)
+
+func init() {
+	pflag.StringVar(&Flags.Out, "out", "",
+		fmt.Sprintf(`The archive created by 'package' (%s).`, toCodeStyle(`--out=mystyle-1.2.0.zip`)))
+
+	commandInfo["package"] = "Validate and archive a style for distribution via `sync`."
+	Actions["package"] = packageStyle
+}
+
+// packageStyle validates a style and writes it to a zip archive that can be
+// listed in a `.vale.ini` file's `Packages` key.
+func packageStyle(args []string, flags *core.CLIFlags) error {
+	if len(args) != 1 {
+		return core.NewE100("package", errors.New("one style is required"))
+	}
+
+	dir, err := findStyleDir(args[0], flags)
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(dir)
+
+	meta, err := readStyleMeta(dir, name)
+	if err != nil {
+		return err
+	}
+
+	rules, err := compileStyle(dir, name)
+	if err != nil {
+		return err
+	}
+
+	failures, err := testStyle(dir, name)
+	if err != nil {
+		return err
+	} else if len(failures) > 0 {
+		for _, failure := range failures {
+			pterm.Error.Println(failure)
+		}
+		return core.NewE100("package", fmt.Errorf(
+			"%d %s failed", len(failures), pluralize("test", len(failures))))
+	}
+
+	out := flags.Out
+	if out == "" {
+		out = fmt.Sprintf("%s-%s.zip", name, strings.TrimPrefix(meta.Version, "v"))
+	}
+
+	if err = archiveStyle(dir, out, meta); err != nil {
+		return core.NewE100("package", err)
+	}
+
+	pterm.Success.Printfln("Packaged %d %s from '%s' in '%s'.",
+		rules, pluralize("rule", rules), name, out)
+
+	return nil
+}
+
+// findStyleDir returns the directory of the given style, which is either a
+// path or the name of a style on the current StylesPath.
+func findStyleDir(style string, flags *core.CLIFlags) (string, error) {
+	if core.IsDir(style) {
+		return filepath.Abs(style)
+	}
+
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return "", err
+	}
+
+	for _, p := range cfg.SearchPaths() {
+		if dir := filepath.Join(p, style); core.IsDir(dir) {
+			return dir, nil
+		}
+	}
+
+	return "", core.NewE100("package", fmt.Errorf(
+		"'%s' is neither a directory nor a style on StylesPath", style))
+}
+
+// readStyleMeta reads the style's `meta.json` file, which must include a
+// version.
+//
+// If the style doesn't specify the minimum version of Vale it requires, we
+// assume it requires the current version.
+func readStyleMeta(dir, name string) (Meta, error) {
+	meta := Meta{}
+
+	path := filepath.Join(dir, "meta.json")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return meta, core.NewE100("package", fmt.Errorf(
+			"'%s' is missing a meta.json file", name))
+	} else if err = json.Unmarshal(b, &meta); err != nil {
+		return meta, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+
+	if !reStyleVersion.MatchString(meta.Version) {
+		return meta, core.NewE201FromTarget(
+			"'version' must be a semantic version (e.g., '1.2.0').", "version", path)
+	}
+
+	if meta.Name == "" {
+		meta.Name = name
+	}
+	if meta.Vale == "" && version != "master" {
+		meta.Vale = version
+	}
+
+	return meta, nil
+}
+
+// newStyleConfig returns a configuration that only uses the given style.
+func newStyleConfig(dir, name string) (*core.Config, error) {
+	cfg, err := core.NewConfig(&core.CLIFlags{IgnoreGlobal: true})
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.Paths = []string{filepath.Dir(dir)}
+	cfg.Styles = []string{name}
+	cfg.GBaseStyles = []string{name}
+	cfg.MinAlertLevel = 0
+
+	return cfg, nil
+}
+
+// compileStyle ensures that all of the style's rules compile, returning the
+// number of rules.
+func compileStyle(dir, name string) (int, error) {
+	cfg, err := newStyleConfig(dir, name)
+	if err != nil {
+		return 0, err
+	}
+
+	mgr, err := check.NewManager(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for rule := range mgr.Rules() {
+		if strings.HasPrefix(rule, name+".") {
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0, core.NewE100("package", fmt.Errorf("'%s' has no rules", name))
+	}
+
+	return count, nil
+}
+
+// testStyle runs the style's test cases (see `styleTestsDir`), returning a
+// description of each failure.
+func testStyle(dir, name string) ([]string, error) {
+	failures := []string{}
+
+	cases, err := filepath.Glob(filepath.Join(dir, styleTestsDir, "*.*.*"))
+	if err != nil || len(cases) == 0 {
+		return failures, err
+	}
+	sort.Strings(cases)
+
+	cfg, err := newStyleConfig(dir, name)
+	if err != nil {
+		return failures, err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return failures, err
+	}
+
+	for _, path := range cases {
+		parts := strings.Split(filepath.Base(path), ".")
+		rule, kind := name+"."+parts[0], parts[1]
+
+		if kind != "valid" && kind != "invalid" {
+			continue
+		} else if _, found := linter.Manager.Rules()[rule]; !found {
+			failures = append(failures, fmt.Sprintf("%s: unknown rule '%s'", path, rule))
+			continue
+		}
+
+		linted, lintErr := linter.Lint([]string{path}, "*")
+		if lintErr != nil {
+			return failures, lintErr
+		}
+
+		found := 0
+		for _, f := range linted {
+			for _, a := range f.Alerts {
+				if a.Check == rule {
+					found++
+				}
+			}
+		}
+
+		if kind == "valid" && found > 0 {
+			failures = append(failures, fmt.Sprintf(
+				"%s: expected no alerts from '%s', got %d", path, rule, found))
+		} else if kind == "invalid" && found == 0 {
+			failures = append(failures, fmt.Sprintf(
+				"%s: expected alerts from '%s', got none", path, rule))
+		}
+	}
+
+	return failures, nil
+}
+
+// archiveStyle writes the style to a zip file laid out like a complete
+// package -- i.e., `<name>/styles/<style>` -- so that `sync` installs it
+// regardless of the archive's name.
+func archiveStyle(dir, out string, meta Meta) error {
+	tmp, err := os.MkdirTemp("", "vale-package")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	root := filepath.Join(tmp, fileNameWithoutExt(out))
+	dst := filepath.Join(root, "styles", filepath.Base(dir))
+
+	tests := filepath.Join(dir, styleTestsDir)
+	err = cp.Copy(dir, dst, cp.Options{
+		Skip: func(src string) (bool, error) {
+			return src == tests, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	} else if err = os.WriteFile(filepath.Join(dst, "meta.json"), b, 0o600); err != nil {
+		return err
+	}
+
+	z := archiver.NewZip()
+	z.OverwriteExisting = true
+
+	return z.Archive([]string{root}, out)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func mockStyle(t *testing.T, invalid string) string {
+	dir := filepath.Join(t.TempDir(), "MyStyle")
+
+	files := map[string]string{
+		"meta.json": `{"version": "1.2.0"}`,
+		"Very.yml":  "extends: existence\nmessage: \"Avoid '%s'.\"\ntokens: [very]\n",
+		filepath.Join(styleTestsDir, "Very.valid.md"):   "This is fine.\n",
+		filepath.Join(styleTestsDir, "Very.invalid.md"): invalid,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestPackageStyle(t *testing.T) {
+	dir := mockStyle(t, "This is very bad.\n")
+
+	meta, err := readStyleMeta(dir, "MyStyle")
+	if err != nil {
+		t.Fatal(err)
+	} else if meta.Name != "MyStyle" || meta.Version != "1.2.0" {
+		t.Fatalf("unexpected metadata: %+v", meta)
+	}
+
+	if rules, compileErr := compileStyle(dir, "MyStyle"); compileErr != nil || rules != 1 {
+		t.Fatalf("expected 1 rule, got %d (%v)", rules, compileErr)
+	}
+
+	failures, err := testStyle(dir, "MyStyle")
+	if err != nil || len(failures) != 0 {
+		t.Fatalf("expected tests to pass, got %v (%v)", failures, err)
+	}
+
+	out := filepath.Join(t.TempDir(), "mystyle-1.2.0.zip")
+	if err = archiveStyle(dir, out, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	styles := t.TempDir()
+	if err = loadLocalZipPkg(fileNameWithoutExt(out), out, styles, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if !core.FileExists(filepath.Join(styles, "MyStyle", "Very.yml")) {
+		t.Error("expected the packaged rule to be installed")
+	} else if core.IsDir(filepath.Join(styles, "MyStyle", styleTestsDir)) {
+		t.Error("expected the style's tests to be left out")
+	}
+}
+
+func TestPackageStyleFailures(t *testing.T) {
+	dir := mockStyle(t, "This is fine.\n")
+
+	failures, err := testStyle(dir, "MyStyle")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(failures) != 1 {
+		t.Errorf("expected 1 failure, got %v", failures)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	} else if _, err = readStyleMeta(dir, "MyStyle"); err == nil {
+		t.Error("expected an error for a missing version")
+	}
+}
@@ -213,3 +213,30 @@ func TestV3Pkg(t *testing.T) {
 		t.Fatal("unable to find 't.tmpl'")
 	}
 }
+
+func TestPkgVersion(t *testing.T) {
+	cases := map[string][]string{
+		"Google":                        {"Google", ""},
+		"Google@v0.6.1":                 {"Google", "v0.6.1"},
+		"https://x.com/a/Foo@2.zip":     {"https://x.com/a/Foo@2.zip", ""},
+		"styles/packages/Acme@beta.zip": {"styles/packages/Acme@beta.zip", ""},
+	}
+
+	for pkg, expected := range cases {
+		name, version := splitPkgVersion(pkg)
+		if name != expected[0] || version != expected[1] {
+			t.Errorf("%s: expected %v, got [%s %s]", pkg, expected, name, version)
+		}
+	}
+
+	url, err := pinnedURL("Google", "https://github.com/errata-ai/Google/releases/latest/download/Google.zip", "v0.6.1")
+	if err != nil {
+		t.Fatal(err)
+	} else if url != "https://github.com/errata-ai/Google/releases/download/v0.6.1/Google.zip" {
+		t.Errorf("Unexpected URL: %s", url)
+	}
+
+	if _, err = pinnedURL("Acme", "https://example.com/Acme.zip", "v1"); err == nil {
+		t.Error("Expected an error for a URL without releases")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// scopeTextLimit is the number of characters of each block's text we show.
+const scopeTextLimit = 60
+
+// printScopes lints a file and prints each block of text that the rules were
+// given: its scope, its lines, and how many rules would see it.
+func printScopes(args []string, cfg *core.Config) error {
+	if len(args) != 1 {
+		return core.NewE100("--print-scopes", errors.New("one file is required"))
+	} else if !core.FileExists(args[0]) {
+		return core.NewE100("--print-scopes", fmt.Errorf("file '%s' not found", args[0]))
+	}
+	path := args[0]
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	_, blocks, err := linter.Scopes(path)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return core.NewE100("--print-scopes", err)
+	}
+	lines := strings.Split(string(content), "\n")
+
+	if len(blocks) == 0 {
+		fmt.Printf("No text in '%s' was given to any rules.\n", path)
+		return nil
+	}
+
+	total := len(linter.Manager.Rules())
+	for _, scoped := range blocks {
+		fmt.Printf("[%s] %s, %d/%d %s\n", scoped.Block.Scope,
+			blockLines(lines, scoped.Block),
+			scoped.Rules, total, pluralize("rule", total))
+		fmt.Printf("  %q\n", truncateText(scoped.Block.Text, scopeTextLimit))
+
+		reasons := make([]string, 0, len(scoped.Skipped))
+		for reason := range scoped.Skipped {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+
+		for _, reason := range reasons {
+			n := scoped.Skipped[reason]
+			fmt.Printf("  skipped %d %s: %s\n", n, pluralize("rule", n), reason)
+		}
+	}
+
+	fmt.Println("\nAny text not listed above (such as code) isn't seen by any rule.")
+	return nil
+}
+
+// blockLines describes the lines of `lines` that a block spans.
+//
+// A block's line, if known, may be any of the lines it spans, so we look for
+// its first line of text at or before it.
+func blockLines(lines []string, blk nlp.Block) string {
+	if strings.HasPrefix(blk.Scope, "summary") || strings.HasPrefix(blk.Scope, "raw") {
+		return "the whole file"
+	}
+
+	// The block's text has been rendered, so we look for its first line's
+	// longest word rather than the line itself.
+	text := strings.TrimSpace(blk.Text)
+	first := ""
+	for _, word := range strings.Fields(strings.SplitN(text, "\n", 2)[0]) {
+		if word = longestUnmarked(word); len(word) > len(first) {
+			first = word
+		}
+	}
+
+	if first == "" {
+		return "unknown lines"
+	}
+
+	last := len(lines) - 1
+	if blk.Line >= 0 && blk.Line < last {
+		last = blk.Line
+	}
+
+	start := 0
+	for i := last; i >= 0 && start == 0; i-- {
+		if strings.Contains(lines[i], first) {
+			start = i + 1
+		}
+	}
+	for i := last + 1; i < len(lines) && start == 0; i++ {
+		if strings.Contains(lines[i], first) {
+			start = i + 1
+		}
+	}
+
+	if start == 0 {
+		return "unknown lines"
+	}
+
+	end := start + strings.Count(text, "\n")
+	if end == start {
+		return fmt.Sprintf("line %d", start)
+	}
+	return fmt.Sprintf("lines %d-%d", start, end)
+}
+
+func truncateText(text string, limit int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) > limit {
+		return string(runes[:limit-3]) + "..."
+	}
+	return string(runes)
+}
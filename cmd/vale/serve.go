@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	gosync "sync"
+	"syscall"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/pflag"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// maxServeBody is the largest request body that `vale serve` accepts.
+const maxServeBody = 10 << 20
+
+// lintRequest is the body of a `POST /lint` request.
+type lintRequest struct {
+	// Text is the content to lint.
+	Text string
+	// Format is the extension that determines how `Text` is parsed (e.g.,
+	// ".md"); it defaults to ".txt".
+	Format string
+	// Path is the (optional) path of the file that `Text` came from, which
+	// determines the config section that applies to it.
+	Path string
+	// Config overrides the server's configuration for this request.
+	Config lintOverrides
+}
+
+// lintOverrides are the configuration options that a request can change.
+type lintOverrides struct {
+	MinAlertLevel string
+	Filter        string
+	Profile       string
+}
+
+// lintServer lints the content sent to it over HTTP, reusing a loaded
+// linter for each distinct set of options.
+type lintServer struct {
+	flags core.CLIFlags
+	cache map[string]*daemonLinter
+
+	// NOTE: A `Linter` isn't safe for concurrent use, so requests are
+	// handled one at a time.
+	mu gosync.Mutex
+}
+
+func init() {
+	pflag.StringVar(&Flags.Host, "host", "127.0.0.1",
+		fmt.Sprintf(`The address that %s listens on.`, toCodeStyle(`serve`)))
+	pflag.IntVar(&Flags.Port, "port", 7777,
+		fmt.Sprintf(`The port that %s listens on (%s).`, toCodeStyle(`serve`), toCodeStyle(`--port=7777`)))
+
+	commandInfo["serve"] = "Serve an HTTP JSON API for linting (`POST /lint`) until interrupted."
+	Actions["serve"] = serve
+}
+
+// serve runs an HTTP server that lints the content of each `POST /lint`
+// request.
+func serve(_ []string, flags *core.CLIFlags) error {
+	// Load the configuration once up front so that any problems are
+	// reported before we start listening.
+	base := *flags
+	if _, err := core.ReadPipeline(&base, false); err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(flags.Host, strconv.Itoa(flags.Port))
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           newLintServer(*flags).routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+	pterm.Info.Printfln("Listening on http://%s.", addr)
+
+	select {
+	case err := <-errs:
+		return core.NewE100("serve", err)
+	case <-ctx.Done():
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdown); err != nil {
+		return core.NewE100("serve", err)
+	}
+	return nil
+}
+
+func newLintServer(flags core.CLIFlags) *lintServer {
+	return &lintServer{flags: flags, cache: map[string]*daemonLinter{}}
+}
+
+func (s *lintServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /lint", s.handleLint)
+	mux.HandleFunc("GET /health", s.handleHealth)
+	return mux
+}
+
+func (s *lintServer) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeServeJSON(w, http.StatusOK, map[string]string{"Version": version})
+}
+
+func (s *lintServer) handleLint(w http.ResponseWriter, r *http.Request) {
+	var req lintRequest
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxServeBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+
+	path, err := requestPath(req)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	linter, err := s.linter(req.Config)
+	if err != nil {
+		writeServeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// NOTE: A request is abandoned if its client goes away or, if set, it
+	// takes longer than `--max-duration`.
+	ctx := r.Context()
+	if budget := s.flags.MaxDuration; budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+
+	f, err := linter.LintContentWithContext(ctx, path, req.Text)
+	if errors.Is(err, context.DeadlineExceeded) {
+		writeServeError(w, http.StatusServiceUnavailable,
+			fmt.Errorf("linting took longer than --max-duration (%s)", s.flags.MaxDuration))
+		return
+	} else if err != nil {
+		writeServeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	result := batchResult{Path: path, Alerts: []core.Alert{}}
+	result.Alerts = append(result.Alerts, f.SortedAlerts()...)
+	limitAlerts(result.Alerts, linter.Manager.Config)
+
+	writeServeJSON(w, http.StatusOK, result)
+}
+
+// linter returns a (cached) linter for the server's configuration, with the
+// given overrides applied.
+func (s *lintServer) linter(overrides lintOverrides) (*lint.Linter, error) {
+	flags := s.flags
+	if overrides.MinAlertLevel != "" {
+		flags.AlertLevel = overrides.MinAlertLevel
+	}
+	if overrides.Filter != "" {
+		flags.Filter = overrides.Filter
+	}
+	if overrides.Profile != "" {
+		flags.Profile = overrides.Profile
+	}
+
+	key, err := json.Marshal(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := core.ReadPipeline(&flags, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedLinter(s.cache, string(key), cfg)
+}
+
+// requestPath returns the path to lint a request's text as.
+func requestPath(req lintRequest) (string, error) {
+	if req.Path != "" {
+		return req.Path, nil
+	}
+
+	ext := req.Format
+	if ext == "" {
+		ext = ".txt"
+	} else if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	if strings.ContainsAny(ext, `/\`) {
+		return "", fmt.Errorf("'%s' isn't a file extension", req.Format)
+	}
+	return "stdin" + ext, nil
+}
+
+func writeServeError(w http.ResponseWriter, status int, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		status = http.StatusRequestEntityTooLarge
+	}
+	writeServeJSON(w, status, map[string]string{"Error": strings.TrimSpace(err.Error())})
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
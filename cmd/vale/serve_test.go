@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestServeLint(t *testing.T) {
+	ini := filepath.Join(t.TempDir(), ".vale.ini")
+	if err := os.WriteFile(ini, []byte("MinAlertLevel = suggestion\n\n[*]\nBasedOnStyles = Vale\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(newLintServer(core.CLIFlags{Path: ini}).routes())
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		body   string
+		status int
+		checks string
+	}{
+		{`{"Text": "This is is teh test.", "Format": "md"}`, http.StatusOK, "Vale.Repetition, Vale.Spelling"},
+		{`{"text": "This is teh test.", "config": {"filter": ".Name == 'Vale.Repetition'"}}`, http.StatusOK, ""},
+		{`{"Txt": "This is teh test."}`, http.StatusBadRequest, ""},
+		{`{"Text": "teh", "Format": "../md"}`, http.StatusBadRequest, ""},
+	} {
+		resp, err := http.Post(srv.URL+"/lint", "application/json", strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result batchResult
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		} else if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d, got %d (%s)", tc.body, tc.status, resp.StatusCode, result.Error)
+			continue
+		}
+
+		checks := []string{}
+		for _, a := range result.Alerts {
+			checks = append(checks, a.Check)
+		}
+		if actual := strings.Join(checks, ", "); actual != tc.checks {
+			t.Errorf("%s: expected '%s', got '%s'", tc.body, tc.checks, actual)
+		}
+	}
+
+	resp, err := http.Get(srv.URL + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected /health to succeed, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// sqlitePrefix marks an `--output` value (or an `[outputs]` sink) as a
+// SQLite database (e.g., `--output=sqlite:results.db`).
+const sqlitePrefix = "sqlite:"
+
+// sqliteSchema is the schema of the database written by `--output=sqlite:`.
+//
+// Each run adds one row to `runs`, one row to `files` for every file that it
+// linted (with or without alerts), and one row to `alerts` for every alert.
+// Existing rows are never changed, so the database holds the history of
+// every run written to it.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+    id          INTEGER PRIMARY KEY,
+    finished    TEXT NOT NULL,    -- when the run finished (RFC 3339, UTC)
+    version     TEXT NOT NULL,    -- the version of Vale
+    config      TEXT NOT NULL,    -- the project's .vale.ini file, if any
+    files       INTEGER NOT NULL, -- the number of files linted
+    errors      INTEGER NOT NULL, -- the number of alerts at each level
+    warnings    INTEGER NOT NULL,
+    suggestions INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS files (
+    id     INTEGER PRIMARY KEY,
+    run_id INTEGER NOT NULL REFERENCES runs (id),
+    path   TEXT NOT NULL,         -- relative to the working directory
+    alerts INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS alerts (
+    id          INTEGER PRIMARY KEY,
+    file_id     INTEGER NOT NULL REFERENCES files (id),
+    rule        TEXT NOT NULL,    -- e.g., 'Vale.Spelling'
+    severity    TEXT NOT NULL,    -- 'suggestion', 'warning', or 'error'
+    message     TEXT NOT NULL,
+    description TEXT NOT NULL,
+    link        TEXT NOT NULL,
+    match       TEXT NOT NULL,
+    line        INTEGER NOT NULL,
+    span_start  INTEGER NOT NULL, -- the columns of the match (1-based)
+    span_end    INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS files_run ON files (run_id);
+CREATE INDEX IF NOT EXISTS alerts_file ON alerts (file_id);
+CREATE INDEX IF NOT EXISTS alerts_rule ON alerts (rule);
+`
+
+// PrintSQLiteAlerts adds the given alerts to the SQLite database named by
+// `--output=sqlite:<path>`.
+func PrintSQLiteAlerts(linted []*core.File, config *core.Config) (bool, error) {
+	if err := checkSQLite(config.Flags.Output, "--output"); err != nil {
+		return false, err
+	}
+
+	path := strings.TrimPrefix(config.Flags.Output, sqlitePrefix)
+	hasErrors, err := writeSQLite(path, linted, config)
+	if err != nil {
+		return hasErrors, core.NewE100("--output", err)
+	}
+	return hasErrors, nil
+}
+
+// checkSQLite returns an error if we won't be able to write to `sink` when
+// it's a SQLite database (see `writeSQLite`), so that a misconfigured output
+// is reported before, rather than after, linting.
+func checkSQLite(sink, option string) error {
+	if !strings.HasPrefix(sink, sqlitePrefix) {
+		return nil
+	} else if strings.TrimPrefix(sink, sqlitePrefix) == "" {
+		return core.NewE100(option, errors.New("'sqlite:' requires a path (e.g., 'sqlite:results.db')"))
+	} else if core.Which([]string{"sqlite3"}) == "" {
+		return core.NewE100(option, errors.New("writing a SQLite database requires 'sqlite3'"))
+	}
+	return core.CheckWritable(option)
+}
+
+// writeSQLite adds a run to the database at `path`, creating it if needed.
+//
+// NOTE: We don't link a SQLite driver into Vale (which would require cgo or
+// a very large dependency); instead, we pipe our statements into the
+// `sqlite3` executable, as we do for other external tools (see `Which`).
+func writeSQLite(path string, linted []*core.File, config *core.Config) (bool, error) {
+	sqlite := core.Which([]string{"sqlite3"})
+	if sqlite == "" {
+		return false, errors.New("writing a SQLite database requires 'sqlite3'")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return false, err
+	}
+
+	script, hasErrors := sqliteScript(linted, config, time.Now())
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(sqlite, "-bail", path) //nolint:gosec
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return hasErrors, fmt.Errorf("sqlite3: %s", strings.TrimSpace(stderr.String()+" "+err.Error()))
+	}
+	return hasErrors, nil
+}
+
+// sqliteScript returns the statements that add a run to the database (see
+// `sqliteSchema`) and whether any of its alerts are errors.
+func sqliteScript(linted []*core.File, config *core.Config, finished time.Time) (string, bool) {
+	var sb strings.Builder
+
+	counts := map[string]int{}
+	for _, f := range linted {
+		for _, a := range f.Alerts {
+			counts[a.Severity]++
+		}
+	}
+
+	sb.WriteString("BEGIN IMMEDIATE;\n")
+	sb.WriteString(sqliteSchema)
+
+	fmt.Fprintf(&sb,
+		"INSERT INTO runs (finished, version, config, files, errors, warnings, suggestions) VALUES (%s, %s, %s, %d, %d, %d, %d);\n",
+		sqlQuote(finished.UTC().Format(time.RFC3339)),
+		sqlQuote(core.ValeVersion),
+		sqlQuote(config.RootINI),
+		len(linted), counts["error"], counts["warning"], counts["suggestion"])
+
+	for _, f := range linted {
+		fmt.Fprintf(&sb,
+			"INSERT INTO files (run_id, path, alerts) VALUES ((SELECT max(id) FROM runs), %s, %d);\n",
+			sqlQuote(reportPath(f.Path)), len(f.Alerts))
+
+		for _, a := range f.SortedAlerts() {
+			span := []int{0, 0}
+			if len(a.Span) == 2 {
+				span = a.Span
+			}
+
+			fmt.Fprintf(&sb,
+				"INSERT INTO alerts (file_id, rule, severity, message, description, link, match, line, span_start, span_end) "+
+					"VALUES ((SELECT max(id) FROM files), %s, %s, %s, %s, %s, %s, %d, %d, %d);\n",
+				sqlQuote(a.Check), sqlQuote(a.Severity), sqlQuote(a.Message), sqlQuote(a.Description),
+				sqlQuote(a.Link), sqlQuote(a.Match), a.Line, span[0], span[1])
+		}
+	}
+
+	sb.WriteString("COMMIT;\n")
+	return sb.String(), counts["error"] > 0
+}
+
+// sqlQuote returns `s` as a SQL string literal.
+func sqlQuote(s string) string {
+	s = strings.ReplaceAll(s, "\x00", "")
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestSQLiteOutput(t *testing.T) {
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		t.Skip("'sqlite3' isn't installed")
+	}
+
+	config, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "reports", "results.db")
+	config.Flags.Output = sqlitePrefix + path
+
+	for run := 0; run < 2; run++ {
+		hasErrors, perr := PrintAlerts(snapshotFiles(), config, nil)
+		if perr != nil {
+			t.Fatal(perr)
+		} else if !hasErrors {
+			t.Error("Expected the run to report errors")
+		}
+	}
+
+	query := "SELECT r.id, f.path, a.rule, a.match, a.span_start FROM alerts a " +
+		"JOIN files f ON a.file_id = f.id JOIN runs r ON f.run_id = r.id ORDER BY a.id;" +
+		"SELECT count(*), sum(errors), sum(suggestions) FROM runs;" +
+		"SELECT count(*) FROM files WHERE alerts = 0;"
+
+	out, err := exec.Command(sqlite, path, query).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"1|docs/README.md|Style.Passive|was made|10",
+		"1|docs/README.md|Vale.Spelling|tset|5",
+		"1|docs/guide.txt|Style.Terms|Javascript|1",
+		"2|docs/README.md|Style.Passive|was made|10",
+		"2|docs/README.md|Vale.Spelling|tset|5",
+		"2|docs/guide.txt|Style.Terms|Javascript|1",
+		"2|2|2",
+		"2",
+	}
+	if got := strings.TrimSpace(string(out)); got != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected database contents:\n%s", got)
+	}
+}
+
+func TestSQLQuote(t *testing.T) {
+	if q := sqlQuote("Don't\x00 stop"); q != "'Don''t stop'" {
+		t.Errorf("Unexpected literal: %s", q)
+	}
+}
+
+func TestCheckSQLite(t *testing.T) {
+	if err := checkSQLite("JSON", "--output"); err != nil {
+		t.Errorf("Expected no error for other outputs, got %v", err)
+	} else if err = checkSQLite(sqlitePrefix, "--output"); err == nil {
+		t.Error("Expected an error for a missing path")
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if err := checkSQLite(sqlitePrefix+"results.db", "[outputs]"); err == nil || !strings.Contains(err.Error(), "sqlite3") {
+		t.Errorf("Expected an error for a missing 'sqlite3', got %v", err)
+	}
+}
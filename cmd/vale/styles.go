@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pterm/pterm"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func init() {
+	commandInfo["fmt-styles"] = "Rewrite the given rules (or StylesPath) in canonical form."
+	Actions["fmt-styles"] = fmtStyles
+}
+
+// fmtStyles canonicalizes the rule definitions found in the given paths.
+//
+// When no paths are given, we format every style in the current StylesPath.
+// With `--check`, files are reported but not modified.
+func fmtStyles(args []string, flags *core.CLIFlags) error {
+	if !flags.Check {
+		if err := core.CheckWritable("fmt-styles"); err != nil {
+			return err
+		}
+	}
+
+	paths := args
+	if len(paths) == 0 {
+		cfg, err := core.ReadPipeline(flags, false)
+		if err != nil {
+			return err
+		}
+		paths = []string{cfg.StylesPath()}
+	}
+
+	changed := []string{}
+	for _, path := range paths {
+		files, err := findRules(path)
+		if err != nil {
+			return core.NewE100("fmt-styles", err)
+		}
+
+		for _, file := range files {
+			diff, err := fmtRule(file, flags.Check)
+			if err != nil {
+				return core.NewE100("fmt-styles", fmt.Errorf("%s: %w", file, err))
+			} else if diff {
+				changed = append(changed, file)
+			}
+		}
+	}
+
+	for _, file := range changed {
+		fmt.Println(file)
+	}
+
+	if flags.Check && len(changed) > 0 {
+		return core.NewE100("fmt-styles", fmt.Errorf(
+			"%d %s not canonically formatted", len(changed), pluralize("rule", len(changed))))
+	} else if !flags.Check {
+		pterm.Success.Printfln("Formatted %d %s.", len(changed), pluralize("rule", len(changed)))
+	}
+
+	return nil
+}
+
+// findRules returns all rule definitions under the given path, skipping the
+// StylesPath's `config` directory.
+func findRules(path string) ([]string, error) {
+	rules := []string{}
+
+	err := filepath.WalkDir(path, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() {
+			if fp != path && (d.Name() == core.ConfigDir || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		} else if filepath.Ext(fp) == ".yml" {
+			rules = append(rules, fp)
+		}
+		return nil
+	})
+
+	return rules, err
+}
+
+// fmtRule formats the given rule, reporting whether or not its content
+// changed.
+func fmtRule(path string, dryRun bool) (bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	out, err := check.FormatRule(src)
+	if err != nil {
+		return false, err
+	} else if bytes.Equal(src, out) {
+		return false, nil
+	}
+
+	if !dryRun {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+		return true, os.WriteFile(path, out, info.Mode().Perm())
+	}
+
+	return true, nil
+}
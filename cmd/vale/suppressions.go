@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+func init() {
+	commandInfo["suppressions"] = "Print the comments that turn off linting in the given files, with their reasons."
+	Actions["suppressions"] = suppressions
+}
+
+// suppressions lists every in-text comment that turns off linting (e.g.,
+// `<!-- vale off: quoting vendor text -->`) in the given files.
+//
+// This is meant to support audits of what isn't being checked -- and why --
+// so comments without a reason are listed as such.
+func suppressions(args []string, flags *core.CLIFlags) error {
+	if len(args) == 0 {
+		return core.NewE100("suppressions", errors.New("at least one argument expected"))
+	}
+
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	linted, err := linter.Lint(args, flags.Glob)
+	if err != nil {
+		return err
+	}
+
+	report := map[string][]core.Suppression{}
+	for _, f := range linted {
+		if len(f.Suppressed) > 0 {
+			report[reportPath(f.Path)] = f.Suppressed
+		}
+	}
+
+	if flags.Output == "JSON" {
+		return printJSON(report)
+	}
+
+	paths := make([]string, 0, len(report))
+	for path := range report {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		for _, s := range report[path] {
+			reason := s.Reason
+			if reason == "" {
+				reason = "(no reason given)"
+			}
+			fmt.Printf("%s:%d\t%s\t%s\n", path, s.Line, s.Comment, reason)
+		}
+	}
+
+	return nil
+}
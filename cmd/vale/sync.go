@@ -35,23 +35,61 @@ func initPath(cfg *core.Config) error {
 	return nil
 }
 
+// latestRelease is the part of a library package's URL that refers to its
+// most recent release.
+const latestRelease = "/releases/latest/download/"
+
+// splitPkgVersion splits a package from the library into its name and the
+// version it's pinned to (if any) -- e.g., `Google@v0.6.1`.
+//
+// URLs and paths are returned as is, since they already refer to a specific
+// archive.
+func splitPkgVersion(pkg string) (string, string) {
+	if strings.ContainsAny(pkg, `/\`) {
+		return pkg, ""
+	}
+	name, version, _ := strings.Cut(pkg, "@")
+	return name, version
+}
+
+// pinnedURL returns the URL of the given release of a library package.
+func pinnedURL(name, url, version string) (string, error) {
+	if !strings.Contains(url, latestRelease) {
+		return "", fmt.Errorf("'%s' can't be pinned to a version; use the URL of a release instead", name)
+	}
+	return strings.Replace(url, latestRelease, "/releases/download/"+version+"/", 1), nil
+}
+
 func readPkg(pkg, path string, idx int) error {
 	lookup, err := getLibrary(path)
 	if err != nil {
 		return err
 	}
 
+	name, version := splitPkgVersion(pkg)
+
 	found := false
 	for _, entry := range lookup {
-		if pkg == entry.Name {
+		if name == entry.Name {
 			found = true
-			if err = download(pkg, entry.URL, path, idx); err != nil {
+
+			url := entry.URL
+			if version != "" {
+				if url, err = pinnedURL(name, url, version); err != nil {
+					return core.NewE100("sync", err)
+				}
+			}
+
+			if err = download(name, url, path, idx); err != nil {
 				return err
 			}
 		}
 	}
 
-	if !found {
+	if !found && version != "" {
+		return core.NewE100("sync", fmt.Errorf(
+			"'%s' isn't in the package library, so it can't be pinned to a version", name))
+	} else if !found {
 		name := fileNameWithoutExt(pkg)
 		if err = loadPkg(name, pkg, path, idx); err != nil {
 			return err
@@ -72,10 +110,20 @@ func loadPkg(name, urlOrPath, styles string, index int) error {
 }
 
 func loadLocalPkg(name, pkgPath, styles string, index int) error {
+	// NOTE: Pins are checked against a package's archive, so a directory
+	// can't be verified -- we refuse it rather than silently skip its pins.
+	if packagePolicy.strict || len(packagePolicy.pins[name]) > 0 {
+		return core.NewE100("sync", fmt.Errorf(
+			"'%s' is a directory, which can't be verified (use an archive instead)", name))
+	}
 	return installPkg(filepath.Dir(pkgPath), name, styles, index)
 }
 
 func loadLocalZipPkg(name, pkgPath, styles string, index int) error {
+	if err := packagePolicy.verify(name, pkgPath, pkgPath); err != nil {
+		return core.NewE100("sync", err)
+	}
+
 	dir, err := os.MkdirTemp("", name)
 	if err != nil {
 		return err
@@ -94,12 +142,20 @@ func download(name, url, styles string, index int) error {
 		return err
 	}
 
-	if err = fetch(url, dir); err != nil {
+	archive, err := fetchArchive(url)
+	if err != nil {
 		if strings.Contains(err.Error(), "unsupported protocol scheme") {
 			err = fmt.Errorf("'%s' is not a valid URL or the local file doesn't exist", url)
 		}
 		return core.NewE100("download", err)
 	}
+	defer os.Remove(archive)
+
+	if err = packagePolicy.verify(name, archive, url); err != nil {
+		return core.NewE100("sync", err)
+	} else if err = archiver.Unarchive(archive, dir); err != nil {
+		return core.NewE100("download", err)
+	}
 
 	return installPkg(dir, name, styles, index)
 }
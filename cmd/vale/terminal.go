@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/pterm/pterm"
+	"golang.org/x/term"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// colorModes are the values accepted by `--color`.
+var colorModes = []string{"auto", "always", "never"}
+
+// themes maps the values accepted by `--theme` to the style of each alert
+// level.
+var themes = map[string]map[string]*pterm.Style{
+	"default": {
+		"error":      pterm.NewStyle(pterm.FgRed),
+		"warning":    pterm.NewStyle(pterm.FgYellow),
+		"suggestion": pterm.NewStyle(pterm.FgBlue),
+	},
+	// NOTE: Blue text is hard to read on dark backgrounds, so we use cyan
+	// instead.
+	"high-contrast": {
+		"error":      pterm.NewStyle(pterm.FgLightRed, pterm.Bold),
+		"warning":    pterm.NewStyle(pterm.FgLightYellow, pterm.Bold),
+		"suggestion": pterm.NewStyle(pterm.FgLightCyan, pterm.Bold),
+	},
+}
+
+// termCaps describes what the terminal we're writing to can display.
+type termCaps struct {
+	color   bool
+	unicode bool
+}
+
+// terminal holds the capabilities found by `configureTerminal`.
+var terminal = termCaps{color: true, unicode: true}
+
+// levelStyles holds the styles of the theme selected by `--theme`.
+var levelStyles = themes["default"]
+
+// detectCaps determines what the terminal can display:
+//
+//   - color is used if stdout is a terminal, `NO_COLOR` isn't set, and
+//     `TERM` isn't "dumb" -- unless `--color` says otherwise; and
+//   - Unicode symbols (such as "✔") are used unless `TERM` is "dumb" or the
+//     locale doesn't use UTF-8.
+func detectCaps(mode string, getenv func(string) string, isTTY bool) termCaps {
+	dumb := getenv("TERM") == "dumb"
+
+	caps := termCaps{unicode: !dumb && isUTF8Locale(getenv)}
+	switch mode {
+	case "always":
+		caps.color = true
+	case "never":
+		caps.color = false
+	default:
+		caps.color = isTTY && !dumb && getenv("NO_COLOR") == ""
+	}
+
+	return caps
+}
+
+// isUTF8Locale reports whether the user's locale uses UTF-8, assuming that
+// it does if no locale is set.
+func isUTF8Locale(getenv func(string) string) bool {
+	if runtime.GOOS == "windows" {
+		return true
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+
+	return true
+}
+
+// configureTerminal applies `--color` (or `--no-color`) and `--theme` to our
+// output.
+//
+// NOTE: We detect the terminal's capabilities before validating the flags so
+// that any errors respect them, too.
+func configureTerminal(flags *core.CLIFlags) error {
+	mode := flags.Color
+	if flags.NoColor {
+		mode = "never"
+	}
+
+	terminal = detectCaps(mode, os.Getenv, term.IsTerminal(int(os.Stdout.Fd())))
+	if terminal.color {
+		pterm.EnableColor()
+		if mode == "always" {
+			// NOTE: Otherwise, colors are only rendered if stdout looks like
+			// a color-capable terminal.
+			color.ForceOpenColor()
+		}
+	} else {
+		pterm.DisableColor()
+	}
+
+	if !terminal.unicode {
+		pterm.DefaultProgressbar.BarCharacter = "#"
+		pterm.DefaultProgressbar.LastCharacter = "#"
+		pterm.DefaultProgressbar.BarFiller = " "
+	}
+
+	if !core.StringInSlice(mode, colorModes) {
+		return core.NewE100("--color", fmt.Errorf(
+			"'%s' isn't one of %s", mode, strings.Join(colorModes, ", ")))
+	}
+
+	styles, ok := themes[flags.Theme]
+	if !ok {
+		return core.NewE100("--theme", fmt.Errorf(
+			"'%s' isn't one of default, high-contrast", flags.Theme))
+	}
+	levelStyles = styles
+
+	return nil
+}
+
+// levelSprint styles `s` as the given alert level.
+func levelSprint(level, s string) string {
+	if style, ok := levelStyles[level]; ok {
+		return style.Sprint(s)
+	}
+	return s
+}
+
+// summarySymbol is the symbol that starts the summary of our CLI output.
+func summarySymbol(failed bool) string {
+	switch {
+	case failed && terminal.unicode:
+		return "✖"
+	case failed:
+		return "x"
+	case terminal.unicode:
+		return "✔"
+	default:
+		return "ok"
+	}
+}
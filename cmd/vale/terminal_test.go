@@ -0,0 +1,52 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestDetectCaps(t *testing.T) {
+	cases := []struct {
+		name  string
+		mode  string
+		env   map[string]string
+		tty   bool
+		color bool
+		utf8  bool
+	}{
+		{"tty", "auto", map[string]string{"LANG": "en_US.UTF-8"}, true, true, true},
+		{"piped", "auto", map[string]string{}, false, false, true},
+		{"no-color", "auto", map[string]string{"NO_COLOR": "1"}, true, false, true},
+		{"dumb", "auto", map[string]string{"TERM": "dumb"}, true, false, false},
+		{"always", "always", map[string]string{"NO_COLOR": "1"}, false, true, true},
+		{"never", "never", map[string]string{}, true, false, true},
+		{"latin1", "auto", map[string]string{"LC_ALL": "en_US.ISO-8859-1", "LANG": "en_US.UTF-8"}, true, true, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			caps := detectCaps(tc.mode, func(k string) string { return tc.env[k] }, tc.tty)
+			if caps.color != tc.color {
+				t.Errorf("color: expected %v, got %v", tc.color, caps.color)
+			}
+			if runtime.GOOS != "windows" && caps.unicode != tc.utf8 {
+				t.Errorf("unicode: expected %v, got %v", tc.utf8, caps.unicode)
+			}
+		})
+	}
+}
+
+func TestSummarySymbol(t *testing.T) {
+	saved := terminal
+	defer func() { terminal = saved }()
+
+	terminal = termCaps{unicode: false}
+	if s := summarySymbol(true); s != "x" {
+		t.Errorf("expected 'x', got '%s'", s)
+	}
+
+	terminal = termCaps{unicode: true}
+	if s := summarySymbol(false); s != "✔" {
+		t.Errorf("expected '✔', got '%s'", s)
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// pkgPolicy is how `sync` verifies the archives of the packages it installs
+// (see the `[packages]` section and `--strict-packages`).
+type pkgPolicy struct {
+	pins   map[string][]string
+	strict bool
+}
+
+// packagePolicy is the policy of the current `sync`, which applies to every
+// package -- including those required by another package.
+var packagePolicy = pkgPolicy{}
+
+// verify checks the archive of the package `name`, which was read from
+// `src`, against its pins:
+//
+//   - `sha256:<hex>` is the archive's expected checksum;
+//   - `minisign:<public key>` requires a valid minisign signature at
+//     `<src>.minisig`; and
+//   - `gpg` (or `gpg:<fingerprint>`) requires a valid GPG signature at
+//     `<src>.asc` from a key in the user's keyring (or the given key).
+//
+// In strict mode, an archive without any pins is refused.
+func (p pkgPolicy) verify(name, archive, src string) error {
+	pins := p.pins[name]
+	if len(pins) == 0 {
+		if p.strict {
+			return fmt.Errorf(
+				"'%s' isn't pinned to a checksum or signature (see the [packages] section)", name)
+		}
+		return nil
+	}
+
+	content, err := os.ReadFile(archive)
+	if err != nil {
+		return err
+	}
+
+	for _, pin := range pins {
+		kind, value, _ := strings.Cut(strings.TrimSpace(pin), ":")
+		switch kind {
+		case "sha256":
+			sum := sha256.Sum256(content)
+			if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, value) {
+				return fmt.Errorf("checksum mismatch for '%s': expected %s, got %s", name, value, actual)
+			}
+		case "minisign":
+			sig, sigErr := readSignature(src + ".minisig")
+			if sigErr != nil {
+				return fmt.Errorf("unable to read the signature of '%s': %w", name, sigErr)
+			} else if sigErr = verifyMinisign(value, sig, content); sigErr != nil {
+				return fmt.Errorf("invalid signature for '%s': %w", name, sigErr)
+			}
+		case "gpg":
+			sig, sigErr := readSignature(src + ".asc")
+			if sigErr != nil {
+				return fmt.Errorf("unable to read the signature of '%s': %w", name, sigErr)
+			} else if sigErr = verifyGPG(value, sig, archive); sigErr != nil {
+				return fmt.Errorf("invalid signature for '%s': %w", name, sigErr)
+			}
+		default:
+			return fmt.Errorf("unknown pin '%s' for '%s'", pin, name)
+		}
+	}
+
+	return nil
+}
+
+// readSignature reads a detached signature from a local path or a URL.
+func readSignature(src string) ([]byte, error) {
+	if core.FileExists(src) {
+		return os.ReadFile(src)
+	}
+
+	resp, err := http.Get(src) //nolint:gosec,noctx
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch '%s' (status code '%d')", src, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// verifyMinisign checks a minisign signature of `content`.
+//
+// A public key is the base64 encoding of its algorithm ("Ed"), its ID (8
+// bytes), and its Ed25519 key. A signature file has four lines:
+//
+//	untrusted comment: <text>
+//	<base64: algorithm ("Ed" or, if pre-hashed, "ED"), key ID, signature>
+//	trusted comment: <text>
+//	<base64: signature of the signature and the trusted comment>
+func verifyMinisign(pubKey string, sig, content []byte) error {
+	key, err := base64.StdEncoding.DecodeString(pubKey)
+	if err != nil || len(key) != 42 || string(key[:2]) != "Ed" {
+		return errors.New("malformed minisign public key")
+	}
+
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(sig), "\r\n", "\n")), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return errors.New("malformed minisign signature")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(raw) != 74 {
+		return errors.New("malformed minisign signature")
+	}
+
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return errors.New("malformed minisign signature")
+	}
+
+	pk := ed25519.PublicKey(key[10:])
+	if !bytes.Equal(raw[2:10], key[2:10]) {
+		return errors.New("signed by a different key")
+	}
+
+	msg := content
+	switch string(raw[:2]) {
+	case "ED":
+		sum := blake2b.Sum512(content)
+		msg = sum[:]
+	case "Ed":
+	default:
+		return errors.New("unsupported minisign algorithm")
+	}
+
+	if !ed25519.Verify(pk, msg, raw[10:]) {
+		return errors.New("the archive doesn't match its signature")
+	}
+
+	trusted := append(append([]byte{}, raw[10:]...), strings.TrimPrefix(lines[2], "trusted comment: ")...)
+	if !ed25519.Verify(pk, trusted, global) {
+		return errors.New("the signature's trusted comment has been modified")
+	}
+
+	return nil
+}
+
+// reGPGFingerprint matches a full (v4) GPG fingerprint.
+//
+// NOTE: We don't accept short (8- or 16-digit) key IDs, which are easy to
+// spoof.
+var reGPGFingerprint = regexp.MustCompile(`^[0-9A-F]{40}$`)
+
+// verifyGPG checks a detached GPG signature of `archive` using the `gpg`
+// executable, optionally requiring that it was made by the key with the
+// given fingerprint.
+func verifyGPG(fingerprint string, sig []byte, archive string) error {
+	wanted := strings.ToUpper(strings.ReplaceAll(fingerprint, " ", ""))
+	if wanted != "" && !reGPGFingerprint.MatchString(wanted) {
+		return fmt.Errorf("'%s' isn't a full, 40-digit key fingerprint", fingerprint)
+	}
+
+	gpg, err := exec.LookPath("gpg")
+	if err != nil {
+		return errors.New("verifying GPG signatures requires 'gpg'")
+	}
+
+	sigFile, err := os.CreateTemp("", "vale-sig.*.asc")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(sigFile.Name())
+
+	if _, err = sigFile.Write(sig); err != nil {
+		sigFile.Close()
+		return err
+	} else if err = sigFile.Close(); err != nil {
+		return err
+	}
+
+	// NOTE: `gpg` exits with a nonzero status for bad signatures, but we
+	// rely on its machine-readable status lines rather than its exit code.
+	out, _ := exec.Command(gpg, "--batch", "--status-fd", "1", "--verify", sigFile.Name(), archive).Output() //nolint:gosec
+
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != "[GNUPG:]" || fields[1] != "VALIDSIG" {
+			continue
+		} else if wanted == "" || strings.ToUpper(fields[2]) == wanted {
+			return nil
+		}
+		return fmt.Errorf("signed by %s rather than %s", fields[2], fingerprint)
+	}
+
+	return errors.New("no valid signature found")
+}
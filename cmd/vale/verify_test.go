@@ -0,0 +1,170 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisignFixture signs `content` like `minisign -S` (pre-hashed, unless
+// `legacy` is set), returning the public key and the signature file.
+func minisignFixture(t *testing.T, content []byte, legacy bool) (string, string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := []byte("vale-key")
+
+	alg, msg := "ED", content
+	if legacy {
+		alg = "Ed"
+	} else {
+		sum := blake2b.Sum512(content)
+		msg = sum[:]
+	}
+
+	sig := append(append([]byte(alg), keyID...), ed25519.Sign(priv, msg)...)
+	comment := "timestamp:1700000000"
+	global := ed25519.Sign(priv, append(append([]byte{}, sig[10:]...), comment...))
+
+	key := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...))
+	file := strings.Join([]string{
+		"untrusted comment: signature from minisign secret key",
+		base64.StdEncoding.EncodeToString(sig),
+		"trusted comment: " + comment,
+		base64.StdEncoding.EncodeToString(global),
+	}, "\n") + "\n"
+
+	return key, file
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "Acme.zip")
+	if err := os.WriteFile(archive, []byte("archive"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("archive"))
+
+	policy := pkgPolicy{pins: map[string][]string{"Acme": {"sha256:" + hex.EncodeToString(sum[:])}}}
+	if err := policy.verify("Acme", archive, archive); err != nil {
+		t.Errorf("Expected a matching checksum, got %v", err)
+	}
+
+	policy.pins["Acme"] = []string{"sha256:" + strings.Repeat("0", 64)}
+	if err := policy.verify("Acme", archive, archive); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Errorf("Expected a checksum mismatch, got %v", err)
+	}
+
+	if err := policy.verify("Other", archive, archive); err != nil {
+		t.Errorf("Expected unpinned packages to be allowed, got %v", err)
+	}
+
+	policy.strict = true
+	if err := policy.verify("Other", archive, archive); err == nil {
+		t.Error("Expected unpinned packages to be refused in strict mode")
+	}
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	content := []byte("style archive")
+
+	for _, legacy := range []bool{false, true} {
+		key, sig := minisignFixture(t, content, legacy)
+
+		if err := verifyMinisign(key, []byte(sig), content); err != nil {
+			t.Errorf("Expected a valid signature (legacy = %v), got %v", legacy, err)
+		}
+
+		if err := verifyMinisign(key, []byte(sig), []byte("tampered")); err == nil {
+			t.Errorf("Expected a tampered archive to be refused (legacy = %v)", legacy)
+		}
+
+		forged := strings.Replace(sig, "timestamp:1700000000", "timestamp:1800000000", 1)
+		if err := verifyMinisign(key, []byte(forged), content); err == nil {
+			t.Errorf("Expected a modified trusted comment to be refused (legacy = %v)", legacy)
+		}
+	}
+
+	other, _ := minisignFixture(t, content, false)
+	_, sig := minisignFixture(t, content, false)
+	if err := verifyMinisign(other, []byte(sig), content); err == nil {
+		t.Error("Expected a signature from another key to be refused")
+	}
+}
+
+func TestVerifyMinisignPin(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "Acme.zip")
+
+	content := []byte("archive")
+	if err := os.WriteFile(archive, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, sig := minisignFixture(t, content, false)
+	if err := os.WriteFile(archive+".minisig", []byte(sig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := pkgPolicy{pins: map[string][]string{"Acme": {"minisign:" + key}}, strict: true}
+	if err := policy.verify("Acme", archive, archive); err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+
+	policy.pins["Acme"] = []string{"md5:abc"}
+	if err := policy.verify("Acme", archive, archive); err == nil {
+		t.Error("Expected an unknown pin to be an error")
+	}
+}
+
+func TestVerifyGPGPin(t *testing.T) {
+	for _, pin := range []string{"DEADBEEF", "0123456789ABCDEF", "not a fingerprint"} {
+		err := verifyGPG(pin, nil, "Acme.zip")
+		if err == nil || !strings.Contains(err.Error(), "40-digit") {
+			t.Errorf("%s: expected a short key ID to be refused, got %v", pin, err)
+		}
+	}
+}
+
+func TestVerifyLocalDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Local")
+	if err := os.MkdirAll(filepath.Join(dir, "styles"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := packagePolicy
+	defer func() { packagePolicy = saved }()
+
+	for _, policy := range []pkgPolicy{
+		{strict: true},
+		{pins: map[string][]string{"Local": {"sha256:" + strings.Repeat("0", 64)}}},
+	} {
+		packagePolicy = policy
+		if err := loadPkg("Local", dir, t.TempDir(), 0); err == nil || !strings.Contains(err.Error(), "can't be verified") {
+			t.Errorf("%+v: expected a local directory to be refused, got %v", policy, err)
+		}
+	}
+}
+
+func TestVerifyLocalZip(t *testing.T) {
+	zip, err := filepath.Abs(filepath.Join(TestData, "write-good.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved := packagePolicy
+	defer func() { packagePolicy = saved }()
+
+	packagePolicy = pkgPolicy{pins: map[string][]string{"write-good": {"sha256:" + strings.Repeat("0", 64)}}}
+	if err = loadPkg("write-good", zip, t.TempDir(), 0); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Errorf("Expected a checksum mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// webhookAttempts is the number of times we try to deliver a payload.
+const webhookAttempts = 4
+
+// webhookSecretEnv is the environment variable holding the key used to sign
+// webhook payloads.
+const webhookSecretEnv = "VALE_WEBHOOK_SECRET"
+
+// webhookBackoff is the delay before the first retry; it doubles after each
+// failed attempt.
+var webhookBackoff = time.Second
+
+// WebhookPayload is the JSON envelope sent by `--webhook`.
+type WebhookPayload struct {
+	Version string
+	Time    time.Time
+	Summary map[string]int          // alert counts by severity
+	Files   map[string][]core.Alert // alerts by file path
+}
+
+func newWebhookPayload(linted []*core.File) WebhookPayload {
+	payload := WebhookPayload{
+		Version: version,
+		Time:    time.Now().UTC(),
+		Summary: map[string]int{},
+		Files:   map[string][]core.Alert{},
+	}
+
+	for _, level := range core.AlertLevels {
+		payload.Summary[level] = 0
+	}
+
+	for _, f := range linted {
+		alerts := f.SortedAlerts()
+		for _, a := range alerts {
+			payload.Summary[a.Severity]++
+		}
+		payload.Files[f.Path] = alerts
+	}
+
+	return payload
+}
+
+// sendWebhook POSTs the results of a run to `url`.
+//
+// If `VALE_WEBHOOK_SECRET` is set, the payload is signed using HMAC-SHA256
+// and the signature is sent in the `X-Vale-Signature` header (formatted as
+// `sha256=<hex digest>`). Failed deliveries -- network errors, 429s, and 5xx
+// responses -- are retried with exponential backoff.
+func sendWebhook(url string, linted []*core.File) error {
+	body, err := json.Marshal(newWebhookPayload(linted))
+	if err != nil {
+		return core.NewE100("--webhook", err)
+	}
+
+	signature := ""
+	if secret := os.Getenv(webhookSecretEnv); secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	delay := webhookBackoff
+
+	for attempt := 1; ; attempt++ {
+		retry, postErr := postWebhook(client, url, body, signature)
+		if postErr == nil {
+			return nil
+		} else if !retry || attempt == webhookAttempts {
+			return core.NewE100("--webhook", postErr)
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// postWebhook makes a single delivery attempt, reporting whether or not a
+// failure is worth retrying.
+func postWebhook(client *http.Client, url string, body []byte, signature string) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body)) //nolint:noctx
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vale/"+version)
+	if signature != "" {
+		req.Header.Set("X-Vale-Signature", signature)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("'%s' responded with '%s'", url, resp.Status)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestWebhook(t *testing.T) {
+	webhookBackoff = time.Millisecond
+	t.Setenv(webhookSecretEnv, "secret")
+
+	attempts := 0
+	var payload WebhookPayload
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if r.Header.Get("X-Vale-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Error("Invalid signature")
+		}
+
+		if err = json.Unmarshal(body, &payload); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	linted := []*core.File{{Path: "README.md", Alerts: []core.Alert{
+		{Check: "Vale.Spelling", Severity: "error", Line: 1, Span: []int{1, 3}},
+	}}}
+
+	if err := sendWebhook(server.URL, linted); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	} else if payload.Summary["error"] != 1 || len(payload.Files["README.md"]) != 1 {
+		t.Errorf("Unexpected payload: %+v", payload)
+	}
+}
+
+func TestWebhookNoRetry(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if err := sendWebhook(server.URL, nil); err == nil {
+		t.Error("Expected an error")
+	} else if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// whyMarkup are the characters we ignore when looking for a line's text in
+// the blocks a rule saw.
+const whyMarkup = "*_`[]()#>|<"
+
+// parseWhy splits a `--why` value of the form `Style.Rule:file:line`.
+func parseWhy(spec string) (string, string, int, error) {
+	rule, rest, ok := strings.Cut(spec, ":")
+
+	idx := strings.LastIndex(rest, ":")
+	if !ok || idx < 0 {
+		return "", "", 0, errors.New("expected 'Style.Rule:file:line'")
+	}
+
+	line, err := strconv.Atoi(rest[idx+1:])
+	if err != nil || line < 1 {
+		return "", "", 0, fmt.Errorf("invalid line '%s'", rest[idx+1:])
+	}
+
+	return rule, rest[:idx], line, nil
+}
+
+// explainRule re-runs a single rule against a file and describes what it saw
+// on the given line.
+func explainRule(spec string, cfg *core.Config) error {
+	name, path, line, err := parseWhy(spec)
+	if err != nil {
+		return core.NewE100("--why", err)
+	} else if !core.FileExists(path) {
+		return core.NewE100("--why", fmt.Errorf("file '%s' not found", path))
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	rule, found := linter.Manager.Rules()[name]
+	if !found {
+		return core.NewE100("--why", fmt.Errorf("rule '%s' isn't loaded", name))
+	}
+	info := rule.Fields()
+
+	file, traces, err := linter.Explain(name, path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Rule:     %s (extends: %s, level: %s, scope: %v)\n",
+		name, info.Extends, info.Level, info.Scope)
+	if pattern := rule.Pattern(); pattern != "" {
+		fmt.Printf("Pattern:  %s\n", pattern)
+	}
+	fmt.Printf("Location: %s:%d\n\n", path, line)
+
+	if file == nil || len(traces) == 0 {
+		fmt.Println("The rule was never considered: no styles apply to this file.")
+		return nil
+	}
+
+	reported := 0
+	for _, a := range file.Alerts {
+		if a.Line == line {
+			fmt.Printf("Reported %d:%d '%s': %s\n", a.Line, a.Span[0], a.Match, a.Message)
+			reported++
+		}
+	}
+	if reported == 0 {
+		fmt.Printf("Nothing was reported on line %d.\n", line)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return core.NewE100("--why", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if line > len(lines) {
+		return core.NewE100("--why", fmt.Errorf("'%s' only has %d lines", path, len(lines)))
+	}
+	text := lines[line-1]
+
+	seen := 0
+	skipped := map[string]bool{}
+	for _, t := range traces {
+		if !sawLine(t, text, line) {
+			if t.Skipped != "" {
+				skipped[t.Skipped] = true
+			}
+			continue
+		}
+		seen++
+
+		fmt.Printf("\n[%s] %q\n", t.Block.Scope, t.Block.Text)
+		if t.Skipped != "" {
+			fmt.Printf("  skipped: %s\n", t.Skipped)
+			continue
+		}
+		explainMatches(rule, t, cfg)
+	}
+
+	if seen == 0 {
+		fmt.Printf("\nThe rule never saw any text from line %d.\n", line)
+		for reason := range skipped {
+			fmt.Printf("  (elsewhere, it was skipped because %s)\n", reason)
+		}
+	}
+
+	return nil
+}
+
+// sawLine determines if the given trace's block includes the text of `line`.
+func sawLine(t lint.Trace, text string, line int) bool {
+	if t.Block.Line >= 0 && t.Block.Line+1 == line {
+		return true
+	}
+
+	longest := longestUnmarked(text)
+	return len(longest) > 2 && strings.Contains(t.Block.Text, longest)
+}
+
+// longestUnmarked returns the longest run of `text` that's free of markup.
+func longestUnmarked(text string) string {
+	longest := ""
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune(whyMarkup, r)
+	}) {
+		part = strings.TrimSpace(part)
+		if len(part) > len(longest) {
+			longest = part
+		}
+	}
+	return longest
+}
+
+// explainMatches describes each of the rule's pattern matches in a block,
+// including those that weren't reported.
+func explainMatches(rule check.Rule, t lint.Trace, cfg *core.Config) {
+	reported := map[string]bool{}
+	for _, a := range t.Alerts {
+		reported[a.Match] = true
+		fmt.Printf("  reported '%s': %s\n", a.Match, a.Message)
+	}
+
+	re, err := regexp2.CompileStd(rule.Pattern())
+	if rule.Pattern() == "" || err != nil {
+		if len(t.Alerts) == 0 {
+			fmt.Println("  nothing was reported")
+		}
+		return
+	}
+
+	matches := re.FindAllString(t.Block.Text, -1)
+	if len(matches) == 0 && len(t.Alerts) == 0 {
+		fmt.Println("  the pattern didn't match")
+	}
+
+	for _, m := range matches {
+		if reported[m] {
+			continue
+		} else if exception := check.MatchedException(rule, m, cfg); exception != "" {
+			fmt.Printf("  matched '%s', but it was suppressed by the exception '%s'\n", m, exception)
+		} else {
+			fmt.Printf("  matched '%s', but the rule's conditions weren't met\n", m)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+func init() {
+	commandInfo["why-skipped"] = "Explain whether (and why) the given file would be skipped, and which config sections apply to it."
+	Actions["why-skipped"] = whySkipped
+}
+
+// whySkipped explains what a run would do with the given file: whether it
+// would be linted and, if not, why not.
+func whySkipped(args []string, flags *core.CLIFlags) error {
+	if len(args) != 1 {
+		return core.NewE100("why-skipped", errors.New("one file is required"))
+	} else if !core.FileExists(args[0]) {
+		return core.NewE100("why-skipped", fmt.Errorf("file '%s' not found", args[0]))
+	} else if core.IsDir(args[0]) {
+		return core.NewE100("why-skipped", fmt.Errorf("'%s' is a directory", args[0]))
+	}
+
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	result, err := linter.Triage(args[0], flags.Glob)
+	if err != nil {
+		return core.NewE100("why-skipped", err)
+	}
+
+	if flags.Output == "JSON" {
+		return printJSON(result)
+	}
+
+	fmt.Printf("File:     %s\n", result.Path)
+	if result.Format == "unknown" {
+		fmt.Printf("Format:   %s\n", result.Format)
+	} else {
+		fmt.Printf("Format:   %s (%s)\n", result.Format, result.Ext)
+	}
+	fmt.Printf("Sections: %s\n", listOrNone(result.Sections))
+	if result.Reason == "" || len(result.Styles) > 0 {
+		fmt.Printf("Styles:   %s\n", listOrNone(result.Styles))
+	}
+	fmt.Println()
+
+	if result.Reason != "" {
+		fmt.Printf("Skipped: %s.\n", result.Reason)
+		return nil
+	}
+
+	fmt.Println("Linted.")
+	if result.Format == "unknown" {
+		fmt.Printf("Vale doesn't recognize its format, so it's linted as plain text (see %s).\n",
+			toCodeStyle("[formats]"))
+	}
+	return nil
+}
+
+// listOrNone formats a list of names for display.
+func listOrNone(names []string) string {
+	if len(names) == 0 {
+		return "(none)"
+	}
+	return strings.Join(names, ", ")
+}
@@ -31,6 +31,7 @@ var fixers = map[string]fixer{
 	"remove":  remove,
 	"convert": convert,
 	"edit":    edit,
+	"split":   split,
 }
 
 // ParseAlert returns a slice of suggestions for the given Vale alert.
@@ -60,6 +61,47 @@ func FixAlert(alert core.Alert, cfg *core.Config) ([]string, error) {
 	return []string{}, fmt.Errorf("unknown action '%s'", action)
 }
 
+// ImpliedAction returns the `replace` action implied by an alert from a rule
+// that doesn't define an `action` of its own, provided that there's exactly
+// one possible replacement: a `substitution` swap with a single replacement
+// or a `capitalization` rule that uses `$title` or `$sentence`.
+func ImpliedAction(alert core.Alert, rule Rule) (core.Action, bool) {
+	replacement := ""
+
+	switch r := rule.(type) {
+	case Substitution:
+		if r.Stem {
+			// An inflected match would need an inflected replacement.
+			return core.Action{}, false
+		}
+
+		matches := r.matches(alert.Match)
+		if len(matches) != 1 || r.ranked[matches[0].index] {
+			return core.Action{}, false
+		}
+
+		expected, err := subMsg(r, matches[0].index, strings.TrimSpace(alert.Match))
+		if err != nil || strings.Contains(expected, "|") {
+			return core.Action{}, false
+		}
+		replacement = expected
+	case Capitalization:
+		if r.Match != "$title" && r.Match != "$sentence" {
+			return core.Action{}, false
+		}
+
+		expected, matched := r.Check(alert.Match, r.exceptRe)
+		if matched {
+			return core.Action{}, false
+		}
+		replacement = expected
+	default:
+		return core.Action{}, false
+	}
+
+	return core.Action{Name: "replace", Params: []string{replacement}}, true
+}
+
 func suggest(alert core.Alert, cfg *core.Config) ([]string, error) {
 	if len(alert.Action.Params) == 0 {
 		return []string{}, errors.New("no parameters")
@@ -115,6 +157,11 @@ func script(name string, alert core.Alert, cfg *core.Config) ([]string, error) {
 func spelling(alert core.Alert, cfg *core.Config) ([]string, error) {
 	var suggestions = []string{}
 
+	if len(alert.Suggestions) > 0 {
+		// They've already been ranked (see `Manager.Suggest`).
+		return alert.Suggestions, nil
+	}
+
 	name := strings.Split(alert.Check, ".")
 	path := filepath.Join(cfg.StylesPath(), name[0], name[1]+".yml")
 
@@ -142,6 +189,12 @@ func replace(alert core.Alert, _ *core.Config) ([]string, error) {
 	return alert.Action.Params, nil
 }
 
+// split suggests the sentence rewritten at each of its split points (see
+// `Occurrence.Split`).
+func split(alert core.Alert, _ *core.Config) ([]string, error) {
+	return alert.Action.Params, nil
+}
+
 func remove(_ core.Alert, _ *core.Config) ([]string, error) {
 	return []string{""}, nil
 }
@@ -0,0 +1,396 @@
+package check
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/errata-ai/regexp2"
+	"github.com/jdkato/twine/nlp/tag"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// consonantSounds are the beginnings of words that are spelled with a vowel
+// but pronounced with a consonant -- "a user", "a one-time fee".
+var consonantSounds = []string{
+	"eu", "ewe", "one-", "ouija", "ubiq", "uganda", "ukr", "uku", "unanim",
+	"unic", "unif", "unilateral", "union", "uniq", "unis", "unit",
+	"univers", "unix", "ura", "ure", "uri", "uro", "usa", "use", "usi", "usu",
+	"uten", "uter", "uti", "uto",
+}
+
+// vowelSounds are the beginnings of words that are spelled with a consonant
+// but pronounced with a vowel -- "an hour", "an honest mistake".
+var vowelSounds = []string{"heir", "honest", "honor", "honour", "hour"}
+
+// vowelLetters are the letters whose names start with a vowel sound, which
+// is how an initialism is read -- "an HTML page", but "a URL".
+const vowelLetters = "AEFHILMNORSX"
+
+// pluralQuantifiers are the words that require a plural noun.
+var pluralQuantifiers = []string{
+	"both", "few", "fewer", "many", "multiple", "numerous", "several", "these",
+	"those", "various", "two", "three", "four", "five", "six", "seven",
+	"eight", "nine", "ten", "eleven", "twelve",
+}
+
+// singularQuantifiers are the words that require a singular noun.
+var singularQuantifiers = []string{"another", "each", "every"}
+
+// invariantNouns are nouns whose singular form ends in an "s".
+var invariantNouns = []string{
+	"analysis", "basis", "bus", "canvas", "chassis", "class", "crisis",
+	"gas", "lens", "means", "news", "process", "series", "species", "status",
+	"thesis",
+}
+
+// Agreement reports the grammatical mistakes that are easiest to miss while
+// editing: an indefinite article that doesn't match the sound of the next
+// word ("a hour", "an user") and a noun or verb whose number doesn't match
+// its quantifier ("many file", "each user have").
+//
+// Both are heuristics built on part-of-speech tagging, so they favor
+// missing a mistake over reporting a false one.
+type Agreement struct {
+	Definition `mapstructure:",squash"`
+	// `articles` (`bool`): If `true`, report an indefinite article that
+	// doesn't match the sound of the word that follows it.
+	Articles bool
+	// `quantifiers` (`bool`): If `true`, report a noun (or verb) that
+	// doesn't agree with the quantifier before it.
+	Quantifiers bool
+	// `exceptions` (`array`): An array of words that are never reported --
+	// e.g., an acronym that's read as a word ("a FAQ").
+	Exceptions []string
+
+	exceptRe *regexp2.Regexp
+}
+
+// NewAgreement creates a new `agreement`-based rule.
+func NewAgreement(cfg *core.Config, generic baseCheck, path string) (Agreement, error) {
+	rule := Agreement{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	if !rule.Articles && !rule.Quantifiers {
+		return rule, core.NewE201FromPosition(
+			"One of 'articles' or 'quantifiers' is required.", path, 1)
+	}
+
+	re, err := updateExceptions(rule.Exceptions, cfg.AcceptedTokens, false)
+	if err != nil {
+		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+	rule.exceptRe = re
+
+	rule.Definition.Scope = []string{"sentence"}
+	return rule, nil
+}
+
+// agreementToken is a tagged word along with its location in the sentence.
+type agreementToken struct {
+	tag.Token
+	start int
+}
+
+// Run checks a single sentence.
+//
+// The second substitution in the rule's message is what was expected --
+// e.g., "'an hour'" or "a plural noun".
+func (a Agreement) Run(blk nlp.Block, f *core.File, _ *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	txt := blk.Text
+	words := locateTokens(txt, nlp.TextToTokens(txt, &f.NLP))
+
+	for i := range words {
+		if a.Articles {
+			if alert, found := a.checkArticle(txt, words, i); found {
+				alerts = append(alerts, alert)
+			}
+		}
+		if a.Quantifiers {
+			alerts = append(alerts, a.checkQuantifier(txt, words, i)...)
+		}
+	}
+
+	return alerts, nil
+}
+
+// checkArticle reports the `i`-th word if it's an indefinite article that
+// doesn't match the sound of the next word.
+func (a Agreement) checkArticle(txt string, words []agreementToken, i int) (core.Alert, bool) {
+	article := words[i]
+
+	lower := strings.ToLower(article.Text)
+	if (lower != "a" && lower != "an") || article.Tag != "DT" || i+1 >= len(words) {
+		return core.Alert{}, false
+	}
+
+	next := words[i+1]
+	if !startsPhrase(next.Tag) || isMatch(a.exceptRe, next.Text) {
+		return core.Alert{}, false
+	}
+
+	an, known := wantsAn(next.Text)
+	if !known || an == (lower == "an") {
+		return core.Alert{}, false
+	}
+
+	fixed := "a"
+	if an {
+		fixed = "an"
+	}
+	if article.Text != lower {
+		fixed = strings.ToUpper(fixed[:1]) + fixed[1:]
+	}
+
+	match := txt[article.start : next.start+len(next.Text)]
+	replacement := fixed + txt[article.start+len(article.Text):next.start+len(next.Text)]
+
+	alert := a.makeAlert(match, article.start, "'"+replacement+"'")
+	alert.Action = core.Action{Name: "replace", Params: []string{replacement}}
+
+	return alert, true
+}
+
+// checkQuantifier reports the noun phrase (or verb) following the `i`-th word
+// if it doesn't agree with it.
+func (a Agreement) checkQuantifier(txt string, words []agreementToken, i int) []core.Alert {
+	var alerts []core.Alert
+
+	lower := strings.ToLower(words[i].Text)
+
+	plural := core.StringInSlice(lower, pluralQuantifiers)
+	if !plural && !core.StringInSlice(lower, singularQuantifiers) {
+		return alerts
+	} else if i > 0 && inNounPhrase(words[i-1].Tag) {
+		// The quantifier is part of a modifier ("the top ten list") or
+		// refers back to a noun ("the users each have").
+		return alerts
+	}
+
+	// The head of the noun phrase is its last noun: "several config *files*".
+	head := -1
+	for j := i + 1; j < len(words); j++ {
+		t := words[j].Tag
+		if strings.HasPrefix(t, "NN") {
+			head = j
+		} else if head >= 0 || !isModifier(t) {
+			break
+		}
+	}
+
+	if head < 0 || isMatch(a.exceptRe, words[head].Text) {
+		return alerts
+	} else if strings.IndexFunc(words[head].Text, unicode.IsLetter) < 0 {
+		// E.g., masked code.
+		return alerts
+	} else if head+1 < len(words) && isCoordinator(words[head+1].Text) {
+		// E.g., "each server or client".
+		return alerts
+	} else if lower == "both" && hasCoordinator(words[head+1:]) {
+		// E.g., "both array destructuring and object destructuring".
+		return alerts
+	}
+
+	noun := words[head]
+	if isPluralNoun(noun) != plural {
+		expected := "a singular noun"
+		if plural {
+			expected = "a plural noun"
+		}
+		match := txt[words[i].start : noun.start+len(noun.Text)]
+		alerts = append(alerts, a.makeAlert(match, words[i].start, expected))
+	} else if head+1 < len(words) && (i == 0 || !isPreposition(words[i-1].Tag)) {
+		// NOTE: After a preposition, the noun phrase isn't the subject ("any
+		// of these reasons *is*"); in a question, it may not come first ("how
+		// many words *does* it contain").
+		verb := words[head+1]
+		if number, known := verbNumber(verb); known && number != plural {
+			expected := "a singular verb"
+			if plural {
+				expected = "a plural verb"
+			}
+			alerts = append(alerts, a.makeAlert(verb.Text, verb.start, expected))
+		}
+	}
+
+	return alerts
+}
+
+func (a Agreement) makeAlert(match string, start int, expected string) core.Alert {
+	alert := core.Alert{Check: a.Name, Severity: a.Level, Link: a.Link,
+		Span: []int{start, start + len(match)}, Match: match, Action: a.Action}
+	alert.Message, alert.Description = formatMessages(a.Message, a.Description, match, expected)
+	return alert
+}
+
+// Fields provides access to the internal rule definition.
+func (a Agreement) Fields() Definition {
+	return a.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (a Agreement) Pattern() string {
+	return ""
+}
+
+// locateTokens finds each tagged token in `txt`, dropping any (such as
+// normalized quotes) that can't be found.
+func locateTokens(txt string, tokens []tag.Token) []agreementToken {
+	located := []agreementToken{}
+
+	cursor := 0
+	for _, tok := range tokens {
+		idx := strings.Index(txt[cursor:], tok.Text)
+		if idx < 0 {
+			continue
+		}
+		located = append(located, agreementToken{Token: tok, start: cursor + idx})
+		cursor += idx + len(tok.Text)
+	}
+
+	return located
+}
+
+// wantsAn reports whether `word` is pronounced with a leading vowel sound,
+// along with whether we can tell.
+func wantsAn(word string) (bool, bool) {
+	first, _ := utf8.DecodeRuneInString(word)
+	switch {
+	case unicode.IsDigit(first):
+		// "an 8-bit value", "an 11-day trip", "an 18-month plan".
+		digits := strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) })
+		if digits < 0 {
+			digits = len(word)
+		}
+		lead := word[:digits]
+		return first == '8' || lead == "11" || lead == "18", true
+	case !unicode.IsLetter(first) || first > unicode.MaxASCII:
+		return false, false
+	case isInitialism(word):
+		return strings.ContainsRune(vowelLetters, unicode.ToUpper(first)), true
+	}
+
+	lower := strings.ToLower(word)
+	if lower == "one" || lower == "once" {
+		return false, true
+	}
+	for _, prefix := range consonantSounds {
+		if strings.HasPrefix(lower, prefix) {
+			return false, true
+		}
+	}
+	for _, prefix := range vowelSounds {
+		if strings.HasPrefix(lower, prefix) {
+			return true, true
+		}
+	}
+
+	return strings.ContainsRune("aeiou", unicode.ToLower(first)), true
+}
+
+// isInitialism reports whether `word` is probably read letter by letter:
+// "HTML" and "SQL" are, while "NASA" and "JSON" are read as words.
+func isInitialism(word string) bool {
+	letters := 0
+	vowels := 0
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			break
+		} else if !unicode.IsUpper(r) {
+			return false
+		}
+		letters++
+		if strings.ContainsRune("AEIOU", r) {
+			vowels++
+		}
+	}
+	return letters > 1 && (vowels == 0 || letters <= 3)
+}
+
+// startsPhrase reports whether a word tagged `t` can follow an indefinite
+// article.
+func startsPhrase(t string) bool {
+	return strings.HasPrefix(t, "NN") || strings.HasPrefix(t, "JJ") ||
+		strings.HasPrefix(t, "RB") || t == "CD" || t == "VBG" || t == "VBN"
+}
+
+// isModifier reports whether a word tagged `t` can come between a
+// quantifier and its noun: "many *new* files".
+func isModifier(t string) bool {
+	return strings.HasPrefix(t, "JJ") || t == "VBN" || t == "VBG"
+}
+
+// inNounPhrase reports whether a word tagged `t` is part of a noun phrase
+// that's already begun.
+func inNounPhrase(t string) bool {
+	return t == "DT" || t == "PRP$" || strings.HasPrefix(t, "JJ") || strings.HasPrefix(t, "NN")
+}
+
+func isCoordinator(word string) bool {
+	lower := strings.ToLower(word)
+	return lower == "and" || lower == "or" || lower == "nor"
+}
+
+func hasCoordinator(words []agreementToken) bool {
+	for _, w := range words {
+		if isCoordinator(w.Text) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPreposition(t string) bool {
+	return t == "IN" || t == "TO" || strings.HasPrefix(t, "W")
+}
+
+// isPluralNoun reports whether `noun` is plural.
+//
+// The tagger often labels a plural noun as singular when it follows an
+// unexpected word ("every files"), so we also look at its ending.
+func isPluralNoun(noun agreementToken) bool {
+	if noun.Tag == "NNS" || noun.Tag == "NNPS" {
+		return !core.StringInSlice(strings.ToLower(noun.Text), invariantNouns)
+	}
+
+	lower := strings.ToLower(noun.Text)
+	if (noun.Tag != "NN" && noun.Tag != "NNP") || core.StringInSlice(lower, invariantNouns) {
+		return false
+	}
+
+	return strings.HasSuffix(lower, "s") && !strings.HasSuffix(lower, "ss") &&
+		!strings.HasSuffix(lower, "us") && !strings.HasSuffix(lower, "is")
+}
+
+// verbNumber returns whether `verb` is plural, along with whether it has a
+// number at all.
+func verbNumber(verb agreementToken) (bool, bool) {
+	switch strings.ToLower(verb.Text) {
+	case "was":
+		return false, true
+	case "were":
+		return true, true
+	}
+
+	switch verb.Tag {
+	case "VBZ":
+		return false, true
+	case "VBP":
+		return true, true
+	default:
+		return false, false
+	}
+}
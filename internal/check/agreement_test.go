@@ -0,0 +1,116 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func runAgreement(t *testing.T, def baseCheck, text string) []core.Alert {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewAgreement(cfg, def, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(nlp.NewBlock("", text, "sentence"), file, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return alerts
+}
+
+func TestAgreementArticles(t *testing.T) {
+	def := baseCheck{
+		"message":    "Use %[2]s.",
+		"articles":   true,
+		"exceptions": []string{"FAQ"},
+	}
+
+	cases := map[string][]string{
+		"It took a hour to finish.":                  {"Use 'an hour'."},
+		"An user opened an unique issue.":            {"Use 'A user'.", "Use 'a unique'."},
+		"Use a URL or an HTML page, not a XML file.": {"Use 'an XML'."},
+		"It's a honest mistake in a 8-bit value.":    {"Use 'an honest'.", "Use 'an 8-bit'."},
+		"It's a one-time fee for a European union.":  {},
+		"Read a FAQ, an SQL query, or a NASA paper.": {},
+		"Pick option a or b.":                        {},
+	}
+
+	for text, expected := range cases {
+		alerts := runAgreement(t, def, text)
+
+		messages := []string{}
+		for _, a := range alerts {
+			messages = append(messages, a.Message)
+		}
+
+		if len(messages) != len(expected) {
+			t.Errorf("%q: expected %v, got %v", text, expected, messages)
+			continue
+		}
+		for i := range expected {
+			if messages[i] != expected[i] {
+				t.Errorf("%q: expected %v, got %v", text, expected, messages)
+			}
+		}
+	}
+
+	alerts := runAgreement(t, def, "It took a hour.")
+	if len(alerts) != 1 || alerts[0].Match != "a hour" || alerts[0].Action.Params[0] != "an hour" {
+		t.Errorf("Unexpected alert: %+v", alerts)
+	}
+}
+
+func TestAgreementQuantifiers(t *testing.T) {
+	def := baseCheck{
+		"message":     "'%s': expected %s.",
+		"quantifiers": true,
+	}
+
+	cases := map[string][]string{
+		"Many file is missing.":                         {"'Many file': expected a plural noun."},
+		"Several new config file were added.":           {"'Several new config file': expected a plural noun."},
+		"Every files is here.":                          {"'Every files': expected a singular noun."},
+		"Each user have an account.":                    {"'have': expected a singular verb."},
+		"Many users has access.":                        {"'has': expected a plural verb."},
+		"Both server and client run.":                   {},
+		"Each series has a name.":                       {},
+		"Several users were added to the top ten list.": {},
+	}
+
+	for text, expected := range cases {
+		alerts := runAgreement(t, def, text)
+
+		messages := []string{}
+		for _, a := range alerts {
+			messages = append(messages, a.Message)
+		}
+
+		if len(messages) != len(expected) || (len(expected) > 0 && messages[0] != expected[0]) {
+			t.Errorf("%q: expected %v, got %v", text, expected, messages)
+		}
+	}
+}
+
+func TestAgreementRequiresPrimitive(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = NewAgreement(cfg, baseCheck{"message": "%s"}, ""); err == nil {
+		t.Error("Expected an error for a rule without 'articles' or 'quantifiers'")
+	}
+}
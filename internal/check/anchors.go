@@ -0,0 +1,299 @@
+package check
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+var reInlineLink = regexp2.MustCompileStd(`\[[^\]]*\]\(([^)\s]*#[^)\s]*)`)
+var reLinkDefTarget = regexp2.MustCompileStd(`(?m)^ {0,3}\[[^\]]+\]:[ \t]*(\S*#\S*)`)
+
+var reATXHeading = regexp.MustCompile(`^ {0,3}#{1,6}[ \t]+(.+?)[ \t#]*$`)
+var reSetextLine = regexp.MustCompile(`^ {0,3}(?:=+|-+)[ \t]*$`)
+var reHeadingID = regexp.MustCompile(`[ \t]*\{#([^}\s]+)[^}]*\}[ \t]*$`)
+var reHTMLAnchor = regexp.MustCompile(`(?i)<[a-z][^>]*?\s(?:id|name)\s*=\s*["']([^"']+)["']`)
+var reFrontMatter = regexp.MustCompile(`(?s)\A---\n.*?\n---[ \t]*\n`)
+var reLinkText = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+
+// Anchors checks that the fragments of a Markdown file's internal links --
+// e.g., `#install` or `setup.md#install` -- refer to a heading (or other
+// anchor) that exists, suggesting the closest existing anchor for those that
+// don't.
+//
+// Linked files are read from disk, so a link to a renamed heading in another
+// file is caught even if only the linking file is linted.
+type Anchors struct {
+	Definition `mapstructure:",squash"`
+
+	index *anchorIndex
+}
+
+// anchorIndex caches the anchors of each linked file, which are usually
+// linked to from many others.
+type anchorIndex struct {
+	files map[string]anchorEntry
+	mu    sync.Mutex
+}
+
+type anchorEntry struct {
+	modified time.Time
+	anchors  []string
+}
+
+// NewAnchors creates a new `Anchors` rule.
+func NewAnchors(_ *core.Config, generic baseCheck, path string) (Anchors, error) {
+	rule := Anchors{index: &anchorIndex{files: map[string]anchorEntry{}}}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	return rule, nil
+}
+
+// Run executes the `Anchors` rule.
+//
+// This rule only applies to Markdown files and expects to be given the raw
+// contents of the file (`scope: raw`). The second substitution in its message
+// is a suggestion (if any) -- e.g., " (did you mean 'setup.md#installing'?)".
+func (r Anchors) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	if f.NormedExt != ".md" {
+		return alerts, nil
+	}
+	txt := maskCode(blk.Text)
+
+	var own []string
+	for _, re := range []*regexp2.Regexp{reInlineLink, reLinkDefTarget} {
+		for _, m := range re.FindAllStringSubmatchIndex(txt, -1) {
+			loc := []int{m[2], m[3]}
+
+			dest, err := re2Loc(txt, loc)
+			if err != nil {
+				return alerts, err
+			}
+
+			target, fragment, _ := strings.Cut(dest, "#")
+			if fragment == "" || strings.Contains(target, ":") || strings.HasPrefix(target, "//") {
+				// Not an internal link to an anchor.
+				continue
+			}
+
+			var anchors []string
+			if target == "" {
+				if own == nil {
+					own = markdownAnchors(blk.Text)
+				}
+				anchors = own
+			} else {
+				path, pathErr := url.PathUnescape(target)
+				if pathErr != nil || !core.HasAnySuffix(strings.ToLower(path), []string{".md", ".markdown"}) {
+					continue
+				} else if !filepath.IsAbs(path) {
+					path = filepath.Join(filepath.Dir(f.Path), path)
+				}
+
+				f.AddDependency(path)
+
+				found, ok := r.index.lookup(path)
+				if !ok {
+					// The file itself is missing, which is a different
+					// problem.
+					continue
+				}
+				anchors = found
+			}
+
+			if unescaped, escErr := url.PathUnescape(fragment); escErr == nil {
+				fragment = unescaped
+			}
+			if core.StringInSlice(fragment, anchors) {
+				continue
+			}
+
+			a, err := makeAlert(r.Definition, loc, blk.Text, cfg)
+			if err != nil {
+				return alerts, err
+			}
+
+			hint := ""
+			if closest := closestAnchor(fragment, anchors); closest != "" {
+				fixed := target + "#" + closest
+				hint = " (did you mean '" + fixed + "'?)"
+				a.Action = core.Action{Name: "replace", Params: []string{fixed}}
+			}
+			a.Message, a.Description = formatMessages(r.Message, r.Description, a.Match, hint)
+
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (r Anchors) Fields() Definition {
+	return r.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (r Anchors) Pattern() string {
+	return ""
+}
+
+// lookup returns the anchors of the Markdown file at `path`, reporting false
+// if it can't be read.
+func (idx *anchorIndex) lookup(path string) ([]string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return nil, false
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, false
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if entry, found := idx.files[abs]; found && entry.modified.Equal(info.ModTime()) {
+		return entry.anchors, true
+	}
+
+	content, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, false
+	}
+
+	anchors := markdownAnchors(string(content))
+	idx.files[abs] = anchorEntry{modified: info.ModTime(), anchors: anchors}
+
+	return anchors, true
+}
+
+// markdownAnchors returns the anchors defined by a Markdown document: the
+// (GitHub-style) IDs of its headings, their custom IDs (`{#id}`), and the
+// `id` (or `name`) of any HTML elements.
+func markdownAnchors(content string) []string {
+	plain := reFrontMatter.ReplaceAllStringFunc(content, func(m string) string {
+		return strings.Repeat("\n", strings.Count(m, "\n"))
+	})
+
+	// NOTE: We use the masked text to find headings (so that we skip code
+	// blocks), but the original text for their IDs since they may contain
+	// code spans.
+	lines := strings.Split(plain, "\n")
+	masked := strings.Split(maskCode(plain), "\n")
+
+	anchors := []string{}
+	seen := map[string]int{}
+
+	for i, line := range masked {
+		heading := ""
+		if reATXHeading.MatchString(line) {
+			if m := reATXHeading.FindStringSubmatch(lines[i]); m != nil {
+				heading = m[1]
+			}
+		} else if i+1 < len(masked) && reSetextLine.MatchString(masked[i+1]) && strings.TrimSpace(line) != "" {
+			heading = strings.TrimSpace(lines[i])
+		}
+
+		if heading == "" {
+			continue
+		} else if id := reHeadingID.FindStringSubmatch(heading); id != nil {
+			anchors = append(anchors, id[1])
+			continue
+		}
+
+		slug := headingSlug(heading)
+		if n, found := seen[slug]; found {
+			seen[slug] = n + 1
+			slug += "-" + strconv.Itoa(n+1)
+		} else {
+			seen[slug] = 0
+		}
+		anchors = append(anchors, slug)
+	}
+
+	for _, m := range reHTMLAnchor.FindAllStringSubmatch(strings.Join(masked, "\n"), -1) {
+		anchors = append(anchors, m[1])
+	}
+
+	return anchors
+}
+
+// headingSlug generates a heading's ID in the style of GitHub: its text is
+// lowercased, punctuation is removed, and spaces become hyphens.
+func headingSlug(heading string) string {
+	heading = reLinkText.ReplaceAllString(heading, "$1")
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+
+	return b.String()
+}
+
+// closestAnchor returns the anchor most similar to `fragment`, if any is
+// close enough to be a likely match (e.g., a heading that's been reworded).
+func closestAnchor(fragment string, anchors []string) string {
+	best, bestDist := "", -1
+	for _, anchor := range anchors {
+		d := editDistance(strings.ToLower(fragment), strings.ToLower(anchor))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = anchor, d
+		}
+	}
+
+	limit := max(2, len([]rune(fragment))/3)
+	if bestDist < 0 || bestDist > limit {
+		return ""
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between `a` and `b`.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	prev := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		cur := make([]int, len(t)+1)
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(t)]
+}
@@ -0,0 +1,70 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestMarkdownAnchors(t *testing.T) {
+	content := "---\ntitle: x\n---\n\n# Getting `started`!\n\nSetext\n------\n\n" +
+		"## Usage\n\n## Usage\n\n## FAQ {#questions}\n\n```\n# not-a-heading\n```\n\n" +
+		"See [docs](https://example.com).\n\n<span id=\"legacy\"></span>\n"
+
+	expected := []string{"getting-started", "setext", "usage", "usage-1", "questions", "legacy"}
+	if anchors := markdownAnchors(content); !reflect.DeepEqual(anchors, expected) {
+		t.Errorf("Expected %v, got %v", expected, anchors)
+	}
+}
+
+func TestAnchors(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, "setup.md"), []byte("# Setup\n\n## Installing Vale\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewAnchors(cfg, baseCheck{
+		"message": "'%s' is broken%s.",
+		"scope":   []string{"raw"},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "# Index\n\n[a](setup.md#installing-vale), [b](setup.md#install-vale), " +
+		"[c](#index), [d](#zzz), [e](missing.md#x), [f](https://example.com/#x)\n"
+	file := &core.File{Path: filepath.Join(dir, "index.md"), NormedExt: ".md"}
+
+	alerts, err := rule.Run(nlp.NewBlock("", text, "raw.md"), file, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []string{}
+	for _, a := range alerts {
+		messages = append(messages, a.Message)
+	}
+
+	expected := []string{
+		"'setup.md#install-vale' is broken (did you mean 'setup.md#installing-vale'?).",
+		"'#zzz' is broken.",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Fatalf("Expected %v, got %v", expected, messages)
+	}
+
+	action := alerts[0].Action
+	if action.Name != "replace" || !reflect.DeepEqual(action.Params, []string{"setup.md#installing-vale"}) {
+		t.Errorf("Expected a replacement, got %v", action)
+	}
+}
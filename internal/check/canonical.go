@@ -0,0 +1,182 @@
+package check
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// keyOrder is the canonical order of top-level keys in a rule definition.
+//
+// Keys not listed here are placed after the known keys, sorted
+// alphabetically.
+var keyOrder = []string{
+	"extends",
+	"message",
+	"description",
+	"link",
+	"level",
+	"scope",
+	"lang",
+	"limit",
+	"ignorecase",
+	"nonword",
+	"vocab",
+	"action",
+}
+
+// sortedLists are the keys whose (scalar) values can be sorted without
+// changing a rule's meaning.
+//
+// NOTE: Lists of patterns (e.g., `tokens`, `exceptions`, or `filters`) are
+// compiled into alternations, where order can matter, so we leave them as
+// written.
+var sortedLists = []string{"ignore"}
+
+// FormatRule returns the canonical form of the given rule definition.
+//
+// Canonicalization consists of ordering the top-level keys, sorting and
+// de-duplicating order-independent lists (e.g., `ignore`), sorting the keys
+// of `swap` and `either` maps, and normalizing scalar quoting. Comments are
+// preserved.
+func FormatRule(src []byte) ([]byte, error) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(src, &doc); err != nil {
+		return nil, err
+	} else if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		// Nothing we can safely do; return the input as-is.
+		return src, nil
+	}
+	root := doc.Content[0]
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch {
+		case core.StringInSlice(key.Value, sortedLists):
+			canonicalList(value)
+		case key.Value == "swap" || key.Value == "either":
+			canonicalMap(value)
+		}
+	}
+	canonicalKeys(root)
+	normalizeStyle(root)
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	} else if err = enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func canonicalKeys(node *yaml.Node) {
+	rank := func(key string) int {
+		for i, k := range keyOrder {
+			if k == key {
+				return i
+			}
+		}
+		return len(keyOrder)
+	}
+	sortPairs(node, func(a, b string) bool {
+		ra, rb := rank(a), rank(b)
+		if ra != rb {
+			return ra < rb
+		}
+		return a < b
+	})
+}
+
+func canonicalMap(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	sortPairs(node, func(a, b string) bool {
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+}
+
+func canonicalList(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		return
+	}
+
+	seen := map[string]bool{}
+	items := []*yaml.Node{}
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			// Only lists of plain strings are safe to sort.
+			return
+		} else if seen[item.Value] {
+			continue
+		}
+		seen[item.Value] = true
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return strings.ToLower(items[i].Value) < strings.ToLower(items[j].Value)
+	})
+	node.Content = items
+}
+
+func sortPairs(node *yaml.Node, less func(a, b string) bool) {
+	type pair struct {
+		key, value *yaml.Node
+	}
+
+	pairs := []pair{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, pair{node.Content[i], node.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i].key.Value, pairs[j].key.Value)
+	})
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for _, p := range pairs {
+		content = append(content, p.key, p.value)
+	}
+	node.Content = content
+}
+
+// normalizeStyle resets the quoting style of all scalars, letting the encoder
+// choose the simplest valid representation.
+//
+// Block scalars (`|` and `>`) are left alone since they're typically used for
+// readability (e.g., in `script` rules). Scalars that need quoting and
+// contain a single quote (e.g., "'%s'") are double-quoted, rather than
+// single-quoted with each quote doubled.
+func normalizeStyle(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Style = 0
+			if strings.Contains(node.Value, "'") && needsQuotes(node) {
+				node.Style = yaml.DoubleQuotedStyle
+			}
+		}
+		return
+	}
+	node.Style &^= yaml.FlowStyle
+	for _, child := range node.Content {
+		normalizeStyle(child)
+	}
+}
+
+// needsQuotes reports whether the encoder would quote the given scalar.
+func needsQuotes(node *yaml.Node) bool {
+	out, err := yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Tag: node.Tag, Value: node.Value})
+	return err == nil && strings.HasPrefix(string(out), "'")
+}
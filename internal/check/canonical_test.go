@@ -0,0 +1,110 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestFormatRule(t *testing.T) {
+	cases := []struct {
+		in, out string
+	}{
+		{
+			in: `level: warning
+tokens:
+  - "foo"
+  - Bar
+  - foo
+message: "Avoid '%s'."
+extends: existence
+`,
+			out: `extends: existence
+message: Avoid '%s'.
+level: warning
+tokens:
+  - foo
+  - Bar
+  - foo
+`,
+		},
+		{
+			in: `extends: existence
+message: "'%s'"
+nonword: true
+tokens: [b, a]
+`,
+			out: `extends: existence
+message: "'%s'"
+nonword: true
+tokens:
+  - b
+  - a
+`,
+		},
+		{
+			in: `swap:
+  # A comment.
+  Zeta: z
+  alpha: a
+extends: substitution
+`,
+			out: `extends: substitution
+swap:
+  alpha: a
+  # A comment.
+  Zeta: z
+`,
+		},
+	}
+
+	for _, c := range cases {
+		b, err := FormatRule([]byte(c.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != c.out {
+			t.Errorf("Expected:\n%s\ngot:\n%s", c.out, string(b))
+		}
+
+		again, err := FormatRule(b)
+		if err != nil {
+			t.Fatal(err)
+		} else if string(again) != string(b) {
+			t.Errorf("Expected idempotent output, got:\n%s", string(again))
+		}
+	}
+}
+
+func TestFormatRuleRoundTrip(t *testing.T) {
+	src := `extends: spelling
+message: "'%s' isn't spelled \"right\"."
+ignore: [b.txt, a.txt, b.txt]
+filters:
+  - '[pP]y.*\b'
+  - "it's"
+exceptions: ["true", '1.0', "don't"]
+swap:
+  "can't": "cannot"
+  'a': "b"
+`
+	formatted, err := FormatRule([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var before, after map[string]interface{}
+	if err = yaml.Unmarshal([]byte(src), &before); err != nil {
+		t.Fatal(err)
+	} else if err = yaml.Unmarshal(formatted, &after); err != nil {
+		t.Fatalf("Expected valid YAML, got %v:\n%s", err, formatted)
+	}
+
+	// Only `ignore` is sorted (and de-duplicated).
+	before["ignore"] = []interface{}{"a.txt", "b.txt"}
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("Expected %v, got %v:\n%s", before, after, formatted)
+	}
+}
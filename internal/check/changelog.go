@@ -0,0 +1,255 @@
+package check
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/regexp2"
+	"github.com/jdkato/twine/nlp/tag"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// defaultReferences matches the usual ways of referring to an issue or pull
+// request: `#123`, `owner/repo#123`, and `issue 123` (or `PR #123`).
+const defaultReferences = `(?i)\b(?:issue|pr|pull request|bug)\s+#?\d+\b|(?<![\w&/])(?:[\w.-]+/[\w.-]+)?#\d+\b`
+
+// Changelog checks the structure of release notes, which otherwise require a
+// handful of heavy `sequence` rules.
+//
+// Sections are found using the document's outline rather than its text, so
+// they're only checked for markup files linted with `scope: raw`.
+type Changelog struct {
+	Definition `mapstructure:",squash"`
+	// `past` (`bool`): If `true`, every list item must start with a verb in
+	// the past tense -- e.g., "Fixed a crash" rather than "Fix a crash".
+	Past bool
+	// `exceptions` (`array`): An array of words that a list item may start
+	// with regardless of their tense.
+	Exceptions []string
+	// `references` (`string`): A regular expression matching the required
+	// format of issue references -- e.g., `\(#\d+\)`.
+	References string
+	// `candidates` (`string`): A regular expression matching anything that
+	// looks like an issue reference. Defaults to `#123`, `owner/repo#123`,
+	// and `issue 123`.
+	Candidates string
+	// `sections` (`array`): The headings allowed under each release. If set,
+	// every release must have at least one of them.
+	Sections []string
+	// `release` (`int`): The level of each release's heading. Defaults to 2.
+	Release int
+
+	exceptRe     *regexp2.Regexp
+	referenceRe  *regexp2.Regexp
+	candidatesRe *regexp2.Regexp
+}
+
+// NewChangelog creates a new `changelog`-based rule.
+func NewChangelog(cfg *core.Config, generic baseCheck, path string) (Changelog, error) {
+	rule := Changelog{Release: 2, Candidates: defaultReferences}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	if !rule.Past && rule.References == "" && len(rule.Sections) == 0 {
+		return rule, core.NewE201FromPosition(
+			"One of 'past', 'references', or 'sections' is required.", path, 1)
+	} else if rule.Release < 1 || rule.Release > 5 {
+		return rule, core.NewE201FromTarget(
+			"'release' must be a heading level between 1 and 5.", "release", path)
+	}
+
+	if rule.References != "" {
+		re, errc := regexp2.CompileStd(rule.References)
+		if errc != nil {
+			return rule, core.NewE201FromTarget(errc.Error(), "references", path)
+		}
+		rule.referenceRe = re
+
+		re, errc = regexp2.CompileStd(rule.Candidates)
+		if errc != nil {
+			return rule, core.NewE201FromTarget(errc.Error(), "candidates", path)
+		}
+		rule.candidatesRe = re
+	}
+
+	re, err := updateExceptions(rule.Exceptions, cfg.AcceptedTokens, false)
+	if err != nil {
+		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+	rule.exceptRe = re
+
+	return rule, nil
+}
+
+// Run executes the `changelog`-based rule.
+//
+// The second substitution in a rule's message is a description of the
+// problem -- e.g., "an unknown section".
+func (c Changelog) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	report := func(loc []int, kind string) error {
+		a, err := makeAlert(c.Definition, loc, blk.Text, cfg)
+		if err != nil {
+			return err
+		}
+		a.Message, a.Description = formatMessages(c.Message, c.Description, a.Match, kind)
+		alerts = append(alerts, a)
+		return nil
+	}
+
+	if strings.HasPrefix(blk.Scope, "raw") {
+		for _, problem := range c.sectionProblems(f) {
+			if loc := headingLoc(blk.Text, problem.heading); loc != nil {
+				if err := report(loc, problem.kind); err != nil {
+					return alerts, err
+				}
+			}
+		}
+		return alerts, nil
+	}
+
+	if c.Past && strings.Contains(blk.Scope, "list") {
+		if loc := c.presentTense(blk.Text, f); loc != nil {
+			if err := report(loc, "not in the past tense"); err != nil {
+				return alerts, err
+			}
+		}
+	}
+
+	if c.referenceRe != nil {
+		for _, loc := range c.misformattedRefs(blk.Text) {
+			if err := report(loc, "not a valid issue reference"); err != nil {
+				return alerts, err
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+// presentTense returns the (rune) location of the first word of `txt` if
+// it's not a verb in the past tense.
+//
+// NOTE: The tagger often reads a capitalized participle at the start of a
+// fragment ("Added support for ...") as an adjective, so we also accept any
+// word ending in "-ed" that isn't tagged as another form of a verb.
+func (c Changelog) presentTense(txt string, f *core.File) []int {
+	var first *tag.Token
+	for _, tok := range nlp.TextToTokens(txt, &f.NLP) {
+		if strings.IndexFunc(tok.Text, isWordRune) >= 0 {
+			first = &tok
+			break
+		}
+	}
+
+	if first == nil || isMatch(c.exceptRe, first.Text) {
+		return nil
+	} else if first.Tag == "VBD" || first.Tag == "VBN" {
+		return nil
+	} else if strings.HasSuffix(strings.ToLower(first.Text), "ed") && !strings.HasPrefix(first.Tag, "VB") {
+		return nil
+	}
+
+	idx := strings.Index(txt, first.Text)
+	if idx < 0 {
+		return nil
+	}
+
+	start := utf8.RuneCountInString(txt[:idx])
+	return []int{start, start + utf8.RuneCountInString(first.Text)}
+}
+
+// misformattedRefs returns the locations of the issue references in `txt`
+// that don't follow the rule's `references` format.
+func (c Changelog) misformattedRefs(txt string) [][]int {
+	valid := c.referenceRe.FindAllStringIndex(txt, -1)
+
+	var locs [][]int
+	for _, loc := range c.candidatesRe.FindAllStringIndex(txt, -1) {
+		ok := false
+		for _, v := range valid {
+			if v[0] <= loc[0] && loc[1] <= v[1] {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			locs = append(locs, loc)
+		}
+	}
+
+	return locs
+}
+
+type sectionProblem struct {
+	heading core.Heading
+	kind    string
+}
+
+// sectionProblems checks that every release heading in `f` has at least one
+// section and that each of its sections is one of the rule's `sections`.
+func (c Changelog) sectionProblems(f *core.File) []sectionProblem {
+	var problems []sectionProblem
+	if len(c.Sections) == 0 {
+		return problems
+	}
+
+	for i, h := range f.Outline {
+		if h.Level != c.Release {
+			continue
+		}
+
+		found := 0
+		for _, sub := range f.Outline[i+1:] {
+			if sub.Level <= c.Release {
+				break
+			} else if sub.Level != c.Release+1 {
+				continue
+			}
+
+			found++
+			if !c.isSection(sub.Text) {
+				problems = append(problems, sectionProblem{sub, "an unknown section"})
+			}
+		}
+
+		if found == 0 {
+			problems = append(problems, sectionProblem{h, "a release without sections"})
+		}
+	}
+
+	return problems
+}
+
+func (c Changelog) isSection(text string) bool {
+	for _, s := range c.Sections {
+		if strings.EqualFold(strings.TrimSpace(text), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields provides access to the internal rule definition.
+func (c Changelog) Fields() Definition {
+	return c.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (c Changelog) Pattern() string {
+	if c.referenceRe != nil {
+		return c.referenceRe.String()
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestChangelog(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewChangelog(cfg, baseCheck{
+		"message":    "'%s' is %s.",
+		"scope":      []string{"list", "raw"},
+		"past":       true,
+		"exceptions": []string{"Breaking"},
+		"references": `\(#\d+\)`,
+		"sections":   []string{"Added", "Fixed"},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := &core.File{NormedExt: ".md"}
+
+	items := map[string][]string{
+		"Fixed a crash when saving (#12).": {},
+		"Added support for YAML (#13).":    {},
+		"Breaking: removed the old API.":   {},
+		"Fix a crash when saving.":         {"'Fix' is not in the past tense."},
+		"Removed the flag, see #14.":       {"'#14' is not a valid issue reference."},
+		"Updated the docs for issue 15.":   {"'issue 15' is not a valid issue reference."},
+		"Changed the default &#160;(#16).": {},
+	}
+
+	for text, expected := range items {
+		alerts, errR := rule.Run(nlp.NewBlock("", text, "text.list.md"), file, cfg)
+		if errR != nil {
+			t.Fatal(errR)
+		}
+
+		messages := []string{}
+		for _, a := range alerts {
+			messages = append(messages, a.Message)
+		}
+		if !reflect.DeepEqual(messages, expected) {
+			t.Errorf("%q: expected %v, got %v", text, expected, messages)
+		}
+	}
+
+	text := "# Changelog\n\n## 1.1.0\n\n### Added\n\n- A\n\n### Misc\n\n- B\n\n## 1.0.0\n\nFirst release.\n"
+	file.Outline = []core.Heading{
+		{Text: "Changelog", Level: 1, Line: 1},
+		{Text: "1.1.0", Level: 2, Line: 3},
+		{Text: "Added", Level: 3, Line: 5, Content: true},
+		{Text: "Misc", Level: 3, Line: 9, Content: true},
+		{Text: "1.0.0", Level: 2, Line: 13, Content: true},
+	}
+
+	alerts, err := rule.Run(nlp.NewBlock("", text, "raw.md"), file, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []string{}
+	for _, a := range alerts {
+		messages = append(messages, a.Message)
+	}
+
+	expected := []string{
+		"'Misc' is an unknown section.",
+		"'1.0.0' is a release without sections.",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Expected %v, got %v", expected, messages)
+	}
+}
+
+func TestChangelogOptions(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, generic := range []baseCheck{
+		{},
+		{"past": true, "release": 0},
+		{"references": `(`},
+	} {
+		if _, err = NewChangelog(cfg, generic, "test.yml"); err == nil {
+			t.Errorf("Expected an error for %v", generic)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/errata-ai/regexp2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// rePandocCitation matches the key of a Pandoc citation -- e.g., `[@doe99]`,
+// `[see @doe99, p. 3]`, `[-@doe99]`, or `@doe99 says` -- but not an email
+// address or a mention inside of a URL.
+var rePandocCitation = regexp2.MustCompileStd(`(?<![\w@\\/.-])-?@(\w(?:[\w:.#$%&+?<>~/-]*\w)?)`)
+
+// reRSTCitation matches a `sphinxcontrib-bibtex` citation role -- e.g.,
+// :cite:`doe99` or :cite:p:`doe99,smith04` -- capturing its keys.
+var reRSTCitation = regexp2.MustCompileStd(":cite(?::\\w+)?:`([^`]+)`")
+
+// reURL matches a URL, whose `@` (e.g., `https://example.com/@user`) isn't
+// a citation.
+var reURL = regexp.MustCompile(`\b(?:https?|ftp|mailto):[^\s)>\]]+`)
+
+var reRSTLiteral = regexp.MustCompile("(?s)``.+?``")
+var reBibEntry = regexp.MustCompile(`(?m)^[ \t]*@(\w+)[ \t]*[{(][ \t]*([^,\s]+)[ \t]*,`)
+var reFrontMatterYAML = regexp.MustCompile(`(?s)\A---\n(.*?)\n---[ \t]*\n`)
+var reNotNewline = regexp.MustCompile(`[^\n]`)
+
+// Citations checks that the citation keys of a Markdown (Pandoc) or
+// reStructuredText (`sphinxcontrib-bibtex`) document refer to entries in its
+// bibliography, suggesting the closest existing key for those that don't.
+//
+// The bibliographies -- BibTeX (`.bib`) or CSL JSON (`.json`) files -- are
+// those listed in the document's front matter (`bibliography: refs.bib`) and
+// in the config's `Bibliography` option. A document without any isn't
+// checked.
+type Citations struct {
+	Definition `mapstructure:",squash"`
+
+	index *bibIndex
+}
+
+// bibIndex caches the keys of each bibliography, which are usually shared by
+// many documents.
+type bibIndex struct {
+	files map[string]bibEntry
+	mu    sync.Mutex
+}
+
+type bibEntry struct {
+	modified time.Time
+	keys     map[string]bool
+}
+
+// NewCitations creates a new `Citations` rule.
+func NewCitations(_ *core.Config, generic baseCheck, path string) (Citations, error) {
+	rule := Citations{index: &bibIndex{files: map[string]bibEntry{}}}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	return rule, nil
+}
+
+// Run executes the `Citations` rule.
+//
+// This rule only applies to Markdown and reStructuredText files and expects
+// to be given the raw contents of the file (`scope: raw`). The second
+// substitution in its message is a suggestion (if any) -- e.g., " (did you
+// mean 'doe1999'?)".
+func (r Citations) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	var re *regexp2.Regexp
+	switch f.NormedExt {
+	case ".md":
+		re = rePandocCitation
+	case ".rst":
+		re = reRSTCitation
+	default:
+		return alerts, nil
+	}
+
+	paths := frontMatterBibliography(blk.Text, f.Path)
+	paths = append(paths, cfg.Bibliography...)
+	if len(paths) == 0 {
+		return alerts, nil
+	}
+
+	keys := map[string]bool{}
+	for _, path := range paths {
+		f.AddDependency(path)
+
+		found, err := r.index.lookup(path)
+		if err != nil {
+			return alerts, core.NewE100(f.Path, err)
+		}
+		for key := range found {
+			keys[key] = true
+		}
+	}
+
+	known := make([]string, 0, len(keys))
+	for key := range keys {
+		known = append(known, key)
+	}
+	sort.Strings(known)
+
+	blank := func(m string) string {
+		return reNotNewline.ReplaceAllString(m, " ")
+	}
+
+	txt := blk.Text
+	if f.NormedExt == ".md" {
+		txt = maskCode(txt)
+	} else {
+		// NOTE: In reStructuredText, single backticks delimit roles rather
+		// than code.
+		txt = reRSTLiteral.ReplaceAllStringFunc(txt, blank)
+	}
+
+	txt = reURL.ReplaceAllStringFunc(txt, blank)
+	txt = reFrontMatterYAML.ReplaceAllStringFunc(txt, blank)
+
+	for _, loc := range citationKeys(re, txt) {
+		key, err := re2Loc(txt, loc)
+		if err != nil {
+			return alerts, err
+		} else if keys[key] {
+			continue
+		}
+
+		a, err := makeAlert(r.Definition, loc, blk.Text, cfg)
+		if err != nil {
+			return alerts, err
+		}
+
+		hint := ""
+		if closest := closestAnchor(key, known); closest != "" {
+			hint = " (did you mean '" + closest + "'?)"
+			a.Action = core.Action{Name: "replace", Params: []string{closest}}
+		}
+		a.Message, a.Description = formatMessages(r.Message, r.Description, a.Match, hint)
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (r Citations) Fields() Definition {
+	return r.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (r Citations) Pattern() string {
+	return ""
+}
+
+// citationKeys returns the (rune) locations of each citation key matched by
+// `re`, splitting comma-separated lists of keys (as in reStructuredText).
+func citationKeys(re *regexp2.Regexp, txt string) [][]int {
+	var locs [][]int
+
+	runes := []rune(txt)
+	for _, m := range re.FindAllStringSubmatchIndex(txt, -1) {
+		start := m[2]
+		for _, key := range strings.Split(string(runes[m[2]:m[3]]), ",") {
+			size := len([]rune(key))
+
+			trimmed := strings.TrimLeft(key, " \t\n")
+			offset := size - len([]rune(trimmed))
+			trimmed = strings.TrimRight(trimmed, " \t\n")
+
+			if trimmed != "" {
+				begin := start + offset
+				locs = append(locs, []int{begin, begin + len([]rune(trimmed))})
+			}
+			start += size + 1
+		}
+	}
+
+	return locs
+}
+
+// frontMatterBibliography returns the bibliographies listed in a document's
+// YAML front matter, relative to the document.
+func frontMatterBibliography(content, path string) []string {
+	m := reFrontMatterYAML.FindStringSubmatch(content)
+	if m == nil {
+		return nil
+	}
+
+	var meta struct {
+		Bibliography interface{} `yaml:"bibliography"`
+	}
+	if err := yaml.Unmarshal([]byte(m[1]), &meta); err != nil {
+		return nil
+	}
+
+	var listed []string
+	switch v := meta.Bibliography.(type) {
+	case string:
+		listed = append(listed, v)
+	case []interface{}:
+		for _, entry := range v {
+			if s, ok := entry.(string); ok {
+				listed = append(listed, s)
+			}
+		}
+	}
+
+	paths := []string{}
+	for _, p := range listed {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(filepath.Dir(path), p)
+		}
+		paths = append(paths, p)
+	}
+
+	return paths
+}
+
+// lookup returns the keys of the bibliography at `path`.
+func (idx *bibIndex) lookup(path string) (map[string]bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("bibliography '%s' not found", path)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if entry, found := idx.files[abs]; found && entry.modified.Equal(info.ModTime()) {
+		return entry.keys, nil
+	}
+
+	content, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := bibliographyKeys(abs, content)
+	if err != nil {
+		return nil, err
+	}
+	idx.files[abs] = bibEntry{modified: info.ModTime(), keys: keys}
+
+	return keys, nil
+}
+
+// bibliographyKeys returns the keys defined by a BibTeX or CSL JSON file.
+func bibliographyKeys(path string, content []byte) (map[string]bool, error) {
+	keys := map[string]bool{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".bib", ".bibtex":
+		for _, m := range reBibEntry.FindAllStringSubmatch(string(content), -1) {
+			kind := strings.ToLower(m[1])
+			if kind != "string" && kind != "comment" && kind != "preamble" {
+				keys[m[2]] = true
+			}
+		}
+	case ".json":
+		var entries []struct {
+			ID interface{} `json:"id"`
+		}
+		if err := json.Unmarshal(content, &entries); err != nil {
+			return nil, fmt.Errorf("bibliography '%s' isn't valid CSL JSON: %w", path, err)
+		}
+		for _, entry := range entries {
+			if entry.ID != nil {
+				keys[fmt.Sprint(entry.ID)] = true
+			}
+		}
+	default:
+		return nil, fmt.Errorf("bibliography '%s' must be a '.bib' or CSL '.json' file", path)
+	}
+
+	return keys, nil
+}
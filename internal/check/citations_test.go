@@ -0,0 +1,101 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestBibliographyKeys(t *testing.T) {
+	bib := "@string{acm = \"ACM\"}\n@article{doe1999,\n  title = {Things},\n}\n  @Book( smith04 , title={Stuff})\n"
+
+	keys, err := bibliographyKeys("refs.bib", []byte(bib))
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(keys, map[string]bool{"doe1999": true, "smith04": true}) {
+		t.Errorf("Unexpected BibTeX keys: %v", keys)
+	}
+
+	keys, err = bibliographyKeys("refs.json", []byte(`[{"id": "doe1999"}, {"id": 42}]`))
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(keys, map[string]bool{"doe1999": true, "42": true}) {
+		t.Errorf("Unexpected CSL JSON keys: %v", keys)
+	}
+
+	if _, err = bibliographyKeys("refs.yml", nil); err == nil {
+		t.Error("Expected an error for an unsupported bibliography")
+	}
+}
+
+func TestCitations(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, "refs.bib"), []byte("@article{doe1999,\n}\n@book{smith04,\n}\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewCitations(cfg, baseCheck{
+		"message": "'%s' is missing%s.",
+		"scope":   []string{"raw"},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			"index.md",
+			"---\nbibliography: refs.bib\n---\n\nAs @smith04 says [see @doe1999, p. 3; -@doe199].\n" +
+				"Email foo@example.com, see https://example.com/@user, or `@code`.\n",
+			[]string{"'doe199' is missing (did you mean 'doe1999'?)."},
+		},
+		{
+			"index.md",
+			"No bibliography [@zzz].\n",
+			[]string{},
+		},
+		{
+			"index.rst",
+			"As shown :cite:p:`doe1999, smith4`, and ``:cite:`x```.\n",
+			[]string{"'smith4' is missing (did you mean 'smith04'?)."},
+		},
+	}
+
+	for _, tc := range cases {
+		ext := filepath.Ext(tc.name)
+
+		cfg.Bibliography = nil
+		if ext == ".rst" {
+			cfg.Bibliography = []string{filepath.Join(dir, "refs.bib")}
+		}
+
+		file := &core.File{Path: filepath.Join(dir, tc.name), NormedExt: ext}
+		alerts, rerr := rule.Run(nlp.NewBlock("", tc.text, "raw"+ext), file, cfg)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+
+		messages := []string{}
+		for _, a := range alerts {
+			messages = append(messages, a.Message)
+		}
+
+		if !reflect.DeepEqual(messages, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, messages)
+		}
+	}
+}
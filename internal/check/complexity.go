@@ -0,0 +1,149 @@
+package check
+
+import (
+	"strings"
+
+	"github.com/jdkato/twine/summarize"
+)
+
+// complexityMetrics are the sentence-complexity metrics supported by
+// `readability` rules. Unlike grade-level formulas, each is compared to its
+// own limit (see `Readability.Limits`).
+var complexityMetrics = map[string]func(*summarize.Document) float64{
+	// The average number of clauses per sentence -- i.e., one plus the
+	// number of subordinating conjunctions and relative pronouns.
+	"Clause Depth": clauseDepth,
+	// The number of nominalizations (e.g., "implementation") per 100 words.
+	"Nominalization Density": nominalizationDensity,
+	// The percentage of sentences that use the passive voice.
+	"Passive Voice": passiveRatio,
+}
+
+var subordinators = map[string]bool{
+	"after": true, "although": true, "because": true, "before": true,
+	"if": true, "once": true, "since": true, "though": true, "unless": true,
+	"until": true, "when": true, "whenever": true, "where": true,
+	"whereas": true, "wherever": true, "whether": true, "which": true,
+	"while": true, "who": true, "whom": true, "whose": true,
+}
+
+var nominalSuffixes = []string{"tion", "sion", "ment", "ance", "ence", "ity", "ness"}
+
+var beVerbs = map[string]bool{
+	"am": true, "are": true, "is": true, "was": true, "were": true,
+	"be": true, "been": true, "being": true,
+}
+
+var irregularParticiples = map[string]bool{
+	"arisen": true, "beaten": true, "begun": true, "bitten": true,
+	"blown": true, "bought": true, "broken": true, "brought": true,
+	"built": true, "caught": true, "chosen": true, "dealt": true,
+	"done": true, "drawn": true, "driven": true, "eaten": true,
+	"fallen": true, "felt": true, "forbidden": true, "forgiven": true,
+	"forgotten": true, "found": true, "frozen": true, "given": true,
+	"grown": true, "heard": true, "held": true, "hidden": true,
+	"hung": true, "kept": true, "known": true, "laid": true, "led": true,
+	"left": true, "lost": true, "made": true, "meant": true, "met": true,
+	"paid": true, "said": true, "seen": true, "sent": true, "shaken": true,
+	"shown": true, "sold": true, "spoken": true, "spent": true,
+	"stolen": true, "struck": true, "taken": true, "taught": true,
+	"thought": true, "thrown": true, "told": true, "torn": true,
+	"understood": true, "withdrawn": true, "won": true, "worn": true,
+	"written": true,
+}
+
+func clauseDepth(doc *summarize.Document) float64 {
+	if len(doc.Sentences) == 0 {
+		return 0
+	}
+
+	clauses := 0
+	for _, s := range doc.Sentences {
+		clauses++
+		for _, w := range s.Words {
+			if subordinators[normalizeWord(w.Text)] {
+				clauses++
+			}
+		}
+	}
+
+	return float64(clauses) / float64(len(doc.Sentences))
+}
+
+func nominalizationDensity(doc *summarize.Document) float64 {
+	if doc.NumWords == 0 {
+		return 0
+	}
+
+	count := 0
+	for _, s := range doc.Sentences {
+		for _, w := range s.Words {
+			if isNominalization(normalizeWord(w.Text)) {
+				count++
+			}
+		}
+	}
+
+	return float64(count) / doc.NumWords * 100
+}
+
+func passiveRatio(doc *summarize.Document) float64 {
+	if len(doc.Sentences) == 0 {
+		return 0
+	}
+
+	passive := 0
+	for _, s := range doc.Sentences {
+		if isPassive(s) {
+			passive++
+		}
+	}
+
+	return float64(passive) / float64(len(doc.Sentences)) * 100
+}
+
+// isNominalization reports if `word` looks like a noun formed from a verb or
+// adjective (e.g., "utilization" or "effectiveness").
+//
+// We ignore short words, which are mostly false positives (e.g., "city" or
+// "moment").
+func isNominalization(word string) bool {
+	if len(word) < 8 {
+		return false
+	}
+	for _, suffix := range nominalSuffixes {
+		if strings.HasSuffix(word, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPassive reports if `s` contains a form of "to be" followed by a past
+// participle, optionally separated by an adverb (e.g., "was quickly
+// written").
+func isPassive(s summarize.Sentence) bool {
+	for i, w := range s.Words {
+		if !beVerbs[normalizeWord(w.Text)] {
+			continue
+		}
+
+		for j := i + 1; j < len(s.Words) && j <= i+2; j++ {
+			next := normalizeWord(s.Words[j].Text)
+			if isParticiple(next) {
+				return true
+			} else if next != "not" && !strings.HasSuffix(next, "ly") {
+				break
+			}
+		}
+	}
+	return false
+}
+
+func isParticiple(word string) bool {
+	return irregularParticiples[word] || (len(word) > 4 && strings.HasSuffix(word, "ed"))
+}
+
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.Trim(word, `.,;:!?"'()[]`))
+}
@@ -8,6 +8,9 @@ import (
 )
 
 // Conditional ensures that the present of First ensures the present of Second.
+//
+// If Exclusive is set, the relationship is inverted: the presence of First
+// ensures the *absence* of Second within the same scope.
 type Conditional struct {
 	Definition `mapstructure:",squash"`
 	Exceptions []string
@@ -17,6 +20,7 @@ type Conditional struct {
 	exceptRe   *regexp2.Regexp
 	Ignorecase bool
 	Vocab      bool
+	Exclusive  bool
 }
 
 // NewConditional creates a new `conditional`-based rule.
@@ -62,6 +66,10 @@ func (c Conditional) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.
 	alerts := []core.Alert{}
 
 	txt := blk.Text
+	if c.Exclusive {
+		return c.runExclusive(txt, cfg)
+	}
+
 	// We first look for the consequent of the conditional statement.
 	// For example, if we're ensuring that abbreviations have been defined
 	// parenthetically, we'd have something like:
@@ -105,6 +113,45 @@ func (c Conditional) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.
 	return alerts, nil
 }
 
+// runExclusive reports every match of `second` in a block that also contains
+// a match of `first`.
+//
+// For example, a block that uses "click" (first) shouldn't also use "tap"
+// (second). The second substitution in the rule's message is the match of
+// `first`.
+func (c Conditional) runExclusive(txt string, cfg *core.Config) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	locs := c.patterns[1].FindAllStringIndex(txt, 1)
+	if len(locs) == 0 {
+		return alerts, nil
+	}
+
+	first, err := re2Loc(txt, locs[0])
+	if err != nil {
+		return alerts, err
+	}
+
+	for _, loc := range c.patterns[0].FindAllStringIndex(txt, -1) {
+		s, errc := re2Loc(txt, loc)
+		if errc != nil {
+			return alerts, errc
+		} else if isMatch(c.exceptRe, s) {
+			continue
+		}
+
+		a, erra := makeAlert(c.Definition, loc, txt, cfg)
+		if erra != nil {
+			return alerts, erra
+		}
+		a.Message, a.Description = formatMessages(c.Message, c.Description, s, first)
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
 // Fields provides access to the internal rule definition.
 func (c Conditional) Fields() Definition {
 	return c.Definition
@@ -0,0 +1,49 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestConditionalExclusive(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewConditional(cfg, baseCheck{
+		"message":    "Don't use '%s' alongside '%s'.",
+		"first":      `\bclick\b`,
+		"second":     `\btap\b`,
+		"exclusive":  true,
+		"exceptions": []string{"Tap"},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string][]string{
+		"Tap the button, then tap Save.":              {},
+		"Click the button, then tap Save.":            {},
+		"click the button, then tap Save or tap Esc.": {"Don't use 'tap' alongside 'click'.", "Don't use 'tap' alongside 'click'."},
+		"Tap Save, then click Done.":                  {},
+	}
+
+	for text, expected := range cases {
+		alerts, errR := rule.Run(nlp.NewBlock("", text, "text"), &core.File{}, cfg)
+		if errR != nil {
+			t.Fatal(errR)
+		}
+
+		messages := []string{}
+		for _, a := range alerts {
+			messages = append(messages, a.Message)
+		}
+		if !reflect.DeepEqual(messages, expected) {
+			t.Errorf("%q: expected %v, got %v", text, expected, messages)
+		}
+	}
+}
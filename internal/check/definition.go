@@ -40,9 +40,39 @@ type Definition struct {
 	Message     string
 	Name        string
 	Scope       []string
+	Lang        []string
 	Selector    Selector
 }
 
+// MatchesLang determines if a rule should run on a document written in the
+// given language.
+//
+// Rules without a `lang` run on every document. Otherwise, tags are compared
+// subtag by subtag for as many subtags as both have, so a less specific tag
+// matches any of its more specific ones in either direction: a rule for `en`
+// runs on `en-US` and `en-GB` documents, and a rule for `en-US` runs on `en`
+// documents (whose region we don't know) but not on `en-GB` ones.
+func (d Definition) MatchesLang(lang string) bool {
+	if len(d.Lang) == 0 {
+		return true
+	}
+
+	lang = normalizeLang(lang)
+	for _, tag := range d.Lang {
+		tag = normalizeLang(tag)
+		if lang == tag || strings.HasPrefix(lang, tag+"-") || strings.HasPrefix(tag, lang+"-") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeLang converts locale identifiers like `en_US` to `en-us`.
+func normalizeLang(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
 var defaultStyles = []string{"Vale"}
 var extensionPoints = []string{
 	"capitalization",
@@ -57,6 +87,14 @@ var extensionPoints = []string{
 	"sequence",
 	"metric",
 	"script",
+	"placeholder",
+	"punctuation",
+	"jargon",
+	"duplicates",
+	"grammar",
+	"agreement",
+	"changelog",
+	"pipeline",
 }
 var defaultRules = map[string]map[string]interface{}{
 	"Avoid": {
@@ -87,6 +125,7 @@ var defaultRules = map[string]map[string]interface{}{
 		"message": "'%s' is repeated!",
 		"scope":   "text",
 		"alpha":   true,
+		"reflow":  true,
 		"action": core.Action{
 			Name:   "edit",
 			Params: []string{"truncate", " "},
@@ -107,6 +146,30 @@ var defaultRules = map[string]map[string]interface{}{
 		"ignore": []interface{}{},
 		"path":   "internal",
 	},
+	"References": {
+		"extends": "references",
+		"name":    "Vale.References",
+		"message": "'%s' is %s.",
+		"level":   "error",
+		"scope":   "raw",
+		"path":    "internal",
+	},
+	"Citations": {
+		"extends": "citations",
+		"name":    "Vale.Citations",
+		"message": "'%s' isn't in the bibliography%s.",
+		"level":   "error",
+		"scope":   "raw",
+		"path":    "internal",
+	},
+	"Anchors": {
+		"extends": "anchors",
+		"name":    "Vale.Anchors",
+		"message": "'%s' doesn't link to an existing heading%s.",
+		"level":   "error",
+		"scope":   "raw",
+		"path":    "internal",
+	},
 }
 
 const (
@@ -156,6 +219,32 @@ func buildRule(cfg *core.Config, generic baseCheck) (Rule, error) {
 		return NewMetric(cfg, generic, path)
 	case "script":
 		return NewScript(cfg, generic, path)
+	case "placeholder":
+		return NewPlaceholder(cfg, generic, path)
+	case "punctuation":
+		return NewPunctuation(cfg, generic, path)
+	case "jargon":
+		return NewJargon(cfg, generic, path)
+	case "duplicates":
+		return NewDuplicates(cfg, generic, path)
+	case "grammar":
+		return NewGrammar(cfg, generic, path)
+	case "agreement":
+		return NewAgreement(cfg, generic, path)
+	case "changelog":
+		return NewChangelog(cfg, generic, path)
+	case "pipeline":
+		return NewPipeline(cfg, generic, path)
+	case "citations":
+		return NewCitations(cfg, generic, path)
+	case "references":
+		// NOTE: This is an internal-only extension point; see
+		// `Vale.References`.
+		return NewReferences(cfg, generic, path)
+	case "anchors":
+		// NOTE: This is an internal-only extension point; see
+		// `Vale.Anchors`.
+		return NewAnchors(cfg, generic, path)
 	default:
 		return Existence{}, core.NewE201FromTarget(
 			fmt.Sprintf("'extends' key must be one of %v.", extensionPoints),
@@ -343,7 +432,7 @@ func updateExceptions(previous []string, current []string, vocab bool) (*regexp2
 	}
 
 	// NOTE: This is required to ensure that we have greedy alternation.
-	sort.Slice(previous, func(p, q int) bool {
+	sort.SliceStable(previous, func(p, q int) bool {
 		return len(previous[p]) > len(previous[q])
 	})
 
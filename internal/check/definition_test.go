@@ -0,0 +1,29 @@
+package check
+
+import "testing"
+
+func TestMatchesLang(t *testing.T) {
+	cases := []struct {
+		rule     []string
+		lang     string
+		expected bool
+	}{
+		{nil, "de", true},
+		{[]string{"en"}, "en", true},
+		{[]string{"en"}, "en-US", true},
+		{[]string{"en"}, "en_GB", true},
+		{[]string{"en-US"}, "en", true},
+		{[]string{"en-US"}, "EN-us", true},
+		{[]string{"en-US"}, "en-GB", false},
+		{[]string{"en"}, "eng", false},
+		{[]string{"de", "fr"}, "fr-CA", true},
+		{[]string{"de", "fr"}, "en", false},
+	}
+
+	for _, tc := range cases {
+		d := Definition{Lang: tc.rule}
+		if actual := d.MatchesLang(tc.lang); actual != tc.expected {
+			t.Errorf("%v on '%s': expected %t, got %t", tc.rule, tc.lang, tc.expected, actual)
+		}
+	}
+}
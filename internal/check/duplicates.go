@@ -0,0 +1,205 @@
+package check
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// doubledMarks are the punctuation marks that are reported when repeated
+// (e.g., "a,, b" or "a; ; b").
+//
+// Periods are handled separately since they may be part of an ellipsis.
+var doubledMarks = map[rune]bool{',': true, ';': true}
+
+// Duplicates looks for immediately repeated words -- such as "the the" -- and
+// doubled punctuation.
+//
+// Unlike `repetition`, which is driven by a user-provided regex, it makes a
+// single pass over the block's text. Each alert includes a `replace` action
+// that removes the duplicate.
+type Duplicates struct {
+	Definition `mapstructure:",squash"`
+	// `ignorecase` (`bool`): Report repeats that differ in case ("The the").
+	Ignorecase bool
+	// `reflow` (`bool`): Report repeats that span a soft line break, as in
+	// hard-wrapped Markdown.
+	Reflow bool
+	// `punctuation` (`bool`): Report doubled commas, semicolons, and periods.
+	Punctuation bool
+	// `exceptions` (`array`): An array of strings to be ignored.
+	Exceptions []string
+	// `vocab` (`boolean`): If `true`, use the user's `Vocab` as a list of
+	// exceptions.
+	Vocab bool
+
+	exceptRe *regexp2.Regexp
+}
+
+// NewDuplicates creates a new `duplicates`-based rule.
+func NewDuplicates(cfg *core.Config, generic baseCheck, path string) (Duplicates, error) {
+	rule := Duplicates{Vocab: true}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	re, err := updateExceptions(rule.Exceptions, cfg.AcceptedTokens, rule.Vocab)
+	if err != nil {
+		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+	rule.exceptRe = re
+
+	return rule, nil
+}
+
+// Run executes the `duplicates`-based rule.
+func (d Duplicates) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	txt := blk.Text
+	runes := []rune(txt)
+
+	report := func(loc []int, repeated, repl string) error {
+		a, err := makeAlert(d.Definition, loc, txt, cfg)
+		if err != nil {
+			return err
+		}
+		a.Message, a.Description = formatMessages(d.Message, d.Description, repeated)
+		a.Action = core.Action{Name: "replace", Params: []string{repl}}
+		alerts = append(alerts, a)
+		return nil
+	}
+
+	prev := []int{-1, -1}
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			end := wordEnd(runes, i)
+			word := string(runes[i:end])
+
+			if prev[0] >= 0 && d.isRepeat(runes, prev, []int{i, end}) && !isMatch(d.exceptRe, word) {
+				first := string(runes[prev[0]:prev[1]])
+				if err := report([]int{prev[0], end}, first, first); err != nil {
+					return alerts, err
+				}
+			}
+
+			prev = []int{i, end}
+			i = end
+			continue
+		}
+
+		if d.Punctuation {
+			if end := doubledEnd(runes, i); end > 0 {
+				if err := report([]int{i, end}, string(r), string(r)); err != nil {
+					return alerts, err
+				}
+				i = end
+				continue
+			}
+		}
+
+		i++
+	}
+
+	return alerts, nil
+}
+
+// isRepeat determines if the word at `curr` repeats the word at `prev`.
+//
+// Only words made up of letters count, and they must be separated by nothing
+// but whitespace on the same (logical) line.
+func (d Duplicates) isRepeat(runes []rune, prev, curr []int) bool {
+	a, b := string(runes[prev[0]:prev[1]]), string(runes[curr[0]:curr[1]])
+
+	if d.Ignorecase {
+		if !strings.EqualFold(a, b) {
+			return false
+		}
+	} else if a != b {
+		return false
+	}
+
+	if !core.IsLetter(strings.NewReplacer("'", "", "’", "", "-", "").Replace(a)) {
+		return false
+	}
+
+	gap := string(runes[prev[1]:curr[0]])
+	if strings.TrimSpace(gap) != "" || gap == "" {
+		return false
+	} else if d.Reflow {
+		return !reBlankLine.MatchString(gap)
+	}
+
+	return !strings.Contains(gap, "\n")
+}
+
+// Fields provides access to the internal rule definition.
+func (d Duplicates) Fields() Definition {
+	return d.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (d Duplicates) Pattern() string {
+	return ""
+}
+
+// wordEnd returns the end of the word starting at `i`, which may include
+// inner apostrophes and hyphens ("it's", "well-known").
+func wordEnd(runes []rune, i int) int {
+	isWord := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+	}
+
+	j := i
+	for j < len(runes) {
+		if isWord(runes[j]) {
+			j++
+		} else if strings.ContainsRune("'’-", runes[j]) && j+1 < len(runes) && isWord(runes[j+1]) {
+			j += 2
+		} else {
+			break
+		}
+	}
+
+	return j
+}
+
+// doubledEnd returns the end of the doubled punctuation mark starting at `i`,
+// or -1 if there isn't one.
+func doubledEnd(runes []rune, i int) int {
+	r := runes[i]
+
+	if doubledMarks[r] {
+		j := i + 1
+		for j < len(runes) && (runes[j] == ' ' || runes[j] == '\t') {
+			j++
+		}
+		if j < len(runes) && runes[j] == r {
+			return j + 1
+		}
+		return -1
+	}
+
+	// A pair of periods after a word ("the end.. Next"), but not an
+	// ellipsis or a relative path ("../").
+	if r == '.' && i > 0 && unicode.IsLetter(runes[i-1]) && i+1 < len(runes) && runes[i+1] == '.' {
+		if i+2 == len(runes) || unicode.IsSpace(runes[i+2]) {
+			return i + 2
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,69 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestDuplicates(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		def      baseCheck
+		text     string
+		expected []string
+	}{
+		{
+			def:      baseCheck{},
+			text:     "This is is fine, but The the isn't. Redis. Redis is well-known known.",
+			expected: []string{"is is"},
+		},
+		{
+			def:      baseCheck{"ignorecase": true},
+			text:     "The the cat\nsat sat.",
+			expected: []string{"The the", "sat sat"},
+		},
+		{
+			def:      baseCheck{"reflow": true},
+			text:     "It ends with the\nthe next line.\n\nand\n\nand not this.",
+			expected: []string{"the\nthe"},
+		},
+		{
+			def:      baseCheck{"punctuation": true},
+			text:     "One,, two; ; three.. Wait... see ../docs.",
+			expected: []string{",,", "; ;", ".."},
+		},
+	}
+
+	for _, c := range cases {
+		c.def["message"] = "%s"
+
+		rule, err := NewDuplicates(cfg, c.def, "test.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		alerts, err := rule.Run(nlp.NewBlock("", c.text, "text"), nil, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		matches := []string{}
+		for _, a := range alerts {
+			matches = append(matches, a.Match)
+			if a.Action.Name != "replace" || a.Action.Params[0] != a.Message {
+				t.Errorf("%q: unexpected action %v", a.Match, a.Action)
+			}
+		}
+
+		if !reflect.DeepEqual(matches, c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.text, c.expected, matches)
+		}
+	}
+}
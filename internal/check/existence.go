@@ -19,10 +19,16 @@ type Existence struct {
 	Exceptions []string
 	exceptRe   *regexp2.Regexp
 	pattern    *regexp2.Regexp
+	literal    *literalMatcher
+	regex      string
 	Append     bool
 	IgnoreCase bool
 	Nonword    bool
 	Vocab      bool
+	// `inline` (`bool`): With `nonword`, also report phrases that are
+	// interrupted by inline markup in the source (e.g., "in *order* to" or a
+	// phrase around a code span) at their exact location.
+	Inline bool
 }
 
 // NewExistence creates a new `Rule` that extends `Existence`.
@@ -59,6 +65,14 @@ func NewExistence(cfg *core.Config, generic baseCheck, path string) (Existence,
 		}
 	}
 	regex = fmt.Sprintf(regex, strings.Join(parsed, "|"))
+	rule.regex = regex
+
+	if len(rule.Raw) == 0 && (rule.Nonword || cfg.WordTemplate == "") {
+		rule.literal = newLiteralMatcher(parsed, rule.IgnoreCase, !rule.Nonword)
+		if rule.literal != nil {
+			return rule, nil
+		}
+	}
 
 	re, err = regexp2.CompileStd(regex)
 	if err != nil {
@@ -77,7 +91,7 @@ func NewExistence(cfg *core.Config, generic baseCheck, path string) (Existence,
 func (e Existence) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 
-	for _, loc := range e.pattern.FindAllStringIndex(blk.Text, -1) {
+	for _, loc := range e.locations(blk.Text) {
 		converted, err := re2Loc(blk.Text, loc)
 		if err != nil {
 			return alerts, err
@@ -89,6 +103,7 @@ func (e Existence) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Al
 			if erra != nil {
 				return alerts, erra
 			}
+			a.Inline = e.Nonword && e.Inline
 			alerts = append(alerts, a)
 		}
 	}
@@ -96,6 +111,19 @@ func (e Existence) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Al
 	return alerts, nil
 }
 
+// locations returns the (rune) locations of all of the rule's matches.
+func (e Existence) locations(txt string) [][]int {
+	if e.literal == nil {
+		return e.pattern.FindAllStringIndex(txt, -1)
+	}
+
+	locs := [][]int{}
+	for _, m := range e.literal.findAll(txt) {
+		locs = append(locs, m.loc)
+	}
+	return locs
+}
+
 // Fields provides access to the internal rule definition.
 func (e Existence) Fields() Definition {
 	return e.Definition
@@ -103,5 +131,5 @@ func (e Existence) Fields() Definition {
 
 // Pattern is the internal regex pattern used by this rule.
 func (e Existence) Pattern() string {
-	return e.pattern.String()
+	return e.regex
 }
@@ -0,0 +1,40 @@
+package check
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// MatchedException returns the exception (or accepted vocabulary term) that
+// prevents the given rule from reporting `s`, if there is one.
+//
+// This is used to explain why a rule didn't report a match (see `--why`).
+func MatchedException(rule Rule, s string, cfg *core.Config) string {
+	var terms []string
+
+	v := reflect.ValueOf(rule)
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	if field := v.FieldByName("Exceptions"); field.IsValid() {
+		terms = append(terms, field.Interface().([]string)...)
+	}
+	if field := v.FieldByName("Vocab"); field.IsValid() && field.Bool() {
+		terms = append(terms, cfg.AcceptedTokens...)
+	}
+
+	for _, term := range terms {
+		re, err := regexp2.CompileStd(`^(?:` + term + `)$`)
+		if err == nil && isMatch(re, s) {
+			// Case-sensitive exceptions are stored with an inline flag.
+			return strings.TrimPrefix(term, "(?-i)")
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,41 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestMatchedException(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.AcceptedTokens = []string{"Vale"}
+
+	def := map[string]interface{}{
+		"extends":    "existence",
+		"name":       "Test.Existence",
+		"level":      "error",
+		"message":    "'%s'",
+		"tokens":     []interface{}{`\w+`},
+		"exceptions": []interface{}{"foo(?:bar)?"},
+	}
+
+	rule, err := NewExistence(cfg, def, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"foobar": "foo(?:bar)?",
+		"Vale":   "Vale",
+		"baz":    "",
+	}
+
+	for s, expected := range cases {
+		if found := MatchedException(rule, s, cfg); found != expected {
+			t.Errorf("%s: expected '%s', got '%s'", s, expected, found)
+		}
+	}
+}
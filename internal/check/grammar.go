@@ -0,0 +1,179 @@
+package check
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/errata-ai/regexp2"
+	"github.com/jdkato/twine/nlp/tag"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// finiteVerbTags are the part-of-speech tags that begin a new verb group (and
+// therefore a new clause).
+var finiteVerbTags = []string{"VBD", "VBP", "VBZ", "MD"}
+
+// Grammar checks sentences using a few built-in part-of-speech primitives.
+//
+// Each of these could be written as a `sequence` rule, but a `grammar` rule
+// only has to tag a sentence once (rather than matching a token pattern at
+// every position), which makes common grammar-adjacent rules -- "don't start
+// a sentence with a conjunction", for example -- much cheaper.
+type Grammar struct {
+	Definition `mapstructure:",squash"`
+	// `starts` (`string`): A regular expression matching the part-of-speech
+	// tags that a sentence may not start with -- e.g., `CC|RB` for
+	// conjunctions and adverbs.
+	Starts string
+	// `verbless` (`bool`): If `true`, report sentences that lack a verb.
+	Verbless bool
+	// `clauses` (`int`): Report sentences with more than `clauses` clauses,
+	// as counted by their groups of finite verbs.
+	Clauses int
+	// `exceptions` (`array`): An array of words that a sentence may start
+	// with regardless of their tag.
+	Exceptions []string
+
+	exceptRe *regexp2.Regexp
+	startsRe *regexp2.Regexp
+}
+
+// NewGrammar creates a new `grammar`-based rule.
+func NewGrammar(cfg *core.Config, generic baseCheck, path string) (Grammar, error) {
+	rule := Grammar{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	if rule.Starts == "" && !rule.Verbless && rule.Clauses == 0 {
+		return rule, core.NewE201FromPosition(
+			"One of 'starts', 'verbless', or 'clauses' is required.", path, 1)
+	} else if rule.Clauses < 0 {
+		return rule, core.NewE201FromTarget(
+			"'clauses' must be a positive integer.", "clauses", path)
+	}
+
+	if rule.Starts != "" {
+		re, errc := regexp2.CompileStd(`^(?:` + rule.Starts + `)$`)
+		if errc != nil {
+			return rule, core.NewE201FromTarget(errc.Error(), "starts", path)
+		}
+		rule.startsRe = re
+	}
+
+	re, err := updateExceptions(rule.Exceptions, cfg.AcceptedTokens, false)
+	if err != nil {
+		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+	rule.exceptRe = re
+
+	rule.Definition.Scope = []string{"sentence"}
+	return rule, nil
+}
+
+// Run checks a single sentence against each of the rule's primitives.
+func (g Grammar) Run(blk nlp.Block, f *core.File, _ *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	words := []tag.Token{}
+	for _, tok := range nlp.TextToTokens(blk.Text, &f.NLP) {
+		if strings.IndexFunc(tok.Text, isWordRune) >= 0 {
+			words = append(words, tok)
+		}
+	}
+
+	if len(words) == 0 {
+		return alerts, nil
+	}
+	txt := blk.Text
+
+	if g.startsRe != nil {
+		first := words[0]
+
+		matched, err := g.startsRe.MatchString(first.Tag)
+		if err != nil {
+			return alerts, err
+		} else if matched && !isMatch(g.exceptRe, first.Text) {
+			if start := strings.Index(txt, first.Text); start >= 0 {
+				alerts = append(alerts, g.makeAlert(first.Text, start))
+			}
+		}
+	}
+
+	sentence := strings.TrimSpace(txt)
+	start := strings.Index(txt, sentence)
+
+	if g.Verbless && !hasVerb(words) {
+		alerts = append(alerts, g.makeAlert(sentence, start))
+	}
+
+	if g.Clauses > 0 && countClauses(words) > g.Clauses {
+		alerts = append(alerts, g.makeAlert(sentence, start))
+	}
+
+	return alerts, nil
+}
+
+func (g Grammar) makeAlert(match string, start int) core.Alert {
+	a := core.Alert{Check: g.Name, Severity: g.Level, Link: g.Link,
+		Span: []int{start, start + len(match)}, Match: match, Action: g.Action}
+	a.Message, a.Description = formatMessages(g.Message, g.Description, match)
+	return a
+}
+
+// Fields provides access to the internal rule definition.
+func (g Grammar) Fields() Definition {
+	return g.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (g Grammar) Pattern() string {
+	return ""
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func hasVerb(words []tag.Token) bool {
+	for _, word := range words {
+		if strings.HasPrefix(word.Tag, "VB") || word.Tag == "MD" {
+			return true
+		}
+	}
+	return false
+}
+
+// countClauses estimates the number of clauses in a sentence by counting its
+// groups of finite verbs: "has not been finished" is a single group, for
+// example, while "He left when it rained" has two.
+func countClauses(words []tag.Token) int {
+	count := 0
+
+	inGroup := false
+	for _, word := range words {
+		switch {
+		case core.StringInSlice(word.Tag, finiteVerbTags):
+			if !inGroup {
+				count++
+			}
+			inGroup = true
+		case strings.HasPrefix(word.Tag, "VB") || strings.HasPrefix(word.Tag, "RB"):
+			// Auxiliaries, participles, and adverbs (e.g., "not") continue
+			// the current group.
+		default:
+			inGroup = false
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,112 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func makeGrammar(def baseCheck) (*Grammar, error) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := NewGrammar(cfg, def, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func runGrammar(t *testing.T, rule *Grammar, text string) []core.Alert {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(nlp.NewBlock("", text, "sentence"), file, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return alerts
+}
+
+func TestGrammarStarts(t *testing.T) {
+	rule, err := makeGrammar(baseCheck{
+		"message":    "Don't start a sentence with '%s'.",
+		"starts":     "CC",
+		"exceptions": []string{"Yet"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]int{
+		"And then the build failed.":         1,
+		"But we fixed it quickly.":           1,
+		"The build failed, and we fixed it.": 0,
+		"Yet it failed again.":               0,
+	}
+
+	for text, expected := range cases {
+		alerts := runGrammar(t, rule, text)
+		if len(alerts) != expected {
+			t.Errorf("%q: expected %d alerts, got %v", text, expected, alerts)
+		} else if expected > 0 && alerts[0].Span[0] != 0 {
+			t.Errorf("%q: expected the alert to start at 0, got %v", text, alerts[0].Span)
+		}
+	}
+}
+
+func TestGrammarVerbless(t *testing.T) {
+	rule, err := makeGrammar(baseCheck{"message": "'%s' lacks a verb.", "verbless": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if alerts := runGrammar(t, rule, "A quick fix for the old server."); len(alerts) != 1 {
+		t.Errorf("Expected a verbless sentence, got %v", alerts)
+	}
+
+	if alerts := runGrammar(t, rule, "The server restarts every night."); len(alerts) != 0 {
+		t.Errorf("Expected no alerts, got %v", alerts)
+	}
+}
+
+func TestGrammarClauses(t *testing.T) {
+	rule, err := makeGrammar(baseCheck{"message": "Too many clauses.", "clauses": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	long := "The server crashed because the disk was full, so we restarted it after the logs were rotated."
+	if alerts := runGrammar(t, rule, long); len(alerts) != 1 {
+		t.Errorf("Expected a long sentence, got %v", alerts)
+	}
+
+	short := "The report has not been finished because the data is late."
+	if alerts := runGrammar(t, rule, short); len(alerts) != 0 {
+		t.Errorf("Expected no alerts, got %v", alerts)
+	}
+}
+
+func TestGrammarInvalid(t *testing.T) {
+	for _, def := range []baseCheck{
+		{"message": "Nothing to check."},
+		{"message": "Negative.", "clauses": -1},
+		{"message": "Bad regex.", "starts": "CC("},
+	} {
+		if _, err := makeGrammar(def); err == nil {
+			t.Errorf("Expected an error for %v", def)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package check
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+//go:embed data/en-familiar.txt
+var defaultCorpus []byte
+
+// jargonSuffixes maps inflectional suffixes to their replacements, allowing
+// us to recognize forms such as "accepted" or "aching" from their base word.
+var jargonSuffixes = [][2]string{
+	{"'s", ""}, {"ies", "y"}, {"es", ""}, {"s", ""},
+	{"ied", "y"}, {"ed", "e"}, {"ed", ""},
+	{"ing", "e"}, {"ing", ""},
+	{"ily", "y"}, {"ly", ""},
+	{"ier", "y"}, {"er", "e"}, {"er", ""},
+	{"iest", "y"}, {"est", "e"}, {"est", ""},
+}
+
+// Jargon flags words that aren't among the most frequent words of a
+// general-English corpus.
+//
+// By default, the corpus consists of the 500 most frequent words in English
+// web text followed by the Dale-Chall list of familiar words. This is useful
+// for plain-language initiatives: it finds uncommon words that no one thought
+// to include in an `existence` or `substitution` rule.
+type Jargon struct {
+	Definition `mapstructure:",squash"`
+	// `threshold` (`int`): Only the `threshold` most frequent words of the
+	// corpus are considered familiar; the default (0) uses all of them.
+	Threshold int
+	// `corpus` (`string`): A custom corpus -- a file with one word per line,
+	// ordered from most to least frequent.
+	Corpus string
+	// `min` (`int`): The minimum length of a word to be checked.
+	Min int
+	// `exceptions` (`array`): An array of strings to be ignored.
+	Exceptions []string
+	// `vocab` (`boolean`): If `true`, use the user's `Vocab` as a list of
+	// exceptions.
+	Vocab bool
+
+	exceptRe *regexp2.Regexp
+	ranks    map[string]int
+}
+
+// NewJargon creates a new `jargon`-based rule.
+func NewJargon(cfg *core.Config, generic baseCheck, path string) (Jargon, error) {
+	rule := Jargon{Vocab: true, Min: 3}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	if rule.Threshold < 0 {
+		return rule, core.NewE201FromTarget(
+			"'threshold' must be a non-negative integer.", "threshold", path)
+	}
+
+	corpus := defaultCorpus
+	if rule.Corpus != "" {
+		loc := core.FindAsset(cfg, rule.Corpus)
+		if loc == "" {
+			return rule, core.NewE201FromTarget(
+				"Unable to resolve the corpus.", "corpus", path)
+		}
+
+		corpus, err = os.ReadFile(loc)
+		if err != nil {
+			return rule, core.NewE201FromTarget(err.Error(), "corpus", path)
+		}
+	}
+
+	rule.ranks, err = readCorpus(corpus)
+	if err != nil {
+		return rule, core.NewE201FromTarget(err.Error(), "corpus", path)
+	}
+
+	re, err := updateExceptions(rule.Exceptions, cfg.AcceptedTokens, rule.Vocab)
+	if err != nil {
+		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+	rule.exceptRe = re
+
+	return rule, nil
+}
+
+// Run looks for words that aren't in the corpus, or that are ranked below the
+// rule's threshold.
+//
+// Capitalized words (other than the first word of the block), acronyms, and
+// words containing digits are assumed to be names and are ignored.
+func (j Jargon) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	txt := blk.Text
+	sic := sicRanges(txt, cfg.SicMarkers)
+
+	cursor := 0
+	for i, word := range nlp.WordTokenizer.Tokenize(txt) {
+		offset := strings.Index(txt[cursor:], word)
+		if offset < 0 {
+			continue
+		}
+		offset += cursor
+		cursor = offset + len(word)
+
+		if !j.isCandidate(word, i == 0) || j.isFamiliar(word) {
+			continue
+		} else if isMatch(j.exceptRe, word) {
+			continue
+		}
+
+		loc := []int{offset, offset + len(word)}
+
+		a := core.Alert{Check: j.Name, Severity: j.Level, Span: loc,
+			Link: j.Link, Match: word, Action: j.Action}
+
+		// NOTE: We still return exempted words (as hidden alerts) so that
+		// any later occurrences are located correctly.
+		a.Hide = inRanges(sic, loc[0], loc[1])
+
+		a.Message, a.Description = formatMessages(j.Message, j.Description, word)
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (j Jargon) Fields() Definition {
+	return j.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (j Jargon) Pattern() string {
+	return ""
+}
+
+// isCandidate reports whether `word` should be checked at all.
+func (j Jargon) isCandidate(word string, first bool) bool {
+	if len([]rune(word)) < j.Min {
+		return false
+	}
+
+	for i, r := range word {
+		switch {
+		case unicode.IsUpper(r) && (i > 0 || !first):
+			return false
+		case !unicode.IsLetter(r) && r != '\'' && r != '-':
+			return false
+		}
+	}
+
+	return true
+}
+
+// isFamiliar reports whether `word`, or its base form, is ranked within the
+// rule's threshold.
+func (j Jargon) isFamiliar(word string) bool {
+	word = strings.ToLower(word)
+	if j.hasRank(word) {
+		return true
+	}
+
+	for _, suffix := range jargonSuffixes {
+		base, found := strings.CutSuffix(word, suffix[0])
+		if !found || len(base) < 2 {
+			continue
+		}
+		base += suffix[1]
+
+		if j.hasRank(base) {
+			return true
+		} else if n := len(base); suffix[1] == "" && n > 2 && base[n-1] == base[n-2] {
+			// A doubled consonant: e.g., "stopped" -> "stop".
+			if j.hasRank(base[:n-1]) {
+				return true
+			}
+		}
+	}
+
+	// Hyphenated compounds are familiar if all of their parts are.
+	if parts := strings.Split(word, "-"); len(parts) > 1 {
+		for _, part := range parts {
+			if part != "" && !j.isFamiliar(part) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func (j Jargon) hasRank(word string) bool {
+	rank, found := j.ranks[word]
+	return found && (j.Threshold == 0 || rank <= j.Threshold)
+}
+
+// readCorpus ranks the words of a corpus by their (1-based) position; blank
+// lines and lines starting with `#` are ignored.
+func readCorpus(corpus []byte) (map[string]int, error) {
+	ranks := map[string]int{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(corpus))
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		} else if _, found := ranks[word]; !found {
+			ranks[word] = len(ranks) + 1
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ranks, err
+	} else if len(ranks) == 0 {
+		return ranks, errors.New("the corpus is empty")
+	}
+
+	return ranks, nil
+}
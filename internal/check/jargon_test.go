@@ -0,0 +1,84 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestJargon(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.AcceptedTokens = []string{"idempotent"}
+
+	corpus := filepath.Join(t.TempDir(), "corpus.txt")
+	if err = os.WriteFile(corpus, []byte("# Custom\nthe\ncat\nsat\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		def      baseCheck
+		text     string
+		expected []string
+	}{
+		{
+			def:      baseCheck{},
+			text:     "We leveraged the synergies of Acme and the idempotent API.",
+			expected: []string{"leveraged", "synergies"},
+		},
+		{
+			def:      baseCheck{},
+			text:     "Operationalize the plan: it's the right thing, and it helped.",
+			expected: []string{"Operationalize"},
+		},
+		{
+			def:      baseCheck{"vocab": false, "exceptions": []interface{}{"synerg(?:y|ies)"}},
+			text:     "The idempotent synergies.",
+			expected: []string{"idempotent"},
+		},
+		{
+			def:      baseCheck{"threshold": 50},
+			text:     "The people walked home.",
+			expected: []string{"people", "walked", "home"},
+		},
+		{
+			def:      baseCheck{"corpus": corpus},
+			text:     "The cats sat on the mat.",
+			expected: []string{"mat"},
+		},
+	}
+
+	for _, c := range cases {
+		c.def["message"] = "%s"
+
+		rule, err := NewJargon(cfg, c.def, "test.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		alerts, err := rule.Run(nlp.NewBlock("", c.text, "text"), nil, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		words := []string{}
+		for _, a := range alerts {
+			words = append(words, a.Match)
+		}
+
+		if !reflect.DeepEqual(words, c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.text, c.expected, words)
+		}
+	}
+
+	_, err = NewJargon(cfg, baseCheck{"corpus": "missing.txt"}, "test.yml")
+	if err == nil {
+		t.Error("Expected an error for a missing corpus")
+	}
+}
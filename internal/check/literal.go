@@ -0,0 +1,167 @@
+package check
+
+import (
+	"sort"
+	"unicode"
+
+	"github.com/errata-ai/regexp2/syntax"
+)
+
+// literalMatch is a single match found by a `literalMatcher`: the (rune)
+// location of the match and the index of the token that it matched.
+type literalMatch struct {
+	loc   []int
+	index int
+}
+
+// A literalMatcher finds the same matches as an alternation of literal
+// tokens -- e.g., `(?i)\b(?:foo|bar baz)\b` -- using an Aho-Corasick
+// automaton.
+//
+// Styles with long lists of banned terms are common and a regex alternation
+// is slow to both compile and run in these cases, while the automaton's
+// run time doesn't depend on the number of tokens.
+type literalMatcher struct {
+	nodes      []literalNode
+	lengths    []int // the length (in runes) of each token
+	ignoreCase bool
+	word       bool // are the tokens wrapped in `\b`?
+}
+
+type literalNode struct {
+	next map[rune]int
+	fail int
+	out  []int // the tokens that end at this node, in order
+}
+
+// newLiteralMatcher compiles `tokens` into a `literalMatcher`, returning nil
+// if any of them isn't a literal (in which case we need a regex).
+func newLiteralMatcher(tokens []string, ignoreCase, word bool) *literalMatcher {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	for _, token := range tokens {
+		if !reLiteralTerm.MatchString(token) {
+			return nil
+		}
+	}
+
+	m := literalMatcher{
+		nodes:      []literalNode{{next: map[rune]int{}}},
+		ignoreCase: ignoreCase,
+		word:       word,
+	}
+
+	for idx, token := range tokens {
+		state := 0
+		for _, r := range token {
+			r = m.fold(r)
+			next, ok := m.nodes[state].next[r]
+			if !ok {
+				next = len(m.nodes)
+				m.nodes = append(m.nodes, literalNode{next: map[rune]int{}})
+				m.nodes[state].next[r] = next
+			}
+			state = next
+		}
+		m.nodes[state].out = append(m.nodes[state].out, idx)
+		m.lengths = append(m.lengths, len([]rune(token)))
+	}
+
+	// Compute the failure links breadth-first, so that each node's link (and
+	// its output) is ready before those of its children.
+	queue := []int{}
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for r, child := range m.nodes[state].next {
+			fail := m.nodes[state].fail
+			for fail > 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].next[r]; ok && next != child {
+				fail = next
+			}
+
+			m.nodes[child].fail = fail
+			m.nodes[child].out = append(m.nodes[child].out, m.nodes[fail].out...)
+
+			queue = append(queue, child)
+		}
+	}
+
+	return &m
+}
+
+func (m *literalMatcher) fold(r rune) rune {
+	if m.ignoreCase {
+		// NOTE: This is what `regexp2` does for `(?i)`.
+		return unicode.ToLower(r)
+	}
+	return r
+}
+
+// findAll returns all non-overlapping matches in `text`.
+//
+// Like a regex alternation, we report the leftmost match and, of the tokens
+// that match there, the first one listed.
+func (m *literalMatcher) findAll(text string) []literalMatch {
+	runes := []rune(text)
+
+	candidates := []literalMatch{}
+
+	state := 0
+	for i, r := range runes {
+		r = m.fold(r)
+		for state > 0 {
+			if _, ok := m.nodes[state].next[r]; ok {
+				break
+			}
+			state = m.nodes[state].fail
+		}
+		state = m.nodes[state].next[r]
+
+		for _, idx := range m.nodes[state].out {
+			start, end := i+1-m.lengths[idx], i+1
+			if !m.word || (isBoundary(runes, start) && isBoundary(runes, end)) {
+				candidates = append(candidates, literalMatch{
+					loc: []int{start, end}, index: idx})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(p, q int) bool {
+		if candidates[p].loc[0] != candidates[q].loc[0] {
+			return candidates[p].loc[0] < candidates[q].loc[0]
+		}
+		return candidates[p].index < candidates[q].index
+	})
+
+	matches := []literalMatch{}
+
+	pos := 0
+	for _, c := range candidates {
+		if c.loc[0] >= pos {
+			matches = append(matches, c)
+			pos = c.loc[1]
+		}
+	}
+
+	return matches
+}
+
+// isBoundary reports whether `\b` matches at the given position.
+func isBoundary(runes []rune, pos int) bool {
+	before := pos > 0 && syntax.IsWordChar(runes[pos-1])
+	after := pos < len(runes) && syntax.IsWordChar(runes[pos])
+	return before != after
+}
@@ -0,0 +1,88 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/regexp2"
+)
+
+var literalTexts = []string{
+	"An e-mail about the data base, the database, and the Data Base.",
+	"foo foobar barfoo foo-bar foo bar baz foo_bar",
+	"Über über ÜBER uber, naïve NAÏVE naive",
+	"it's its it’s it s",
+	"aaa aa a aaaa",
+	"",
+}
+
+func TestLiteralMatcher(t *testing.T) {
+	tokens := [][]string{
+		{"data base", "database", "e-mail"},
+		{"foo", "foobar", "foo bar", "bar", "foo bar baz"},
+		{"über", "naïve", "uber"},
+		{"it's", "its", "it"},
+		{"a", "aa", "aaa"},
+		{"aa", "a", "aaa"},
+		{"bar", "ba", "r f"},
+	}
+
+	for _, list := range tokens {
+		for _, noCase := range []bool{false, true} {
+			for _, word := range []bool{false, true} {
+				m := newLiteralMatcher(list, noCase, word)
+				if m == nil {
+					t.Fatalf("Expected %v to be literal", list)
+				}
+
+				template := nonwordTemplate
+				if word {
+					template = wordTemplate
+				}
+				regex := fmt.Sprintf(template, strings.Join(list, "|"))
+				if noCase {
+					regex = ignoreCase + regex
+				}
+				re := regexp2.MustCompileStd(regex)
+
+				for _, text := range literalTexts {
+					expected := re.FindAllStringIndex(text, -1)
+
+					locs := [][]int{}
+					for _, match := range m.findAll(text) {
+						locs = append(locs, match.loc)
+					}
+
+					if len(expected) == 0 && len(locs) == 0 {
+						continue
+					} else if !reflect.DeepEqual(expected, locs) {
+						t.Errorf("%s on %q: expected %v, got %v", regex, text, expected, locs)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLiteralMatcherFallback(t *testing.T) {
+	for _, list := range [][]string{{"foo", "ba[rz]"}, {"e.g."}, {}} {
+		if newLiteralMatcher(list, false, true) != nil {
+			t.Errorf("Expected %v to need a regex", list)
+		}
+	}
+}
+
+func BenchmarkLiteralMatcher(b *testing.B) {
+	tokens := make([]string, 5000)
+	for i := range tokens {
+		tokens[i] = fmt.Sprintf("term%d", i)
+	}
+	text := strings.Repeat("This paragraph mentions term42 and term4999 once. ", 20)
+
+	m := newLiteralMatcher(tokens, true, true)
+	for n := 0; n < b.N; n++ {
+		m.findAll(text)
+	}
+}
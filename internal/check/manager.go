@@ -1,10 +1,13 @@
 package check
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/karrick/godirwalk"
@@ -20,6 +23,7 @@ type Manager struct {
 
 	scopes       map[string]struct{}
 	rules        map[string]Rule
+	order        []string
 	styles       []string
 	needsTagging bool
 }
@@ -72,13 +76,51 @@ func NewManager(config *core.Config) (*Manager, error) {
 	}
 
 	mgr.rules, err = filter(&mgr)
+	mgr.order = ruleOrder(mgr.rules, mgr.Config.Flags)
+
+	if err == nil && mgr.needsTagging {
+		// NOTE: We load any tagging models up front so that an invalid one
+		// is reported as a config error rather than in the middle of a run.
+		if loadErr := nlp.LoadTaggers(mgr.Config.TaggerDirs()); loadErr != nil {
+			return &mgr, core.NewE100("taggers", loadErr)
+		}
+	}
+
 	return &mgr, err
 }
 
+// ruleOrder returns the order in which rules run.
+//
+// By default, this is the (arbitrary) order of Go's map iteration, which
+// changes between runs. `--deterministic` sorts rules by name, while
+// `--shuffle` uses a seeded random order.
+func ruleOrder(rules map[string]Rule, flags *core.CLIFlags) []string {
+	order := maps.Keys(rules)
+	if flags == nil {
+		return order
+	}
+
+	if flags.Deterministic || flags.Shuffle != 0 {
+		sort.Strings(order)
+	}
+
+	if flags.Shuffle != 0 {
+		r := rand.New(rand.NewSource(flags.Shuffle)) //nolint:gosec
+		r.Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	}
+
+	return order
+}
+
 // AddRule adds the given rule to the manager.
 func (mgr *Manager) AddRule(name string, rule Rule) error {
 	if _, found := mgr.rules[name]; !found {
 		mgr.rules[name] = rule
+		if mgr.order != nil {
+			mgr.order = append(mgr.order, name)
+		}
 		return nil
 	}
 	return fmt.Errorf("the rule '%s' has already been added", name)
@@ -98,6 +140,29 @@ func (mgr *Manager) Rules() map[string]Rule {
 	return mgr.rules
 }
 
+// Order is the names of the Manager's rules, in the order they should run.
+func (mgr *Manager) Order() []string {
+	if mgr.order == nil {
+		return maps.Keys(mgr.rules)
+	}
+	return mgr.order
+}
+
+// Suggest fills in the `Suggestions` of the given spelling alerts.
+//
+// Ranking suggestions is much slower than finding misspellings, so it's only
+// done for the outputs that include them (e.g., `--output=JSON`).
+func (mgr *Manager) Suggest(alerts []core.Alert) {
+	for i := range alerts {
+		a := &alerts[i]
+		if a.Hide || len(a.Suggestions) > 0 {
+			continue
+		} else if rule, ok := mgr.rules[a.Check].(Spelling); ok {
+			a.Suggestions = rule.Suggest(a.Match)
+		}
+	}
+}
+
 // HasScope returns `true` if the manager has a rule that applies to `scope`.
 func (mgr *Manager) HasScope(scope string) bool {
 	_, found := mgr.scopes[scope]
@@ -105,8 +170,11 @@ func (mgr *Manager) HasScope(scope string) bool {
 }
 
 // NeedsTagging indicates if POS tagging is needed.
+//
+// Tagging is only used by expensive rules, so the `quick` profile never needs
+// it.
 func (mgr *Manager) NeedsTagging() bool {
-	return mgr.needsTagging
+	return mgr.needsTagging && mgr.Config.Flags.Profile != "quick"
 }
 
 // AssignNLP determines what NLP tasks a file needs.
@@ -117,6 +185,7 @@ func (mgr *Manager) AssignNLP(f *core.File) nlp.Info {
 		Splitting:    mgr.HasScope("paragraph"),
 		Tagging:      mgr.NeedsTagging(),
 		Endpoint:     f.NLP.Endpoint,
+		Taggers:      f.NLP.Taggers,
 		Lang:         f.NLP.Lang,
 	}
 }
@@ -135,6 +204,33 @@ func (mgr *Manager) addStyle(path string) error {
 	})
 }
 
+// checkStyleVersion ensures that the running version of Vale is new enough for
+// the style in `dir`, according to the `min_vale_version` (or `vale_version`)
+// key in its `meta.json` file.
+func checkStyleVersion(dir string) error {
+	path := filepath.Join(dir, "meta.json")
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	meta := struct {
+		MinVale string `json:"min_vale_version"`
+		Vale    string `json:"vale_version"`
+	}{}
+	if err = json.Unmarshal(b, &meta); err != nil {
+		return core.NewE201FromPosition(err.Error(), path, 1)
+	}
+
+	required := meta.MinVale
+	if required == "" {
+		required = meta.Vale
+	}
+
+	return core.CheckValeVersion(required, fmt.Sprintf("'%s'", filepath.Base(dir)))
+}
+
 func (mgr *Manager) addRuleFromSource(name, path string) error {
 	if strings.HasSuffix(name, ".yml") {
 		f, err := os.ReadFile(path)
@@ -183,7 +279,7 @@ func (mgr *Manager) addCheck(file []byte, chkName, path string) error {
 		mgr.scopes[base] = struct{}{}
 	}
 
-	if rule.Fields().Extends == "sequence" {
+	if core.StringInSlice(rule.Fields().Extends, taggedPoints) {
 		mgr.needsTagging = true
 	}
 
@@ -191,6 +287,14 @@ func (mgr *Manager) addCheck(file []byte, chkName, path string) error {
 		mgr.needsTagging = true
 	}
 
+	if p, ok := rule.(Pipeline); ok && p.tagged {
+		mgr.needsTagging = true
+	}
+
+	if c, ok := rule.(Changelog); ok && c.Past {
+		mgr.needsTagging = true
+	}
+
 	return mgr.AddRule(chkName, rule)
 }
 
@@ -205,29 +309,18 @@ func (mgr *Manager) loadDefaultRules() error {
 		}
 	}
 
-	repetition := defaultRules["Repetition"]
-	if level, ok := mgr.Config.RuleToLevel["Vale.Repetition"]; ok {
-		repetition["level"] = level
-	}
-	repetition["path"] = "internal"
-
-	rule, err := buildRule(mgr.Config, repetition)
-	if err != nil {
-		return err
-	}
-	mgr.rules["Vale.Repetition"] = rule
-
-	spelling := defaultRules["Spelling"]
-	if level, ok := mgr.Config.RuleToLevel["Vale.Spelling"]; ok {
-		spelling["level"] = level
-	}
-	spelling["path"] = "internal"
+	for _, name := range []string{"Repetition", "Spelling", "References", "Anchors", "Citations"} {
+		def := defaultRule(name)
+		if level, ok := mgr.Config.RuleToLevel["Vale."+name]; ok {
+			def["level"] = level
+		}
 
-	rule, err = buildRule(mgr.Config, spelling)
-	if err != nil {
-		return err
+		rule, err := buildRule(mgr.Config, def)
+		if err != nil {
+			return err
+		}
+		mgr.rules["Vale."+name] = rule
 	}
-	mgr.rules["Vale.Spelling"] = rule
 
 	// TODO: where should this go?
 	mgr.loadVocabRules()
@@ -249,7 +342,9 @@ func (mgr *Manager) loadStyles(styles []string) error {
 				need = append(need, style)
 				continue
 			}
-			if err := mgr.addStyle(p); err != nil {
+			if err := checkStyleVersion(p); err != nil {
+				return err
+			} else if err = mgr.addStyle(p); err != nil {
 				return err
 			}
 			found = append(found, style)
@@ -268,14 +363,26 @@ func (mgr *Manager) loadStyles(styles []string) error {
 	return nil
 }
 
+// defaultRule returns a copy of the named built-in rule's definition, which
+// may be modified without affecting other managers.
+func defaultRule(name string) map[string]interface{} {
+	def := maps.Clone(defaultRules[name])
+	def["path"] = "internal"
+	return def
+}
+
 func (mgr *Manager) loadVocabRules() {
 	if len(mgr.Config.AcceptedTokens) > 0 {
-		vocab := defaultRules["Terms"]
+		vocab := defaultRule("Terms")
+
+		swap := map[string]string{}
 		for _, term := range mgr.Config.AcceptedTokens {
 			if core.IsPhrase(term) {
-				vocab["swap"].(map[string]string)[strings.ToLower(term)] = term
+				swap[strings.ToLower(term)] = term
 			}
 		}
+		vocab["swap"] = swap
+
 		if level, ok := mgr.Config.RuleToLevel["Vale.Terms"]; ok {
 			vocab["level"] = level
 		}
@@ -284,10 +391,9 @@ func (mgr *Manager) loadVocabRules() {
 	}
 
 	if len(mgr.Config.RejectedTokens) > 0 {
-		avoid := defaultRules["Avoid"]
-		for _, term := range mgr.Config.RejectedTokens {
-			avoid["tokens"] = append(avoid["tokens"].([]string), term)
-		}
+		avoid := defaultRule("Avoid")
+		avoid["tokens"] = append([]string{}, mgr.Config.RejectedTokens...)
+
 		if level, ok := mgr.Config.RuleToLevel["Vale.Avoid"]; ok {
 			avoid["level"] = level
 		}
@@ -1,7 +1,10 @@
 package check
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
 )
 
 /*var checktests = []struct {
@@ -53,3 +56,50 @@ func TestFormatMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestRuleOrder(t *testing.T) {
+	rules := map[string]Rule{}
+	for _, name := range []string{"C.One", "A.Two", "B.Three", "A.Four", "D.Five"} {
+		rules[name] = Existence{}
+	}
+
+	order := ruleOrder(rules, &core.CLIFlags{Deterministic: true})
+	if got := strings.Join(order, ","); got != "A.Four,A.Two,B.Three,C.One,D.Five" {
+		t.Errorf("expected rules sorted by name, got %s", got)
+	}
+
+	first := ruleOrder(rules, &core.CLIFlags{Shuffle: 42})
+	for i := 0; i < 10; i++ {
+		again := ruleOrder(rules, &core.CLIFlags{Shuffle: 42})
+		if strings.Join(first, ",") != strings.Join(again, ",") {
+			t.Fatalf("expected the same seed to give the same order: %v != %v", first, again)
+		}
+	}
+}
+
+func TestDefaultRulesUnchanged(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.GBaseStyles = []string{"Vale"}
+	cfg.RuleToLevel["Vale.Spelling"] = "warning"
+	cfg.AcceptedTokens = []string{"foo bar"}
+	cfg.RejectedTokens = []string{"baz"}
+
+	for i := 0; i < 2; i++ {
+		if _, err = NewManager(cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if level := defaultRules["Spelling"]["level"]; level != "error" {
+		t.Errorf("expected the built-in level to be unchanged, got %v", level)
+	}
+	if swap := defaultRules["Terms"]["swap"].(map[string]string); len(swap) != 0 {
+		t.Errorf("expected the built-in swap to be empty, got %v", swap)
+	}
+	if tokens := defaultRules["Avoid"]["tokens"].([]string); len(tokens) != 0 {
+		t.Errorf("expected the built-in tokens to be empty, got %v", tokens)
+	}
+}
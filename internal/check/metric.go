@@ -25,6 +25,9 @@ type Metric struct {
 	// `metric` (`string`): the formula to be dynamically evaluated.
 	//
 	// Variables: # of words, # of sentences, etc.
+	//
+	// The formula may end with its condition -- e.g.,
+	// `(complex_words / words) * 100 > 10` -- instead of using `condition`.
 	Formula   string
 	Condition string
 
@@ -44,9 +47,47 @@ func NewMetric(_ *core.Config, generic baseCheck, path string) (Metric, error) {
 	rule.Definition.Scope = []string{"summary"}
 	rule.Formula = headings.ReplaceAllString(rule.Formula, "heading_$1")
 
+	if strings.TrimSpace(rule.Condition) == "" {
+		formula, condition := splitCondition(rule.Formula)
+		if condition == "" {
+			return rule, core.NewE201FromTarget(
+				"a comparison (e.g., '> 10') is required in `formula` or `condition`",
+				"formula", path)
+		}
+		rule.Formula, rule.Condition = formula, condition
+	}
+
 	return rule, nil
 }
 
+// splitCondition splits a formula such as `words / sentences > 10` into its
+// expression (`words / sentences`) and its condition (`> 10`).
+//
+// Only a comparison outside of any parentheses counts, so that it applies to
+// the formula's result as a whole.
+func splitCondition(formula string) (string, string) {
+	depth := 0
+	for i := 0; i < len(formula); i++ {
+		switch c := formula[i]; c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case '<', '>', '=', '!':
+			if depth != 0 {
+				continue
+			}
+			rest := formula[i:]
+			for _, op := range []string{">=", "<=", "==", "!=", ">", "<"} {
+				if strings.HasPrefix(rest, op) {
+					return strings.TrimSpace(formula[:i]), strings.TrimSpace(rest)
+				}
+			}
+		}
+	}
+	return formula, ""
+}
+
 // Run calculates the readability level of the given text.
 func (o Metric) Run(_ nlp.Block, f *core.File, _ *core.Config) ([]core.Alert, error) {
 	alerts := []core.Alert{}
@@ -0,0 +1,59 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestSplitCondition(t *testing.T) {
+	cases := []struct {
+		formula   string
+		expr      string
+		condition string
+	}{
+		{"(complex_words / words) * 100 > 10", "(complex_words / words) * 100", "> 10"},
+		{"words / sentences >= 20", "words / sentences", ">= 20"},
+		{"math.max(headings, 1) == 1", "math.max(headings, 1)", "== 1"},
+		{"words / sentences", "words / sentences", ""},
+	}
+
+	for _, c := range cases {
+		expr, condition := splitCondition(c.formula)
+		if expr != c.expr || condition != c.condition {
+			t.Errorf("%q: expected (%q, %q), got (%q, %q)", c.formula, c.expr, c.condition, expr, condition)
+		}
+	}
+}
+
+func TestMetricInlineCondition(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewMetric(cfg, baseCheck{
+		"message": "This topic has %s headings.",
+		"formula": "headings + heading.h2 > 2",
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &core.File{Metrics: map[string]int{"heading.h1": 1, "heading.h2": 1}}
+	f.Summary.WriteString("A short topic. It has two sentences.")
+
+	alerts, err := rule.Run(nlp.Block{}, f, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(alerts) != 1 || !strings.Contains(alerts[0].Message, "3.00") {
+		t.Fatalf("Expected one alert for 3 headings, got %v", alerts)
+	}
+
+	_, err = NewMetric(cfg, baseCheck{"formula": "words / sentences"}, "test.yml")
+	if err == nil {
+		t.Error("Expected a formula without a comparison to be rejected")
+	}
+}
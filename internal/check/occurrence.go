@@ -1,8 +1,10 @@
 package check
 
 import (
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/errata-ai/regexp2"
 
@@ -18,6 +20,11 @@ type Occurrence struct {
 	Min        int
 	pattern    *regexp2.Regexp
 	Ignorecase bool
+	// `split` (`bool`): If `true`, a sentence-scoped rule with a `max`
+	// (e.g., a limit on sentence length) suggests where to split the
+	// sentence: its alert covers the whole sentence and has a `split` action
+	// whose parameters are the sentence rewritten at each split point.
+	Split bool
 }
 
 // NewOccurrence creates a new `occurrence`-based rule.
@@ -45,13 +52,18 @@ func NewOccurrence(_ *core.Config, generic baseCheck, path string) (Occurrence,
 		return rule, core.NewE201FromPosition(err.Error(), path, 1)
 	}
 
+	if rule.Split && (rule.Max == 0 || !core.StringInSlice("sentence", rule.Scope)) {
+		return rule, core.NewE201FromTarget(
+			"'split' requires 'max' and 'scope: sentence'.", "split", path)
+	}
+
 	rule.pattern = re
 	return rule, nil
 }
 
 // Run checks the number of occurrences of a user-defined regex against a
 // certain threshold.
-func (o Occurrence) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Alert, error) {
+func (o Occurrence) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
 	var a core.Alert
 	var err error
 	var alerts []core.Alert
@@ -99,6 +111,10 @@ func (o Occurrence) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.A
 				return alerts, nil
 			}
 
+			if o.Split && occurrences > o.Max {
+				return o.splitAlert(txt, f, cfg, occurrences)
+			}
+
 			a, err = makeAlert(o.Definition, span, txt, cfg)
 			if err != nil {
 				return alerts, err
@@ -113,6 +129,106 @@ func (o Occurrence) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.A
 	return alerts, nil
 }
 
+// splitAlert reports a sentence that's too long, suggesting where to split
+// it (see `split`).
+func (o Occurrence) splitAlert(txt string, f *core.File, cfg *core.Config, n int) ([]core.Alert, error) {
+	trimmed := strings.TrimSpace(txt)
+
+	start := utf8.RuneCountInString(txt[:strings.Index(txt, trimmed)])
+	loc := []int{start, start + utf8.RuneCountInString(trimmed)}
+
+	def := o.Definition
+	if rewrites := splitSentence(trimmed, f); len(rewrites) > 0 {
+		def.Action = core.Action{Name: "split", Params: rewrites}
+	}
+
+	a, err := makeAlert(def, loc, txt, cfg)
+	if err != nil {
+		return nil, err
+	}
+	a.Message, a.Description = formatMessages(o.Message, o.Description, strconv.Itoa(n))
+
+	return []core.Alert{a}, nil
+}
+
+// maxSplits is the maximum number of rewrites suggested for a sentence.
+const maxSplits = 3
+
+// splitSentence rewrites `sentence` as two sentences at each of its split
+// points -- a semicolon or a comma followed by a coordinating conjunction --
+// starting with those closest to its middle.
+//
+// "and" is dropped from the start of the second sentence (since it adds
+// nothing on its own), while other conjunctions are kept: "..., but it
+// failed" becomes "... But it failed".
+func splitSentence(sentence string, f *core.File) []string {
+	type point struct{ end, resume int }
+
+	var info *nlp.Info
+	if f != nil {
+		info = &f.NLP
+	}
+	tokens := nlp.TextToTokens(sentence, info)
+
+	// We locate each token in the sentence, since the tagger doesn't report
+	// their offsets.
+	offsets := make([]int, len(tokens))
+	cursor := 0
+	for i, tok := range tokens {
+		offsets[i] = -1
+		if idx := strings.Index(sentence[cursor:], tok.Text); idx >= 0 {
+			offsets[i] = cursor + idx
+			cursor += idx + len(tok.Text)
+		}
+	}
+
+	var points []point
+	for i := 0; i+1 < len(tokens); i++ {
+		if offsets[i] < 0 || offsets[i+1] < 0 {
+			continue
+		}
+
+		switch {
+		case tokens[i].Text == ";":
+			points = append(points, point{offsets[i], offsets[i+1]})
+		case tokens[i].Text == "," && tokens[i+1].Tag == "CC" && i+2 < len(tokens) && offsets[i+2] >= 0:
+			resume := offsets[i+1]
+			if strings.EqualFold(tokens[i+1].Text, "and") {
+				resume = offsets[i+2]
+			}
+			points = append(points, point{offsets[i], resume})
+		}
+	}
+
+	middle := len(sentence) / 2
+	sort.SliceStable(points, func(i, j int) bool {
+		return abs(points[i].end-middle) < abs(points[j].end-middle)
+	})
+
+	var rewrites []string
+	for _, p := range points {
+		first := strings.TrimSpace(sentence[:p.end])
+		second := strings.TrimSpace(sentence[p.resume:])
+		if first == "" || second == "" {
+			continue
+		}
+
+		rewrites = append(rewrites, first+". "+core.CapFirst(second))
+		if len(rewrites) == maxSplits {
+			break
+		}
+	}
+
+	return rewrites
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
 // Fields provides access to the internal rule definition.
 func (o Occurrence) Fields() Definition {
 	return o.Definition
@@ -0,0 +1,53 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestOccurrenceSplit(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewOccurrence(cfg, baseCheck{
+		"message": "Try to keep sentences short (%s words).",
+		"scope":   "sentence",
+		"max":     10,
+		"token":   `\b(\w+)\b`,
+		"split":   true,
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "The service was rebuilt over the summer, and it now handles twice the traffic; restarts are faster too."
+	alerts, err := rule.Run(nlp.NewBlock("", text, "sentence"), &core.File{}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(alerts) != 1 {
+		t.Fatalf("Expected 1 alert, got %v", alerts)
+	}
+
+	a := alerts[0]
+	if a.Match != text || a.Action.Name != "split" {
+		t.Errorf("Expected the whole sentence with a 'split' action, got %+v", a)
+	}
+
+	expected := []string{
+		"The service was rebuilt over the summer. It now handles twice the traffic; restarts are faster too.",
+		"The service was rebuilt over the summer, and it now handles twice the traffic. Restarts are faster too.",
+	}
+	if !reflect.DeepEqual(a.Action.Params, expected) {
+		t.Errorf("Expected %v, got %v", expected, a.Action.Params)
+	}
+
+	_, err = NewOccurrence(cfg, baseCheck{"scope": "paragraph", "max": 10, "token": `\w+`, "split": true}, "test.yml")
+	if err == nil {
+		t.Error("Expected 'split' to require 'scope: sentence'")
+	}
+}
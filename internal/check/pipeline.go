@@ -0,0 +1,228 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// pipelineSteps are the step types that are only valid within a `pipeline`.
+var pipelineSteps = []string{"filter", "threshold"}
+
+// pipelineKeys are the keys that check steps inherit from their pipeline.
+var pipelineKeys = []string{
+	"action", "description", "lang", "level", "limit", "link", "message",
+	"name", "scope", "selector",
+}
+
+// Pipeline runs a series of steps on each block, in order:
+//
+//   - `filter` steps mask the text matched by their `tokens`, hiding it from
+//     any steps that follow;
+//   - `threshold` steps discard the alerts found so far unless there are more
+//     than `max` of them; and
+//   - all other steps are regular checks (e.g., `existence`), which inherit
+//     their pipeline's `message`, `level`, `scope`, etc. unless they set
+//     their own.
+//
+// This allows a single rule to replace several near-duplicate rules that only
+// differ by what they ignore.
+type Pipeline struct {
+	Definition `mapstructure:",squash"`
+	Steps      []map[string]interface{}
+	steps      []pipelineStep
+	tagged     bool
+}
+
+// pipelineStep is a single step of a `pipeline`; exactly one of `rule`,
+// `mask`, or `max` applies, depending on `kind`.
+type pipelineStep struct {
+	kind string
+	rule Rule
+	mask *regexp2.Regexp
+	max  int
+}
+
+type pipelineFilter struct {
+	Extends    string
+	Tokens     []string
+	Ignorecase bool
+}
+
+type pipelineThreshold struct {
+	Extends string
+	Max     int
+}
+
+// NewPipeline creates a new `pipeline`-based rule.
+func NewPipeline(cfg *core.Config, generic baseCheck, path string) (Pipeline, error) {
+	rule := Pipeline{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	checks := 0
+	for _, step := range rule.Steps {
+		built, stepErr := newPipelineStep(cfg, generic, step, path)
+		if stepErr != nil {
+			return rule, stepErr
+		}
+
+		if built.rule != nil {
+			checks++
+			if core.StringInSlice(built.rule.Fields().Extends, taggedPoints) || step["pos"] != nil {
+				rule.tagged = true
+			} else if c, ok := built.rule.(Changelog); ok && c.Past {
+				rule.tagged = true
+			}
+		}
+
+		rule.steps = append(rule.steps, built)
+	}
+
+	if checks == 0 {
+		return rule, core.NewE201FromTarget(
+			"'steps' must include at least one check (e.g., 'existence').",
+			"steps",
+			path)
+	}
+
+	return rule, nil
+}
+
+func newPipelineStep(cfg *core.Config, parent baseCheck, step map[string]interface{}, path string) (pipelineStep, error) {
+	kind, _ := step["extends"].(string)
+
+	points := append([]string{}, pipelineSteps...)
+	for _, point := range extensionPoints {
+		if point != "pipeline" {
+			points = append(points, point)
+		}
+	}
+
+	if !core.StringInSlice(kind, points) {
+		return pipelineStep{}, core.NewE201FromTarget(
+			fmt.Sprintf("a step's 'extends' key must be one of %v.", points),
+			"steps",
+			path)
+	}
+
+	switch kind {
+	case "filter":
+		f := pipelineFilter{}
+		if err := decodeRule(step, &f); err != nil {
+			return pipelineStep{}, readStructureError(err, path)
+		} else if len(f.Tokens) == 0 {
+			return pipelineStep{}, core.NewE201FromTarget(
+				"'filter' steps require 'tokens'.", "filter", path)
+		}
+
+		regex := fmt.Sprintf(nonwordTemplate, strings.Join(f.Tokens, "|"))
+		if f.Ignorecase {
+			regex = ignoreCase + regex
+		}
+
+		re, err := regexp2.CompileStd(regex)
+		if err != nil {
+			return pipelineStep{}, core.NewE201FromPosition(err.Error(), path, 1)
+		}
+
+		return pipelineStep{kind: kind, mask: re}, nil
+	case "threshold":
+		t := pipelineThreshold{}
+		if err := decodeRule(step, &t); err != nil {
+			return pipelineStep{}, readStructureError(err, path)
+		}
+		return pipelineStep{kind: kind, max: t.Max}, nil
+	}
+
+	generic := baseCheck{}
+	for key, value := range step {
+		generic[key] = value
+	}
+	for _, key := range pipelineKeys {
+		if _, ok := generic[key]; !ok && parent[key] != nil {
+			generic[key] = parent[key]
+		}
+	}
+	generic["path"] = path
+
+	chk, err := buildRule(cfg, generic)
+	if err != nil {
+		return pipelineStep{}, err
+	}
+
+	return pipelineStep{kind: kind, rule: chk}, nil
+}
+
+// Run executes the `pipeline`-based rule.
+func (p Pipeline) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	for _, step := range p.steps {
+		switch step.kind {
+		case "filter":
+			blk.Text = maskMatches(step.mask, blk.Text)
+		case "threshold":
+			reported := 0
+			for _, a := range alerts {
+				if !a.Hide {
+					reported++
+				}
+			}
+			if reported <= step.max {
+				alerts = nil
+			}
+		default:
+			found, err := step.rule.Run(blk, f, cfg)
+			if err != nil {
+				return alerts, err
+			}
+			alerts = append(alerts, found...)
+		}
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (p Pipeline) Fields() Definition {
+	return p.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (p Pipeline) Pattern() string {
+	return ""
+}
+
+// maskMatches replaces the non-whitespace characters matched by `re` with
+// asterisks, preserving the rune offsets of the rest of the text.
+func maskMatches(re *regexp2.Regexp, txt string) string {
+	locs := re.FindAllStringIndex(txt, -1)
+	if len(locs) == 0 {
+		return txt
+	}
+
+	runes := []rune(txt)
+	for _, loc := range locs {
+		for i := loc[0]; i < loc[1]; i++ {
+			if !unicode.IsSpace(runes[i]) {
+				runes[i] = '*'
+			}
+		}
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,84 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func makePipeline(steps []map[string]interface{}) (*Pipeline, error) {
+	def := baseCheck{
+		"name":    "Test.Pipeline",
+		"message": "Avoid '%s'.",
+		"level":   "warning",
+		"steps":   steps,
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := NewPipeline(cfg, def, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func TestPipeline(t *testing.T) {
+	rule, err := makePipeline([]map[string]interface{}{
+		{"extends": "filter", "tokens": []string{`"[^"]+"`}},
+		{"extends": "existence", "tokens": []string{"very"}},
+		{"extends": "threshold", "max": 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		text     string
+		expected int
+	}{
+		{`A very, very long "very" sentence.`, 2},
+		{`A very long "very" sentence.`, 0},
+		{`A "very, very" long sentence.`, 0},
+	}
+
+	for _, tc := range cases {
+		alerts, runErr := rule.Run(nlp.NewBlock("", tc.text, ""), file, cfg)
+		if runErr != nil {
+			t.Fatal(runErr)
+		}
+
+		if len(alerts) != tc.expected {
+			t.Errorf("%q: expected %d alerts, got %v", tc.text, tc.expected, alerts)
+		}
+		for _, a := range alerts {
+			if a.Check != "Test.Pipeline" || a.Message != "Avoid 'very'." {
+				t.Errorf("%q: unexpected alert %v", tc.text, a)
+			}
+		}
+	}
+}
+
+func TestPipelineRequiresCheck(t *testing.T) {
+	_, err := makePipeline([]map[string]interface{}{
+		{"extends": "filter", "tokens": []string{"foo"}},
+	})
+	if err == nil {
+		t.Error("expected an error for a pipeline without checks")
+	}
+}
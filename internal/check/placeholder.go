@@ -0,0 +1,164 @@
+package check
+
+import (
+	"strings"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+var defaultMarkers = []string{"TODO", "TBD", "FIXME", "XXX"}
+
+var reLorem = regexp2.MustCompileStd(`(?i)\blorem ipsum\b`)
+var reTemplateVar = regexp2.MustCompileStd(`\{\{\s*[\w.-]+\s*\}\}`)
+
+// Placeholder looks for content that was never filled in: markers such as
+// `TODO`, filler text (lorem ipsum), template variables (`{{name}}`), and
+// empty sections.
+//
+// Empty sections are found using the document's outline rather than its
+// text, so they're only reported for markup files linted with `scope: raw`.
+type Placeholder struct {
+	Definition `mapstructure:",squash"`
+	Markers    []string
+	Lorem      bool
+	Templates  bool
+	Sections   bool
+
+	markers *regexp2.Regexp
+}
+
+// NewPlaceholder creates a new `placeholder`-based rule.
+func NewPlaceholder(_ *core.Config, generic baseCheck, path string) (Placeholder, error) {
+	rule := Placeholder{Lorem: true, Templates: true, Sections: true}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	if len(rule.Markers) == 0 {
+		rule.Markers = defaultMarkers
+	}
+
+	re, err := regexp2.CompileStd(`\b(?:` + strings.Join(rule.Markers, "|") + `)\b`)
+	if err != nil {
+		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+	rule.markers = re
+
+	return rule, nil
+}
+
+// Run executes the `placeholder`-based rule.
+//
+// The second substitution in a rule's message is a description of the
+// placeholder -- e.g., "an empty section".
+func (p Placeholder) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	raw := strings.HasPrefix(blk.Scope, "raw")
+
+	txt := blk.Text
+	if raw && f.NormedExt == ".md" {
+		// Markers (and braces) in code samples are usually intentional.
+		txt = maskCode(txt)
+	}
+
+	report := func(loc []int, kind string) error {
+		// NOTE: Masking preserves offsets, so we report the original text.
+		a, err := makeAlert(p.Definition, loc, blk.Text, cfg)
+		if err != nil {
+			return err
+		}
+		a.Message, a.Description = formatMessages(p.Message, p.Description, a.Match, kind)
+		alerts = append(alerts, a)
+		return nil
+	}
+
+	patterns := []struct {
+		re   *regexp2.Regexp
+		kind string
+		on   bool
+	}{
+		{p.markers, "a placeholder marker", true},
+		{reLorem, "filler text", p.Lorem},
+		{reTemplateVar, "a template variable", p.Templates},
+	}
+
+	for _, pat := range patterns {
+		if !pat.on {
+			continue
+		}
+		for _, loc := range pat.re.FindAllStringIndex(txt, -1) {
+			if err := report(loc, pat.kind); err != nil {
+				return alerts, err
+			}
+		}
+	}
+
+	if p.Sections && raw {
+		for _, h := range f.EmptySections() {
+			if loc := headingLoc(txt, h); loc != nil {
+				if err := report(loc, "an empty section"); err != nil {
+					return alerts, err
+				}
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (p Placeholder) Fields() Definition {
+	return p.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (p Placeholder) Pattern() string {
+	return p.markers.String()
+}
+
+// headingLoc returns the (rune) location of the given heading's text in
+// `txt`, starting the search on the heading's line.
+func headingLoc(txt string, h core.Heading) []int {
+	runes := []rune(txt)
+
+	start, line := 0, 1
+	for start < len(runes) && line < h.Line {
+		if runes[start] == '\n' {
+			line++
+		}
+		start++
+	}
+
+	rest := string(runes[start:])
+	if idx := strings.Index(rest, h.Text); idx >= 0 && h.Text != "" {
+		begin := start + len([]rune(rest[:idx]))
+		return []int{begin, begin + len([]rune(h.Text))}
+	}
+
+	// The heading's text may include markup (e.g., `## Using *foo*`), so we
+	// fall back to its entire line (minus any leading `#` or `=` markers).
+	for start < len(runes) && strings.ContainsRune("#= \t", runes[start]) {
+		start++
+	}
+
+	end := start
+	for end < len(runes) && runes[end] != '\n' {
+		end++
+	}
+
+	if strings.TrimSpace(string(runes[start:end])) == "" {
+		return nil
+	}
+	return []int{start, end}
+}
@@ -0,0 +1,69 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestPlaceholder(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewPlaceholder(cfg, baseCheck{
+		"message": "'%s' is %s.",
+		"scope":   []string{"raw"},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "# Title\n\nTODO: intro for {{product}}.\n\n## Empty\n\n## Usage\n\n`TODO` is fine.\n"
+	file := &core.File{NormedExt: ".md", Outline: []core.Heading{
+		{Text: "Title", Level: 1, Line: 1, Content: true},
+		{Text: "Empty", Level: 2, Line: 5},
+		{Text: "Usage", Level: 2, Line: 7, Content: true},
+	}}
+
+	alerts, err := rule.Run(nlp.NewBlock("", text, "raw.md"), file, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []string{}
+	for _, a := range alerts {
+		messages = append(messages, a.Message)
+	}
+
+	expected := []string{
+		"'TODO' is a placeholder marker.",
+		"'{{product}}' is a template variable.",
+		"'Empty' is an empty section.",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Expected %v, got %v", expected, messages)
+	}
+}
+
+func TestEmptySections(t *testing.T) {
+	file := &core.File{Outline: []core.Heading{
+		{Text: "A", Level: 1},
+		{Text: "B", Level: 2},
+		{Text: "C", Level: 3},
+		{Text: "D", Level: 2, Content: true},
+		{Text: "E", Level: 2},
+	}}
+
+	names := []string{}
+	for _, h := range file.EmptySections() {
+		names = append(names, h.Text)
+	}
+
+	if expected := []string{"C", "E"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
@@ -0,0 +1,64 @@
+package check
+
+import "github.com/errata-ai/vale/v3/internal/core"
+
+// taggedPoints are the extension points whose rules require part-of-speech
+// tagging.
+var taggedPoints = []string{"sequence", "grammar", "agreement"}
+
+// expensivePoints are the extension points that the `quick` profile skips:
+// `sequence`, `grammar`, and `agreement` rules require part-of-speech tagging,
+// `spelling` rules look up every word in one or more dictionaries, and
+// `script` rules run in an embedded interpreter.
+var expensivePoints = []string{"sequence", "grammar", "agreement", "spelling", "script"}
+
+// IsDocumentScoped determines if the given rule evaluates an entire document
+// (e.g., its readability) rather than individual blocks, which `--editor`
+// skips.
+func IsDocumentScoped(rule Rule) bool {
+	fields := rule.Fields()
+	if fields.Extends == "readability" || fields.Extends == "metric" {
+		return true
+	}
+	return core.StringInSlice("summary", fields.Scope)
+}
+
+// structuralPoints are the extension points that check a document's
+// structure -- its internal links, footnotes, or sections -- rather than its
+// prose.
+var structuralPoints = []string{"anchors", "references", "changelog"}
+
+// IsStructural determines if the given rule checks a whole document (see
+// `IsDocumentScoped`) or its structure, which fragments such as pull request
+// descriptions don't have.
+func IsStructural(rule Rule) bool {
+	return IsDocumentScoped(rule) || core.StringInSlice(rule.Fields().Extends, structuralPoints)
+}
+
+// IsExpensive determines if the given rule is too slow for the `quick`
+// profile (see `--profile`).
+func IsExpensive(rule Rule) bool {
+	for _, point := range expensivePoints {
+		if rule.Fields().Extends == point {
+			return true
+		}
+	}
+
+	if sub, ok := rule.(Substitution); ok && sub.POS != "" {
+		return true
+	}
+
+	if c, ok := rule.(Changelog); ok && c.Past {
+		return true
+	}
+
+	if p, ok := rule.(Pipeline); ok {
+		for _, step := range p.steps {
+			if step.rule != nil && IsExpensive(step.rule) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
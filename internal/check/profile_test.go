@@ -0,0 +1,36 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestIsExpensive(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]bool{
+		"Spelling":   true,
+		"Repetition": false,
+		"Terms":      false,
+	}
+
+	for name, expected := range cases {
+		def := map[string]interface{}{}
+		for k, v := range defaultRules[name] {
+			def[k] = v
+		}
+
+		rule, err := buildRule(cfg, def)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if IsExpensive(rule) != expected {
+			t.Errorf("%s: expected %v", name, expected)
+		}
+	}
+}
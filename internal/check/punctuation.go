@@ -0,0 +1,252 @@
+package check
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+var (
+	reStraightQuote = regexp2.MustCompileStd(`["']`)
+	reCurlyQuote    = regexp2.MustCompileStd(`[“”‘’]`)
+	reThreePeriods  = regexp2.MustCompileStd(`(?<!\.)\.(?: ?\.){2}(?!\.)`)
+	reEllipsis      = regexp2.MustCompileStd(`…`)
+	reEmDash        = regexp2.MustCompileStd(`(?<=\S)[ \t]*—[ \t]*(?=\S)`)
+	reSpaces        = regexp2.MustCompileStd(`(?<=\w[.!?…]+["'”’)]*)[ ]{2,}(?=\S)`)
+)
+
+var punctuationStyles = map[string][]string{
+	"quotes":   {"curly", "straight"},
+	"ellipses": {"character", "periods"},
+	"dashes":   {"closed", "spaced"},
+}
+
+// Punctuation enforces a typographic style: curly or straight quotes, ellipsis
+// characters or periods, closed or spaced em dashes, and single spaces after
+// sentences.
+//
+// Each alert includes a `replace` action with the exact replacement, which is
+// difficult to express with `substitution` rules.
+type Punctuation struct {
+	Definition `mapstructure:",squash"`
+	Quotes     string // "curly" or "straight"
+	Ellipses   string // "character" or "periods"
+	Dashes     string // "closed" or "spaced"
+	Spacing    bool   // report multiple spaces after a sentence
+}
+
+// punctuationEdit is a single replacement within a block of text.
+type punctuationEdit struct {
+	start, end int // rune offsets
+	repl       string
+	kind       string
+}
+
+// NewPunctuation creates a new `punctuation`-based rule.
+func NewPunctuation(_ *core.Config, generic baseCheck, path string) (Punctuation, error) {
+	rule := Punctuation{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	options := map[string]string{
+		"quotes": rule.Quotes, "ellipses": rule.Ellipses, "dashes": rule.Dashes}
+
+	for key, value := range options {
+		if value != "" && !core.StringInSlice(value, punctuationStyles[key]) {
+			return rule, core.NewE201FromTarget(
+				fmt.Sprintf("'%s' must be one of %v.", key, punctuationStyles[key]),
+				key,
+				path)
+		}
+	}
+
+	return rule, nil
+}
+
+// Run executes the `punctuation`-based rule.
+//
+// The rule's message is formatted like a `substitution` rule's: the first
+// substitution is the expected text and the second is the observed text. The
+// third is a description of the problem -- e.g., "a straight quote" -- which
+// is useful for whitespace-only differences.
+//
+// Alerts span entire words (e.g., `"don't` -> `“don’t`) rather than single
+// characters, since a lone quote or space is difficult to locate in the
+// source.
+func (p Punctuation) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	txt := blk.Text
+	runes := []rune(txt)
+
+	edits := p.edits(runes, txt)
+	for i := 0; i < len(edits); {
+		start, end := wordBounds(runes, edits[i].start, edits[i].end)
+
+		// Group any other edits that touch the same words.
+		j := i + 1
+		for j < len(edits) {
+			s, e := wordBounds(runes, edits[j].start, edits[j].end)
+			if s >= end {
+				break
+			}
+			end = max(end, e)
+			j++
+		}
+
+		var expected strings.Builder
+		var kinds []string
+
+		cursor := start
+		for _, e := range edits[i:j] {
+			expected.WriteString(string(runes[cursor:e.start]) + e.repl)
+			if !core.StringInSlice(e.kind, kinds) {
+				kinds = append(kinds, e.kind)
+			}
+			cursor = e.end
+		}
+		expected.WriteString(string(runes[cursor:end]))
+
+		a, err := makeAlert(p.Definition, []int{start, end}, txt, cfg)
+		if err != nil {
+			return alerts, err
+		}
+
+		a.Message, a.Description = formatMessages(p.Message, p.Description,
+			expected.String(), a.Match, strings.Join(kinds, " and "))
+		a.Action = core.Action{Name: "replace", Params: []string{expected.String()}}
+
+		alerts = append(alerts, a)
+		i = j
+	}
+
+	return alerts, nil
+}
+
+// edits returns the replacements needed to conform to the rule's style,
+// sorted by position.
+func (p Punctuation) edits(runes []rune, txt string) []punctuationEdit {
+	type fixer struct {
+		re   *regexp2.Regexp
+		kind string
+		fix  func(match string, start int) string
+	}
+
+	var fixers []fixer
+	switch p.Quotes {
+	case "curly":
+		fixers = append(fixers, fixer{reStraightQuote, "a straight quote", func(_ string, start int) string {
+			return curlQuote(runes, start)
+		}})
+	case "straight":
+		fixers = append(fixers, fixer{reCurlyQuote, "a curly quote", func(m string, _ int) string {
+			if strings.ContainsAny(m, "“”") {
+				return `"`
+			}
+			return "'"
+		}})
+	}
+
+	switch p.Ellipses {
+	case "character":
+		fixers = append(fixers, fixer{reThreePeriods, "periods for an ellipsis", func(string, int) string {
+			return "…"
+		}})
+	case "periods":
+		fixers = append(fixers, fixer{reEllipsis, "an ellipsis character", func(string, int) string {
+			return "..."
+		}})
+	}
+
+	switch p.Dashes {
+	case "closed":
+		fixers = append(fixers, fixer{reEmDash, "a spaced em dash", func(string, int) string {
+			return "—"
+		}})
+	case "spaced":
+		fixers = append(fixers, fixer{reEmDash, "a closed em dash", func(string, int) string {
+			return " — "
+		}})
+	}
+
+	if p.Spacing {
+		fixers = append(fixers, fixer{reSpaces, "multiple spaces after a sentence", func(string, int) string {
+			return " "
+		}})
+	}
+
+	var edits []punctuationEdit
+	for _, f := range fixers {
+		for _, loc := range f.re.FindAllStringIndex(txt, -1) {
+			match := string(runes[loc[0]:loc[1]])
+			if repl := f.fix(match, loc[0]); repl != match {
+				edits = append(edits, punctuationEdit{loc[0], loc[1], repl, f.kind})
+			}
+		}
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+
+	return edits
+}
+
+// Fields provides access to the internal rule definition.
+func (p Punctuation) Fields() Definition {
+	return p.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (p Punctuation) Pattern() string {
+	return ""
+}
+
+// wordBounds expands the given range to include the surrounding words.
+func wordBounds(runes []rune, start, end int) (int, int) {
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+	return start, end
+}
+
+// curlQuote returns the curly version of the straight quote at `idx`.
+//
+// A quote is an opening quote if it's preceded by whitespace, an opening
+// bracket, or a dash (or if it starts the text); otherwise, it's a closing
+// quote or an apostrophe.
+func curlQuote(runes []rune, idx int) string {
+	opening := idx == 0
+	if !opening {
+		prev := runes[idx-1]
+		opening = unicode.IsSpace(prev) || strings.ContainsRune("([{<—–-“‘", prev)
+	}
+
+	switch {
+	case runes[idx] == '"' && opening:
+		return "“"
+	case runes[idx] == '"':
+		return "”"
+	case opening:
+		return "‘"
+	default:
+		return "’"
+	}
+}
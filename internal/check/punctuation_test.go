@@ -0,0 +1,71 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestPunctuation(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		def      baseCheck
+		text     string
+		expected []string
+	}{
+		{
+			def:      baseCheck{"quotes": "curly", "ellipses": "character", "spacing": true},
+			text:     `She said "don't go" and left...  Then she returned.`,
+			expected: []string{"“don’t", "go”", "left… Then"},
+		},
+		{
+			def:      baseCheck{"quotes": "straight", "ellipses": "periods"},
+			text:     "It’s “fine”… really.",
+			expected: []string{"It's", `"fine"...`},
+		},
+		{
+			def:      baseCheck{"dashes": "closed"},
+			text:     "A pause — then more—and more.",
+			expected: []string{"pause—then"},
+		},
+		{
+			def:      baseCheck{"dashes": "spaced"},
+			text:     "A pause — then more—and more.",
+			expected: []string{"more — and"},
+		},
+	}
+
+	for _, c := range cases {
+		c.def["message"] = "%s"
+
+		rule, err := NewPunctuation(cfg, c.def, "test.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		alerts, err := rule.Run(nlp.NewBlock("", c.text, "text"), nil, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fixes := []string{}
+		for _, a := range alerts {
+			fixes = append(fixes, a.Action.Params[0])
+		}
+
+		if !reflect.DeepEqual(fixes, c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.text, c.expected, fixes)
+		}
+	}
+
+	_, err = NewPunctuation(cfg, baseCheck{"quotes": "smart"}, "test.yml")
+	if err == nil {
+		t.Error("Expected an error for an invalid quote style")
+	}
+}
@@ -13,10 +13,16 @@ import (
 type Readability struct {
 	Definition `mapstructure:",squash"`
 	// `metrics` (`array`): One or more of Gunning Fog, Coleman-Liau,
-	// Flesch-Kincaid, SMOG, and Automated Readability.
+	// Flesch-Kincaid, SMOG, and Automated Readability (which are averaged),
+	// or Clause Depth, Nominalization Density, and Passive Voice (which are
+	// each compared to their entry in `limits`).
 	Metrics []string
 	// `grade` (`float`): The highest acceptable score.
 	Grade float64
+	// `limits` (`map`): The highest acceptable value of each
+	// sentence-complexity metric (Clause Depth, Nominalization Density, or
+	// Passive Voice) in `metrics`.
+	Limits map[string]float64
 }
 
 // NewReadability creates a new `readability`-based rule.
@@ -28,7 +34,20 @@ func NewReadability(_ *core.Config, generic baseCheck, path string) (Readability
 		return rule, readStructureError(err, path)
 	}
 
-	if core.AllStringsInSlice(rule.Metrics, readabilityMetrics) {
+	known := true
+	for _, metric := range rule.Metrics {
+		if _, complexity := complexityMetrics[metric]; complexity {
+			if _, found := rule.Limits[metric]; !found {
+				return rule, core.NewE201FromTarget(
+					fmt.Sprintf("'%s' requires a limit (see `limits`).", metric),
+					metric, path)
+			}
+		} else if !core.StringInSlice(metric, readabilityMetrics) {
+			known = false
+		}
+	}
+
+	if known {
 		// NOTE: This is the only extension point that doesn't support scoping.
 		// The reason for this is that we need to split on sentences to
 		// calculate readability, which means that specifying a scope smaller
@@ -63,18 +82,41 @@ func (o Readability) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Al
 		grade += doc.AutomatedReadability()
 	}
 
-	grade /= float64(len(o.Metrics))
-	if grade > o.Grade {
-		a := core.Alert{Check: o.Name, Severity: o.Level,
-			Span: []int{1, 1}, Link: o.Link}
-		a.Message, a.Description = formatMessages(o.Message, o.Description,
-			fmt.Sprintf("%.2f", grade))
-		alerts = append(alerts, a)
+	grades := 0
+	for _, metric := range o.Metrics {
+		if core.StringInSlice(metric, readabilityMetrics) {
+			grades++
+		}
+	}
+
+	if grades > 0 {
+		grade /= float64(grades)
+		if grade > o.Grade {
+			alerts = append(alerts, o.alert(grade))
+		}
+	}
+
+	// Sentence-complexity metrics are on different scales, so each is
+	// compared to its own limit rather than averaged.
+	for _, metric := range o.Metrics {
+		if measure, found := complexityMetrics[metric]; found {
+			if value := measure(doc); value > o.Limits[metric] {
+				alerts = append(alerts, o.alert(value))
+			}
+		}
 	}
 
 	return alerts, nil
 }
 
+func (o Readability) alert(score float64) core.Alert {
+	a := core.Alert{Check: o.Name, Severity: o.Level,
+		Span: []int{1, 1}, Link: o.Link}
+	a.Message, a.Description = formatMessages(o.Message, o.Description,
+		fmt.Sprintf("%.2f", score))
+	return a
+}
+
 // Fields provides access to the internal rule definition.
 func (o Readability) Fields() Definition {
 	return o.Definition
@@ -0,0 +1,65 @@
+package check
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jdkato/twine/summarize"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestComplexityMetrics(t *testing.T) {
+	doc := summarize.NewDocument(
+		"The configuration was written by the team. " +
+			"We ship it because users asked, although it is incomplete.")
+
+	cases := []struct {
+		metric   string
+		expected float64
+	}{
+		{"Clause Depth", 2},
+		{"Nominalization Density", 100.0 / 17},
+		{"Passive Voice", 50},
+	}
+
+	for _, c := range cases {
+		actual := complexityMetrics[c.metric](doc)
+		if math.Abs(actual-c.expected) > 0.01 {
+			t.Errorf("%s: expected %.2f, got %.2f", c.metric, c.expected, actual)
+		}
+	}
+}
+
+func TestReadabilityLimits(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewReadability(cfg, baseCheck{
+		"message": "Too passive (%s%%).",
+		"metrics": []string{"Passive Voice"},
+	}, "test.yml")
+	if err == nil {
+		t.Fatal("Expected a complexity metric without a limit to be rejected")
+	}
+
+	rule, err := NewReadability(cfg, baseCheck{
+		"message": "Too complex (%s).",
+		"metrics": []string{"Passive Voice", "Clause Depth"},
+		"limits":  map[string]float64{"Passive Voice": 25, "Clause Depth": 3},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blk := nlp.NewBlock("", "The file was deleted. The build is built nightly. It works.", "summary")
+	alerts, err := rule.Run(blk, &core.File{}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(alerts) != 1 || alerts[0].Message != "Too complex (66.67)." {
+		t.Fatalf("Expected one alert for passive voice, got %v", alerts)
+	}
+}
@@ -0,0 +1,173 @@
+package check
+
+import (
+	"strings"
+
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+var reFencedCode = regexp2.MustCompileStd("(?ms)^ {0,3}(`{3,}|~{3,}).*?^ {0,3}\\1")
+var reInlineCode = regexp2.MustCompileStd("`[^`\\n]+`")
+
+var reFootnoteDef = regexp2.MustCompileStd(`(?m)^ {0,3}\[\^([^\]\s]+)\]:`)
+var reFootnoteRef = regexp2.MustCompileStd(`\[\^([^\]\s]+)\](?!:)`)
+
+var reLinkRefDef = regexp2.MustCompileStd(`(?m)^ {0,3}\[([^\]^][^\]]*)\]:[ \t]*\S`)
+var reFullLinkRef = regexp2.MustCompileStd(`\]\[([^\]]*)\]`)
+var reShortLinkRef = regexp2.MustCompileStd(`(?<![\]\\])\[([^\]^][^\]]*)\](?![(:]|\[[^\]])`)
+
+// References checks the referential integrity of Markdown footnotes and
+// reference-style links.
+//
+// It reports footnotes and references that are used but never defined, as
+// well as those that are defined but never used.
+type References struct {
+	Definition `mapstructure:",squash"`
+}
+
+// NewReferences creates a new `References` rule.
+func NewReferences(_ *core.Config, generic baseCheck, path string) (References, error) {
+	rule := References{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	return rule, nil
+}
+
+// Run executes the `References` rule.
+//
+// This rule only applies to Markdown files and expects to be given the raw
+// contents of the file (`scope: raw`).
+func (r References) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	if f.NormedExt != ".md" {
+		return alerts, nil
+	}
+
+	// Code (blocks and spans) can contain bracketed text that isn't a
+	// reference, so we mask it before searching.
+	txt := maskCode(blk.Text)
+
+	footnotes := findLabels(reFootnoteDef, txt)
+	footnoteRefs := findLabels(reFootnoteRef, txt)
+
+	links := findLabels(reLinkRefDef, txt)
+	linkRefs := findLabels(reFullLinkRef, txt)
+
+	// NOTE: Shortcut references (`[foo]`) are indistinguishable from plain
+	// bracketed text, so they count as uses but we never report them as
+	// undefined.
+	shortcuts := findLabels(reShortLinkRef, txt)
+
+	report := func(loc []int, detail string) error {
+		a, err := makeAlert(r.Definition, loc, txt, cfg)
+		if err != nil {
+			return err
+		}
+		a.Message, a.Description = formatMessages(r.Message, r.Description, a.Match, detail)
+		alerts = append(alerts, a)
+		return nil
+	}
+
+	for label, locs := range footnoteRefs {
+		if _, found := footnotes[label]; !found {
+			for _, loc := range locs {
+				if err := report(loc, "referenced but never defined"); err != nil {
+					return alerts, err
+				}
+			}
+		}
+	}
+
+	for label, locs := range footnotes {
+		if _, found := footnoteRefs[label]; !found {
+			if err := report(locs[0], "defined but never referenced"); err != nil {
+				return alerts, err
+			}
+		}
+	}
+
+	for label, locs := range linkRefs {
+		if _, found := links[label]; !found {
+			for _, loc := range locs {
+				if err := report(loc, "referenced but never defined"); err != nil {
+					return alerts, err
+				}
+			}
+		}
+	}
+
+	for label, locs := range links {
+		_, full := linkRefs[label]
+		_, short := shortcuts[label]
+		if !full && !short {
+			if err := report(locs[0], "defined but never referenced"); err != nil {
+				return alerts, err
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (r References) Fields() Definition {
+	return r.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (r References) Pattern() string {
+	return ""
+}
+
+// findLabels maps each (normalized) label matched by `re` to the locations
+// of its brackets -- e.g., `[^1]` or `[foo]`.
+func findLabels(re *regexp2.Regexp, txt string) map[string][][]int {
+	labels := map[string][][]int{}
+
+	runes := []rune(txt)
+	for _, m := range re.FindAllStringSubmatchIndex(txt, -1) {
+		label := normalizeLabel(string(runes[m[2]:m[3]]))
+		if label == "" {
+			// Collapsed references (`[foo][]`) are covered by shortcuts.
+			continue
+		}
+
+		start := m[2] - 1
+		if runes[start] == '^' {
+			start--
+		}
+		labels[label] = append(labels[label], []int{start, m[3] + 1})
+	}
+
+	return labels
+}
+
+// maskCode replaces the contents of any Markdown code blocks and spans with
+// spaces, preserving the text's line and (rune) column offsets.
+func maskCode(txt string) string {
+	runes := []rune(txt)
+	for _, re := range []*regexp2.Regexp{reFencedCode, reInlineCode} {
+		for _, loc := range re.FindAllStringIndex(string(runes), -1) {
+			for i := loc[0]; i < loc[1]; i++ {
+				if runes[i] != '\n' {
+					runes[i] = ' '
+				}
+			}
+		}
+	}
+	return string(runes)
+}
+
+// normalizeLabel implements CommonMark's label matching: labels are
+// case-insensitive and consecutive whitespace is collapsed.
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
@@ -0,0 +1,61 @@
+package check
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestReferences(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := baseCheck{}
+	for k, v := range defaultRules["References"] {
+		def[k] = v
+	}
+	delete(def, "path")
+
+	rule, err := NewReferences(cfg, def, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "A[^1], [b][Foo  Bar], [^2], and [c][missing].\n\n" +
+		"`[^3]` is code.\n\n" +
+		"[foo bar]: https://example.com\n" +
+		"[unused]: https://example.com\n\n" +
+		"[^1]: Used.\n" +
+		"[^4]: Unused.\n"
+
+	file := &core.File{NormedExt: ".md"}
+	alerts, err := rule.Run(nlp.NewBlock("", text, "raw.md"), file, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []string{}
+	for _, a := range alerts {
+		messages = append(messages, a.Message)
+	}
+	sort.Strings(messages)
+
+	expected := []string{
+		"'[^2]' is referenced but never defined.",
+		"'[^4]' is defined but never referenced.",
+		"'[missing]' is referenced but never defined.",
+		"'[unused]' is defined but never referenced.",
+	}
+	if len(messages) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, messages)
+	}
+	for i := range expected {
+		if messages[i] != expected[i] {
+			t.Errorf("Expected '%s', got '%s'", expected[i], messages[i])
+		}
+	}
+}
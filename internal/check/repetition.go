@@ -1,6 +1,7 @@
 package check
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/errata-ai/regexp2"
@@ -9,6 +10,9 @@ import (
 	"github.com/errata-ai/vale/v3/internal/nlp"
 )
 
+// reBlankLine matches a paragraph break in hard-wrapped text.
+var reBlankLine = regexp.MustCompile(`\n[ \t]*\n`)
+
 // Repetition looks for repeated uses of Tokens.
 type Repetition struct {
 	Definition `mapstructure:",squash"`
@@ -18,6 +22,7 @@ type Repetition struct {
 	Alpha      bool
 	Vocab      bool
 	Exceptions []string
+	Reflow     bool
 
 	exceptRe *regexp2.Regexp
 	pattern  *regexp2.Regexp
@@ -61,6 +66,10 @@ func NewRepetition(cfg *core.Config, generic baseCheck, path string) (Repetition
 // Run executes the `repetition`-based rule.
 //
 // The rule looks for repeated matches of its regex -- such as "this this".
+//
+// With `reflow`, repeats that span a soft line break (as in hard-wrapped
+// Markdown or reStructuredText) are also reported, but repeats that span a
+// paragraph break are not.
 func (o Repetition) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Alert, error) {
 	var curr, prev string
 	var hit bool
@@ -95,7 +104,7 @@ func (o Repetition) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.A
 				return alerts, err
 			}
 
-			if !strings.Contains(converted, "\n") && !isMatch(o.exceptRe, converted) {
+			if o.sameLine(converted) && !isMatch(o.exceptRe, converted) {
 				floc := []int{ploc[0], loc[1]}
 
 				a, erra := makeAlert(o.Definition, floc, txt, cfg)
@@ -116,6 +125,14 @@ func (o Repetition) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.A
 	return alerts, nil
 }
 
+// sameLine determines if the given repeat is on a single logical line.
+func (o Repetition) sameLine(s string) bool {
+	if o.Reflow {
+		return !reBlankLine.MatchString(s)
+	}
+	return !strings.Contains(s, "\n")
+}
+
 // Fields provides access to the internal rule definition.
 func (o Repetition) Fields() Definition {
 	return o.Definition
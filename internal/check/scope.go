@@ -27,6 +27,9 @@ var allowedScopes = []string{
 	"alt",
 	"title",
 	"blockquote",
+	"footnote",
+	"details",
+	"reference",
 	"summary",
 	"raw",
 }
@@ -1,6 +1,8 @@
 package check
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strings"
 
@@ -71,9 +73,14 @@ func (s Script) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert,
 		return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
 	}
 
-	for _, match := range parseMatches(compiled.Get("matches").Array()) {
-		matchText := blk.Text[match["begin"].(int):match["end"].(int)]
-		matchLoc := []int{match["begin"].(int), match["end"].(int)}
+	matches, err := parseMatches(compiled.Get("matches"), len(blk.Text))
+	if err != nil {
+		return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
+	}
+
+	for _, match := range matches {
+		matchText := blk.Text[match.begin:match.end]
+		matchLoc := []int{match.begin, match.end}
 		// NOTE: We can't call `makeAlert` here because `script`-based rules
 		// don't use our custom regexp2 library, which means the offsets
 		// (`re2loc`) will be off.
@@ -85,8 +92,12 @@ func (s Script) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert,
 			Match:    matchText,
 			Action:   s.Action}
 
-		if matchMsg, ok := match["message"].(string); ok {
-			a.Message, a.Description = formatMessages(matchMsg, s.Description, matchText)
+		if match.level != "" {
+			a.Severity = match.level
+		}
+
+		if match.message != "" {
+			a.Message, a.Description = formatMessages(match.message, s.Description, matchText)
 		} else {
 			a.Message, a.Description = formatMessages(s.Message, s.Description, matchText)
 		}
@@ -97,22 +108,56 @@ func (s Script) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert,
 	return alerts, nil
 }
 
-func parseMatches(a []interface{}) []map[string]interface{} {
-	matches := []map[string]interface{}{}
-	for _, i := range a {
-		m, _ := i.(map[string]interface{})
-		match := map[string]interface{}{
-			"begin": int(m["begin"].(int64)),
-			"end":   int(m["end"].(int64)),
+// scriptMatch is a single entry of a script's `matches` array.
+type scriptMatch struct {
+	begin   int
+	end     int
+	message string
+	level   string
+}
+
+// parseMatches reads a script's `matches`, an array of maps with byte offsets
+// into `scope` (`begin` and `end`) and, optionally, their own `message` and
+// `level`.
+func parseMatches(v *tengo.Variable, size int) ([]scriptMatch, error) {
+	matches := []scriptMatch{}
+	if v.IsUndefined() {
+		return matches, errors.New("the script must define a 'matches' array")
+	}
+
+	raw, ok := v.Value().([]interface{})
+	if !ok {
+		return matches, fmt.Errorf("'matches' must be an array, not %s", v.ValueType())
+	}
+
+	for i, entry := range raw {
+		m, isMap := entry.(map[string]interface{})
+		if !isMap {
+			return matches, fmt.Errorf("matches[%d] must be a map", i)
 		}
 
-		if msg, ok := m["message"].(string); ok {
-			match["message"] = msg
+		begin, hasBegin := m["begin"].(int64)
+		end, hasEnd := m["end"].(int64)
+		if !hasBegin || !hasEnd {
+			return matches, fmt.Errorf("matches[%d] must have integer 'begin' and 'end' keys", i)
+		} else if begin < 0 || end < begin || int(end) > size {
+			return matches, fmt.Errorf("matches[%d] has an invalid range [%d, %d]", i, begin, end)
+		}
+
+		match := scriptMatch{begin: int(begin), end: int(end)}
+		match.message, _ = m["message"].(string)
+
+		if level, hasLevel := m["level"].(string); hasLevel {
+			if !core.StringInSlice(level, core.AlertLevels) {
+				return matches, fmt.Errorf("matches[%d] has an invalid level '%s'", i, level)
+			}
+			match.level = level
 		}
 
 		matches = append(matches, match)
 	}
-	return matches
+
+	return matches, nil
 }
 
 // Fields provides access to the internal rule definition.
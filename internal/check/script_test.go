@@ -0,0 +1,78 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestScriptHeadingPeriod(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewScript(cfg, baseCheck{
+		"message": "Don't end a heading with a period.",
+		"scope":   "heading",
+		"level":   "warning",
+		"script": `
+text := import("text")
+
+matches := []
+if text.has_suffix(scope, ".") && !text.has_suffix(scope, "?.") {
+	matches = append(matches, {begin: len(scope) - 1, end: len(scope)})
+}
+if text.has_suffix(scope, "!") {
+	matches = append(matches, {begin: len(scope) - 1, end: len(scope), level: "error", message: "No '%s' in headings."})
+}
+`,
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for heading, expected := range map[string]string{
+		"Installing the CLI.":  "warning",
+		"Why use the CLI?":     "",
+		"Install it now!":      "error",
+		"Installing the tools": "",
+	} {
+		alerts, runErr := rule.Run(nlp.NewBlock("", heading, "heading"), &core.File{}, cfg)
+		if runErr != nil {
+			t.Fatal(runErr)
+		}
+
+		switch {
+		case expected == "" && len(alerts) != 0:
+			t.Errorf("%q: expected no alerts, got %v", heading, alerts)
+		case expected != "" && (len(alerts) != 1 || alerts[0].Severity != expected):
+			t.Errorf("%q: expected one %s, got %v", heading, expected, alerts)
+		}
+	}
+}
+
+func TestScriptInvalidMatches(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, script := range []string{
+		`x := 1`,
+		`matches := "oops"`,
+		`matches := [{begin: 0}]`,
+		`matches := [{begin: 2, end: 100}]`,
+		`matches := [{begin: 0, end: 1, level: "fatal"}]`,
+	} {
+		rule, ruleErr := NewScript(cfg, baseCheck{"script": script}, "test.yml")
+		if ruleErr != nil {
+			t.Fatal(ruleErr)
+		}
+
+		if _, runErr := rule.Run(nlp.NewBlock("", "Some text.", "text"), &core.File{}, cfg); runErr == nil {
+			t.Errorf("Expected an error for %q", script)
+		}
+	}
+}
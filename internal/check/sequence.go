@@ -14,11 +14,14 @@ import (
 
 // NLPToken represents a token of text with NLP-related attributes.
 type NLPToken struct {
-	Pattern  string
-	Tag      string
-	Skip     int
-	re       *regexp2.Regexp
-	Negate   bool
+	Pattern string
+	Tag     string
+	Skip    int
+	re      *regexp2.Regexp
+	Negate  bool
+	// Context tokens must match, but aren't part of the alert's span: they
+	// act as a lookbehind (or lookahead) for the tokens that are reported.
+	Context  bool
 	optional bool
 	start    bool
 	end      bool
@@ -28,7 +31,6 @@ type NLPToken struct {
 type Sequence struct {
 	Definition   `mapstructure:",squash"`
 	Tokens       []NLPToken
-	history      []int
 	Ignorecase   bool
 	needsTagging bool
 }
@@ -134,8 +136,14 @@ func tokensMatch(token NLPToken, word tag.Token) bool {
 	return true
 }
 
-func sequenceMatches(idx int, chk Sequence, target NLPToken, words []tag.Token) ([]string, int) {
+// sequenceMatches returns the words matched by the sequence anchored at the
+// `idx`-th token, along with whether each of them is context-only.
+//
+// `history` holds the anchors already used by the current run, which are
+// skipped.
+func sequenceMatches(idx int, chk Sequence, target NLPToken, words []tag.Token, history []int) ([]string, []bool, int) {
 	var text []string
+	var context []bool
 
 	toks := chk.Tokens
 
@@ -144,7 +152,7 @@ func sequenceMatches(idx int, chk Sequence, target NLPToken, words []tag.Token)
 	index := 0
 
 	for jdx, tok := range words {
-		if tokensMatch(target, tok) && !core.IntInSlice(jdx, chk.history) {
+		if tokensMatch(target, tok) && !core.IntInSlice(jdx, history) {
 			index = jdx
 			// We've found our context.
 			//
@@ -158,12 +166,13 @@ func sequenceMatches(idx int, chk Sequence, target NLPToken, words []tag.Token)
 				// side to check -- hence, `idx > 0`.
 				for i := 1; idx-i >= 0; i++ {
 					if jdx-i < 0 {
-						return []string{}, index
+						return []string{}, nil, index
 					}
 					tok := toks[idx-i]
 
 					word := words[jdx-i]
 					text = append([]string{word.Text}, text...)
+					context = append([]bool{tok.Context}, context...)
 
 					// NOTE: We have to perform this conversion because the token slice is made
 					// with the right-hand orientation in mind. For example,
@@ -177,7 +186,7 @@ func sequenceMatches(idx int, chk Sequence, target NLPToken, words []tag.Token)
 
 					mat := tokensMatch(tok, word)
 					if !mat && !tok.optional {
-						return []string{}, index
+						return []string{}, nil, index
 					} else if mat && tok.optional {
 						break
 					}
@@ -190,16 +199,17 @@ func sequenceMatches(idx int, chk Sequence, target NLPToken, words []tag.Token)
 				// side to check.
 				for i := 0; idx+i < sizeT; i++ {
 					if jdx+i >= sizeW {
-						return []string{}, index
+						return []string{}, nil, index
 					}
 					tok := toks[idx+i]
 
 					word := words[jdx+i]
 					text = append(text, word.Text)
+					context = append(context, tok.Context)
 
 					mat := tokensMatch(tok, word)
 					if !mat && !tok.optional {
-						return []string{}, index
+						return []string{}, nil, index
 					} else if mat && tok.optional {
 						break
 					}
@@ -209,7 +219,36 @@ func sequenceMatches(idx int, chk Sequence, target NLPToken, words []tag.Token)
 		}
 	}
 
-	return text, index
+	return text, context, index
+}
+
+// reportedSteps returns the part of `seq` (the text of `steps`) that isn't
+// context-only, along with its offset in `seq`.
+//
+// Context is only trimmed from the ends of a sequence; if every step is
+// context, the whole sequence is reported.
+func reportedSteps(steps []string, context []bool, seq string) (string, int) {
+	first, last := -1, -1
+	for i := range steps {
+		if i < len(context) && context[i] {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+
+	if first < 0 {
+		return seq, 0
+	}
+
+	before := len(stepsToString(steps[:first]))
+	match := stepsToString(steps[first : last+1])
+	if start := strings.Index(seq[before:], match); start >= 0 {
+		return match, before + start
+	}
+	return seq, 0
 }
 
 func stepsToString(steps []string) string {
@@ -229,6 +268,10 @@ func (s Sequence) Run(blk nlp.Block, f *core.File, _ *core.Config) ([]core.Alert
 	var alerts []core.Alert
 	var offset []string
 
+	// NOTE: This is per-run state: rules are shared by every file being
+	// linted, so they can't record anything themselves.
+	var history []int
+
 	// This is *always* sentence-scoped.
 	words := nlp.TextToTokens(blk.Text, &f.NLP)
 
@@ -238,17 +281,21 @@ func (s Sequence) Run(blk nlp.Block, f *core.File, _ *core.Config) ([]core.Alert
 			// We're looking for our "anchor" ...
 			for _, loc := range tok.re.FindAllStringIndex(txt, -1) {
 				// These are all possible violations in `txt`:
-				steps, index := sequenceMatches(idx, s, tok, words)
-				s.history = append(s.history, index) //nolint:staticcheck
+				steps, context, index := sequenceMatches(idx, s, tok, words, history)
+				history = append(history, index)
 
 				if len(steps) > 0 {
 					seq := stepsToString(steps)
 					ssp := strings.Index(txt, seq)
 
+					// Context-only tokens are matched, but not reported.
+					match, start := reportedSteps(steps, context, seq)
+					ssp += start
+
 					a := core.Alert{
 						Check: s.Name, Severity: s.Level, Link: s.Link,
-						Span: []int{ssp, ssp + len(seq)}, Hide: false,
-						Match: seq, Action: s.Action}
+						Span: []int{ssp, ssp + len(match)}, Hide: false,
+						Match: match, Action: s.Action}
 
 					a.Message, a.Description = formatMessages(s.Message,
 						s.Description, steps...)
@@ -0,0 +1,120 @@
+package check
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestSequenceContext(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		tokens []interface{}
+		match  string
+		span   []int
+	}{
+		{
+			tokens: []interface{}{
+				map[string]interface{}{"pattern": "the"},
+				map[string]interface{}{"pattern": "set"},
+				map[string]interface{}{"pattern": "up"},
+			},
+			match: "the set up",
+			span:  []int{4, 14},
+		},
+		{
+			tokens: []interface{}{
+				map[string]interface{}{"pattern": "the", "context": true},
+				map[string]interface{}{"pattern": "set"},
+				map[string]interface{}{"pattern": "up"},
+			},
+			match: "set up",
+			span:  []int{8, 14},
+		},
+		{
+			tokens: []interface{}{
+				map[string]interface{}{"pattern": "the"},
+				map[string]interface{}{"pattern": "set"},
+				map[string]interface{}{"pattern": "up", "context": true},
+			},
+			match: "the set",
+			span:  []int{4, 11},
+		},
+	}
+
+	for _, c := range cases {
+		rule, err := NewSequence(cfg, baseCheck{
+			"message": "Use 'setup' as a noun.",
+			"tokens":  c.tokens,
+		}, "test.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		text := "Run the set up script."
+		alerts, err := rule.Run(nlp.NewBlock("", text, "sentence"), &core.File{}, cfg)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(alerts) != 1 {
+			t.Fatalf("Expected one alert, got %v", alerts)
+		}
+
+		a := alerts[0]
+		if a.Match != c.match || a.Span[0] != c.span[0] || a.Span[1] != c.span[1] {
+			t.Errorf("Expected '%s' at %v, got '%s' at %v", c.match, c.span, a.Match, a.Span)
+		}
+	}
+}
+
+// TestConcurrentSequence runs a single rule -- as shared by every file in a
+// run -- from many goroutines at once: run it with `-race`.
+func TestConcurrentSequence(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewSequence(cfg, baseCheck{
+		"message": "Use 'setup' as a noun.",
+		"tokens": []interface{}{
+			map[string]interface{}{"pattern": "the"},
+			map[string]interface{}{"pattern": "set"},
+			map[string]interface{}{"pattern": "up"},
+		},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "Run the set up script, then the set up test."
+
+	var wg sync.WaitGroup
+	counts := make([]int, 16)
+
+	for i := range counts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for run := 0; run < 20; run++ {
+				alerts, rerr := rule.Run(nlp.NewBlock("", text, "sentence"), &core.File{}, cfg)
+				if rerr != nil || len(alerts) != 2 {
+					return
+				}
+				counts[i]++
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, n := range counts {
+		if n != 20 {
+			t.Errorf("Goroutine %d: only %d of 20 runs found both sequences", i, n)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package check
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// closingQuotes maps each closing quote to its opening counterpart.
+var closingQuotes = map[rune]rune{'"': '"', '”': '“', '\'': '\'', '’': '‘'}
+
+// sicRanges returns the (byte) ranges of `txt` that are exempted by an inline
+// marker such as `[sic]`: either the word or the quoted phrase immediately
+// preceding it.
+//
+// This allows writers to keep an intentional misspelling (e.g., in a quote)
+// without adding it to their vocabulary.
+func sicRanges(txt string, markers []string) [][]int {
+	var ranges [][]int
+
+	for _, marker := range markers {
+		if marker == "" {
+			continue
+		}
+
+		offset := 0
+		for {
+			idx := strings.Index(txt[offset:], marker)
+			if idx < 0 {
+				break
+			}
+			idx += offset
+			offset = idx + len(marker)
+
+			end := len(strings.TrimRightFunc(txt[:idx], unicode.IsSpace))
+			if end == 0 {
+				continue
+			}
+
+			last, size := utf8.DecodeLastRuneInString(txt[:end])
+			if opening, ok := closingQuotes[last]; ok {
+				if start := strings.LastIndex(txt[:end-size], string(opening)); start >= 0 {
+					ranges = append(ranges, []int{start, end})
+					continue
+				}
+			}
+
+			start := strings.LastIndexFunc(txt[:end], unicode.IsSpace) + 1
+			ranges = append(ranges, []int{start, end})
+		}
+	}
+
+	return ranges
+}
+
+// inRanges determines if the given (byte) span is inside any of `ranges`.
+func inRanges(ranges [][]int, start, end int) bool {
+	for _, r := range ranges {
+		if start >= r[0] && end <= r[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSicRanges(t *testing.T) {
+	markers := []string{"[sic]", "(sic)"}
+
+	cases := map[string][]string{
+		"He wrote teh [sic] here.":               {"teh"},
+		`She said "teh qiuck fox" [sic] twice.`:  {`"teh qiuck fox"`},
+		"A quote: “definately”(sic), and more.":  {"“definately”"},
+		"Nothing to exempt, even in teh text.":   nil,
+		"[sic] at the start has nothing before.": nil,
+	}
+
+	for text, expected := range cases {
+		var found []string
+		for _, r := range sicRanges(text, markers) {
+			found = append(found, text[r[0]:r[1]])
+		}
+		if !reflect.DeepEqual(found, expected) {
+			t.Errorf("%q: expected %v, got %v", text, expected, found)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/errata-ai/regexp2"
 	"github.com/mitchellh/mapstructure"
@@ -24,19 +25,47 @@ var defaultFilters = []*regexp.Regexp{
 
 // Spelling checks text against a Hunspell dictionary.
 type Spelling struct {
-	Definition   `mapstructure:",squash"`
-	Filters      []*regexp.Regexp
-	Ignore       []string
-	Exceptions   []string
+	Definition     `mapstructure:",squash"`
+	Filters        []*regexp.Regexp
+	Ignore         []string
+	Exceptions     []string
+	Dictionaries   []string
+	Aff            string
+	Dic            string
+	Dicpath        string
+	Threshold      int
+	Frequencies    string
+	MaxSuggestions int `mapstructure:"max_suggestions"`
+	Sections       []spellingSection
+	exceptRe       *regexp2.Regexp
+	gs             *spell.Checker
+	suggested      *suggestionCache
+	Custom         bool
+	Append         bool
+}
+
+// spellingSection is a set of dictionaries (and word lists) that's only
+// active under headings matching `Heading` -- e.g., an API-terms dictionary
+// for "Reference" sections.
+type spellingSection struct {
+	Heading      string
 	Dictionaries []string
-	Aff          string
-	Dic          string
-	Dicpath      string
-	Threshold    int
-	exceptRe     *regexp2.Regexp
-	gs           *spell.Checker
-	Custom       bool
-	Append       bool
+	Ignore       []string
+
+	re *regexp.Regexp
+	gs *spell.Checker
+}
+
+// suggestionCacheSize is the number of misspellings whose suggestions we
+// keep.
+const suggestionCacheSize = 1024
+
+// suggestionCache holds the suggestions for recent misspellings, which tend to
+// recur throughout a run (e.g., a product name that's missing from a
+// vocabulary).
+type suggestionCache struct {
+	words map[string][]string
+	mu    sync.Mutex
 }
 
 func addFilters(s *Spelling, generic baseCheck, _ *core.Config) error {
@@ -83,7 +112,7 @@ func addExceptions(s *Spelling, generic baseCheck, cfg *core.Config) error { //n
 func NewSpelling(cfg *core.Config, generic baseCheck, path string) (Spelling, error) {
 	var model *spell.Checker
 
-	rule := Spelling{}
+	rule := Spelling{suggested: &suggestionCache{words: map[string][]string{}}}
 	name, _ := generic["name"].(string)
 
 	err := addFilters(&rule, generic, cfg)
@@ -145,6 +174,10 @@ func NewSpelling(cfg *core.Config, generic baseCheck, path string) (Spelling, er
 		}
 	}
 
+	if err = addSections(&rule, cfg, path); err != nil {
+		return rule, err
+	}
+
 	if !rule.Custom {
 		rule.Filters = append(rule.Filters, defaultFilters...)
 	}
@@ -153,10 +186,78 @@ func NewSpelling(cfg *core.Config, generic baseCheck, path string) (Spelling, er
 	return rule, nil
 }
 
+func addSections(s *Spelling, cfg *core.Config, path string) error {
+	root := filepath.Join(cfg.StylesPath(), core.DictDir)
+	if s.Dicpath != "" {
+		found, err := resolveDicpath(s.Dicpath, cfg)
+		if err != nil {
+			return core.NewE201FromTarget(err.Error(), "dicpath", path)
+		}
+		root = found
+	}
+
+	for i := range s.Sections {
+		sec := &s.Sections[i]
+		if sec.Heading == "" || len(sec.Dictionaries) == 0 {
+			return core.NewE201FromTarget(
+				"each section needs a 'heading' pattern and at least one dictionary", "sections", path)
+		}
+
+		re, err := regexp.Compile(sec.Heading)
+		if err != nil {
+			return core.NewE201FromTarget(err.Error(), sec.Heading, path)
+		}
+		sec.re = re
+
+		options := []spell.CheckerOption{spell.WithPath(root)}
+		for _, name := range sec.Dictionaries {
+			options = append(options, spell.UsingDictionary(name))
+		}
+
+		sec.gs, err = spell.NewChecker(options...)
+		if err != nil {
+			return core.NewE201FromTarget(err.Error(), "sections", path)
+		}
+
+		for _, ignore := range sec.Ignore {
+			for _, p := range []string{ignore, filepath.Join(cfg.StylesPath(), core.IgnoreDir, ignore)} {
+				if err = sec.gs.AddWordListFile(p); err != nil && core.FileExists(p) {
+					return core.NewE201FromTarget(err.Error(), ignore, path)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// sectionCheckers returns the checkers of the sections that apply to the
+// current position in `f`, including those of any enclosing sections.
+func (s Spelling) sectionCheckers(f *core.File) []*spell.Checker {
+	var active []*spell.Checker
+	if len(s.Sections) == 0 {
+		return active
+	}
+
+	enclosing := f.Section()
+	for _, sec := range s.Sections {
+		for _, h := range enclosing {
+			if sec.re.MatchString(h.Text) {
+				active = append(active, sec.gs)
+				break
+			}
+		}
+	}
+
+	return active
+}
+
 // Run performs spell-checking on the provided text.
-func (s Spelling) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert, error) {
+func (s Spelling) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
 	var alerts []core.Alert
 
+	sections := s.sectionCheckers(f)
+
 	txt := blk.Text
 	// This ensures that we respect `.aff` entries like `ICONV ’ '`,
 	// allowing us to avoid false positives.
@@ -164,21 +265,35 @@ func (s Spelling) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert
 	// See https://github.com/errata-ai/vale/v2/issues/148.
 	txt = s.gs.Convert(txt)
 
+	sic := sicRanges(txt, cfg.SicMarkers)
+
+	cursor := 0
 OUTER:
 	for _, word := range nlp.WordTokenizer.Tokenize(txt) {
+		offset := strings.Index(txt[cursor:], word)
+		if offset < 0 {
+			offset = strings.Index(txt, word)
+		} else {
+			offset += cursor
+			cursor = offset + len(word)
+		}
+
 		for _, filter := range s.Filters {
 			if filter.MatchString(word) {
 				continue OUTER
 			}
 		}
 
-		if !s.gs.Spell(word) && !isMatch(s.exceptRe, word) {
-			offset := strings.Index(txt, word)
+		if !s.gs.Spell(word) && !spelledBy(sections, word) && !isMatch(s.exceptRe, word) {
 			loc := []int{offset, offset + len(word)}
 
 			a := core.Alert{Check: s.Name, Severity: s.Level, Span: loc,
 				Link: s.Link, Match: word, Action: s.Action}
 
+			// NOTE: We still return exempted words (as hidden alerts) so that
+			// any later occurrences are located correctly.
+			a.Hide = inRanges(sic, loc[0], loc[1])
+
 			a.Message, a.Description = formatMessages(s.Message,
 				s.Description, word)
 
@@ -189,6 +304,15 @@ OUTER:
 	return alerts, nil
 }
 
+func spelledBy(checkers []*spell.Checker, word string) bool {
+	for _, checker := range checkers {
+		if checker.Spell(word) {
+			return true
+		}
+	}
+	return false
+}
+
 // Fields provides access to the internal rule definition.
 func (s Spelling) Fields() Definition {
 	return s.Definition
@@ -199,47 +323,63 @@ func (s Spelling) Pattern() string {
 	return ""
 }
 
-// Pattern is the internal regex pattern used by this rule.
+// Suggest returns the replacements for `word`, from most to least likely.
 func (s Spelling) Suggest(word string) []string {
-	return s.gs.Suggest(word)
+	if s.suggested == nil {
+		return s.gs.Suggest(word)
+	}
+
+	s.suggested.mu.Lock()
+	found, ok := s.suggested.words[word]
+	s.suggested.mu.Unlock()
+
+	if ok {
+		return found
+	}
+	found = s.gs.Suggest(word)
+
+	s.suggested.mu.Lock()
+	if len(s.suggested.words) >= suggestionCacheSize {
+		clear(s.suggested.words)
+	}
+	s.suggested.words[word] = found
+	s.suggested.mu.Unlock()
+
+	return found
 }
 
 func makeSpeller(s *Spelling, cfg *core.Config, rulePath string) (*spell.Checker, error) {
 	var options []spell.CheckerOption
-	var found bool
+
+	if s.MaxSuggestions < 0 {
+		return nil, errors.New("max_suggestions must be a non-negative integer")
+	} else if s.MaxSuggestions > 0 {
+		options = append(options, spell.WithMaxSuggestions(s.MaxSuggestions))
+	}
+
+	if s.Frequencies != "" {
+		freqloc := core.FindAsset(cfg, s.Frequencies)
+		if !core.FileExists(freqloc) {
+			return nil, errors.New("unable to resolve frequencies")
+		}
+		options = append(options, spell.WithFrequencies(freqloc))
+	}
 
 	affloc := core.FindAsset(cfg, s.Aff)
 	dicloc := core.FindAsset(cfg, s.Dic)
 
 	if core.FileExists(affloc) && core.FileExists(dicloc) {
-		return spell.NewChecker(spell.UsingDictionaryByPath(dicloc, affloc))
+		options = append(options, spell.UsingDictionaryByPath(dicloc, affloc))
+		return spell.NewChecker(options...)
 	}
 
 	options = append(options, spell.WithDefault(s.Append))
 	if s.Dicpath != "" {
-		cwd, _ := os.Getwd()
-
-		// There are a few cases we need to consider:
-		paths := []string{
-			// 1. An absolute path (similar to $DICPATH)
-			s.Dicpath,
-			// 2. Relative to StylesPath
-			filepath.Join(cfg.StylesPath(), s.Dicpath),
-			// 4. Relative to cwd
-			filepath.Join(cwd, s.Dicpath),
-		}
-
-		for _, p := range paths {
-			if core.IsDir(p) {
-				options = append(options, spell.WithPath(p))
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			return nil, errors.New("unable to resolve dicpath")
+		found, err := resolveDicpath(s.Dicpath, cfg)
+		if err != nil {
+			return nil, err
 		}
+		options = append(options, spell.WithPath(found))
 	}
 
 	if len(s.Dictionaries) > 0 {
@@ -258,3 +398,26 @@ func makeSpeller(s *Spelling, cfg *core.Config, rulePath string) (*spell.Checker
 
 	return spell.NewChecker(options...)
 }
+
+// resolveDicpath finds the directory referred to by a rule's `dicpath`.
+func resolveDicpath(dicpath string, cfg *core.Config) (string, error) {
+	cwd, _ := os.Getwd()
+
+	// There are a few cases we need to consider:
+	paths := []string{
+		// 1. An absolute path (similar to $DICPATH)
+		dicpath,
+		// 2. Relative to StylesPath
+		filepath.Join(cfg.StylesPath(), dicpath),
+		// 4. Relative to cwd
+		filepath.Join(cwd, dicpath),
+	}
+
+	for _, p := range paths {
+		if core.IsDir(p) {
+			return p, nil
+		}
+	}
+
+	return "", errors.New("unable to resolve dicpath")
+}
@@ -0,0 +1,125 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestSpellingSections(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"api.aff": "SET UTF-8\n",
+		"api.dic": "1\nkubectl\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewSpelling(cfg, baseCheck{
+		"name":    "Test.Spelling",
+		"message": "Did you really mean '%s'?",
+		"dicpath": dir,
+		"sections": []interface{}{
+			map[string]interface{}{"heading": "^Reference$", "dictionaries": []interface{}{"api"}},
+		},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &core.File{}
+	lint := func() int {
+		alerts, runErr := rule.Run(nlp.NewBlock("", "Run kubectl first.", "text"), f, cfg)
+		if runErr != nil {
+			t.Fatal(runErr)
+		}
+		return len(alerts)
+	}
+
+	f.AddHeading("Overview", 1, 1)
+	if n := lint(); n != 1 {
+		t.Errorf("Expected 'kubectl' to be misspelled outside of 'Reference', got %d alerts", n)
+	}
+
+	f.AddHeading("Reference", 1, 5)
+	f.AddHeading("Commands", 2, 9)
+	if n := lint(); n != 0 {
+		t.Errorf("Expected 'kubectl' to be accepted in a 'Reference' subsection, got %d alerts", n)
+	}
+
+	f.AddHeading("FAQ", 1, 20)
+	if n := lint(); n != 1 {
+		t.Errorf("Expected 'kubectl' to be misspelled after 'Reference', got %d alerts", n)
+	}
+
+	_, err = NewSpelling(cfg, baseCheck{
+		"name":     "Test.Spelling",
+		"sections": []interface{}{map[string]interface{}{"heading": "Reference"}},
+	}, "test.yml")
+	if err == nil {
+		t.Error("Expected a section without dictionaries to be rejected")
+	}
+}
+
+func TestSpellingSuggestions(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewSpelling(cfg, baseCheck{
+		"name":            "Test.Spelling",
+		"message":         "Did you really mean '%s'?",
+		"max_suggestions": 3,
+		"action":          map[string]interface{}{"name": "suggest", "params": []interface{}{"spellings"}},
+	}, "test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(nlp.NewBlock("", "I recieve teh mail.", "text"), &core.File{}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(alerts) != 2 {
+		t.Fatalf("Expected two alerts, got %v", alerts)
+	}
+
+	// Suggestions are only ranked on request.
+	for _, a := range alerts {
+		if len(a.Suggestions) > 0 {
+			t.Errorf("%s: expected no suggestions yet, got %v", a.Match, a.Suggestions)
+		}
+	}
+
+	mgr, err := NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if err = mgr.AddRule("Test.Spelling", rule); err != nil {
+		t.Fatal(err)
+	}
+	mgr.Suggest(alerts)
+
+	for i, expected := range []string{"receive", "the"} {
+		a := alerts[i]
+		if len(a.Suggestions) == 0 || len(a.Suggestions) > 3 || a.Suggestions[0] != expected {
+			t.Errorf("%s: expected '%s' first (of at most 3), got %v", a.Match, expected, a.Suggestions)
+		}
+
+		fixes, ferr := FixAlert(a, cfg)
+		if ferr != nil {
+			t.Fatal(ferr)
+		} else if len(fixes) != len(a.Suggestions) || fixes[0] != a.Suggestions[0] {
+			t.Errorf("%s: expected the fixes to match %v, got %v", a.Match, a.Suggestions, fixes)
+		}
+	}
+}
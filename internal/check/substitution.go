@@ -2,6 +2,7 @@ package check
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -12,6 +13,18 @@ import (
 	"github.com/errata-ai/vale/v3/internal/nlp"
 )
 
+// reLiteralTerm matches a term without any regex syntax.
+var reLiteralTerm = regexp.MustCompile(`^[\p{L}\p{N}' -]+$`)
+
+// inflections maps the nominal and verbal inflectional suffixes ("-s",
+// "-ed", "-ing", etc.) to the endings they replace, which we use to match
+// inflected forms of a term's words.
+var inflections = [][2]string{
+	{"'s", ""}, {"ies", "y"}, {"es", ""}, {"s", ""},
+	{"ied", "y"}, {"ed", "e"}, {"ed", ""},
+	{"ing", "e"}, {"ing", ""},
+}
+
 // Substitution switches the values of Swap for its keys.
 type Substitution struct {
 	Definition `mapstructure:",squash"`
@@ -20,12 +33,31 @@ type Substitution struct {
 	Swap       map[string]string
 	exceptRe   *regexp2.Regexp
 	pattern    *regexp2.Regexp
+	literal    *literalMatcher
+	regex      string
 	Ignorecase bool
 	Nonword    bool
 	Vocab      bool
 	Capitalize bool
+	// `variants` (`bool`): Match the hyphenated, spaced, and closed forms of
+	// each (literal) term -- e.g., `e-mail` also matches "e mail" and "email".
+	Variants bool
+	// `simplify` (`map`): Maps a preferred phrase to the phrases that it
+	// replaces (e.g., `to: [in order to, in order for]`). Like `swap`, the
+	// preferred phrase may list ranked alternatives (`to|so that`), which are
+	// suggested in that order.
+	Simplify map[string][]string
+	// `stem` (`bool`): Also match the inflected forms of each word of a
+	// (literal) term -- e.g., `make a decision` also matches "makes a
+	// decision" and "making decisions".
+	Stem bool
+	// `inline` (`bool`): With `nonword`, also report phrases that are
+	// interrupted by inline markup in the source (e.g., "in *order* to" or a
+	// phrase around a code span) at their exact location.
+	Inline bool
 
 	msgMap []string
+	ranked []bool
 	// Deprecated
 	POS string
 }
@@ -57,15 +89,37 @@ func NewSubstitution(cfg *core.Config, generic baseCheck, path string) (Substitu
 		func() bool { return !rule.Nonword },
 		func() string { return "" }, true)
 
+	simplified, err := simplifyTerms(rule.Simplify, rule.Swap, path)
+	if err != nil {
+		return rule, err
+	}
+	for term, replacement := range simplified {
+		if rule.Swap == nil {
+			rule.Swap = map[string]string{}
+		}
+		rule.Swap[term] = replacement
+	}
+
 	terms := maps.Keys(rule.Swap)
 	sort.Slice(terms, func(p, q int) bool {
-		return len(terms[p]) > len(terms[q])
+		if len(terms[p]) != len(terms[q]) {
+			return len(terms[p]) > len(terms[q])
+		}
+		return terms[p] < terms[q]
 	})
 
 	replacements := []string{}
 	for _, regexstr := range terms {
-		rule.msgMap = append(rule.msgMap, regexstr)
 		replacement := rule.Swap[regexstr]
+		_, isRanked := simplified[regexstr]
+		rule.ranked = append(rule.ranked, isRanked)
+
+		if rule.Stem {
+			regexstr = termInflections(regexstr, rule.Variants)
+		} else if rule.Variants {
+			regexstr = termVariants(regexstr)
+		}
+		rule.msgMap = append(rule.msgMap, regexstr)
 
 		opens := strings.Count(regexstr, "(")
 		if opens != strings.Count(regexstr, "(?")+strings.Count(regexstr, `\(`) {
@@ -80,13 +134,22 @@ func NewSubstitution(cfg *core.Config, generic baseCheck, path string) (Substitu
 	}
 	regex = fmt.Sprintf(regex, strings.TrimRight(tokens, "|"))
 
+	rule.regex = regex
+	rule.repl = replacements
+
+	if rule.Nonword || cfg.WordTemplate == "" {
+		rule.literal = newLiteralMatcher(rule.msgMap, rule.Ignorecase, !rule.Nonword)
+		if rule.literal != nil {
+			return rule, nil
+		}
+	}
+
 	re, err = regexp2.CompileStd(regex)
 	if err != nil {
 		return rule, core.NewE201FromPosition(err.Error(), path, 1)
 	}
 
 	rule.pattern = re
-	rule.repl = replacements
 	return rule, nil
 }
 
@@ -97,66 +160,117 @@ func (s Substitution) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core
 	var alerts []core.Alert
 
 	txt := blk.Text
-	// Leave early if we can to avoid calling `FindAllStringSubmatchIndex`
-	// unnecessarily.
-	if !s.pattern.MatchStringStd(txt) {
+
+	matches := s.matches(txt)
+	if len(matches) == 0 {
 		return alerts, nil
 	}
+	sic := sicRanges(txt, cfg.SicMarkers)
 
-	for _, submat := range s.pattern.FindAllStringSubmatchIndex(txt, -1) {
-		for idx, mat := range submat {
-			if mat != -1 && idx > 0 && idx%2 == 0 {
-				loc := []int{mat, submat[idx+1]}
+	for _, m := range matches {
+		loc := m.loc
 
-				converted, err := re2Loc(txt, loc)
-				if err != nil {
-					return alerts, err
-				}
-
-				observed := strings.TrimSpace(converted)
-				expected, msgErr := subMsg(s, (idx/2)-1, observed)
-				if msgErr != nil {
-					return alerts, msgErr
-				}
+		converted, err := re2Loc(txt, loc)
+		if err != nil {
+			return alerts, err
+		}
 
-				same := matchToken(expected, observed, false)
-				if !same && !isMatch(s.exceptRe, observed) {
-					action := s.Fields().Action
-					if action.Name == "replace" && len(action.Params) == 0 {
-						action.Params = strings.Split(expected, "|")
-
-						if s.Capitalize && observed == core.CapFirst(observed) {
-							cased := []string{}
-							for _, param := range action.Params {
-								cased = append(cased, core.CapFirst(param))
-							}
-							action.Params = cased
-						}
-
-						expected = core.ToSentence(action.Params, "or")
-						// NOTE: For backwards-compatibility, we need to ensure
-						// that we don't double quote.
-						s.Message = convertMessage(s.Message)
-					}
+		observed := strings.TrimSpace(converted)
+		expected, msgErr := subMsg(s, m.index, observed)
+		if msgErr != nil {
+			return alerts, msgErr
+		}
 
-					a, aerr := makeAlert(s.Definition, loc, txt, cfg)
-					if aerr != nil {
-						return alerts, aerr
+		same := matchToken(expected, observed, false)
+		if !same && !isMatch(s.exceptRe, observed) {
+			message := s.Message
+			action := s.Fields().Action
+			if action.Name == "replace" && len(action.Params) == 0 {
+				action.Params = strings.Split(expected, "|")
+
+				if s.Capitalize && observed == core.CapFirst(observed) {
+					cased := []string{}
+					for _, param := range action.Params {
+						cased = append(cased, core.CapFirst(param))
 					}
+					action.Params = cased
+				}
 
-					a.Message, a.Description = formatMessages(s.Message,
-						s.Description, expected, observed)
-					a.Action = action
+				expected = core.ToSentence(action.Params, "or")
+				// NOTE: For backwards-compatibility, we need to ensure
+				// that we don't double quote.
+				message = convertMessage(message)
+			}
 
-					alerts = append(alerts, a)
+			if s.ranked[m.index] {
+				// `simplify` entries always list the preferred phrase first,
+				// followed by its alternatives in order of preference.
+				params := action.Params
+				if len(params) == 0 {
+					params = strings.Split(expected, "|")
 				}
+				expected = rankedSentence(params)
+				message = convertMessage(message)
+			}
+
+			a, aerr := makeAlert(s.Definition, loc, txt, cfg)
+			if aerr != nil {
+				return alerts, aerr
 			}
+
+			a.Message, a.Description = formatMessages(message,
+				s.Description, expected, observed)
+			a.Action = action
+			a.Hide = s.isSic(sic, txt, loc)
+			a.Inline = s.Nonword && s.Inline
+
+			alerts = append(alerts, a)
 		}
 	}
 
 	return alerts, nil
 }
 
+// matches returns the (rune) location of each match, along with the index of
+// the term that it matched.
+func (s Substitution) matches(txt string) []literalMatch {
+	if s.literal != nil {
+		return s.literal.findAll(txt)
+	}
+
+	// Leave early if we can to avoid calling `FindAllStringSubmatchIndex`
+	// unnecessarily.
+	if !s.pattern.MatchStringStd(txt) {
+		return nil
+	}
+
+	matches := []literalMatch{}
+	for _, submat := range s.pattern.FindAllStringSubmatchIndex(txt, -1) {
+		for idx, mat := range submat {
+			if mat != -1 && idx > 0 && idx%2 == 0 {
+				matches = append(matches, literalMatch{
+					loc: []int{mat, submat[idx+1]}, index: (idx / 2) - 1})
+			}
+		}
+	}
+
+	return matches
+}
+
+// isSic determines if the given (rune) location has been exempted by a sic
+// marker.
+//
+// Exempted matches are returned as hidden alerts so that any later
+// occurrences are located correctly.
+func (s Substitution) isSic(sic [][]int, txt string, loc []int) bool {
+	if len(sic) == 0 {
+		return false
+	}
+	runes := []rune(txt)
+	start := len(string(runes[:loc[0]]))
+	return inRanges(sic, start, start+len(string(runes[loc[0]:loc[1]])))
+}
+
 // Fields provides access to the internal rule definition.
 func (s Substitution) Fields() Definition {
 	return s.Definition
@@ -164,7 +278,97 @@ func (s Substitution) Fields() Definition {
 
 // Pattern is the internal regex pattern used by this rule.
 func (s Substitution) Pattern() string {
-	return s.pattern.String()
+	return s.regex
+}
+
+// termVariants returns a pattern that matches the hyphenated, spaced, and
+// closed forms of a literal term, such as "e-mail", "e mail", and "email".
+//
+// Terms that contain other regex syntax are returned as-is since we can't
+// safely determine their word boundaries.
+func termVariants(term string) string {
+	if !reLiteralTerm.MatchString(term) {
+		return term
+	}
+
+	parts := strings.FieldsFunc(term, func(r rune) bool {
+		return r == '-' || r == ' '
+	})
+
+	return strings.Join(parts, `(?:-|\s+)?`)
+}
+
+// termInflections returns a pattern that matches the inflected forms of each
+// word of a literal term, such as "makes a decision" for "make a decision".
+//
+// Short words (e.g., "to" or "be") are left as-is since their inflections are
+// mostly irregular.
+func termInflections(term string, variants bool) string {
+	if !reLiteralTerm.MatchString(term) {
+		return term
+	}
+
+	sep := " "
+	parts := strings.Fields(term)
+	if variants {
+		sep = `(?:-|\s+)?`
+		parts = strings.FieldsFunc(term, func(r rune) bool {
+			return r == '-' || r == ' '
+		})
+	}
+
+	for i, part := range parts {
+		if len([]rune(part)) < 4 || strings.Contains(part, "'") {
+			continue
+		}
+
+		forms := []string{part}
+		seen := map[string]bool{part: true}
+		for _, suffix := range inflections {
+			if !strings.HasSuffix(part, suffix[1]) {
+				continue
+			}
+			form := strings.TrimSuffix(part, suffix[1]) + suffix[0]
+			if !seen[form] {
+				forms = append(forms, form)
+				seen[form] = true
+			}
+		}
+		parts[i] = `(?:` + strings.Join(forms, "|") + `)`
+	}
+
+	return strings.Join(parts, sep)
+}
+
+// simplifyTerms inverts a `simplify` map, returning the preferred phrase of
+// each of its terms.
+func simplifyTerms(simplify map[string][]string, swap map[string]string, path string) (map[string]string, error) {
+	terms := map[string]string{}
+	for preferred, phrases := range simplify {
+		for _, phrase := range phrases {
+			if _, found := swap[phrase]; found {
+				return nil, core.NewE201FromTarget(
+					fmt.Sprintf("'%s' is in both 'swap' and 'simplify'.", phrase),
+					phrase, path)
+			} else if other, found := terms[phrase]; found && other != preferred {
+				return nil, core.NewE201FromTarget(
+					fmt.Sprintf("'%s' is simplified to both '%s' and '%s'.", phrase, other, preferred),
+					phrase, path)
+			}
+			terms[phrase] = preferred
+		}
+	}
+	return terms, nil
+}
+
+// rankedSentence lists the preferred phrase followed by its alternatives, in
+// order of preference: "'to'" or "'to' (alternatively, 'so that' or 'for')".
+func rankedSentence(params []string) string {
+	if len(params) == 1 {
+		return core.ToSentence(params, "or")
+	}
+	return fmt.Sprintf("'%s' (alternatively, %s)",
+		params[0], core.ToSentence(params[1:], "or"))
 }
 
 func convertMessage(s string) string {
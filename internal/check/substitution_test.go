@@ -1,6 +1,7 @@
 package check
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/errata-ai/vale/v3/internal/core"
@@ -126,3 +127,98 @@ func TestRegexEscapedParens(t *testing.T) {
 		t.Fatalf("Expected message `%s`, got `%s`", expected, message)
 	}
 }
+
+func TestSubstitutionVariants(t *testing.T) {
+	swap := map[string]interface{}{
+		"extends":    "substitution",
+		"name":       "Test.Variants",
+		"message":    "Use '%s' instead of '%s'.",
+		"ignorecase": true,
+		"variants":   true,
+		"swap": map[string]string{
+			"e-mail":   "email",
+			"log in":   "sign in",
+			"(?:foo)s": "bar",
+		},
+	}
+
+	rule, err := makeSubstitution(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "Send an E-mail, an e mail, or an\nemail. Then log-in, login, or log in. Remail is fine."
+	alerts, err := rule.Run(nlp.NewBlock(text, text, "text"), &core.File{}, &core.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches := []string{}
+	for _, a := range alerts {
+		matches = append(matches, a.Match)
+	}
+
+	expected := []string{"E-mail", "e mail", "log-in", "login", "log in"}
+	if strings.Join(matches, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, matches)
+	}
+}
+
+func TestSubstitutionSimplify(t *testing.T) {
+	swap := map[string]interface{}{
+		"extends":    "substitution",
+		"name":       "Test.Simplify",
+		"message":    "Use '%s' instead of '%s'.",
+		"ignorecase": true,
+		"stem":       true,
+		"simplify": map[string][]string{
+			"to|so that": {"in order to", "in order for"},
+			"decide":     {"make a decision"},
+		},
+		"swap": map[string]string{
+			"utilize": "use",
+		},
+	}
+
+	rule, err := makeSubstitution(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "We made changes in order for you to utilize it. " +
+		"She makes a decision in order to be safe, and they're making a decision."
+	alerts, err := rule.Run(nlp.NewBlock(text, text, "text"), &core.File{}, &core.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []string{}
+	for _, a := range alerts {
+		messages = append(messages, a.Message)
+	}
+
+	expected := []string{
+		"Use 'to' (alternatively, 'so that') instead of 'in order for'.",
+		"Use 'use' instead of 'utilize'.",
+		"Use 'decide' instead of 'makes a decision'.",
+		"Use 'to' (alternatively, 'so that') instead of 'in order to'.",
+		"Use 'decide' instead of 'making a decision'.",
+	}
+	if strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v, got %v", expected, messages)
+	}
+}
+
+func TestSubstitutionSimplifyConflict(t *testing.T) {
+	swap := map[string]interface{}{
+		"extends":  "substitution",
+		"name":     "Test.Conflict",
+		"message":  "Use '%s' instead of '%s'.",
+		"simplify": map[string][]string{"to": {"in order to"}},
+		"swap":     map[string]string{"in order to": "for"},
+	}
+
+	if _, err := makeSubstitution(swap); err == nil {
+		t.Fatal("Expected an error for a term in both 'swap' and 'simplify'")
+	}
+}
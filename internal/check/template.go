@@ -0,0 +1,191 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// ruleTemplate is the starting point of a new rule: a default message and
+// the extension point's own keys, pre-populated with placeholder values that
+// load without errors.
+type ruleTemplate struct {
+	message string
+	body    string
+}
+
+var ruleTemplates = map[string]ruleTemplate{
+	"capitalization": {
+		message: "'%s' should be in title case.",
+		body: `scope: heading
+# One of '$title', '$sentence', '$lower', '$upper', or a pattern.
+match: $title
+`,
+	},
+	"conditional": {
+		message: "'%s' has no definition.",
+		body: `# A pattern whose matches must be preceded by a match of 'second'.
+first: '\b([A-Z]{3,5})\b'
+second: '(?:\b[A-Z][a-z]+ )+\(([A-Z]{3,5})\)'
+`,
+	},
+	"consistency": {
+		message: "Inconsistent spelling of '%s'.",
+		body: `either:
+  advisor: adviser
+`,
+	},
+	"existence": {
+		message: "Consider removing '%s'.",
+		body: `ignorecase: true
+tokens:
+  - very
+`,
+	},
+	"occurrence": {
+		message: "Try to keep sentences short (< 25 words).",
+		body: `scope: sentence
+max: 25
+token: \b(\w+)\b
+`,
+	},
+	"repetition": {
+		message: "'%s' is repeated!",
+		body: `alpha: true
+tokens:
+  - '[^\s]+'
+`,
+	},
+	"substitution": {
+		message: "Use '%s' instead of '%s'.",
+		body: `ignorecase: true
+swap:
+  utilize: use
+`,
+	},
+	"readability": {
+		message: "Try to keep the Flesch-Kincaid grade level (%s) below 8.",
+		body: `grade: 8
+metrics:
+  - Flesch-Kincaid
+`,
+	},
+	"spelling": {
+		message: "Did you really mean '%s'?",
+		body: `ignore:
+  - vocab.txt
+`,
+	},
+	"sequence": {
+		message: "Use 'a' instead of 'an' before '%s'.",
+		body: `tokens:
+  - tag: DT
+    pattern: an
+  - pattern: '[^aeiou]\w+'
+`,
+	},
+	"metric": {
+		message: "Try to keep the document under 1,000 words (%s).",
+		body: `formula: words > 1000
+`,
+	},
+	"script": {
+		message: "Avoid exclamation points.",
+		body: `# Inline Tengo source or the name of a file in 'config/scripts'.
+script: |
+  text := import("text")
+  matches := []
+  for loc in text.re_find("!", scope, -1) {
+    matches = append(matches, {begin: loc[0].begin, end: loc[0].end})
+  }
+`,
+	},
+	"placeholder": {
+		message: "Replace the placeholder '%s'.",
+		body: `markers:
+  - TODO
+  - TBD
+`,
+	},
+	"punctuation": {
+		message: "%s",
+		body: `quotes: curly
+`,
+	},
+	"jargon": {
+		message: "'%s' may be unfamiliar to some readers.",
+		body: `min: 4
+`,
+	},
+	"duplicates": {
+		message: "'%s' is repeated!",
+		body: `ignorecase: true
+`,
+	},
+	"grammar": {
+		message: "Avoid starting a sentence with '%s'.",
+		body: `scope: sentence
+# A pattern matching the part-of-speech tag of each sentence's first word.
+starts: CC
+`,
+	},
+	"agreement": {
+		message: "Check the agreement in '%s' (expected %s).",
+		body: `scope: sentence
+# Report 'a'/'an' mismatches ("a hour") and quantifier mismatches ("many file").
+articles: true
+quantifiers: true
+`,
+	},
+	"changelog": {
+		message: "%s",
+		body: `scope: raw
+past: true
+`,
+	},
+	"pipeline": {
+		message: "Avoid '%s'.",
+		body: `steps:
+  - extends: existence
+    tokens:
+      - very
+`,
+	},
+}
+
+// RuleTemplate returns the skeleton of a new rule that extends the given
+// extension point, including its required keys.
+//
+// An empty `message` uses a default one suited to the extension point.
+func RuleTemplate(extends, message, level string) ([]byte, error) {
+	tmpl, ok := ruleTemplates[extends]
+	if !ok {
+		return nil, fmt.Errorf("'%s' must be one of %v", extends, extensionPoints)
+	} else if !core.StringInSlice(level, core.AlertLevels) {
+		return nil, fmt.Errorf("'%s' must be one of %v", level, core.AlertLevels)
+	}
+
+	if message == "" {
+		message = tmpl.message
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "extends: %s\n", extends)
+	fmt.Fprintf(&b, "message: %s\n", quoteScalar(message))
+	fmt.Fprintf(&b, "level: %s\n", level)
+	b.WriteString(tmpl.body)
+
+	return FormatRule([]byte(b.String()))
+}
+
+// ExtensionPoints returns the names of the extension points that rules can
+// extend.
+func ExtensionPoints() []string {
+	return append([]string{}, extensionPoints...)
+}
+
+// quoteScalar double-quotes a YAML scalar, escaping it as needed.
+func quoteScalar(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
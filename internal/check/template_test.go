@@ -0,0 +1,68 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestRuleTemplates(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mgr, err := NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for _, extends := range ExtensionPoints() {
+		src, terr := RuleTemplate(extends, "", "warning")
+		if terr != nil {
+			t.Fatalf("%s: %v", extends, terr)
+		}
+
+		path := filepath.Join(dir, extends+".yml")
+		if err = os.WriteFile(path, src, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		name := "Test." + extends
+		if err = mgr.AddRuleFromFile(name, path); err != nil {
+			t.Errorf("%s: the template doesn't load: %v\n%s", extends, err, src)
+		}
+	}
+
+	text := "This is very important!"
+	alerts, err := mgr.Rules()["Test.script"].Run(nlp.NewBlock("", text, "text"), &core.File{}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(alerts) != 1 {
+		t.Errorf("Expected one alert from the script template, got %v", alerts)
+	}
+}
+
+func TestRuleTemplateMessage(t *testing.T) {
+	src, err := RuleTemplate("existence", `Don't use "%s".`, "error")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"extends: existence\n", `message: Don't use "%s".` + "\n", "level: error\n"} {
+		if !strings.Contains(string(src), line) {
+			t.Errorf("Expected %q in:\n%s", line, src)
+		}
+	}
+
+	if _, err = RuleTemplate("nonexistent", "", "warning"); err == nil {
+		t.Error("Expected an error for an unknown extension point")
+	} else if _, err = RuleTemplate("existence", "", "fatal"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
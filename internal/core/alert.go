@@ -1,5 +1,7 @@
 package core
 
+import "strings"
+
 // AlertLevels holds the possible values for "level" in an external rule.
 var AlertLevels = []string{"suggestion", "warning", "error"}
 
@@ -28,8 +30,14 @@ type Alert struct {
 	Severity    string   // 'suggestion', 'warning', or 'error'
 	Match       string   // the actual matched text
 	Line        int      // the source line
-	Limit       int      `json:"-"` // the max times to report
-	Hide        bool     `json:"-"` // should we hide this alert?
+	Suggestions []string `json:",omitempty"` // ranked replacements, if any
+	Key         string   `json:",omitempty"` // the resource key, if any
+	ID          string   `json:",omitempty"` // a stable ID (see `--assign-ids`)
+	FirstSeen   string   `json:",omitempty"` // the date this alert first appeared
+	Runs        int      `json:",omitempty"` // the number of runs it's appeared in
+	Limit       int      `json:"-"`          // the max times to report
+	Hide        bool     `json:"-"`          // should we hide this alert?
+	Inline      bool     `json:"-"`          // may span inline markup (see `inline`)
 }
 
 // FormatAlert ensures that all required fields have data.
@@ -54,8 +62,18 @@ func (a ByPosition) Less(i, j int) bool {
 
 	if ai.Line != aj.Line {
 		return ai.Line < aj.Line
+	} else if ai.Span[0] != aj.Span[0] {
+		return ai.Span[0] < aj.Span[0]
+	}
+
+	// Alerts at the same position are found by rules that run in an
+	// arbitrary order, so we break ties to keep the output stable.
+	if ai.Span[1] != aj.Span[1] {
+		return ai.Span[1] < aj.Span[1]
+	} else if ai.Check != aj.Check {
+		return ai.Check < aj.Check
 	}
-	return ai.Span[0] < aj.Span[0]
+	return ai.Message < aj.Message
 }
 
 // ByName sorts Files by their path.
@@ -67,3 +85,43 @@ func (a ByName) Less(i, j int) bool {
 	ai, aj := a[i], a[j]
 	return ai.Path < aj.Path
 }
+
+// redacted replaces matched text when `RedactContext` is enabled.
+const redacted = "[REDACTED]"
+
+// contextQuotes are the pairs of quotation marks that a message may put
+// around its match.
+var contextQuotes = [][2]string{{"'", "'"}, {`"`, `"`}, {"‘", "’"}, {"“", "”"}}
+
+// LimitContext restricts how much of the linted document appears in an
+// Alert, according to the `ContextChars` and `RedactContext` options.
+//
+// The matched text is shortened (or redacted) in `Match` and, where it's
+// quoted, in `Message` -- other occurrences of the same text in a message
+// (e.g., "use" in "Use 'use' instead of 'utilize'.") are the rule's own
+// wording. Redaction also drops any `Suggestions`, which are derived from
+// the matched text.
+func LimitContext(a *Alert, cfg *Config) {
+	if cfg.RedactContext {
+		a.Suggestions = nil
+	}
+	if a.Match == "" {
+		return
+	}
+
+	replacement := a.Match
+	if cfg.RedactContext {
+		replacement = redacted
+	} else if runes := []rune(a.Match); cfg.ContextChars > 0 && len(runes) > cfg.ContextChars {
+		replacement = string(runes[:cfg.ContextChars]) + "…"
+	}
+
+	for _, q := range contextQuotes {
+		quoted := q[0] + a.Match + q[1]
+		if strings.Contains(a.Message, quoted) {
+			a.Message = strings.Replace(a.Message, quoted, q[0]+replacement+q[1], 1)
+			break
+		}
+	}
+	a.Match = replacement
+}
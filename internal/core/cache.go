@@ -0,0 +1,52 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+// CachePath returns the path to `name` in Vale's cache directory, creating
+// any parent directories (unless writes are disabled; see `ReadOnly`).
+//
+// Unlike the state directory (see `StatePath`), the cache only holds data
+// that can be safely deleted; it may be overridden by setting
+// `VALE_CACHE_PATH`.
+func CachePath(name string) (string, error) {
+	if fromEnv, hasEnv := os.LookupEnv("VALE_CACHE_PATH"); hasEnv {
+		path := filepath.Join(fromEnv, name)
+		if ReadOnly {
+			return path, nil
+		}
+		return path, os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	}
+	return xdgPath(xdg.CacheHome, filepath.Join("vale", name), xdg.CacheFile)
+}
+
+// ResultCachePath returns the path of the cached results (see `--no-cache`)
+// for the project configured by `cfg`.
+//
+// Each project -- identified by its `.vale.ini` file or, without one, the
+// current directory -- has its own cache, so that its entries aren't
+// discarded by runs in other projects.
+func ResultCachePath(cfg *Config) (string, error) {
+	project := cfg.RootINI
+	if project == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		project = cwd
+	}
+
+	abs, err := filepath.Abs(project)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(abs))
+	return CachePath(filepath.Join("results", hex.EncodeToString(sum[:8])+".json"))
+}
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResultCachePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("VALE_CACHE_PATH", dir)
+
+	a, err := ResultCachePath(&Config{RootINI: filepath.Join("a", ".vale.ini")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ResultCachePath(&Config{RootINI: filepath.Join("b", ".vale.ini")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a == b {
+		t.Errorf("Expected each project to have its own cache, got '%s' for both", a)
+	} else if !strings.HasPrefix(a, filepath.Join(dir, "results")) {
+		t.Errorf("Expected '%s' to be in VALE_CACHE_PATH", a)
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/errata-ai/ini"
+	"github.com/errata-ai/regexp2"
 
 	"github.com/errata-ai/vale/v3/internal/glob"
 )
@@ -39,16 +41,20 @@ var (
 	IgnoreDir = filepath.Join(ConfigDir, "ignore")
 	ActionDir = filepath.Join(ConfigDir, "actions")
 	ScriptDir = filepath.Join(ConfigDir, "scripts")
+	TaggerDir = filepath.Join(ConfigDir, "taggers")
 )
 
 // ConfigDirs is a list of all directories that contain user-defined, non-style
 // configuration files.
-var ConfigDirs = []string{VocabDir, DictDir, TmplDir, IgnoreDir, ActionDir, ScriptDir}
+var ConfigDirs = []string{VocabDir, DictDir, TmplDir, IgnoreDir, ActionDir, ScriptDir, TaggerDir}
 
 // ConfigVars is a list of all supported environment variables.
 var ConfigVars = map[string]string{
-	"VALE_CONFIG_PATH": "Override the default search process by specifying a .vale.ini file.",
-	"VALE_STYLES_PATH": "Specify the location of the default StylesPath.",
+	"VALE_CONFIG_PATH":    "Override the default search process by specifying a .vale.ini file.",
+	"VALE_CONFIG_CONTENT": "Override the default search process by specifying the contents of a .vale.ini file.",
+	"VALE_STYLES_PATH":    "Specify the location of the default StylesPath.",
+	"VALE_STATE_PATH":     "Specify the location of the state (e.g., lock files) shared between runs.",
+	"VALE_CACHE_PATH":     "Specify the location of the cached results used to skip unchanged files.",
 }
 
 // ConfigNames is a list of all possible configuration file names.
@@ -123,7 +129,7 @@ func getConfigAsset(target string, paths, dirs []string) string {
 // NOTE: if this file does not exist *and* the user has not specified a
 // project-specific configuration file, Vale raises an error.
 func DefaultConfig() (string, error) {
-	root, err := xdg.ConfigFile("vale/.vale.ini")
+	root, err := xdgPath(xdg.ConfigHome, "vale/.vale.ini", xdg.ConfigFile)
 	if err != nil {
 		return "", fmt.Errorf("failed to find default config: %w", err)
 	}
@@ -139,7 +145,7 @@ func DefaultStylesPath() (string, error) {
 		return fromEnv, nil
 	}
 
-	styles, err := xdg.DataFile("vale/styles/config.yml")
+	styles, err := xdgPath(xdg.DataHome, "vale/styles/config.yml", xdg.DataFile)
 	if err != nil {
 		return "", fmt.Errorf("failed to find default styles: %w", err)
 	}
@@ -147,6 +153,12 @@ func DefaultStylesPath() (string, error) {
 	return filepath.Dir(styles), nil
 }
 
+// Profiles are the run-mode presets accepted by `--profile`.
+//
+// The `quick` profile skips expensive rules (such as spelling) and is meant
+// for running on every keystroke; `thorough` runs every rule.
+var Profiles = []string{"quick", "thorough"}
+
 // CLIFlags holds the values that are defined at runtime by the user.
 //
 // For example, `vale --minAlertLevel=error`.
@@ -155,10 +167,20 @@ type CLIFlags struct {
 	Built        string
 	Glob         string
 	InExt        string
+	Format       string
 	Output       string
 	Path         string
 	Sources      string
 	Filter       string
+	Checkpoint   string
+	Why          string
+	Profile      string
+	Webhook      string
+	Out          string
+	Shard        string
+	Color        string
+	Theme        string
+	Shuffle      int64
 	Local        bool
 	NoExit       bool
 	Normalize    bool
@@ -170,6 +192,87 @@ type CLIFlags struct {
 	Version      bool
 	Help         bool
 	IgnoreGlobal bool
+	Check        bool
+	Compare      bool
+	Fast         bool
+	Editor       bool
+	Verbose      bool
+	Stats        bool
+	PrintScopes  bool
+	Batch        bool
+	Fix          bool
+	NoColor      bool
+	NoCache      bool
+	AssignIDs    bool
+
+	// Baseline hides the alerts recorded in the project's
+	// `.vale-baseline.json` file, while UpdateBaseline records them.
+	Baseline       bool
+	UpdateBaseline bool
+
+	// RetryFailed only lints the files that had alerts (or crashed) the
+	// last time that they were linted.
+	RetryFailed bool
+
+	// Sitemap, MaxPages, CrawlDelay, and CrawlWorkers control `vale crawl`:
+	// the sitemap listing the pages to lint, the maximum number of pages,
+	// the minimum time between requests, and the number of concurrent
+	// requests.
+	Sitemap      string
+	MaxPages     int
+	CrawlDelay   time.Duration
+	CrawlWorkers int
+
+	// Tracker, TrackerURL, Project, GroupBy, IssueTemplate, and DryRun
+	// control `vale export-issues`: the issue tracker ("github" or "jira"),
+	// its API's base URL, the repository (`owner/name`) or project key to
+	// file issues in, how to group alerts into issues ("file" or "rule"),
+	// a template for each issue's body, and whether to only print the
+	// issues instead of filing them.
+	Tracker       string
+	TrackerURL    string
+	Project       string
+	GroupBy       string
+	IssueTemplate string
+	DryRun        bool
+
+	// Extends, Level, and Message control `vale new-rule`: the new rule's
+	// extension point, level, and message.
+	Extends string
+	Level   string
+	Message string
+
+	// Host and Port are the address that `vale serve` listens on.
+	Host string
+	Port int
+
+	// Selector is a CSS selector for the content area of fetched pages
+	// (e.g., `main`); see `lint.LintURL`.
+	Selector string
+
+	// MaxDuration is a soft time budget for a run: once it's exceeded, no
+	// new files are linted and the results are reported as partial.
+	MaxDuration time.Duration
+
+	// Jobs is the number of files to lint at once; zero uses one worker per
+	// CPU.
+	Jobs int
+
+	// CrashBundle is a directory in which to write diagnostics for each file
+	// whose linting panicked.
+	CrashBundle string
+
+	// NoWrite disables every write to the filesystem (see `ReadOnly`).
+	NoWrite bool
+
+	// StrictPackages refuses to sync any downloaded package that isn't
+	// pinned to a checksum or signed (see the `[packages]` section).
+	StrictPackages bool
+
+	// Deterministic fixes the order in which rules run and vocabularies are
+	// read; Shuffle, on the other hand, randomizes the order in which rules
+	// run (using the given seed) to surface order-dependent behavior.
+	Deterministic bool
 }
 
 // Config holds the configuration values from both the CLI and `.vale.ini`.
@@ -193,6 +296,7 @@ type Config struct {
 	Stylesheets       map[string]string          // XSLT stylesheet
 	TokenIgnores      map[string][]string        // A list of tokens to ignore
 	CommentDelimiters map[string][2]string       // Strings to treat as comment delimiters. Indicates the start and end delimiters.
+	MarkdownFlavors   map[string]string          // A map of syntax to Markdown flavor
 	WordTemplate      string                     // The template used in YAML -> regexp list conversions
 	RootINI           string                     // the path to the project's .vale.ini file
 	Paths             []string                   // A list of paths to search for styles
@@ -201,11 +305,60 @@ type Config struct {
 	AcceptedTokens []string `json:"-"` // Project-specific vocabulary (okay)
 	RejectedTokens []string `json:"-"` // Project-specific vocabulary (avoid)
 
+	// AcceptedPatterns are regular expressions (from a vocabulary's
+	// `patterns.txt`) for tokens that every rule accepts -- e.g., version
+	// strings or ticket IDs.
+	AcceptedPatterns []string `json:"-"`
+
 	FallbackPath string               `json:"-"`
 	SecToPat     map[string]glob.Glob `json:"-"`
 	Styles       []string             `json:"-"`
 
-	NLPEndpoint string // An external API to call for NLP-related work.
+	NLPEndpoint string   // An external API to call for NLP-related work.
+	SicMarkers  []string // Markers that exempt the preceding word (e.g., `[sic]`)
+	Webhook     string   // An endpoint to POST results to after each run.
+
+	// Bibliography lists the BibTeX or CSL JSON files that every document's
+	// citations may refer to (see `Vale.Citations`).
+	Bibliography []string
+
+	// DetectLanguage is the unit -- "document" or "paragraph" -- whose
+	// language is detected (overriding `Lang`), if any.
+	DetectLanguage string
+
+	// Languages maps a language code to the styles that apply to text
+	// detected as being in that language (see the `[languages]` section).
+	Languages map[string][]string
+
+	// Outputs maps a rule, style, or level to the sinks that its alerts are
+	// sent to (see the `[outputs]` section).
+	Outputs map[string][]string
+
+	// Rollout maps a rule or style to the percentage of files that it's
+	// active for (see the `[rollout]` section).
+	Rollout map[string]int
+
+	// Policy maps an alert level to the number of days after which its
+	// alerts are escalated to the next level (see the `[policy]` section).
+	Policy map[string]int
+
+	// Groups maps a name to the rules that it enables, disables, or levels
+	// as one unit (see the `[groups]` section).
+	Groups map[string][]string
+
+	// Attributes maps the name of a document attribute or variable (e.g.,
+	// AsciiDoc's `{product-name}`) to the value that it's replaced by before
+	// linting (see the `[attributes]` section).
+	Attributes map[string]string
+
+	MinValeVersion string // The minimum version of Vale the project requires
+
+	ContextChars  int  // The max number of matched characters to include in output
+	RedactContext bool // Omit all matched text from output
+
+	// RequireSuppressReason requires each in-text comment that turns off
+	// linting to include a reason (e.g., `vale off: quoting vendor text`).
+	RequireSuppressReason bool
 
 	// Command-line configuration
 	Flags *CLIFlags `json:"-"`
@@ -222,6 +375,12 @@ func NewConfig(flags *CLIFlags) (*Config, error) {
 	cfg.Flags = flags
 	cfg.Formats = make(map[string]string)
 	cfg.Asciidoctor = make(map[string]string)
+	cfg.Outputs = make(map[string][]string)
+	cfg.Rollout = make(map[string]int)
+	cfg.Policy = make(map[string]int)
+	cfg.Groups = make(map[string][]string)
+	cfg.Attributes = make(map[string]string)
+	cfg.Languages = make(map[string][]string)
 	cfg.GChecks = make(map[string]bool)
 	cfg.MinAlertLevel = 1
 	cfg.RuleToLevel = make(map[string]string)
@@ -231,9 +390,11 @@ func NewConfig(flags *CLIFlags) (*Config, error) {
 	cfg.Stylesheets = make(map[string]string)
 	cfg.TokenIgnores = make(map[string][]string)
 	cfg.CommentDelimiters = make(map[string][2]string)
+	cfg.MarkdownFlavors = make(map[string]string)
 	cfg.FormatToLang = make(map[string]string)
 	cfg.Paths = []string{}
 	cfg.ConfigFiles = []string{}
+	cfg.SicMarkers = []string{"[sic]", "(sic)"}
 
 	found, _ := DefaultStylesPath()
 	if !flags.IgnoreGlobal && IsDir(found) {
@@ -297,6 +458,18 @@ func (c *Config) StylesPath() string {
 	return ""
 }
 
+// TaggerDirs returns the directories that may contain part-of-speech
+// tagging models for non-English languages.
+func (c *Config) TaggerDirs() []string {
+	dirs := []string{}
+	for _, p := range c.Paths {
+		if dir := filepath.Join(p, TaggerDir); IsDir(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
 func (c *Config) SearchPaths() []string {
 	if len(c.Paths) == 0 {
 		// This represents the 'no value set' case.
@@ -330,6 +503,32 @@ func (c *Config) addWordList(r io.Reader, accept bool) error {
 	return scanner.Err()
 }
 
+// AddPatternFile adds the accepted patterns from a vocabulary's
+// `patterns.txt` file, one regular expression per line.
+func (c *Config) AddPatternFile(name string) error {
+	fd, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	line := 0
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		line++
+
+		pattern := strings.TrimSpace(scanner.Text())
+		if len(pattern) == 0 || strings.HasPrefix(pattern, "# ") {
+			continue
+		} else if _, err = regexp2.CompileStd(pattern); err != nil {
+			return NewE201FromPosition(err.Error(), name, line)
+		}
+		c.AcceptedPatterns = append(c.AcceptedPatterns, pattern)
+	}
+
+	return scanner.Err()
+}
+
 func (c *Config) String() string {
 	b, _ := json.MarshalIndent(c, "", "  ")
 	return string(b)
@@ -339,7 +538,7 @@ func (c *Config) String() string {
 func GetPackages(src string) ([]string, error) {
 	packages := []string{}
 
-	uCfg, err := ini.Load(src)
+	uCfg, err := ini.Load(configSource(src))
 	if err != nil {
 		return packages, err
 	}
@@ -348,6 +547,29 @@ func GetPackages(src string) ([]string, error) {
 	return core.Key("Packages").Strings(","), nil
 }
 
+// GetPackagePins reads the `[packages]` section of a `.vale.ini` file, which
+// maps a package's name to the checksum and signatures its archive must
+// have:
+//
+//	[packages]
+//	Google = sha256:4a7c...
+//	Acme = sha256:9f2e..., minisign:RWQ...
+func GetPackagePins(src string) (map[string][]string, error) {
+	pins := map[string][]string{}
+
+	uCfg, err := ini.Load(configSource(src))
+	if err != nil {
+		return pins, err
+	}
+
+	section := uCfg.Section("packages")
+	for _, k := range section.KeyStrings() {
+		pins[k] = section.Key(k).Strings(",")
+	}
+
+	return pins, nil
+}
+
 func pipeConfig(cfg *Config) ([]string, error) {
 	var sources []string
 
@@ -3,6 +3,7 @@ package core
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -120,3 +121,25 @@ func TestFallbackToDefault(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestAddPatternFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.txt")
+	if err := os.WriteFile(path, []byte("# Ticket IDs\nJIRA-\\d+\n\n[0-9a-f]{8}(?:-[0-9a-f]{4}){3}-[0-9a-f]{12}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewConfig(&CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	} else if err = cfg.AddPatternFile(path); err != nil {
+		t.Fatal(err)
+	} else if len(cfg.AcceptedPatterns) != 2 {
+		t.Errorf("Expected 2 patterns, got %v", cfg.AcceptedPatterns)
+	}
+
+	if err = os.WriteFile(path, []byte("JIRA-\\d+\n(unclosed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	} else if err = cfg.AddPatternFile(path); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("Expected an error on line 2, got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// editorConfigSection is a single `[pattern]` section of an `.editorconfig`
+// file.
+type editorConfigSection struct {
+	pattern string
+	props   map[string]string
+}
+
+// editorConfigFile is a parsed `.editorconfig` file.
+type editorConfigFile struct {
+	root     bool
+	sections []editorConfigSection
+}
+
+var editorConfigs = map[string]*editorConfigFile{}
+var editorConfigMu sync.Mutex
+
+// EditorConfig returns the EditorConfig properties (e.g., `charset`) that
+// apply to the file at `path`.
+//
+// As specified by EditorConfig, we search the file's directory and each of
+// its parents until we find a file with `root = true`; closer files take
+// precedence.
+func EditorConfig(path string) map[string]string {
+	props := map[string]string{}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return props
+	}
+
+	var found []*editorConfigFile
+	var dirs []string
+
+	dir := filepath.Dir(abs)
+	for {
+		if ec := readEditorConfig(filepath.Join(dir, ".editorconfig")); ec != nil {
+			found = append(found, ec)
+			dirs = append(dirs, dir)
+			if ec.root {
+				break
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	// Apply the furthest files first so that closer ones take precedence.
+	for i := len(found) - 1; i >= 0; i-- {
+		rel, relErr := filepath.Rel(dirs[i], abs)
+		if relErr != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, sec := range found[i].sections {
+			if matched, _ := doublestar.Match(sec.pattern, rel); matched {
+				for k, v := range sec.props {
+					props[k] = v
+				}
+			}
+		}
+	}
+
+	return props
+}
+
+// readEditorConfig parses (and caches) the `.editorconfig` file at `path`,
+// returning nil if it doesn't exist.
+func readEditorConfig(path string) *editorConfigFile {
+	editorConfigMu.Lock()
+	defer editorConfigMu.Unlock()
+
+	if ec, found := editorConfigs[path]; found {
+		return ec
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		editorConfigs[path] = nil
+		return nil
+	}
+	defer f.Close()
+
+	ec := &editorConfigFile{}
+
+	var sec *editorConfigSection
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			pat := line[1 : len(line)-1]
+			if strings.Contains(pat, "/") {
+				pat = strings.TrimPrefix(pat, "/")
+			} else {
+				// Patterns without a slash match files in any directory.
+				pat = "**/" + pat
+			}
+			ec.sections = append(ec.sections, editorConfigSection{
+				pattern: pat, props: map[string]string{}})
+			sec = &ec.sections[len(ec.sections)-1]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.ToLower(strings.TrimSpace(value))
+
+		if sec == nil {
+			ec.root = key == "root" && value == "true"
+		} else {
+			sec.props[key] = value
+		}
+	}
+
+	editorConfigs[path] = ec
+	return ec
+}
+
+// DecodeCharset converts text in the given EditorConfig `charset` to UTF-8.
+func DecodeCharset(b []byte, charset string) ([]byte, error) {
+	var enc encoding.Encoding
+
+	switch charset {
+	case "", "unset", "utf-8":
+		return b, nil
+	case "utf-8-bom":
+		return bytes.TrimPrefix(b, []byte("\ufeff")), nil
+	case "latin1":
+		enc = charmap.ISO8859_1
+	case "utf-16le":
+		enc = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	case "utf-16be":
+		enc = unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+	default:
+		return b, fmt.Errorf("unsupported charset '%s'", charset)
+	}
+
+	return enc.NewDecoder().Bytes(b)
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditorConfig(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "docs")
+
+	if err := os.MkdirAll(sub, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		filepath.Join(root, ".editorconfig"): "root = true\n\n[*]\ncharset = utf-8\n\n[docs/*.txt]\ncharset = latin1\n",
+		filepath.Join(sub, ".editorconfig"):  "[legacy.{md,txt}]\ncharset = UTF-16LE\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := map[string]string{
+		filepath.Join(root, "README.md"):  "utf-8",
+		filepath.Join(sub, "notes.txt"):   "latin1",
+		filepath.Join(sub, "legacy.txt"):  "utf-16le",
+		filepath.Join(sub, "nested", "a"): "utf-8",
+	}
+
+	for path, expected := range cases {
+		if charset := EditorConfig(path)["charset"]; charset != expected {
+			t.Errorf("%s: expected '%s', got '%s'", path, expected, charset)
+		}
+	}
+}
+
+func TestDecodeCharset(t *testing.T) {
+	decoded, err := DecodeCharset([]byte("Caf\xe9"), "latin1")
+	if err != nil {
+		t.Fatal(err)
+	} else if string(decoded) != "Café" {
+		t.Errorf("Expected 'Café', got '%s'", decoded)
+	}
+
+	if _, err = DecodeCharset([]byte("abc"), "ebcdic"); err == nil {
+		t.Error("Expected an error for an unsupported charset")
+	}
+}
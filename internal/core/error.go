@@ -5,13 +5,15 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/pterm/pterm"
 )
 
+// lineNumber is the style of the line numbers shown with an error's context.
+var lineNumber = pterm.NewStyle(pterm.FgGreen, pterm.Bold)
+
 type lineError struct {
 	content string
 	line    int
@@ -39,10 +41,10 @@ func annotate(file []byte, target string, finder errorCondition) (lineError, err
 			context.span = []int{s, s + len(target)}
 
 			sb.WriteString(
-				fmt.Sprintf("\033[1;32m%4d\033[0m* %s\n", idx, markup))
+				fmt.Sprintf("%s* %s\n", lineNumber.Sprintf("%4d", idx), markup))
 		} else {
 			sb.WriteString(
-				fmt.Sprintf("\033[1;32m%4d\033[0m  %s\n", idx, markup))
+				fmt.Sprintf("%s  %s\n", lineNumber.Sprintf("%4d", idx), markup))
 		}
 		idx++
 	}
@@ -97,7 +99,7 @@ func NewE100(context string, err error) error {
 //
 // <path>:<line>:<start>:<end>
 func NewE201(msg, value, path string, finder errorCondition) error {
-	f, err := os.ReadFile(path)
+	f, err := readConfigFile(path)
 	if err != nil {
 		return NewE100("NewE201", errors.New(msg))
 	}
@@ -107,6 +109,11 @@ func NewE201(msg, value, path string, finder errorCondition) error {
 		return NewE100("NewE201/annotate", err)
 	}
 
+	if isInlineConfig(path) {
+		// There's no file to refer to, so we just name the source.
+		path = filepath.Base(path)
+	}
+
 	title := fmt.Sprintf(
 		"Invalid value [%s:%d:%d]:",
 		filepath.ToSlash(path),
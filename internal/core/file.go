@@ -2,12 +2,14 @@ package core
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/jdkato/twine/summarize"
 
@@ -17,8 +19,20 @@ import (
 
 var commentControlRE = regexp.MustCompile(`^vale (.+\..+|[^.]+) = (YES|NO|on|off)$`)
 
+var commentLevelRE = regexp.MustCompile(`^vale (.+\..+|[^.]+) = (suggestion|warning|error)$`)
+
 var commentStyleRE = regexp.MustCompile(`^vale styles? = (.*)$`)
 
+var commentLangRE = regexp.MustCompile(`^vale lang = ([\w-]+)$`)
+
+// A Suppression is an in-text comment that turns off linting, such as
+// `<!-- vale off: quoting vendor text -->`.
+type Suppression struct {
+	Comment string // the comment, without its reason (e.g., "vale off")
+	Reason  string // the text following the comment's colon, if any
+	Line    int
+}
+
 // A File represents a linted text file.
 type File struct {
 	NLP        nlp.Info          // -
@@ -33,36 +47,72 @@ type File struct {
 	Path       string            // the full path
 	NormedPath string            // the normalized path
 	Transform  string            // XLST transform
+	Flavor     string            // the Markdown flavor to parse with
 	RealExt    string            // actual file extension
 	Checks     map[string]bool   // syntax-specific checks assigned in .vale
 	ChkToCtx   map[string]string // maps a temporary context to a particular check
 	Comments   map[string]bool   // comment control statements
+	Levels     map[string]string // comment-assigned severities
 	Metrics    map[string]int    // count-based metrics
+	Outline    []Heading         // the document's headings, in order
+	Suppressed []Suppression     // the comments that turned off linting
+	Depends    []string          // other files that its alerts depend on (see `AddDependency`)
+	Fragment   bool              // a fragment (e.g., a PR comment) rather than a document
+	LangSet    bool              // `NLP.Lang` was set by a `vale lang` comment
 	history    map[string]int    // -
 	limits     map[string]int    // -
 	simple     bool              // -
+	strict     bool              // suppressions must include a reason
+	cursor     int               // the line of the last suppression
 	Lookup     bool              // -
 }
 
+// A Heading is a single entry in a File's outline.
+type Heading struct {
+	Text    string
+	Level   int
+	Line    int
+	Content bool `json:"-"` // true if the heading is followed by any body content
+}
+
 // NewFile initializes a File.
 func NewFile(src string, config *Config) (*File, error) {
 	var format, ext string
-	var fbytes []byte
-	var lookup bool
-
-	if FileExists(src) {
-		fbytes, _ = os.ReadFile(src)
-		if config.Flags.InExt != ".txt" {
-			ext, format = FormatFromExt(config.Flags.InExt, config.Formats)
-		} else {
-			ext, format = FormatFromExt(src, config.Formats)
+
+	if !FileExists(src) {
+		ext, format = FormatFromExt(config.Flags.InExt, config.Formats)
+		return newFile("stdin"+config.Flags.InExt, []byte(src), ext, format, true, config)
+	}
+
+	fbytes, _ := os.ReadFile(src)
+	if charset := EditorConfig(src)["charset"]; charset != "" {
+		decoded, err := DecodeCharset(fbytes, charset)
+		if err != nil {
+			return &File{}, NewE100(src, err)
 		}
-	} else {
+		fbytes = decoded
+	}
+
+	if config.Flags.InExt != ".txt" {
 		ext, format = FormatFromExt(config.Flags.InExt, config.Formats)
-		fbytes = []byte(src)
-		src = "stdin" + config.Flags.InExt
-		lookup = true
+	} else {
+		ext, format = FormatFromExt(src, config.Formats)
 	}
+
+	return newFile(src, fbytes, ext, format, false, config)
+}
+
+// NewFileFromContent initializes a File whose content is given in memory
+// rather than read from `path`.
+//
+// The file is otherwise treated as if it were stored at `path`: its format
+// and its sections of the config are determined by it.
+func NewFileFromContent(path, content string, config *Config) (*File, error) {
+	ext, format := FormatFromExt(path, config.Formats)
+	return newFile(path, []byte(content), ext, format, false, config)
+}
+
+func newFile(src string, fbytes []byte, ext, format string, lookup bool, config *Config) (*File, error) {
 	filepaths := []string{src}
 
 	normed := ReplaceExt(src, config.Formats)
@@ -126,6 +176,19 @@ func NewFile(src string, config *Config) (*File, error) {
 			break
 		}
 	}
+
+	flavor := "gfm"
+	for _, sec := range sortedKeys(config.MarkdownFlavors) {
+		pat, err := glob.Compile(sec)
+		if err != nil {
+			return &File{}, NewE100(src, err)
+		} else if pat.Match(src) {
+			// NOTE: We check the sections in a fixed order, so that a file
+			// matched by more than one of them always gets the same flavor.
+			flavor = config.MarkdownFlavors[sec]
+			break
+		}
+	}
 	content := Sanitize(string(fbytes))
 
 	// NOTE: We need to perform a clone here because we perform inplace editing
@@ -134,13 +197,23 @@ func NewFile(src string, config *Config) (*File, error) {
 	// See lint/walk.go.
 	lines := strings.SplitAfter(strings.Clone(content), "\n")
 
+	if config.DetectLanguage != "" {
+		if detected := nlp.DetectLanguage(content); detected != "" {
+			lang = detected
+			if styles, found := config.Languages[lang]; found {
+				baseStyles = styles
+			}
+		}
+	}
+
 	file := File{
 		NormedExt: ext, Format: format, RealExt: filepath.Ext(src),
 		BaseStyles: baseStyles, Checks: checks, Lines: lines, Content: content,
 		Comments: make(map[string]bool), history: make(map[string]int),
-		simple: config.Flags.Simple, Transform: transform,
+		Levels: make(map[string]string),
+		simple: config.Flags.Simple, strict: config.RequireSuppressReason, Transform: transform, Flavor: flavor,
 		limits: make(map[string]int), Path: src, Metrics: make(map[string]int),
-		NLP:    nlp.Info{Endpoint: config.NLPEndpoint, Lang: lang},
+		NLP:    nlp.Info{Endpoint: config.NLPEndpoint, Lang: lang, Taggers: config.TaggerDirs()},
 		Lookup: lookup, NormedPath: normed,
 	}
 
@@ -153,6 +226,20 @@ func (f *File) SortedAlerts() []Alert {
 	return f.Alerts
 }
 
+// AddDependency records that f's alerts depend on the contents of the file
+// at `path` (e.g., a linked Markdown file), which may not exist.
+//
+// A stored result (see `--checkpoint`) is only reused if none of its file's
+// dependencies have changed.
+func (f *File) AddDependency(path string) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if !StringInSlice(path, f.Depends) {
+		f.Depends = append(f.Depends, path)
+	}
+}
+
 // ComputeMetrics returns all of f's metrics.
 func (f *File) ComputeMetrics() (map[string]interface{}, error) {
 	params := map[string]interface{}{}
@@ -178,6 +265,13 @@ func (f *File) ComputeMetrics() (map[string]interface{}, error) {
 	params["words"] = doc.NumWords
 	params["polysyllabic_words"] = doc.NumPolysylWords
 	params["syllables"] = doc.NumSyllables
+	params["word_length"] = doc.NumCharacters / doc.NumWords
+
+	total := 0.0
+	for i := 1; i <= 6; i++ {
+		total += float64(f.Metrics["heading.h"+strconv.Itoa(i)])
+	}
+	params["headings"] = total
 
 	return params, nil
 }
@@ -311,16 +405,35 @@ func (f *File) AddAlert(a Alert, blk nlp.Block, lines, pad int, lookup bool) {
 }
 
 // UpdateComments sets a new status based on comment.
+//
+// Comments that turn off linting may end with a reason (e.g., `vale off:
+// quoting vendor text`), which is recorded in `Suppressed`. If the config
+// sets `RequireSuppressReason`, a comment without a reason is reported as an
+// error and has no effect.
 func (f *File) UpdateComments(comment string) {
+	raw := comment
+	comment, reason := splitReason(comment)
+
 	if comment == "vale off" { //nolint:gocritic
-		f.Comments["off"] = true
+		if f.suppress(raw, comment, reason) {
+			f.Comments["off"] = true
+		}
 	} else if comment == "vale on" {
 		f.Comments["off"] = false
+	} else if commentLangRE.MatchString(comment) {
+		f.NLP.Lang = commentLangRE.FindStringSubmatch(comment)[1]
+		f.LangSet = true
 	} else if commentControlRE.MatchString(comment) {
 		check := commentControlRE.FindStringSubmatch(comment)
 		if len(check) == 3 {
-			f.Comments[check[1]] = (check[2] == "NO" || check[2] == "off")
+			off := check[2] == "NO" || check[2] == "off"
+			if !off || f.suppress(raw, comment, reason) {
+				f.Comments[check[1]] = off
+			}
 		}
+	} else if commentLevelRE.MatchString(comment) {
+		check := commentLevelRE.FindStringSubmatch(comment)
+		f.Levels[check[1]] = check[2]
 	} else if commentStyleRE.MatchString(comment) {
 		for _, style := range f.BaseStyles {
 			f.Comments[style] = true
@@ -332,6 +445,51 @@ func (f *File) UpdateComments(comment string) {
 	}
 }
 
+// suppress records a comment that turns off linting, returning false if it
+// should be ignored because it's missing a required reason.
+func (f *File) suppress(raw, comment, reason string) bool {
+	line, col := f.locateComment(raw)
+	f.Suppressed = append(f.Suppressed, Suppression{
+		Comment: comment, Reason: reason, Line: line})
+
+	if reason != "" || !f.strict {
+		return true
+	}
+
+	f.Alerts = append(f.Alerts, Alert{
+		Check:    "Vale.SuppressReason",
+		Severity: "error",
+		Line:     line,
+		Span:     []int{col, col + len(raw) - 1},
+		Match:    raw,
+		Message: fmt.Sprintf(
+			"'%s' must include a reason (e.g., '%s: quoting vendor text').", comment, comment),
+	})
+	return false
+}
+
+// locateComment returns the line and column of the given comment, searching
+// from the last suppression onward.
+func (f *File) locateComment(comment string) (int, int) {
+	for i := f.cursor; i < len(f.Lines); i++ {
+		if col := strings.Index(f.Lines[i], comment); col >= 0 {
+			f.cursor = i + 1
+			return i + 1, utf8.RuneCountInString(f.Lines[i][:col]) + 1
+		}
+	}
+	return f.cursor, 1
+}
+
+// splitReason separates a comment (e.g., `vale off: quoting vendor text`)
+// from its reason.
+func splitReason(comment string) (string, string) {
+	if !strings.HasPrefix(comment, "vale ") {
+		return comment, ""
+	}
+	directive, reason, _ := strings.Cut(comment, ":")
+	return strings.TrimSpace(directive), strings.TrimSpace(reason)
+}
+
 // QueryComments checks if there has been an in-text comment for this check.
 func (f *File) QueryComments(check string) bool {
 	if f.Comments["off"] {
@@ -348,6 +506,21 @@ func (f *File) QueryComments(check string) bool {
 	return false
 }
 
+// QueryLevel returns the severity assigned to this check by an in-text
+// comment, if any.
+//
+// A comment naming the check itself takes precedence over one naming its
+// style.
+func (f *File) QueryLevel(check string) string {
+	if level, ok := f.Levels[check]; ok {
+		return level
+	}
+	if style, _, ok := strings.Cut(check, "."); ok {
+		return f.Levels[style]
+	}
+	return ""
+}
+
 // ResetComments resets the state of all checks back to active.
 func (f *File) ResetComments() {
 	for check := range f.Comments {
@@ -355,4 +528,58 @@ func (f *File) ResetComments() {
 			f.Comments[check] = false
 		}
 	}
+	clear(f.Levels)
+}
+
+// AddHeading adds a new section to f's outline.
+func (f *File) AddHeading(text string, level, line int) {
+	f.Outline = append(f.Outline, Heading{Text: text, Level: level, Line: line})
+}
+
+// Section returns the headings that enclose the current position in f's
+// outline, from the outermost to the innermost.
+func (f *File) Section() []Heading {
+	var enclosing []Heading
+	for _, h := range f.Outline {
+		for len(enclosing) > 0 && enclosing[len(enclosing)-1].Level >= h.Level {
+			enclosing = enclosing[:len(enclosing)-1]
+		}
+		enclosing = append(enclosing, h)
+	}
+	return enclosing
+}
+
+// AddContent records that the current section of f's outline has body
+// content.
+func (f *File) AddContent() {
+	if n := len(f.Outline); n > 0 {
+		f.Outline[n-1].Content = true
+	}
+}
+
+// EmptySections returns the headings in f's outline that are followed by
+// neither body content nor subsections.
+func (f *File) EmptySections() []Heading {
+	var empty []Heading
+
+	for i, h := range f.Outline {
+		if h.Content {
+			continue
+		} else if i+1 < len(f.Outline) && f.Outline[i+1].Level > h.Level {
+			continue
+		}
+		empty = append(empty, h)
+	}
+
+	return empty
+}
+
+// sortedKeys returns the keys of `m` in lexical order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
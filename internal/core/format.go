@@ -55,8 +55,12 @@ var CommentsByNormedExt = map[string]map[string]string{
 	},
 }
 
+// MarkdownFlavors are the values accepted by `MarkdownFlavor`: GitHub
+// Flavored Markdown (the default), plain CommonMark, and Pandoc's Markdown.
+var MarkdownFlavors = []string{"gfm", "commonmark", "pandoc"}
+
 // FormatByExtension associates a file extension with its "normed" extension
-// and its format (markup, code or text).
+// and its format (markup, code, resource or text).
 var FormatByExtension = map[string][]string{
 	`\.(?:[rc]?py[3w]?|[Ss][Cc]onstruct)$`:     {".py", "code"},
 	`\.(?:adoc|asciidoc|asc)$`:                 {".adoc", "markup"},
@@ -73,27 +77,36 @@ var FormatByExtension = map[string][]string{
 	`\.(?:js|jsx)$`:                   {".js", "code"},
 	`\.(?:lua)$`:                      {".lua", "code"},
 	`\.(?:md|mdown|markdown|markdn)$`: {".md", "markup"},
+	`\.(?:mdx)$`:                      {".md", "markup"},
 	`\.(?:org)$`:                      {".org", "markup"},
 	`\.(?:php)$`:                      {".php", "code"},
 	`\.(?:pl|pm|pod)$`:                {".r", "code"},
+	`\.(?:properties)$`:               {".properties", "resource"},
 	`\.(?:proto)$`:                    {".proto", "code"},
 	`\.(?:ps1|psm1|psd1)$`:            {".ps1", "code"},
 	`\.(?:rb|Gemfile|Rakefile|Brewfile|gemspec)$`: {".rb", "code"},
-	`\.(?:rs)$`:        {".rs", "code"},
-	`\.(?:rst|rest)$`:  {".rst", "markup"},
-	`\.(?:r|R)$`:       {".r", "code"},
-	`\.(?:sass|less)$`: {".c", "code"},
-	`\.(?:scala|sbt)$`: {".c", "code"},
-	`\.(?:swift)$`:     {".c", "code"},
-	`\.(?:ts|tsx)$`:    {".ts", "code"},
-	`\.(?:txt)$`:       {".txt", "text"},
-	`\.(?:xml)$`:       {".xml", "markup"},
-	`\.(?:yaml|yml)$`:  {".yml", "code"},
+	`\.(?:rs)$`:            {".rs", "code"},
+	`\.(?:rst|rest)$`:      {".rst", "markup"},
+	`\.(?:r|R)$`:           {".r", "code"},
+	`\.(?:sass|less)$`:     {".c", "code"},
+	`\.(?:scala|sbt)$`:     {".c", "code"},
+	`\.(?:strings)$`:       {".strings", "resource"},
+	`\.(?:swift)$`:         {".c", "code"},
+	`\.(?:tex|latex|ltx)$`: {".tex", "markup"},
+	`\.(?:ts|tsx)$`:        {".ts", "code"},
+	`\.(?:txt)$`:           {".txt", "text"},
+	`\.(?:xml)$`:           {".xml", "markup"},
+	`\.(?:yaml|yml)$`:      {".yml", "code"},
 }
 
 // FormatFromExt takes a file extension and returns its [normExt, format]
 // list, if supported.
 func FormatFromExt(path string, mapping map[string]string) (string, string) {
+	if filepath.Base(path) == "strings.xml" {
+		// NOTE: Android resource files are identified by name, not extension.
+		return ".xml", "resource"
+	}
+
 	base := strings.Trim(filepath.Ext(path), ".")
 	kind := getFormat("." + base)
 
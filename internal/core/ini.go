@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/errata-ai/ini"
@@ -63,10 +64,12 @@ func loadVocab(root string, cfg *Config) error {
 				return cfg.AddWordListFile(fp, true)
 			} else if name == "reject.txt" {
 				return cfg.AddWordListFile(fp, false)
+			} else if name == "patterns.txt" {
+				return cfg.AddPatternFile(fp)
 			}
 			return nil
 		},
-		Unsorted:            true,
+		Unsorted:            cfg.Flags == nil || !cfg.Flags.Deterministic,
 		AllowNonDirectory:   true,
 		FollowSymbolicLinks: true})
 
@@ -138,6 +141,18 @@ var syntaxOpts = map[string]func(string, *ini.Section, *Config) error{
 		cfg.FormatToLang[label] = sec.Key("Lang").String()
 		return nil
 	},
+	"MarkdownFlavor": func(label string, sec *ini.Section, cfg *Config) error {
+		flavor := strings.ToLower(sec.Key("MarkdownFlavor").String())
+		if !StringInSlice(flavor, MarkdownFlavors) {
+			return NewE201FromTarget(
+				fmt.Sprintf("MarkdownFlavor must be one of %s, but got '%s'",
+					strings.Join(MarkdownFlavors, ", "), flavor),
+				label,
+				cfg.Flags.Path)
+		}
+		cfg.MarkdownFlavors[label] = flavor
+		return nil
+	},
 }
 
 var globalOpts = map[string]func(*ini.Section, *Config){
@@ -241,6 +256,101 @@ var coreOpts = map[string]func(*ini.Section, *Config) error{
 		cfg.NLPEndpoint = sec.Key("NLPEndpoint").MustString("")
 		return nil
 	},
+	"SicMarkers": func(sec *ini.Section, cfg *Config) error { //nolint:unparam
+		cfg.SicMarkers = mergeValues(sec.Key("SicMarkers").StringsWithShadows(","))
+		return nil
+	},
+	"Bibliography": func(sec *ini.Section, cfg *Config) error { //nolint:unparam
+		for _, path := range mergeValues(sec.Key("Bibliography").StringsWithShadows(",")) {
+			if cfg.RootINI != "" {
+				path = determinePath(cfg.RootINI, filepath.FromSlash(path))
+			}
+			cfg.Bibliography = append(cfg.Bibliography, path)
+		}
+		return nil
+	},
+	"DetectLanguage": func(sec *ini.Section, cfg *Config) error {
+		switch unit := sec.Key("DetectLanguage").String(); unit {
+		case "NO", "":
+			cfg.DetectLanguage = ""
+		case "YES", "document":
+			cfg.DetectLanguage = "document"
+		case "paragraph":
+			cfg.DetectLanguage = unit
+		default:
+			return NewE201FromTarget(
+				"DetectLanguage must be 'document', 'paragraph', or 'NO'.",
+				unit,
+				cfg.Flags.Path)
+		}
+		return nil
+	},
+	"Webhook": func(sec *ini.Section, cfg *Config) error { //nolint:unparam
+		cfg.Webhook = sec.Key("Webhook").MustString("")
+		return nil
+	},
+	"MinValeVersion": func(sec *ini.Section, cfg *Config) error { //nolint:unparam
+		cfg.MinValeVersion = sec.Key("MinValeVersion").MustString("")
+		return nil
+	},
+	"ContextChars": func(sec *ini.Section, cfg *Config) error {
+		chars, err := sec.Key("ContextChars").Int()
+		if err != nil || chars < 0 {
+			return NewE201FromTarget(
+				"ContextChars must be a non-negative integer.",
+				"ContextChars",
+				cfg.Flags.Path)
+		}
+		cfg.ContextChars = chars
+		return nil
+	},
+	"RedactContext": func(sec *ini.Section, cfg *Config) error { //nolint:unparam
+		cfg.RedactContext = sec.Key("RedactContext").MustBool(false)
+		return nil
+	},
+	"RequireSuppressReason": func(sec *ini.Section, cfg *Config) error { //nolint:unparam
+		cfg.RequireSuppressReason = sec.Key("RequireSuppressReason").MustBool(false)
+		return nil
+	},
+}
+
+// addGroup defines a named group of rules (e.g., `terminology = Style.A,
+// Other.B`), which can then be enabled, disabled, or leveled as one unit.
+func addGroup(name string, rules []string, cfg *Config) error {
+	if strings.Contains(name, ".") {
+		return NewE201FromTarget(
+			fmt.Sprintf("'%s' can't be used as a group name since it looks like a rule", name),
+			name, cfg.RootINI)
+	} else if _, found := coreOpts[name]; found {
+		return NewE201FromTarget(fmt.Sprintf("'%s' is a core option", name), name, cfg.RootINI)
+	} else if _, found = syntaxOpts[name]; found {
+		return NewE201FromTarget(fmt.Sprintf("'%s' is a syntax-specific option", name), name, cfg.RootINI)
+	}
+
+	members := mergeValues(rules)
+	if len(members) == 0 {
+		return NewE201FromTarget(
+			fmt.Sprintf("'%s' must include at least one rule", name), name, cfg.RootINI)
+	}
+
+	for _, rule := range members {
+		if !strings.Contains(rule, ".") {
+			return NewE201FromTarget(
+				fmt.Sprintf("'%s' isn't a rule (e.g., 'Style.Rule')", rule), rule, cfg.RootINI)
+		}
+	}
+
+	cfg.Groups[name] = members
+	return nil
+}
+
+// groupRules returns the rules that a config key refers to: the members of a
+// group or, otherwise, the key itself.
+func groupRules(key string, cfg *Config) []string {
+	if rules, found := cfg.Groups[key]; found {
+		return rules
+	}
+	return []string{key}
 }
 
 func shadowLoad(source interface{}, others ...interface{}) (*ini.File, error) {
@@ -262,16 +372,16 @@ func processSources(cfg *Config, sources []string) (*ini.File, error) {
 		return uCfg, errors.New("no sources provided")
 	} else if len(sources) == 1 {
 		cfg.Flags.Path = sources[0]
-		return shadowLoad(cfg.Flags.Path)
+		return shadowLoad(configSource(cfg.Flags.Path))
 	}
 
 	t := sources[1:]
 	s := make([]interface{}, len(t))
 	for i, v := range t {
-		s[i] = v
+		s[i] = configSource(v)
 	}
 
-	uCfg, err = shadowLoad(sources[0], s...)
+	uCfg, err = shadowLoad(configSource(sources[0]), s...)
 	cfg.Flags.Path = sources[len(sources)-1]
 
 	return uCfg, err
@@ -283,6 +393,12 @@ func processConfig(uCfg *ini.File, cfg *Config, dry bool) (*ini.File, error) {
 
 	formats := uCfg.Section("formats")
 	adoc := uCfg.Section("asciidoctor")
+	outputs := uCfg.Section("outputs")
+	rollout := uCfg.Section("rollout")
+	policy := uCfg.Section("policy")
+	groups := uCfg.Section("groups")
+	attributes := uCfg.Section("attributes")
+	languages := uCfg.Section("languages")
 
 	// Default settings
 	for _, k := range core.KeyStrings() {
@@ -306,6 +422,60 @@ func processConfig(uCfg *ini.File, cfg *Config, dry bool) (*ini.File, error) {
 		cfg.Asciidoctor[k] = adoc.Key(k).String()
 	}
 
+	// Alert routing
+	for _, k := range outputs.KeyStrings() {
+		sinks := outputs.Key(k).Strings(",")
+		if len(sinks) == 0 {
+			return nil, NewE201FromTarget(
+				fmt.Sprintf("'%s' must be routed to at least one output", k), k, cfg.RootINI)
+		}
+		cfg.Outputs[k] = sinks
+	}
+
+	// Gradual rollouts
+	for _, k := range rollout.KeyStrings() {
+		pct, err := strconv.Atoi(strings.TrimSuffix(rollout.Key(k).String(), "%"))
+		if err != nil || pct < 0 || pct > 100 {
+			return nil, NewE201FromTarget(
+				fmt.Sprintf("'%s' must be a percentage between 0 and 100", k), k, cfg.RootINI)
+		}
+		cfg.Rollout[k] = pct
+	}
+
+	// Escalation policies
+	for _, k := range policy.KeyStrings() {
+		if !StringInSlice(k, []string{"suggestion", "warning"}) {
+			return nil, NewE201FromTarget(
+				fmt.Sprintf("'%s' must be 'suggestion' or 'warning'", k), k, cfg.RootINI)
+		}
+
+		days, err := strconv.Atoi(strings.TrimSuffix(policy.Key(k).String(), "d"))
+		if err != nil || days < 0 {
+			return nil, NewE201FromTarget(
+				fmt.Sprintf("'%s' must be a number of days (e.g., '90d')", k), k, cfg.RootINI)
+		}
+		cfg.Policy[k] = days
+	}
+
+	// Document attributes
+	for _, k := range attributes.KeyStrings() {
+		cfg.Attributes[k] = attributes.Key(k).String()
+	}
+
+	// Per-language styles
+	for _, k := range languages.KeyStrings() {
+		styles := mergeValues(languages.Key(k).StringsWithShadows(","))
+		cfg.Languages[k] = styles
+		cfg.Styles = append(cfg.Styles, styles...)
+	}
+
+	// Rule groups
+	for _, k := range groups.KeyStrings() {
+		if err := addGroup(k, groups.Key(k).Strings(","), cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	// Global settings
 	for _, k := range global.KeyStrings() {
 		if _, option := coreOpts[k]; option {
@@ -316,14 +486,16 @@ func processConfig(uCfg *ini.File, cfg *Config, dry bool) (*ini.File, error) {
 			msg := fmt.Sprintf("'%s' is a syntax-specific option", k)
 			return nil, NewE201FromTarget(msg, k, cfg.RootINI)
 		} else {
-			cfg.GChecks[k] = validateLevel(k, global.Key(k).String(), cfg)
-			cfg.Checks = append(cfg.Checks, k)
+			for _, rule := range groupRules(k, cfg) {
+				cfg.GChecks[rule] = validateLevel(rule, global.Key(k).String(), cfg)
+				cfg.Checks = append(cfg.Checks, rule)
+			}
 		}
 	}
 
 	// Syntax-specific settings
 	for _, sec := range uCfg.SectionStrings() {
-		if StringInSlice(sec, []string{"*", "DEFAULT", "formats", "asciidoctor"}) {
+		if StringInSlice(sec, []string{"*", "DEFAULT", "formats", "asciidoctor", "outputs", "rollout", "packages", "policy", "groups", "attributes", "languages"}) {
 			continue
 		}
 
@@ -342,8 +514,10 @@ func processConfig(uCfg *ini.File, cfg *Config, dry bool) (*ini.File, error) {
 					return nil, err
 				}
 			} else {
-				syntaxMap[k] = validateLevel(k, uCfg.Section(sec).Key(k).String(), cfg)
-				cfg.Checks = append(cfg.Checks, k)
+				for _, rule := range groupRules(k, cfg) {
+					syntaxMap[rule] = validateLevel(rule, uCfg.Section(sec).Key(k).String(), cfg)
+					cfg.Checks = append(cfg.Checks, rule)
+				}
 			}
 		}
 		cfg.RuleKeys = append(cfg.RuleKeys, sec)
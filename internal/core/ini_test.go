@@ -94,3 +94,148 @@ CommentDelimiters = "{/*"
 		})
 	}
 }
+
+func Test_processConfig_contextLimits(t *testing.T) {
+	cases := []struct {
+		description string
+		body        string
+		expected    Alert
+	}{
+		{
+			description: "no limits",
+			body:        "",
+			expected:    Alert{Match: "confidential", Message: "Avoid 'confidential'."},
+		},
+		{
+			description: "truncated",
+			body:        "ContextChars = 4\n",
+			expected:    Alert{Match: "conf…", Message: "Avoid 'conf…'."},
+		},
+		{
+			description: "redacted",
+			body:        "ContextChars = 4\nRedactContext = YES\n",
+			expected:    Alert{Match: "[REDACTED]", Message: "Avoid '[REDACTED]'."},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			uCfg, err := shadowLoad([]byte(c.body))
+			assert.NoError(t, err)
+			conf, err := NewConfig(&CLIFlags{})
+			assert.NoError(t, err)
+			_, err = processConfig(uCfg, conf, false)
+			assert.NoError(t, err)
+
+			actual := Alert{Match: "confidential", Message: "Avoid 'confidential'."}
+			LimitContext(&actual, conf)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+
+	conf, err := NewConfig(&CLIFlags{})
+	assert.NoError(t, err)
+	conf.RedactContext = true
+
+	actual := Alert{Match: "a", Message: "Use 'an' rather than 'a' before a vowel."}
+	LimitContext(&actual, conf)
+	assert.Equal(t, "Use 'an' rather than '[REDACTED]' before a vowel.", actual.Message)
+
+	actual = Alert{Match: "confidentail", Suggestions: []string{"confidential"}}
+	LimitContext(&actual, conf)
+	assert.Nil(t, actual.Suggestions)
+}
+
+func Test_processConfig_groups(t *testing.T) {
+	body := `[groups]
+terminology = Acme.Terms, Vale.Terms, Other.Jargon
+
+[*]
+BasedOnStyles = Vale
+terminology = warning
+
+[*.md]
+terminology = NO
+Other.Jargon = YES
+`
+	uCfg, err := shadowLoad([]byte(body))
+	assert.NoError(t, err)
+	conf, err := NewConfig(&CLIFlags{})
+	assert.NoError(t, err)
+	_, err = processConfig(uCfg, conf, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{"Acme.Terms": true, "Vale.Terms": true, "Other.Jargon": true}, conf.GChecks)
+	assert.Equal(t, "warning", conf.RuleToLevel["Vale.Terms"])
+	assert.Equal(t, map[string]bool{"Acme.Terms": false, "Vale.Terms": false, "Other.Jargon": true}, conf.SChecks["*.md"])
+	assert.NotContains(t, conf.GChecks, "terminology")
+
+	for _, invalid := range []string{
+		"[groups]\nVale.Terms = Acme.Terms\n",
+		"[groups]\nterminology = Acme\n",
+		"[groups]\nterminology =\n",
+	} {
+		uCfg, err = shadowLoad([]byte(invalid))
+		assert.NoError(t, err)
+		conf, err = NewConfig(&CLIFlags{})
+		assert.NoError(t, err)
+		_, err = processConfig(uCfg, conf, false)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_processConfig_languages(t *testing.T) {
+	body := `DetectLanguage = paragraph
+
+[languages]
+de = German, Vale
+fr =
+
+[*]
+BasedOnStyles = Vale
+`
+	uCfg, err := shadowLoad([]byte(body))
+	assert.NoError(t, err)
+	conf, err := NewConfig(&CLIFlags{})
+	assert.NoError(t, err)
+	_, err = processConfig(uCfg, conf, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "paragraph", conf.DetectLanguage)
+	assert.Equal(t, map[string][]string{"de": {"German", "Vale"}, "fr": {}}, conf.Languages)
+	assert.Contains(t, conf.Styles, "German")
+	assert.NotContains(t, conf.SecToPat, "languages")
+
+	uCfg, err = shadowLoad([]byte("DetectLanguage = sentence\n"))
+	assert.NoError(t, err)
+	conf, err = NewConfig(&CLIFlags{})
+	assert.NoError(t, err)
+	_, err = processConfig(uCfg, conf, false)
+	assert.Error(t, err)
+}
+
+func Test_processConfig_markdownFlavors(t *testing.T) {
+	body := `[*.{md,mdx}]
+MarkdownFlavor = commonmark
+
+[*.md]
+MarkdownFlavor = pandoc
+`
+	uCfg, err := shadowLoad([]byte(body))
+	assert.NoError(t, err)
+	conf, err := NewConfig(&CLIFlags{})
+	assert.NoError(t, err)
+	_, err = processConfig(uCfg, conf, false)
+	assert.NoError(t, err)
+
+	// Both sections match, so the first (in lexical order) always wins.
+	for i := 0; i < 20; i++ {
+		f, ferr := NewFileFromContent("README.md", "# Title\n", conf)
+		assert.NoError(t, ferr)
+		assert.Equal(t, "pandoc", f.Flavor)
+	}
+
+	f, err := NewFileFromContent("page.mdx", "# Title\n", conf)
+	assert.NoError(t, err)
+	assert.Equal(t, "commonmark", f.Flavor)
+}
@@ -7,6 +7,15 @@ import (
 	"github.com/errata-ai/vale/v3/internal/nlp"
 )
 
+// reMaskedCode matches a (quoted) code span that has been masked in a block's
+// text.
+var reMaskedCode = regexp.MustCompile(`(?:\\\*){2,}`)
+
+// reInlineMarkup matches the markup that may separate two words of a match in
+// the source document: whitespace, block-quote markers, emphasis, code spans,
+// HTML tags, and the brackets and destinations of links.
+const reInlineMarkup = `(?:\s|>|[*_~` + "`" + `]|<[^>\n]*>|\[|\]\([^)\n]*\))+`
+
 // initialPosition calculates the position of a match (given by the location in
 // the reference document, `loc`) in the source document (`ctx`).
 func initialPosition(ctx, txt string, a Alert) (int, string) {
@@ -25,15 +34,23 @@ func initialPosition(ctx, txt string, a Alert) (int, string) {
 	}
 
 	sub := strings.ToValidUTF8(a.Match, "")
-	pat = regexp.MustCompile(`(?:^|\b|_)` + regexp.QuoteMeta(sub) + `(?:_|\b|$)`)
+	pat = regexp.MustCompile(`(?:^|\b|_)` + quoteWrapped(sub) + `(?:_|\b|$)`)
 
 	fsi := pat.FindAllStringIndex(ctx, -1)
 	if len(fsi) == 0 {
 		idx = strings.Index(ctx, sub)
 		if idx < 0 {
-			// This should only happen if we're in a scope that contains inline
-			// markup (e.g., a sentence with code spans).
-			return guessLocation(ctx, txt, sub)
+			// This should only happen if the match spans inline markup (e.g.,
+			// a phrase interrupted by emphasis or a code span), which rules
+			// opt into locating exactly (see `inline`).
+			var loc []int
+			if a.Inline {
+				loc = inlineLocation(ctx, sub)
+			}
+			if loc == nil {
+				return guessLocation(ctx, txt, sub)
+			}
+			idx, sub = loc[0], ctx[loc[0]:loc[1]]
 		}
 	} else {
 		idx = fsi[0][0]
@@ -67,6 +84,50 @@ func initialPosition(ctx, txt string, a Alert) (int, string) {
 	return nlp.StrLen(ctx[:idx]) + 1, sub
 }
 
+// quoteWrapped escapes `s` for use in a regular expression.
+//
+// If `s` spans multiple lines (e.g., a match in a hard-wrapped paragraph), we
+// allow its line breaks to be surrounded by the source's indentation or
+// block-quote markers, which aren't present in the scoped text.
+func quoteWrapped(s string) string {
+	if !strings.Contains(s, "\n") {
+		return regexp.QuoteMeta(s)
+	}
+
+	parts := []string{}
+	for _, part := range strings.Fields(s) {
+		parts = append(parts, regexp.QuoteMeta(part))
+	}
+
+	return strings.Join(parts, `\s+(?:>\s*)*`)
+}
+
+// inlineLocation finds a match from a block's text (in which inline markup has
+// been rendered and code spans have been masked) in the source document.
+//
+// The words of the match may be separated by markup in the source, and masked
+// code spans (`***`) may match any code. It returns nil if there's no
+// unambiguous way to find the match.
+func inlineLocation(ctx, match string) []int {
+	words := strings.Fields(match)
+	if len(words) < 2 {
+		return nil
+	}
+
+	parts := []string{}
+	for _, word := range words {
+		quoted := regexp.QuoteMeta(word)
+		parts = append(parts, reMaskedCode.ReplaceAllLiteralString(quoted, `\S+?`))
+	}
+
+	pat, err := regexp.Compile(strings.Join(parts, reInlineMarkup))
+	if err != nil {
+		return nil
+	}
+
+	return pat.FindStringIndex(ctx)
+}
+
 func guessLocation(ctx, sub, match string) (int, string) {
 	target := ""
 	for _, s := range nlp.SentenceTokenizer.Segment(sub) {
@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestInlineLocation(t *testing.T) {
+	cases := []struct {
+		ctx      string
+		match    string
+		expected int
+		source   string
+	}{
+		{"First, click **the** button.", "click the button", 8, "click **the** button"},
+		{"Then run the `npm` command.", "run the *** command", 6, "run the `npm` command"},
+		{"See [the docs](https://example.com) for more.", "the docs for", 6, "the docs](https://example.com) for"},
+		{"Do it *in order*\nto test.", "in order to", 8, "in order*\nto"},
+	}
+
+	for _, tc := range cases {
+		pos, sub := initialPosition(tc.ctx, tc.match, Alert{Match: tc.match, Inline: true})
+		if pos != tc.expected || sub != tc.source {
+			t.Errorf("%q: expected (%d, %q), got (%d, %q)", tc.match, tc.expected, tc.source, pos, sub)
+		}
+
+		// Without `inline`, we don't look past the markup.
+		if _, sub = initialPosition(tc.ctx, tc.match, Alert{Match: tc.match}); sub == tc.source {
+			t.Errorf("%q: expected the inline location to require `inline`", tc.match)
+		}
+	}
+}
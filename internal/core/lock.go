@@ -0,0 +1,106 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+// ErrLocked is returned by `TryLockState` if another process holds the lock.
+var ErrLocked = errors.New("locked by another process")
+
+// A FileLock is an exclusive, advisory lock shared between Vale processes.
+//
+// Locks are released when the process exits, so a crashed run never leaves
+// one behind.
+type FileLock struct {
+	f *os.File
+}
+
+// StatePath returns the path to `name` in Vale's state directory, creating
+// any parent directories (unless writes are disabled; see `ReadOnly`).
+//
+// The state directory holds data that's shared between runs (such as lock
+// files) rather than configuration; it may be overridden by setting
+// `VALE_STATE_PATH`.
+func StatePath(name string) (string, error) {
+	if fromEnv, hasEnv := os.LookupEnv("VALE_STATE_PATH"); hasEnv {
+		path := filepath.Join(fromEnv, name)
+		if ReadOnly {
+			return path, nil
+		}
+		return path, os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	}
+	return xdgPath(xdg.StateHome, filepath.Join("vale", name), xdg.StateFile)
+}
+
+// LockState blocks until this process holds the lock for `target`, a file
+// or directory that's shared between Vale processes -- e.g., a `--checkpoint`
+// store or a `StylesPath` being synced.
+//
+// When writes are disabled (see `ReadOnly`), the returned lock is a no-op:
+// `target` is only ever read, so there's nothing to protect.
+func LockState(target string) (*FileLock, error) {
+	return lockState(target, true)
+}
+
+// TryLockState is like `LockState`, but it returns `ErrLocked` rather than
+// waiting for another process to release the lock.
+func TryLockState(target string) (*FileLock, error) {
+	return lockState(target, false)
+}
+
+// Unlock releases the lock.
+func (l *FileLock) Unlock() error {
+	if l == nil || l.f == nil {
+		return nil
+	}
+
+	err := unlockFile(l.f)
+	if closeErr := l.f.Close(); err == nil {
+		err = closeErr
+	}
+	l.f = nil
+
+	return err
+}
+
+// lockState locks a file in the state directory named after `target`.
+//
+// NOTE: We don't lock `target` itself since it may be a directory, may not
+// exist yet, or may be replaced (e.g., by renaming a temporary file over it).
+func lockState(target string, wait bool) (*FileLock, error) {
+	if ReadOnly {
+		return &FileLock{}, nil
+	}
+
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(abs)))
+
+	path, err := StatePath(filepath.Join("locks", hex.EncodeToString(sum[:8])+".lock"))
+	if err != nil {
+		return nil, NewE100("lock", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, NewE100("lock", err)
+	}
+
+	if err = lockFile(f, wait); err != nil {
+		f.Close()
+		if errors.Is(err, ErrLocked) {
+			return nil, err
+		}
+		return nil, NewE100("lock", err)
+	}
+
+	return &FileLock{f: f}, nil
+}
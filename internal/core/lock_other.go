@@ -0,0 +1,17 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package core
+
+import "os"
+
+// NOTE: File locking isn't supported on this platform, so concurrent runs
+// aren't protected from each other.
+
+func lockFile(_ *os.File, _ bool) error {
+	return nil
+}
+
+func unlockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestLockState(t *testing.T) {
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+	target := filepath.Join(t.TempDir(), "state.db")
+
+	lock, err := LockState(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = TryLockState(target); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked, got %v", err)
+	}
+
+	other, err := TryLockState(filepath.Dir(target))
+	if err != nil {
+		t.Errorf("Expected an unrelated target to be unlocked, got %v", err)
+	}
+	_ = other.Unlock()
+
+	if err = lock.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err = TryLockState(target)
+	if err != nil {
+		t.Fatalf("Expected the lock to be released, got %v", err)
+	}
+	_ = lock.Unlock()
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// MessageData is the data available to a rule's `message` (or `description`)
+// when it's written as a template -- e.g., "Use '{{lower .Match}}'".
+type MessageData struct {
+	Match string   // the first substitution (usually, the matched text)
+	Args  []string // every substitution, in the order that `%s` would use
+	Count int      // the first substitution that's a whole number, if any
+}
+
+var messageFuncs = template.FuncMap{
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"capitalize": CapFirst,
+	"plural":     plural,
+}
+
+// messageTemplates caches parsed messages, since each one is used for every
+// alert of its rule; a nil entry is a message that isn't a valid template.
+var messageTemplates sync.Map
+
+// plural returns the form of a word to use with `n`:
+//
+//	{{plural .Count}}                    -> "" or "s"
+//	{{plural .Count "word"}}             -> "word" or "words"
+//	{{plural .Count "child" "children"}} -> "child" or "children"
+func plural(n int, forms ...string) string {
+	switch {
+	case n == 1 && len(forms) > 0:
+		return forms[0]
+	case n == 1:
+		return ""
+	case len(forms) > 1:
+		return forms[1]
+	case len(forms) == 1:
+		return forms[0] + "s"
+	default:
+		return "s"
+	}
+}
+
+// executeMessage renders `msg` as a template, reporting false if it isn't a
+// valid one.
+//
+// NOTE: Messages such as "Use the {{< note >}} shortcode" aren't templates,
+// so we fall back to treating them as plain text.
+func executeMessage(msg string, subs []string) (string, bool) {
+	cached, ok := messageTemplates.Load(msg)
+	if !ok {
+		tmpl, err := template.New("message").Funcs(messageFuncs).Parse(msg)
+		if err != nil {
+			tmpl = nil
+		}
+		cached, _ = messageTemplates.LoadOrStore(msg, tmpl)
+	}
+
+	tmpl, _ := cached.(*template.Template)
+	if tmpl == nil {
+		return msg, false
+	}
+
+	data := MessageData{Args: subs}
+	if len(subs) > 0 {
+		data.Match = subs[0]
+	}
+	for _, sub := range subs {
+		if n, err := strconv.Atoi(sub); err == nil {
+			data.Count = n
+			break
+		}
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return msg, false
+	}
+
+	return b.String(), true
+}
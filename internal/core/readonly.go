@@ -0,0 +1,34 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+)
+
+// ReadOnly disables every write to the filesystem (see `--no-write`), so
+// that Vale can run in a locked-down sandbox.
+//
+// When it's set, the data that Vale would otherwise persist between runs --
+// cached results, alert histories, and locks -- is kept in memory for the
+// duration of the run: existing files are still read, but never updated.
+var ReadOnly = false
+
+// CheckWritable returns an error, attributed to `context`, if writes to the
+// filesystem are disabled.
+func CheckWritable(context string) error {
+	if ReadOnly {
+		return NewE100(context, errors.New(
+			"writing to the filesystem is disabled by '--no-write'"))
+	}
+	return nil
+}
+
+// xdgPath returns `base/name` when writes are disabled and calls `create`
+// (one of the `xdg.*File` functions, which create any parent directories)
+// otherwise.
+func xdgPath(base, name string, create func(string) (string, error)) (string, error) {
+	if ReadOnly {
+		return filepath.Join(base, name), nil
+	}
+	return create(name)
+}
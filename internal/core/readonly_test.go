@@ -0,0 +1,36 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadOnly(t *testing.T) {
+	ReadOnly = true
+	defer func() { ReadOnly = false }()
+
+	root := filepath.Join(t.TempDir(), "missing")
+	t.Setenv("VALE_CACHE_PATH", filepath.Join(root, "cache"))
+	t.Setenv("VALE_STATE_PATH", filepath.Join(root, "state"))
+
+	if _, err := ResultCachePath(&Config{RootINI: ".vale.ini"}); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := LockState("styles")
+	if err != nil {
+		t.Fatal(err)
+	} else if err = lock.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(root); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected nothing to be written, got %v", err)
+	}
+
+	if err = CheckWritable("sync"); err == nil {
+		t.Error("Expected writes to be disabled")
+	}
+}
@@ -3,10 +3,12 @@ package core
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/errata-ai/ini"
 )
@@ -21,6 +23,17 @@ const (
 	StringSrc
 )
 
+const (
+	// StdinConfig names a configuration read from stdin (`--config=-`).
+	StdinConfig = "<stdin>"
+	// EnvConfig names a configuration read from `VALE_CONFIG_CONTENT`.
+	EnvConfig = "<VALE_CONFIG_CONTENT>"
+)
+
+// inlineConfigs holds the configurations that weren't read from a file --
+// see `StdinConfig` and `EnvConfig` -- keyed by their names.
+var inlineConfigs sync.Map
+
 // ReadPipeline loads Vale's configuration according to the local search
 // process.
 //
@@ -28,6 +41,10 @@ const (
 // For example, some assets may not have been downloaded yet via the `sync`
 // command.
 func ReadPipeline(flags *CLIFlags, dry bool) (*Config, error) {
+	if err := readStdinConfig(flags); err != nil {
+		return nil, err
+	}
+
 	config, err := NewConfig(flags)
 	if err != nil {
 		return config, err
@@ -54,7 +71,11 @@ func ReadPipeline(flags *CLIFlags, dry bool) (*Config, error) {
 		}
 	}
 
-	return config, nil
+	if !dry {
+		err = CheckValeVersion(config.MinValeVersion, "MinValeVersion")
+	}
+
+	return config, err
 }
 
 // from updates an existing configuration with values From a user-provided
@@ -81,8 +102,82 @@ func FromString(src string, cfg *Config, dry bool) (*ini.File, error) {
 	return from(StringSrc, src, cfg, dry)
 }
 
+// UsesInlineConfig reports whether the configuration for the given flags
+// comes from stdin or `VALE_CONFIG_CONTENT` rather than a file.
+func UsesInlineConfig(flags *CLIFlags) bool {
+	if flags.Path != "" {
+		return flags.Path == "-" || isInlineConfig(flags.Path)
+	}
+	_, hasEnv := os.LookupEnv("VALE_CONFIG_CONTENT")
+	return hasEnv
+}
+
+// readStdinConfig reads the configuration given through `--config=-`,
+// replacing the flag's value with a path that refers to it.
+//
+// NOTE: stdin can only be read once, so any later calls (e.g., from a
+// command that loads its own configuration) reuse its contents.
+func readStdinConfig(flags *CLIFlags) error {
+	if flags.Path != "-" {
+		return nil
+	}
+
+	path, err := inlinePath(StdinConfig)
+	if err != nil {
+		return NewE100("--config", err)
+	}
+	flags.Path = path
+
+	if _, found := inlineConfigs.Load(StdinConfig); found {
+		return nil
+	}
+
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return NewE100("--config", err)
+	}
+	inlineConfigs.Store(StdinConfig, b)
+
+	return nil
+}
+
+// inlinePath returns the path used to refer to an inline configuration.
+//
+// We place it in the current directory, so that any relative paths (such as
+// `StylesPath`) are resolved just as they would be for a `.vale.ini` file.
+func inlinePath(name string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cwd, name), nil
+}
+
+func isInlineConfig(path string) bool {
+	_, found := inlineConfigs.Load(filepath.Base(path))
+	return found
+}
+
+// readConfigFile reads the configuration file at `path`, which may refer to
+// an inline configuration.
+func readConfigFile(path string) ([]byte, error) {
+	if b, found := inlineConfigs.Load(filepath.Base(path)); found {
+		return b.([]byte), nil
+	}
+	return os.ReadFile(path)
+}
+
+// configSource returns the source to load for the configuration file at
+// `path`: either the path itself or the contents of an inline configuration.
+func configSource(path string) interface{} {
+	if b, found := inlineConfigs.Load(filepath.Base(path)); found {
+		return b.([]byte)
+	}
+	return path
+}
+
 func validateFlags(cfg *Config) error {
-	if cfg.Flags.Path != "" && !FileExists(cfg.Flags.Path) {
+	if cfg.Flags.Path != "" && !FileExists(cfg.Flags.Path) && !isInlineConfig(cfg.Flags.Path) {
 		return NewE100(
 			"--config",
 			fmt.Errorf("path '%s' does not exist", cfg.Flags.Path))
@@ -131,11 +226,32 @@ func loadINI(cfg *Config, dry bool) (*ini.File, error) {
 		}
 	} else if cfg.Flags.Path != "" {
 		// We've been given a value through `--config`.
-		err = uCfg.Append(cfg.Flags.Path)
+		err = uCfg.Append(configSource(cfg.Flags.Path))
 		if err != nil {
 			return nil, NewE100("invalid --config", err)
 		}
 		cfg.AddConfigFile(cfg.Flags.Path)
+
+		if isInlineConfig(cfg.Flags.Path) {
+			cfg.RootINI = cfg.Flags.Path
+		}
+	} else if content, hasContent := os.LookupEnv("VALE_CONFIG_CONTENT"); hasContent {
+		// We've been given the configuration itself through
+		// `VALE_CONFIG_CONTENT` (e.g., in a CI job that shouldn't write any
+		// files to its workspace).
+		inlineConfigs.Store(EnvConfig, []byte(content))
+
+		root, pathErr := inlinePath(EnvConfig)
+		if pathErr != nil {
+			return nil, NewE100("VALE_CONFIG_CONTENT", pathErr)
+		}
+
+		err = uCfg.Append([]byte(content))
+		if err != nil {
+			return nil, NewE100("invalid VALE_CONFIG_CONTENT", err)
+		}
+		cfg.AddConfigFile(root)
+		cfg.RootINI = root
 	} else if fromEnv, hasEnv := os.LookupEnv("VALE_CONFIG_PATH"); hasEnv {
 		// We've been given a value through `VALE_CONFIG_PATH`.
 		err = uCfg.Append(fromEnv)
@@ -156,6 +272,28 @@ func loadINI(cfg *Config, dry bool) (*ini.File, error) {
 		cfg.MinAlertLevel = LevelToInt[cfg.Flags.AlertLevel]
 	}
 
+	if cfg.Flags.Webhook != "" {
+		cfg.Webhook = cfg.Flags.Webhook
+	}
+
+	if cfg.Flags.Profile != "" && !StringInSlice(cfg.Flags.Profile, Profiles) {
+		return nil, NewE100("--profile", fmt.Errorf(
+			"'%s' must be one of %v", cfg.Flags.Profile, Profiles))
+	}
+
+	if cfg.Flags.Stats && cfg.Flags.Output != "JSON" {
+		return nil, NewE100("--stats", errors.New(
+			"'--stats' requires '--output=JSON'"))
+	}
+
+	if cfg.Flags.Deterministic {
+		if cfg.Flags.Shuffle != 0 {
+			return nil, NewE100("--shuffle", errors.New(
+				"'--shuffle' can't be used with '--deterministic'"))
+		}
+		cfg.Flags.Sorted = true
+	}
+
 	// NOTE: In v3.0, we now use the user's config directory as the default
 	// location.
 	//
@@ -3,6 +3,7 @@ package core
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -81,3 +82,79 @@ func TestEnvBase(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestContentBase tests that we respect the `VALE_CONFIG_CONTENT` option for
+// providing a base config.
+func TestContentBase(t *testing.T) {
+	t.Setenv("VALE_CONFIG_CONTENT", "MinAlertLevel = error\n\n[*]\nBasedOnStyles = Vale\n")
+
+	cfg, err := NewConfig(&CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = FromFile(cfg, true)
+	if err != nil {
+		t.Fatal(err)
+	} else if cfg.MinAlertLevel != 2 {
+		t.Errorf("Expected MinAlertLevel = 2, got %d", cfg.MinAlertLevel)
+	} else if filepath.Base(cfg.RootINI) != EnvConfig {
+		t.Errorf("Expected RootINI to be '%s', got '%s'", EnvConfig, cfg.RootINI)
+	}
+
+	t.Setenv("VALE_CONFIG_CONTENT", "BasedOnStyles = Vale\n")
+
+	cfg, err = NewConfig(&CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = FromFile(cfg, true)
+	if err == nil || !strings.Contains(err.Error(), "["+EnvConfig+":1:") {
+		t.Errorf("Expected an error located in '%s', got %v", EnvConfig, err)
+	}
+}
+
+// TestStdinBase tests that we respect `--config=-` for reading a base config
+// from stdin.
+func TestStdinBase(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdin := os.Stdin
+	defer func() {
+		os.Stdin = stdin
+		inlineConfigs.Delete(StdinConfig)
+	}()
+	os.Stdin = r
+
+	_, err = w.WriteString("MinAlertLevel = warning\n\n[*]\nBasedOnStyles = Vale\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	flags := &CLIFlags{Path: "-"}
+	for i := 0; i < 2; i++ {
+		// The second read reuses the content of the first.
+		if err = readStdinConfig(flags); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, cfgErr := NewConfig(flags)
+		if cfgErr != nil {
+			t.Fatal(cfgErr)
+		} else if err = validateFlags(cfg); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = FromFile(cfg, true)
+		if err != nil {
+			t.Fatal(err)
+		} else if cfg.MinAlertLevel != 1 {
+			t.Errorf("Expected MinAlertLevel = 1, got %d", cfg.MinAlertLevel)
+		}
+	}
+}
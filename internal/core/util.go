@@ -10,6 +10,8 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/Masterminds/semver/v3"
+
 	"github.com/errata-ai/vale/v3/internal/nlp"
 )
 
@@ -135,7 +137,16 @@ func CondSprintf(format string, v ...interface{}) string {
 }
 
 // FormatMessage inserts `subs` into `msg`.
+//
+// A message containing `{{` is treated as a template (see `MessageData`), in
+// which case `%s` isn't substituted: this ensures that text inserted by the
+// template (which may contain `%`) is never itself interpreted.
 func FormatMessage(msg string, subs ...string) string {
+	if strings.Contains(msg, "{{") {
+		if formatted, ok := executeMessage(msg, subs); ok {
+			return formatted
+		}
+	}
 	return CondSprintf(msg, StringsToInterface(subs)...)
 }
 
@@ -329,3 +340,42 @@ func ReplaceExt(fp string, formats map[string]string) string {
 
 	return fp
 }
+
+// ValeVersion is the version of the running binary, which is compared against
+// the minimum versions required by projects and styles. It's set by the
+// `vale` command; development builds ("master") satisfy any requirement.
+var ValeVersion = "master"
+
+// CheckValeVersion returns an error if the running version of Vale doesn't
+// satisfy `required`, which is either a minimum version (e.g., `3.4.0`) or a
+// constraint (e.g., `>= 3.4, < 4`).
+//
+// `source` describes where the requirement comes from (e.g.,
+// `MinValeVersion`).
+func CheckValeVersion(required, source string) error {
+	required = strings.TrimSpace(required)
+	if required == "" {
+		return nil
+	}
+
+	current, err := semver.NewVersion(ValeVersion)
+	if err != nil {
+		// A development build.
+		return nil
+	}
+
+	if v, verr := semver.NewVersion(required); verr == nil {
+		required = ">= " + v.String()
+	}
+
+	constraint, err := semver.NewConstraint(required)
+	if err != nil {
+		return NewE100(source, fmt.Errorf("'%s' is not a valid version requirement", required))
+	} else if !constraint.Check(current) {
+		return NewE100(source, fmt.Errorf(
+			"%s requires Vale %s, but this is Vale %s; please upgrade Vale",
+			source, required, current.String()))
+	}
+
+	return nil
+}
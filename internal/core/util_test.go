@@ -82,3 +82,53 @@ func TestNormalizePath(t *testing.T) {
 		t.Errorf("expected = %v, got = %v", expectedOutput, result)
 	}
 }
+
+func TestCheckValeVersion(t *testing.T) {
+	running := ValeVersion
+	defer func() { ValeVersion = running }()
+
+	ValeVersion = "3.4.1"
+	cases := map[string]bool{
+		"":              true,
+		"3.4.0":         true,
+		"v3.4.1":        true,
+		"3.5.0":         false,
+		">= 3.0, < 4.0": true,
+		"~2.0":          false,
+		"not a version": false,
+	}
+
+	for required, ok := range cases {
+		if err := CheckValeVersion(required, "test"); (err == nil) != ok {
+			t.Errorf("%q: expected ok = %v, got %v", required, ok, err)
+		}
+	}
+
+	ValeVersion = "master"
+	if err := CheckValeVersion("99.0.0", "test"); err != nil {
+		t.Errorf("expected development builds to pass, got %v", err)
+	}
+}
+
+func TestFormatMessageTemplate(t *testing.T) {
+	cases := []struct {
+		msg  string
+		subs []string
+		out  string
+	}{
+		{"Use '{{lower .Match}}' instead.", []string{"JavaScript"}, "Use 'javascript' instead."},
+		{"{{capitalize .Match}} is {{upper (index .Args 1)}}.", []string{"this", "loud"}, "This is LOUD."},
+		{"Found {{.Count}} {{plural .Count \"link\"}}.", []string{"1"}, "Found 1 link."},
+		{"Found {{.Count}} {{plural .Count \"child\" \"children\"}}.", []string{"3"}, "Found 3 children."},
+		{"{{if gt .Count 2}}Too many{{else}}Some{{end}} item{{plural .Count}}.", []string{"5"}, "Too many items."},
+		{"'{{.Match}}' is 100% wrong.", []string{"50%"}, "'50%' is 100% wrong."},
+		{"Use the {{< note >}} shortcode, not '%s'.", []string{"Note:"}, "Use the {{< note >}} shortcode, not 'Note:'."},
+		{"Unknown {{.Field}} in '%s'.", []string{"text"}, "Unknown {{.Field}} in 'text'."},
+	}
+
+	for _, tc := range cases {
+		if got := FormatMessage(tc.msg, tc.subs...); got != tc.out {
+			t.Errorf("%q %v: expected %q, got %q", tc.msg, tc.subs, tc.out, got)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package lint
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os/exec"
@@ -42,7 +43,7 @@ func (l *Linter) lintADoc(f *core.File) error {
 	}
 	s = adocSanitizer.Replace(s)
 
-	html, err = callAdoc(f, s, exe, l.Manager.Config.Asciidoctor)
+	html, err = callAdoc(l.context(), f, s, exe, l.Manager.Config.Asciidoctor)
 	if err != nil {
 		return core.NewE100(f.Path, err)
 	}
@@ -81,7 +82,7 @@ func (l *Linter) lintADoc(f *core.File) error {
 	return l.lintHTMLTokens(f, []byte(html), 0)
 }
 
-func callAdoc(_ *core.File, text, exe string, attrs map[string]string) (string, error) {
+func callAdoc(ctx context.Context, _ *core.File, text, exe string, attrs map[string]string) (string, error) {
 	var out bytes.Buffer
 	var eut bytes.Buffer
 
@@ -96,7 +97,7 @@ func callAdoc(_ *core.File, text, exe string, attrs map[string]string) (string,
 	adocArgs = append(adocArgs, parseAttributes(attrs)...)
 	adocArgs = append(adocArgs, []string{"--safe-mode", "secure", "-"}...)
 
-	cmd := exec.Command(exe, adocArgs...)
+	cmd := exec.CommandContext(ctx, exe, adocArgs...)
 	cmd.Stdin = strings.NewReader(text)
 	cmd.Stdout = &out
 	cmd.Stderr = &eut
@@ -2,6 +2,8 @@ package lint
 
 import (
 	"bytes"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -18,7 +20,8 @@ var skipTags = []string{"script", "style", "pre", "figure", "noscript", "iframe"
 //   - `problematic` is added by rst2html to processing errors which, in our
 //     case, could be things like file-insertion URLs.
 //   - `pre` is added by rst2html to code spans.
-var skipClasses = []string{"problematic", "pre", "code"}
+//   - `citation` is added to citations (e.g., Pandoc's `[@doe99]`).
+var skipClasses = []string{"problematic", "pre", "code", "citation"}
 var inlineTags = []string{
 	"b", "big", "i", "small", "abbr", "acronym", "cite", "dfn", "em", "kbd",
 	"strong", "a", "br", "img", "span", "sub", "sup", "code", "tt", "del"}
@@ -29,6 +32,8 @@ var tagToScope = map[string]string{
 	"li":         "text.list",
 	"blockquote": "text.blockquote",
 	"figcaption": "text.figure.caption",
+	"summary":    "text.details",
+	"footnote":   "text.footnote",
 }
 
 func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //nolint:unparam
@@ -38,11 +43,15 @@ func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //
 	buf := bytes.NewBufferString("")
 
 	// The user has specified a custom list of tags/classes to ignore.
+	//
+	// NOTE: Files are linted concurrently, so we never modify the defaults.
+	skipTags := skipTags
 	if len(l.Manager.Config.SkippedScopes) > 0 {
 		skipTags = l.Manager.Config.SkippedScopes
 	}
+	skipClasses := skipClasses
 	if len(l.Manager.Config.IgnoredClasses) > 0 {
-		skipClasses = append(skipClasses, l.Manager.Config.IgnoredClasses...)
+		skipClasses = append(slices.Clip(skipClasses), l.Manager.Config.IgnoredClasses...)
 	}
 
 	skipped := []string{"tt", "code", "kbd"}
@@ -67,6 +76,7 @@ func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //
 			walker.setCls(txt, blockSkip)
 			inBlock = true
 			f.Metrics[txt]++
+			f.AddContent()
 		} else if inBlock && (core.StringInSlice(txt, skipTags) || closed) {
 			inBlock = false
 			if closed {
@@ -77,6 +87,10 @@ func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //
 				// FIXME: See https://github.com/errata-ai/vale/issues/421
 				txt = "code"
 			}
+			if txt == "li" && strings.HasPrefix(getAttribute(tok, "id"), "fn:") {
+				// A footnote definition, as rendered by goldmark.
+				txt = "footnote"
+			}
 			inline = core.StringInSlice(txt, inlineTags)
 			skip = core.StringInSlice(txt, skipped)
 			walker.addTag(txt)
@@ -151,11 +165,19 @@ func (l *Linter) lintScope(f *core.File, state *walker, txt string) error {
 
 			txt = strings.TrimLeft(txt, " ")
 			b := state.block(txt, scope+f.RealExt)
+			if match {
+				f.AddContent()
+			} else {
+				level, _ := strconv.Atoi(tag[1:])
+				f.AddHeading(state.text(), level, b.Line+1)
+			}
+
 			return l.lintBlock(f, b, state.lines, 0, false)
 		}
 	}
 
 	f.Summary.WriteString(txt + "\n\n")
+	f.AddContent()
 
 	b := state.block(txt, "txt")
 	return l.lintProse(f, b, state.lines)
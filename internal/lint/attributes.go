@@ -0,0 +1,150 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// reAdocAttribute matches an AsciiDoc attribute reference (e.g.,
+// `{product-name}`), including an escaping backslash.
+var reAdocAttribute = regexp.MustCompile(`\\?\{([A-Za-z0-9_][A-Za-z0-9_-]*)\}`)
+
+// reMacroVariable matches a Jinja-style variable (e.g., mkdocs-macros'
+// `{{ product_name }}`).
+var reMacroVariable = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.]*)\s*\}\}`)
+
+// attributeRef is an attribute reference that was replaced by its value.
+type attributeRef struct {
+	line  int // The (1-based) line of the reference.
+	start int // The (1-based) column of the value in the substituted line.
+	value int // The length of the value.
+	size  int // The length of the reference.
+}
+
+// attributeValues returns the values of the attributes that apply to `f`
+// and the pattern that matches references to them.
+func attributeValues(f *core.File, cfg *core.Config) (map[string]string, *regexp.Regexp) {
+	values := map[string]string{}
+
+	switch f.NormedExt {
+	case ".adoc":
+		for k, v := range cfg.Asciidoctor {
+			// NOTE: `YES` and `NO` set and unset an attribute rather than
+			// giving it a value.
+			if v != "YES" && v != "NO" {
+				values[k] = v
+			}
+		}
+		for k, v := range cfg.Attributes {
+			values[k] = v
+		}
+		return values, reAdocAttribute
+	case ".md":
+		for k, v := range cfg.Attributes {
+			values[k] = v
+		}
+		return values, reMacroVariable
+	}
+
+	return values, nil
+}
+
+// substituteAttributes replaces each reference to a known attribute in f's
+// content with its value, so that rules see the text as it will be
+// rendered.
+//
+// Unknown (and, in AsciiDoc, escaped) references are left as is.
+func substituteAttributes(f *core.File, cfg *core.Config) []attributeRef {
+	values, re := attributeValues(f, cfg)
+	if len(values) == 0 || re == nil {
+		return nil
+	}
+
+	refs := []attributeRef{}
+	lines := strings.SplitAfter(f.Content, "\n")
+
+	for i, line := range lines {
+		var b strings.Builder
+
+		last := 0
+		for _, m := range re.FindAllStringSubmatchIndex(line, -1) {
+			ref := line[m[0]:m[1]]
+			value, ok := values[line[m[2]:m[3]]]
+			if !ok || strings.HasPrefix(ref, `\`) {
+				continue
+			}
+
+			// NOTE: A value can't span lines, since that would throw off
+			// the line numbers of every alert that follows it.
+			value = strings.Join(strings.Fields(value), " ")
+
+			b.WriteString(line[last:m[0]])
+			refs = append(refs, attributeRef{
+				line:  i + 1,
+				start: nlp.StrLen(b.String()) + 1,
+				value: nlp.StrLen(value),
+				size:  nlp.StrLen(ref),
+			})
+			b.WriteString(value)
+
+			last = m[1]
+		}
+
+		if last > 0 {
+			b.WriteString(line[last:])
+			lines[i] = b.String()
+		}
+	}
+
+	f.Content = strings.Join(lines, "")
+	return refs
+}
+
+// restoreAttributes maps the location of each of f's alerts from the
+// substituted text back to the original one: an alert that overlaps a value
+// spans its entire reference.
+func restoreAttributes(f *core.File, refs []attributeRef) {
+	if len(refs) == 0 {
+		return
+	}
+
+	byLine := map[int][]attributeRef{}
+	for _, ref := range refs {
+		byLine[ref.line] = append(byLine[ref.line], ref)
+	}
+
+	for i := range f.Alerts {
+		a := &f.Alerts[i]
+
+		onLine, ok := byLine[a.Line]
+		if !ok || len(a.Span) != 2 {
+			continue
+		}
+		a.Span = []int{
+			restoreColumn(a.Span[0], onLine, false),
+			restoreColumn(a.Span[1], onLine, true),
+		}
+	}
+}
+
+// restoreColumn maps a column in a substituted line to the original one.
+func restoreColumn(col int, refs []attributeRef, end bool) int {
+	shift := 0
+
+	for _, ref := range refs {
+		if col < ref.start {
+			break
+		} else if col < ref.start+ref.value {
+			if end {
+				return ref.start + shift + ref.size - 1
+			}
+			return ref.start + shift
+		}
+		shift += ref.size - ref.value
+	}
+
+	return col + shift
+}
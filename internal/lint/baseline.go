@@ -0,0 +1,149 @@
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// baselineData is the on-disk representation of a project's baseline: the
+// IDs (see `alertIDs`) of its known alerts, keyed by file (relative to the
+// project).
+type baselineData struct {
+	Files map[string][]string
+}
+
+// BaselineFilePath returns the path to the project's baseline: a
+// `.vale-baseline.json` file next to its `.vale.ini` file (or in the current
+// directory, if there isn't one).
+func BaselineFilePath(cfg *core.Config) (string, error) {
+	return projectFilePath(cfg, ".vale-baseline.json")
+}
+
+// UpdateBaseline records the alerts of each linted file in the project's
+// baseline, returning the number of alerts recorded.
+//
+// As with the alert history (see `TrackAlerts`), the entries of files that
+// weren't linted are left as is.
+func UpdateBaseline(linted []*core.File, cfg *core.Config) (int, error) {
+	if err := core.CheckWritable("--update-baseline"); err != nil {
+		return 0, err
+	}
+
+	path, err := BaselineFilePath(cfg)
+	if err != nil {
+		return 0, core.NewE100("--update-baseline", err)
+	}
+
+	lock, err := core.LockState(path)
+	if err != nil {
+		return 0, err
+	}
+	defer lock.Unlock()
+
+	data, err := readBaseline(path)
+	if err != nil {
+		return 0, core.NewE100("--update-baseline", err)
+	}
+
+	recorded := 0
+	for _, f := range linted {
+		file := rolloutPath(f.Path, cfg.RootINI)
+
+		ids := []string{}
+		for _, ia := range alertIDs(f, file) {
+			ids = append(ids, ia.id)
+		}
+
+		if len(ids) == 0 {
+			delete(data.Files, file)
+			continue
+		}
+
+		sort.Strings(ids)
+		data.Files[file] = ids
+		recorded += len(ids)
+	}
+
+	if err = writeBaseline(path, data); err != nil {
+		return 0, core.NewE100("--update-baseline", err)
+	}
+	return recorded, nil
+}
+
+// ApplyBaseline removes the alerts recorded in the project's baseline (see
+// `UpdateBaseline`), returning the number of alerts removed.
+//
+// Alerts are matched by ID rather than by position, so edits elsewhere in a
+// file don't resurface them; only new alerts are reported.
+func ApplyBaseline(linted []*core.File, cfg *core.Config) (int, error) {
+	path, err := BaselineFilePath(cfg)
+	if err != nil {
+		return 0, core.NewE100("--baseline", err)
+	}
+
+	data, err := readBaseline(path)
+	if err != nil {
+		return 0, core.NewE100("--baseline", err)
+	}
+
+	removed := 0
+	for _, f := range linted {
+		file := rolloutPath(f.Path, cfg.RootINI)
+
+		known := map[string]bool{}
+		for _, id := range data.Files[file] {
+			known[id] = true
+		}
+
+		kept := []core.Alert{}
+		for _, ia := range alertIDs(f, file) {
+			if known[ia.id] {
+				removed++
+			} else {
+				kept = append(kept, *ia.alert)
+			}
+		}
+		f.Alerts = kept
+	}
+
+	return removed, nil
+}
+
+func readBaseline(path string) (baselineData, error) {
+	data := baselineData{Files: map[string][]string{}}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return data, nil
+	} else if err != nil {
+		return data, err
+	}
+
+	if err = json.Unmarshal(b, &data); err != nil {
+		return data, fmt.Errorf("'%s' is malformed: %w", path, err)
+	} else if data.Files == nil {
+		data.Files = map[string][]string{}
+	}
+
+	return data, nil
+}
+
+func writeBaseline(path string, data baselineData) error {
+	// NOTE: Like the alert history, the baseline is meant to be committed.
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestBaseline(t *testing.T) {
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	cfg.RootINI = filepath.Join(root, ".vale.ini")
+
+	path := filepath.Join(root, "docs", "a.md")
+	known := []*core.File{{Path: path, Alerts: []core.Alert{
+		{Check: "Vale.Spelling", Match: "teh", Line: 1, Span: []int{1, 3}},
+		{Check: "Vale.Repetition", Match: "the the", Line: 3, Span: []int{1, 7}},
+	}}}
+
+	recorded, err := UpdateBaseline(known, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if recorded != 2 {
+		t.Fatalf("Expected 2 recorded alerts, got %d", recorded)
+	}
+
+	// Both known alerts moved down a line and a new one was introduced.
+	linted := []*core.File{{Path: path, Alerts: []core.Alert{
+		{Check: "Vale.Spelling", Match: "teh", Line: 2, Span: []int{1, 3}},
+		{Check: "Vale.Spelling", Match: "recieve", Line: 3, Span: []int{1, 7}},
+		{Check: "Vale.Repetition", Match: "the the", Line: 4, Span: []int{1, 7}},
+	}}}
+
+	removed, err := ApplyBaseline(linted, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if removed != 2 {
+		t.Errorf("Expected 2 baselined alerts, got %d", removed)
+	}
+
+	if alerts := linted[0].Alerts; len(alerts) != 1 || alerts[0].Match != "recieve" {
+		t.Errorf("Expected only the new alert, got %+v", alerts)
+	}
+
+	core.ReadOnly = true
+	defer func() { core.ReadOnly = false }()
+
+	if _, err = UpdateBaseline(known, cfg); err == nil {
+		t.Error("Expected updating the baseline to fail with writes disabled")
+	}
+}
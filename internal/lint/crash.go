@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// maxSnippet is the number of bytes of the offending text that a `Crash`
+// keeps.
+const maxSnippet = 4096
+
+// A Crash is a panic that was recovered from while linting a file -- e.g.,
+// due to a pathological regular expression in a style or malformed markup.
+//
+// Crashes don't stop a run: the file is skipped and the remaining files are
+// linted as usual.
+type Crash struct {
+	Path    string // the file being linted
+	Rule    string // the rule that was running, if any
+	Snippet string // the text that the rule (or parser) was given
+	Value   string // the value passed to `panic`
+	Stack   string
+}
+
+// Error implements the `error` interface.
+func (c *Crash) Error() string {
+	if c.Rule == "" {
+		return fmt.Sprintf("%s: panic: %s", c.Path, c.Value)
+	}
+	return fmt.Sprintf("%s: '%s' panicked: %s", c.Path, c.Rule, c.Value)
+}
+
+func newCrash(path, rule, snippet string, value any) *Crash {
+	if len(snippet) > maxSnippet {
+		snippet = snippet[:maxSnippet]
+	}
+	return &Crash{
+		Path:    path,
+		Rule:    rule,
+		Snippet: strings.ToValidUTF8(snippet, ""),
+		Value:   fmt.Sprint(value),
+		Stack:   string(debug.Stack()),
+	}
+}
+
+// runRule runs `chk` on `blk`, converting a panic into a `Crash` attributed
+// to the rule.
+func runRule(name string, chk check.Rule, blk nlp.Block, f *core.File, cfg *core.Config) (alerts []core.Alert, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			alerts, err = nil, newCrash(f.Path, name, blk.Text, r)
+		}
+	}()
+	return chk.Run(blk, f, cfg)
+}
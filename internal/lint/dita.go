@@ -20,6 +20,11 @@ func (l Linter) lintDITA(file *core.File) error {
 		return core.NewE100("lintDITA", errors.New("dita not found"))
 	}
 
+	// NOTE: `dita` can only write its output to a directory.
+	if err := core.CheckWritable("lintDITA"); err != nil {
+		return err
+	}
+
 	tempDir, err := os.MkdirTemp("", "dita-")
 	defer os.RemoveAll(tempDir)
 
@@ -28,7 +33,7 @@ func (l Linter) lintDITA(file *core.File) error {
 	}
 
 	// FIXME: The `dita` command is *slow* (~4s per file)!
-	cmd := exec.Command(dita, []string{
+	cmd := exec.CommandContext(l.context(), dita, []string{
 		"-i",
 		file.Path,
 		"-f",
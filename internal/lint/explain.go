@@ -0,0 +1,59 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// A Trace records a single evaluation of a rule against a block of text.
+type Trace struct {
+	Block   nlp.Block
+	Skipped string       // why the rule didn't run, if it didn't
+	Alerts  []core.Alert // the rule's alerts, relative to the block
+}
+
+// explanation collects the traces of a single rule during `Explain`.
+type explanation struct {
+	rule   string
+	traces []Trace
+}
+
+func (e *explanation) wants(name string) bool {
+	return name == e.rule || strings.HasPrefix(name, e.rule+".")
+}
+
+func (e *explanation) trace(blk nlp.Block, skipped string) {
+	e.traces = append(e.traces, Trace{Block: blk, Skipped: skipped})
+}
+
+func (e *explanation) record(alerts []core.Alert) {
+	// NOTE: The alerts are modified in-place once they're located, so we
+	// keep a copy.
+	last := &e.traces[len(e.traces)-1]
+	last.Alerts = append(last.Alerts, alerts...)
+}
+
+// Explain lints the file at `src` using only the given rule, returning every
+// block of text that the rule was (or wasn't) run against.
+//
+// This is used by `--why` to debug rules.
+func (l *Linter) Explain(rule, src string) (*core.File, []Trace, error) {
+	store := l.store
+	defer func() {
+		l.explain = nil
+		l.store = store
+	}()
+
+	// We always need to actually run the rule.
+	l.store = nil
+	l.explain = &explanation{rule: rule}
+
+	linted, err := l.Lint([]string{src}, "*")
+	if err != nil || len(linted) == 0 {
+		return nil, nil, err
+	}
+
+	return linted[0], l.explain.traces, nil
+}
@@ -0,0 +1,188 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// failedData is the on-disk representation of a project's failed files: the
+// (absolute) paths of the files that had alerts -- or that crashed or
+// weren't linted in time -- when they were last linted.
+type failedData struct {
+	Files []string
+}
+
+// FailedFilePath returns the path to the list of the project's failed files
+// (see `RecordFailed`).
+//
+// Unlike the baseline, this list is local to a machine, so it's kept in the
+// state directory (keyed by the project's `.vale.ini` file).
+func FailedFilePath(cfg *core.Config) (string, error) {
+	project, err := projectFilePath(cfg, ".vale.ini")
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(project))
+	return core.StatePath(filepath.Join("failed", hex.EncodeToString(sum[:8])+".json"))
+}
+
+// RecordFailed updates the list of the project's failed files -- i.e., those
+// with (visible) alerts, crashes, or that ran out of time -- for
+// `--retry-failed`.
+//
+// As with the baseline, the entries of files that weren't linted are left as
+// is, so that retrying a subset of the failed files only removes those that
+// now pass.
+func RecordFailed(linted []*core.File, crashes []*Crash, unlinted []string, cfg *core.Config) error {
+	if core.ReadOnly {
+		return nil
+	}
+
+	path, err := FailedFilePath(cfg)
+	if err != nil {
+		return core.NewE100("--retry-failed", err)
+	}
+
+	lock, err := core.LockState(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	data, err := readFailed(path)
+	if err != nil {
+		return core.NewE100("--retry-failed", err)
+	}
+
+	failed := map[string]bool{}
+	for _, file := range data.Files {
+		failed[file] = true
+	}
+
+	for _, f := range linted {
+		if abs, ok := failedPath(f.Path); ok {
+			failed[abs] = countVisible(f.Alerts) > 0
+		}
+	}
+
+	others := append([]string{}, unlinted...)
+	for _, c := range crashes {
+		others = append(others, c.Path)
+	}
+	for _, p := range others {
+		if abs, ok := failedPath(p); ok {
+			failed[abs] = true
+		}
+	}
+
+	data.Files = []string{}
+	for file, failing := range failed {
+		if failing {
+			data.Files = append(data.Files, file)
+		}
+	}
+	sort.Strings(data.Files)
+
+	if err = writeFailed(path, data); err != nil {
+		return core.NewE100("--retry-failed", err)
+	}
+	return nil
+}
+
+// FailedFiles returns the files recorded by `RecordFailed` that still exist,
+// limited to those within `paths` (if any are given), relative to the
+// current directory.
+func FailedFiles(paths []string, cfg *core.Config) ([]string, error) {
+	path, err := FailedFilePath(cfg)
+	if err != nil {
+		return nil, core.NewE100("--retry-failed", err)
+	}
+
+	data, err := readFailed(path)
+	if err != nil {
+		return nil, core.NewE100("--retry-failed", err)
+	}
+
+	var roots []string
+	for _, p := range paths {
+		if abs, absErr := filepath.Abs(p); absErr == nil {
+			roots = append(roots, abs)
+		}
+	}
+
+	cwd, _ := os.Getwd()
+
+	files := []string{}
+	for _, file := range data.Files {
+		if !core.FileExists(file) || (len(roots) > 0 && !withinAny(file, roots)) {
+			continue
+		}
+
+		// NOTE: We report paths as they'd be given on the command line.
+		if rel, relErr := filepath.Rel(cwd, file); relErr == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// failedPath returns the absolute path of a linted file, if it's a file on
+// disk (rather than, e.g., a string given on the command line).
+func failedPath(path string) (string, bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil || !core.FileExists(abs) {
+		return "", false
+	}
+	return abs, true
+}
+
+// withinAny reports whether `file` is one of `roots` or inside of one.
+func withinAny(file string, roots []string) bool {
+	for _, root := range roots {
+		if file == root || strings.HasPrefix(file, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func readFailed(path string) (failedData, error) {
+	data := failedData{Files: []string{}}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return data, nil
+	} else if err != nil {
+		return data, err
+	}
+
+	if err = json.Unmarshal(b, &data); err != nil {
+		return data, fmt.Errorf("'%s' is malformed: %w", path, err)
+	}
+	return data, nil
+}
+
+func writeFailed(path string, data failedData) error {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, append(b, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestRecordFailed(t *testing.T) {
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.md", "b.md", "c.md", filepath.Join("sub", "d.md")} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(path, []byte("Text."), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	at := func(name string) string { return filepath.Join(dir, name) }
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.RootINI = at(".vale.ini")
+
+	alert := []core.Alert{{Check: "Vale.Spelling"}}
+	hidden := []core.Alert{{Check: "Vale.Spelling", Hide: true}}
+
+	err = RecordFailed([]*core.File{
+		{Path: at("a.md"), Alerts: alert},
+		{Path: at("b.md"), Alerts: hidden},
+		{Path: at(filepath.Join("sub", "d.md")), Alerts: alert},
+		{Path: "stdin.md", Alerts: alert},
+	}, []*Crash{{Path: at("c.md")}}, nil, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := FailedFiles(nil, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if expected := []string{at("a.md"), at("c.md"), at(filepath.Join("sub", "d.md"))}; !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+
+	// Retrying a subset only removes the files that now pass.
+	if err = RecordFailed([]*core.File{{Path: at("a.md")}}, nil, nil, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = FailedFiles([]string{at("a.md"), at("sub")}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if expected := []string{at(filepath.Join("sub", "d.md"))}; !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+
+	// Files that have since been removed are skipped.
+	if err = os.Remove(at("c.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = FailedFiles(nil, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if expected := []string{at(filepath.Join("sub", "d.md"))}; !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+}
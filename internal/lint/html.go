@@ -33,6 +33,7 @@ var blockDelimiters = map[string]string{
 	".md":   "\n```\n$1\n```\n",
 	".rst":  "\n::\n\n%s\n",
 	".org":  orgExample,
+	".tex":  "\n\\begin{verbatim}\n$1\n\\end{verbatim}\n",
 }
 
 func applyBlockPatterns(c *core.Config, exts extensionConfig, content string) (string, error) {
@@ -84,6 +85,7 @@ var inlineDelimiters = map[string]string{
 	".md":   "`$1`",
 	".rst":  "``$1``",
 	".org":  "=$1=",
+	".tex":  "\\verb|$1|",
 }
 
 func applyInlinePatterns(c *core.Config, exts extensionConfig, content string) (string, error) {
@@ -0,0 +1,44 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// routeBlock switches `f` to the language detected in `blk` -- and to that
+// language's styles, if the config's `[languages]` section lists any -- for
+// `DetectLanguage = paragraph`. It returns a function that switches back.
+//
+// A block whose language can't be detected (e.g., a short heading) or that
+// has no styles of its own keeps those of the document. A `vale lang`
+// comment takes precedence over detection for the rest of the document.
+func (l *Linter) routeBlock(f *core.File, blk nlp.Block) func() {
+	if strings.HasPrefix(blk.Scope, "raw") || f.LangSet {
+		// NOTE: The `raw` scope is the whole document, whose language we've
+		// already detected.
+		return func() {}
+	}
+
+	lang := nlp.DetectLanguage(blk.Text)
+	if lang == "" || lang == f.NLP.Lang {
+		return func() {}
+	}
+
+	docLang, docStyles := f.NLP.Lang, f.BaseStyles
+
+	f.NLP.Lang = lang
+	if styles, found := l.Manager.Config.Languages[lang]; found {
+		f.BaseStyles = styles
+	}
+
+	return func() {
+		f.BaseStyles = docStyles
+		if !f.LangSet {
+			// A comment in the block itself sets the language from here on,
+			// so we don't switch back over it.
+			f.NLP.Lang = docLang
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	en := "This is the introduction, and it has a typo: teh.\n\n"
+	de := "Die Datei wird aus dem Verzeichnis gelesen und kann nicht geändert werden.\n"
+
+	cases := []struct {
+		unit     string
+		text     string
+		expected int
+	}{
+		{"", de, 10},
+		{"document", de, 0},
+		{"document", en + de, 11},
+		{"paragraph", en + de, 1},
+	}
+
+	for _, tc := range cases {
+		linter, err := initLinter()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cfg := linter.Manager.Config
+		cfg.DetectLanguage = tc.unit
+		cfg.Languages = map[string][]string{"de": {}}
+
+		f, err := linter.LintContent("test.md", tc.text)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(f.Alerts) != tc.expected {
+			t.Errorf("'%s': expected %d alerts, got %d: %v", tc.unit, tc.expected, len(f.Alerts), f.Alerts)
+		}
+	}
+}
+
+func TestRouteBlockLangComment(t *testing.T) {
+	de := "Die Datei wird aus dem Verzeichnis gelesen und kann nicht geändert werden."
+
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := core.NewFileFromContent("test.md", "", linter.Manager.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.NLP.Lang = "en"
+
+	// A comment within a routed block isn't undone by switching back.
+	restore := linter.routeBlock(f, nlp.NewBlock(de, de, "text.md"))
+	if f.NLP.Lang != "de" {
+		t.Fatalf("Expected the block to be routed to 'de', got '%s'", f.NLP.Lang)
+	}
+	f.UpdateComments("vale lang = fr")
+	restore()
+
+	if f.NLP.Lang != "fr" {
+		t.Errorf("Expected the comment's 'fr', got '%s'", f.NLP.Lang)
+	}
+
+	// ... and it takes precedence over detection from then on.
+	linter.routeBlock(f, nlp.NewBlock(de, de, "text.md"))()
+	if f.NLP.Lang != "fr" {
+		t.Errorf("Expected 'fr' to take precedence, got '%s'", f.NLP.Lang)
+	}
+}
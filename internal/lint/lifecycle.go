@@ -0,0 +1,210 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// lifecycleDate is the format of an alert's first-seen date.
+const lifecycleDate = "2006-01-02"
+
+// escalations maps each level that a policy can apply to to the level its
+// alerts are escalated to.
+var escalations = map[string]string{
+	"suggestion": "warning",
+	"warning":    "error",
+}
+
+// alertRecord is the history of a single alert.
+type alertRecord struct {
+	FirstSeen string
+	Runs      int
+}
+
+// lifecycleData is the on-disk representation of a project's alert history,
+// keyed by file (relative to the project) and then by alert ID.
+type lifecycleData struct {
+	Files map[string]map[string]alertRecord
+}
+
+// StateFilePath returns the path to the project's alert history: a
+// `.vale-state.json` file next to its `.vale.ini` file (or in the current
+// directory, if there isn't one).
+//
+// Unlike the cache, the history is meant to be committed alongside the
+// project so that every run -- on any machine -- shares it.
+func StateFilePath(cfg *core.Config) (string, error) {
+	return projectFilePath(cfg, ".vale-state.json")
+}
+
+// projectFilePath returns the path to `name` next to the project's
+// `.vale.ini` file (or in the current directory, if there isn't one).
+func projectFilePath(cfg *core.Config, name string) (string, error) {
+	if cfg.RootINI != "" {
+		return filepath.Join(filepath.Dir(cfg.RootINI), name), nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cwd, name), nil
+}
+
+// TrackAlerts assigns each alert a stable ID, records it in the project's
+// history, and reports its age (`FirstSeen` and `Runs`).
+//
+// An alert's ID depends on its file, rule, matched text, and occurrence
+// within the file -- but not on its position -- so it survives edits
+// elsewhere in the file. Alerts that are no longer reported are removed from
+// the history of each linted file, while the history of other files is left
+// as is.
+//
+// Alerts that are older than the config's `[policy]` allows are escalated to
+// the next level (e.g., a warning that's been around for 90 days becomes an
+// error).
+//
+// When writes are disabled (see `core.ReadOnly`), the existing history is
+// read but not updated.
+func TrackAlerts(linted []*core.File, cfg *core.Config, now time.Time) error {
+	path, err := StateFilePath(cfg)
+	if err != nil {
+		return core.NewE100("--assign-ids", err)
+	}
+
+	lock, err := core.LockState(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	data, err := readLifecycle(path)
+	if err != nil {
+		return core.NewE100("--assign-ids", err)
+	}
+
+	today := now.Format(lifecycleDate)
+	for _, f := range linted {
+		file := rolloutPath(f.Path, cfg.RootINI)
+
+		previous := data.Files[file]
+		current := map[string]alertRecord{}
+
+		for _, ia := range alertIDs(f, file) {
+			a, id := ia.alert, ia.id
+
+			record, found := previous[id]
+			if !found {
+				record = alertRecord{FirstSeen: today}
+			}
+			record.Runs++
+			current[id] = record
+
+			a.ID, a.FirstSeen, a.Runs = id, record.FirstSeen, record.Runs
+			escalate(a, cfg.Policy, now)
+		}
+
+		if len(current) == 0 {
+			delete(data.Files, file)
+		} else {
+			data.Files[file] = current
+		}
+	}
+
+	if core.ReadOnly {
+		return nil
+	} else if err = writeLifecycle(path, data); err != nil {
+		return core.NewE100("--assign-ids", err)
+	}
+	return nil
+}
+
+// escalate raises the level of `a` if it's older than the policy allows.
+func escalate(a *core.Alert, policy map[string]int, now time.Time) {
+	days, ok := policy[a.Severity]
+	if !ok {
+		return
+	}
+
+	first, err := time.Parse(lifecycleDate, a.FirstSeen)
+	if err != nil {
+		return
+	}
+
+	if now.Sub(first) >= time.Duration(days)*24*time.Hour {
+		a.Severity = escalations[a.Severity]
+	}
+}
+
+// identifiedAlert is an alert along with its ID (see `alertIDs`).
+type identifiedAlert struct {
+	alert *core.Alert
+	id    string
+}
+
+// alertIDs returns each of f's alerts, sorted by position, along with its
+// ID.
+func alertIDs(f *core.File, file string) []identifiedAlert {
+	alerts := f.SortedAlerts()
+
+	ids := make([]identifiedAlert, 0, len(alerts))
+	seen := map[string]int{}
+
+	for i := range alerts {
+		a := &alerts[i]
+		key := a.Check + "\x00" + a.Match
+		seen[key]++
+		ids = append(ids, identifiedAlert{alert: a, id: alertID(file, key, seen[key])})
+	}
+
+	return ids
+}
+
+// alertID identifies the `occurrence`-th alert with the given key in `file`.
+func alertID(file, key string, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d", file, key, occurrence)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func readLifecycle(path string) (lifecycleData, error) {
+	data := lifecycleData{Files: map[string]map[string]alertRecord{}}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return data, nil
+	} else if err != nil {
+		return data, err
+	}
+
+	if err = json.Unmarshal(b, &data); err != nil {
+		return data, fmt.Errorf("'%s' is malformed: %w", path, err)
+	} else if data.Files == nil {
+		data.Files = map[string]map[string]alertRecord{}
+	}
+
+	return data, nil
+}
+
+func writeLifecycle(path string, data lifecycleData) error {
+	// NOTE: The history is meant to be committed, so we indent it to keep
+	// its diffs readable.
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,61 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestTrackAlerts(t *testing.T) {
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	cfg.RootINI = filepath.Join(root, ".vale.ini")
+	cfg.Policy["warning"] = 90
+
+	lintedAt := func(day time.Time, alerts ...core.Alert) []core.Alert {
+		f := &core.File{Path: filepath.Join(root, "docs", "a.md"), Alerts: alerts}
+		if trackErr := TrackAlerts([]*core.File{f}, cfg, day); trackErr != nil {
+			t.Fatal(trackErr)
+		}
+		return f.Alerts
+	}
+
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	first := lintedAt(start,
+		core.Alert{Check: "Vale.Spelling", Match: "teh", Line: 1, Span: []int{1, 3}, Severity: "warning"},
+		core.Alert{Check: "Vale.Spelling", Match: "teh", Line: 4, Span: []int{1, 3}, Severity: "warning"})
+
+	if first[0].ID == "" || first[0].ID == first[1].ID {
+		t.Fatalf("Expected distinct IDs, got %+v", first)
+	} else if first[0].FirstSeen != "2026-01-01" || first[0].Runs != 1 {
+		t.Errorf("Expected a new alert, got %+v", first[0])
+	}
+
+	// The first alert moved (e.g., a line was added above it) and the
+	// second was fixed.
+	later := lintedAt(start.AddDate(0, 0, 30),
+		core.Alert{Check: "Vale.Spelling", Match: "teh", Line: 2, Span: []int{5, 7}, Severity: "warning"})
+
+	if later[0].ID != first[0].ID || later[0].FirstSeen != "2026-01-01" || later[0].Runs != 2 {
+		t.Errorf("Expected the same alert, seen twice, got %+v", later[0])
+	} else if later[0].Severity != "warning" {
+		t.Errorf("Expected a 30-day-old warning to stay a warning, got %s", later[0].Severity)
+	}
+
+	old := lintedAt(start.AddDate(0, 0, 90),
+		core.Alert{Check: "Vale.Spelling", Match: "teh", Line: 2, Span: []int{5, 7}, Severity: "warning"},
+		core.Alert{Check: "Vale.Spelling", Match: "teh", Line: 9, Span: []int{1, 3}, Severity: "warning"})
+
+	if old[0].Severity != "error" || old[0].Runs != 3 {
+		t.Errorf("Expected a 90-day-old warning to become an error, got %+v", old[0])
+	} else if old[1].FirstSeen != "2026-04-01" || old[1].Severity != "warning" {
+		t.Errorf("Expected the reappearing alert to be new, got %+v", old[1])
+	}
+}
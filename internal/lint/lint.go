@@ -1,12 +1,19 @@
 package lint
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/errata-ai/regexp2"
 	"github.com/karrick/godirwalk"
 	"github.com/remeh/sizedwaitgroup"
 
@@ -23,6 +30,18 @@ type Linter struct {
 	Manager   *check.Manager
 	glob      *glob.Glob
 	client    *http.Client
+	store     *ResultStore
+	explain   *explanation
+	stats     *ruleStats
+	scopes    *[]ScopedBlock
+	accepted  *regexp2.Regexp   // the vocabularies' `patterns.txt` entries
+	shard     *shard            // the files to lint (see `--shard`)
+	deadline  time.Time         // when to stop linting new files, if set
+	ctx       context.Context   // cancels the current run (see `LintWithContext`)
+	Skipped   map[string]string // files skipped for not being text -> reason
+	Crashes   []*Crash          // files skipped due to a recovered panic
+	Unlinted  []string          // files skipped for exceeding `--max-duration`
+	OnLinted  func(*core.File)  // called as soon as each file has been linted
 	HasDir    bool
 	nonGlobal bool
 }
@@ -30,6 +49,7 @@ type Linter struct {
 type lintResult struct {
 	file *core.File
 	err  error
+	seq  int // the order in which the file was found
 }
 
 // NewLinter initializes a Linter.
@@ -39,11 +59,35 @@ func NewLinter(cfg *core.Config) (*Linter, error) {
 	globalStyles := len(cfg.GBaseStyles)
 	globalChecks := len(cfg.GChecks)
 
-	return &Linter{
+	linter := &Linter{
 		Manager: mgr,
 
-		client:    http.DefaultClient,
-		nonGlobal: globalStyles+globalChecks == 0}, err
+		client:    &http.Client{Timeout: urlTimeout},
+		nonGlobal: globalStyles+globalChecks == 0}
+
+	if cfg.Flags.Stats {
+		linter.stats = newRuleStats()
+	}
+
+	if cfg.Flags.Jobs < 0 {
+		err = core.NewE100("--jobs", fmt.Errorf("'%d' must be a positive number", cfg.Flags.Jobs))
+	}
+
+	if err == nil && cfg.Flags.Shard != "" {
+		if linter.shard, err = parseShard(cfg.Flags.Shard); err != nil {
+			err = core.NewE100("--shard", err)
+		}
+	}
+
+	if err == nil && len(cfg.AcceptedPatterns) > 0 {
+		linter.accepted, err = compileAccepted(cfg.AcceptedPatterns)
+	}
+
+	if err == nil && cfg.Flags.Checkpoint != "" {
+		linter.store, err = OpenStore(cfg.Flags.Checkpoint, cfg)
+	}
+
+	return linter, err
 }
 
 // Transform applies the configured transformations to text and returns the
@@ -66,13 +110,70 @@ func (l *Linter) Transform(f *core.File) (string, error) {
 // LintString src according to its format.
 func (l *Linter) LintString(src string) ([]*core.File, error) {
 	linted := l.lintFile(src)
+	if linted.err == nil && l.OnLinted != nil {
+		l.OnLinted(linted.file)
+	}
 	return []*core.File{linted.file}, linted.err
 }
 
+// LintContent lints `content` as if it were the file at `path`, which
+// doesn't need to exist.
+func (l *Linter) LintContent(path, content string) (*core.File, error) {
+	return l.LintContentWithContext(context.Background(), path, content)
+}
+
+// LintContentWithContext is like `LintContent`, but it stops -- returning
+// `ctx.Err()` -- as soon as `ctx` is done.
+func (l *Linter) LintContentWithContext(ctx context.Context, path, content string) (*core.File, error) {
+	l.ctx = ctx
+
+	file, err := core.NewFileFromContent(path, content, l.Manager.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	linted := l.lintFormat(file)
+	if linted.err == nil && l.OnLinted != nil {
+		l.OnLinted(linted.file)
+	}
+	return linted.file, linted.err
+}
+
+// LintComment lints `text` as a GitHub Flavored Markdown fragment, such as a
+// pull request description or an issue comment (see `--format=gfm-comment`).
+//
+// The fragment is never looked up on disk, it's always parsed as GFM, and
+// rules that check a document's structure (see `check.IsStructural`) don't
+// apply to it.
+func (l *Linter) LintComment(text string) (*core.File, error) {
+	file, err := core.NewFileFromContent("comment.md", text, l.Manager.Config)
+	if err != nil {
+		return nil, err
+	}
+	file.Flavor = "gfm"
+	file.Fragment = true
+
+	linted := l.lintFormat(file)
+	if linted.err == nil && l.OnLinted != nil {
+		l.OnLinted(linted.file)
+	}
+	return linted.file, linted.err
+}
+
 // Lint src according to its format.
 func (l *Linter) Lint(input []string, pat string) ([]*core.File, error) {
+	return l.LintWithContext(context.Background(), input, pat)
+}
+
+// LintWithContext is like `Lint`, but it stops -- returning `ctx.Err()` --
+// as soon as `ctx` is done: no new files are started, the rules running on
+// files in progress are abandoned, and any external commands (such as
+// `asciidoctor`) are killed.
+func (l *Linter) LintWithContext(ctx context.Context, input []string, pat string) ([]*core.File, error) {
 	var linted []*core.File
 
+	l.ctx = ctx
+
 	done := make(chan core.File)
 	defer close(done)
 
@@ -86,11 +187,23 @@ func (l *Linter) Lint(input []string, pat string) ([]*core.File, error) {
 	}
 
 	l.glob = &gp
+	l.Skipped = map[string]string{}
+	l.Crashes = nil
+	l.Unlinted = nil
+	l.deadline = time.Time{}
+	if budget := l.Manager.Config.Flags.MaxDuration; budget > 0 {
+		l.deadline = time.Now().Add(budget)
+	}
+
 	for _, src := range input {
 		filesChan, errChan := l.lintFiles(done, src)
 
-		for result := range filesChan {
-			if result.err != nil {
+		for result := range inOrder(done, filesChan) {
+			var crash *Crash
+			if errors.As(result.err, &crash) {
+				l.Crashes = append(l.Crashes, crash)
+				continue
+			} else if result.err != nil {
 				err = l.teardown()
 				if err != nil {
 					return linted, err
@@ -100,6 +213,9 @@ func (l *Linter) Lint(input []string, pat string) ([]*core.File, error) {
 				result.file.Path = filepath.ToSlash(result.file.Path)
 			}
 			linted = append(linted, result.file)
+			if l.OnLinted != nil {
+				l.OnLinted(result.file)
+			}
 		}
 
 		if err = <-errChan; err != nil {
@@ -111,6 +227,15 @@ func (l *Linter) Lint(input []string, pat string) ([]*core.File, error) {
 		}
 	}
 
+	linted, err = l.lintIncludes(linted)
+	if err != nil {
+		terr := l.teardown()
+		if terr != nil {
+			return linted, terr
+		}
+		return linted, err
+	}
+
 	err = l.teardown()
 	if err != nil {
 		return linted, err
@@ -126,24 +251,42 @@ func (l *Linter) lintFiles(done <-chan core.File, root string) (<-chan lintResul
 	errChan := make(chan error, 1)
 
 	go func() {
-		wg := sizedwaitgroup.New(5)
+		wg := sizedwaitgroup.New(l.jobs())
+		seq := 0
 
 		err := godirwalk.Walk(root, &godirwalk.Options{
 			Callback: func(fp string, de *godirwalk.Dirent) error {
+				if err := l.context().Err(); err != nil {
+					return err
+				}
+
 				if de.IsDir() && core.ShouldIgnoreDirectory(fp) {
 					return godirwalk.SkipThis
 				} else if de.IsDir() || l.skip(fp) {
 					return nil
+				} else if l.shard != nil && !l.shard.includes(rolloutPath(fp, l.Manager.Config.RootINI)) {
+					return nil
+				} else if reason := walkedNotText(fp, root); reason != "" {
+					l.Skipped[fp] = reason
+					return nil
+				} else if l.expired() {
+					// We finish the files that are in progress, but we don't
+					// start any new ones.
+					l.Unlinted = append(l.Unlinted, fp)
+					return nil
 				}
 
 				wg.Add()
-				go func(fp string) {
+				go func(fp string, seq int) {
+					result := l.lintStored(fp)
+					result.seq = seq
 					select {
-					case filesChan <- l.lintFile(fp):
+					case filesChan <- result:
 					case <-done:
 					}
 					wg.Done()
-				}(fp)
+				}(fp, seq)
+				seq++
 
 				// Abort the walk if done is closed.
 				select {
@@ -153,7 +296,7 @@ func (l *Linter) lintFiles(done <-chan core.File, root string) (<-chan lintResul
 					return nil
 				}
 			},
-			Unsorted:            true,
+			Unsorted:            false,
 			AllowNonDirectory:   true,
 			FollowSymbolicLinks: true,
 		})
@@ -170,15 +313,208 @@ func (l *Linter) lintFiles(done <-chan core.File, root string) (<-chan lintResul
 	return filesChan, errChan
 }
 
-// lintFile creates a new `File` from the path `src` and selects a linter based
-// on its format.
-func (l *Linter) lintFile(src string) lintResult {
-	var err error
+// inOrder passes on the results of a walk -- which may finish in any order
+// -- in the order in which their files were found, buffering any that finish
+// early.
+func inOrder(done <-chan core.File, results <-chan lintResult) <-chan lintResult {
+	ordered := make(chan lintResult)
+
+	go func() {
+		defer close(ordered)
+
+		next := 0
+		pending := map[int]lintResult{}
+
+		for result := range results {
+			pending[result.seq] = result
+			for {
+				r, found := pending[next]
+				if !found {
+					break
+				}
+				delete(pending, next)
+				next++
+
+				select {
+				case ordered <- r:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	return ordered
+}
+
+// jobs returns the number of files to lint at once (see `--jobs`).
+func (l *Linter) jobs() int {
+	if n := l.Manager.Config.Flags.Jobs; n > 0 {
+		return n
+	}
+	return runtime.NumCPU()
+}
+
+// maxFileSize is the size (in bytes) above which we assume a file isn't
+// prose meant to be linted.
+const maxFileSize = 10 << 20
+
+// sniffSize is the number of bytes we inspect to decide if a file is text.
+const sniffSize = 8000
+
+// maxBinaryRatio is the share of a file's sampled bytes that may be control
+// characters (or invalid UTF-8) before we assume it's binary.
+const maxBinaryRatio = 0.1
+
+// maxMinifiedLine is the average line length above which we assume a script
+// or stylesheet has been minified.
+const maxMinifiedLine = 500
+
+// assetExts are extensions of files that are never text, allowing us to skip
+// them without reading them.
+var assetExts = []string{
+	".png", ".jpg", ".jpeg", ".gif", ".bmp", ".ico", ".webp", ".tif", ".tiff",
+	".psd", ".pdf", ".zip", ".gz", ".tgz", ".bz2", ".xz", ".7z", ".rar", ".tar",
+	".jar", ".war", ".class", ".exe", ".dll", ".so", ".dylib", ".o", ".a",
+	".wasm", ".woff", ".woff2", ".ttf", ".otf", ".eot", ".mp3", ".mp4", ".m4a",
+	".mov", ".avi", ".webm", ".wav", ".ogg", ".flac", ".sqlite", ".db", ".pyc",
+}
+
+// bundleExts are extensions of files that are often minified.
+var bundleExts = []string{".js", ".mjs", ".cjs", ".css", ".json", ".map"}
+
+// walkedNotText is `notText` for files found by walking `root`; a file that
+// was named explicitly (i.e., `root` itself) is always linted.
+func walkedNotText(fp, root string) string {
+	if fp == filepath.Clean(root) {
+		return ""
+	}
+	return notText(fp)
+}
+
+// notText returns the reason that the file at `fp` shouldn't be linted -- it's
+// binary, minified, or oversized -- or an empty string if it should be.
+func notText(fp string) string {
+	ext := strings.ToLower(filepath.Ext(fp))
+	if core.StringInSlice(ext, assetExts) {
+		return "binary"
+	} else if ext == ".map" || strings.Contains(strings.ToLower(filepath.Base(fp)), ".min.") {
+		return "minified"
+	}
+
+	info, err := os.Stat(fp)
+	if err != nil {
+		return ""
+	} else if info.Size() > maxFileSize {
+		return "oversized"
+	}
+
+	if strings.HasPrefix(core.EditorConfig(fp)["charset"], "utf-16") {
+		// UTF-16 text is full of null bytes.
+		return ""
+	}
+
+	f, err := os.Open(fp)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	buf := make([]byte, sniffSize)
+	n, _ := f.Read(buf)
+
+	if looksBinary(buf[:n]) {
+		return "binary"
+	} else if core.StringInSlice(ext, bundleExts) && looksMinified(buf[:n]) {
+		return "minified"
+	}
+
+	return ""
+}
 
+// looksBinary reports whether `sample` (the start of a file) contains a null
+// byte or too many control characters and invalid UTF-8 sequences to be text.
+func looksBinary(sample []byte) bool {
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	} else if len(sample) == 0 {
+		return false
+	}
+
+	suspicious := 0
+	for i := 0; i < len(sample); {
+		r, size := utf8.DecodeRune(sample[i:])
+		if r == utf8.RuneError && size == 1 && len(sample)-i >= utf8.UTFMax {
+			// NOTE: We don't count a sequence that may have been cut off by
+			// the end of our sample.
+			suspicious++
+		} else if r < 0x20 && !strings.ContainsRune("\t\n\r\f\v\x1b", r) {
+			suspicious++
+		}
+		i += size
+	}
+
+	return float64(suspicious)/float64(len(sample)) > maxBinaryRatio
+}
+
+// looksMinified reports whether `sample` (the start of a script, stylesheet,
+// or data file) has been minified -- i.e., its lines are very long.
+func looksMinified(sample []byte) bool {
+	lines := bytes.Count(sample, []byte("\n")) + 1
+	return len(sample)/lines > maxMinifiedLine
+}
+
+// lintStored returns the stored results for the file at `fp` if it hasn't
+// changed since it was last linted; otherwise, it lints the file and records
+// the results.
+func (l *Linter) lintStored(fp string) lintResult {
+	if l.store == nil {
+		return l.lintFile(fp)
+	}
+
+	key, err := filepath.Abs(fp)
+	if err != nil {
+		return lintResult{err: err}
+	}
+
+	content, err := os.ReadFile(fp)
+	if err != nil {
+		return lintResult{err: err}
+	} else if alerts, found := l.store.Lookup(key, content); found {
+		return lintResult{file: &core.File{Path: fp, Alerts: alerts}}
+	}
+
+	linted := l.lintFile(fp)
+	if linted.err == nil {
+		linted.err = l.store.Record(key, content, linted.file.Alerts, linted.file.Depends...)
+	}
+
+	return linted
+}
+
+// lintFile creates a new `File` from the path `src` and lints it.
+func (l *Linter) lintFile(src string) lintResult {
 	file, err := core.NewFile(src, l.Manager.Config)
 	if err != nil {
 		return lintResult{err: err}
-	} else if len(file.Checks) == 0 && len(file.BaseStyles) == 0 {
+	}
+	return l.lintFormat(file)
+}
+
+// lintFormat selects a linter based on the format of `file`.
+//
+// A panic -- in a rule or while parsing the file's markup -- is returned as a
+// `Crash`.
+func (l *Linter) lintFormat(file *core.File) (result lintResult) {
+	var err error
+
+	defer func() {
+		if r := recover(); r != nil {
+			result = lintResult{file: file, err: newCrash(file.Path, "", file.Content, r)}
+		}
+	}()
+
+	if len(file.Checks) == 0 && len(file.BaseStyles) == 0 {
 		if len(l.Manager.Config.GBaseStyles) == 0 && len(l.Manager.Config.GChecks) == 0 {
 			// There's nothing to do; bail early.
 			return lintResult{file: file}
@@ -190,7 +526,9 @@ func (l *Linter) lintFile(src string) lintResult {
 	file.NLP = l.Manager.AssignNLP(file)
 	simple := l.Manager.Config.Flags.Simple
 
+	var refs []attributeRef
 	if file.Format == "markup" && !simple { //nolint:gocritic
+		refs = substituteAttributes(file, l.Manager.Config)
 		switch file.NormedExt {
 		case ".adoc":
 			err = l.lintADoc(file)
@@ -206,11 +544,15 @@ func (l *Linter) lintFile(src string) lintResult {
 			err = l.lintHTML(file)
 		case ".org":
 			err = l.lintOrg(file)
+		case ".tex":
+			err = l.lintTeX(file)
 		}
 	} else if file.Format == "code" && !simple {
 		err = l.lintCode(file)
 	} else if file.Format == "fragment" && !simple {
 		err = l.lintFragments(file)
+	} else if file.Format == "resource" && !simple {
+		err = l.lintResource(file)
 	} else if file.NormedExt == ".txt" && !simple {
 		err = l.lintTxt(file)
 	} else {
@@ -218,6 +560,10 @@ func (l *Linter) lintFile(src string) lintResult {
 	}
 
 	if err == nil {
+		// NOTE: This must happen before we lint the `raw` scope, which sees
+		// the original text.
+		restoreAttributes(file, refs)
+
 		// Run all rules with `scope: raw`
 		//
 		// NOTE: We need to use `f.Lines` (instead of `f.Content`) to ensure
@@ -228,7 +574,7 @@ func (l *Linter) lintFile(src string) lintResult {
 		err = l.lintBlock(file, raw, len(file.Lines), 0, true)
 	}
 
-	return lintResult{file, err}
+	return lintResult{file: file, err: err}
 }
 
 func (l *Linter) lintProse(f *core.File, blk nlp.Block, lines int) error {
@@ -266,19 +612,69 @@ func (l *Linter) lintLines(f *core.File) error {
 
 func (l *Linter) lintBlock(f *core.File, blk nlp.Block, lines, pad int, lookup bool) error {
 	f.ChkToCtx = make(map[string]string)
-	for name, chk := range l.Manager.Rules() {
+	if l.Manager.Config.DetectLanguage == "paragraph" {
+		defer l.routeBlock(f, blk)()
+	}
+	if l.scopes != nil {
+		l.recordScope(f, blk)
+	}
+
+	found := 0
+	if l.stats != nil {
+		defer func() { l.stats.recordBlock(f, blk, found) }()
+	}
+
+	var accepted [][]int
+	if l.accepted != nil {
+		accepted = l.accepted.FindAllStringIndex(blk.Text, -1)
+	}
+
+	ctx := l.context()
+
+	rules := l.Manager.Rules()
+	for _, name := range l.Manager.Order() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		chk := rules[name]
+		if l.explain != nil {
+			if !l.explain.wants(name) {
+				continue
+			}
+			l.explain.trace(blk, l.skipReason(name, f, chk, blk))
+		}
+
 		if !l.shouldRun(name, f, chk, blk) {
 			continue
 		}
 
 		info := chk.Fields()
 
-		alerts, err := chk.Run(blk, f, l.Manager.Config)
+		start := time.Now()
+		alerts, err := runRule(name, chk, blk, f, l.Manager.Config)
 		if err != nil {
 			return err
+		} else if len(accepted) > 0 {
+			alerts = dropAccepted(alerts, accepted)
+		}
+
+		if l.explain != nil {
+			l.explain.record(alerts)
+		}
+
+		if l.stats != nil {
+			visible := countVisible(alerts)
+			l.stats.record(name, time.Since(start), visible)
+			found += visible
 		}
+
+		level := f.QueryLevel(name)
 		for i := range alerts {
 			core.FormatAlert(&alerts[i], info.Limit, info.Level, name)
+			if level != "" && level != alerts[i].Severity {
+				overrideLevel(&alerts[i], level)
+			}
 			f.AddAlert(alerts[i], blk, lines, pad, lookup)
 		}
 	}
@@ -286,7 +682,52 @@ func (l *Linter) lintBlock(f *core.File, blk nlp.Block, lines, pad int, lookup b
 	return nil
 }
 
+// compileAccepted combines the accepted patterns into a single expression.
+func compileAccepted(patterns []string) (*regexp2.Regexp, error) {
+	groups := make([]string, len(patterns))
+	for i, p := range patterns {
+		groups[i] = "(?:" + p + ")"
+	}
+	return regexp2.CompileStd(strings.Join(groups, "|"))
+}
+
+// dropAccepted removes the alerts whose matches are entirely within one of
+// the (rune) locations in `accepted`.
+func dropAccepted(alerts []core.Alert, accepted [][]int) []core.Alert {
+	kept := alerts[:0]
+	for _, a := range alerts {
+		inside := false
+		for _, loc := range accepted {
+			if len(a.Span) == 2 && loc[0] <= a.Span[0] && a.Span[1] <= loc[1] {
+				inside = true
+				break
+			}
+		}
+		if !inside {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
+// overrideLevel changes an alert's severity to one assigned by an in-text
+// comment, noting the change in its description so that it isn't silent.
+func overrideLevel(a *core.Alert, level string) {
+	note := fmt.Sprintf("Severity changed from '%s' by an in-text comment.", a.Severity)
+	if a.Description != "" {
+		note = a.Description + " " + note
+	}
+	a.Severity = level
+	a.Description = note
+}
+
 func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk nlp.Block) bool {
+	return l.skipReason(name, f, chk, blk) == ""
+}
+
+// skipReason explains why the given rule shouldn't run on `blk`, returning
+// an empty string if it should.
+func (l *Linter) skipReason(name string, f *core.File, chk check.Rule, blk nlp.Block) string {
 	minLevel := l.Manager.Config.MinAlertLevel
 	run := false
 
@@ -299,20 +740,33 @@ func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk nlp.Bl
 		name = strings.Join([]string{list[0], list[1]}, ".")
 	}
 
+	level := details.Level
+	if override := f.QueryLevel(name); override != "" {
+		level = override
+	}
+
 	chkScope := check.NewScope(details.Scope)
 	if f.QueryComments(name) { //nolint:gocritic
 		// It has been disabled via an in-text comment.
-		return false
-	} else if core.LevelToInt[details.Level] < minLevel {
-		return false
+		return "disabled by an in-text comment"
+	} else if core.LevelToInt[level] < minLevel {
+		return fmt.Sprintf("its level ('%s') is below MinAlertLevel", level)
 	} else if !chkScope.Matches(blk) {
-		return false
+		return fmt.Sprintf("its scope %v doesn't match '%s'", details.Scope, blk.Scope)
+	} else if !details.MatchesLang(f.NLP.Lang) {
+		return fmt.Sprintf("its lang %v doesn't match '%s'", details.Lang, f.NLP.Lang)
+	} else if l.Manager.Config.Flags.Profile == "quick" && check.IsExpensive(chk) {
+		return "it's too expensive for the 'quick' profile"
+	} else if l.Manager.Config.Flags.Editor && check.IsDocumentScoped(chk) {
+		return "it's document-scoped, which '--editor' skips"
+	} else if f.Fragment && check.IsStructural(chk) {
+		return "it checks document structure, which fragments don't have"
 	}
 
 	// Has the check been disabled for this extension?
 	if val, ok := f.Checks[name]; ok && !run {
 		if !val {
-			return false
+			return "disabled for this file's section in the config"
 		}
 		run = true
 	}
@@ -320,17 +774,17 @@ func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk nlp.Bl
 	// Has the check been disabled for all extensions?
 	if val, ok := l.Manager.Config.GChecks[name]; ok && !run {
 		if !val {
-			return false
+			return "disabled globally in the config"
 		}
 		run = true
 	}
 
 	style := strings.Split(name, ".")[0]
 	if !run && !core.StringInSlice(style, f.BaseStyles) {
-		return false
+		return fmt.Sprintf("'%s' isn't in BasedOnStyles for this file", style)
 	}
 
-	return true
+	return l.rolloutReason(name, f)
 }
 
 // setup handles any necessary building, compiling, or pre-processing.
@@ -338,7 +792,27 @@ func (l *Linter) setup() error {
 	return nil
 }
 
+// expired reports whether the run has exceeded its time budget (see
+// `--max-duration`).
+func (l *Linter) expired() bool {
+	return !l.deadline.IsZero() && time.Now().After(l.deadline)
+}
+
+// context returns the context of the current run.
+func (l *Linter) context() context.Context {
+	if l.ctx == nil {
+		return context.Background()
+	}
+	return l.ctx
+}
+
 func (l *Linter) teardown() error {
+	if l.store != nil {
+		if err := l.store.Save(); err != nil {
+			return err
+		}
+	}
+
 	for _, pid := range l.pids {
 		if p, err := os.FindProcess(pid); err == nil {
 			if p.Kill() != nil {
@@ -1,11 +1,23 @@
 package lint
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/errata-ai/vale/v3/internal/check"
 	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
 )
 
 func TestGenderBias(t *testing.T) {
@@ -87,3 +99,845 @@ func BenchmarkLintRST(b *testing.B) {
 func BenchmarkLintMD(b *testing.B) {
 	benchmarkLint(b, "../../testdata/fixtures/benchmarks/bench.md")
 }
+
+func TestWrappedRepetition(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "wrapped.md")
+	content := "A hard-wrapped paragraph that ends with the\nthe next line.\n\n" +
+		"- A list item that ends with a\n  a continuation.\n\n" +
+		"Separate paragraphs that end and\n\nand start with a repeat.\n"
+
+	if err = os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	linted, err := linter.Lint([]string{path}, "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []int{}
+	for _, a := range linted[0].Alerts {
+		if a.Check == "Vale.Repetition" {
+			lines = append(lines, a.Line)
+		}
+	}
+
+	if len(lines) != 2 || lines[0] != 1 || lines[1] != 4 {
+		t.Errorf("Expected alerts on lines [1 4], got %v", lines)
+	}
+}
+
+func TestRuleStats(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	} else if linter.Stats() != nil {
+		t.Fatal("Expected no stats without '--stats'")
+	}
+	linter.stats = newRuleStats()
+
+	linted, err := linter.LintString("This is is a test.\n")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted[0].Alerts) == 0 {
+		t.Fatal("Expected an alert")
+	}
+
+	stats := linter.Stats()
+	if _, ok := stats.Rules["Vale.References"]; !ok {
+		t.Error("Expected an entry for every loaded rule")
+	}
+
+	rep := stats.Rules["Vale.Repetition"]
+	if rep.Invocations == 0 || rep.Matches != 1 {
+		t.Errorf("Unexpected stats for Vale.Repetition: %+v", rep)
+	}
+}
+
+func TestScopes(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "scopes.md")
+	content := "# Title\n\nSome text.\n\n<!-- vale Vale.Repetition = NO -->\nMore text.\n"
+	if err = os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, blocks, err := linter.Scopes(path)
+	if err != nil {
+		t.Fatal(err)
+	} else if linter.scopes != nil {
+		t.Error("Expected scope recording to be reset")
+	}
+
+	disabled := map[string]int{}
+	for _, b := range blocks {
+		disabled[b.Block.Text] = b.Skipped["disabled by an in-text comment"]
+	}
+
+	if _, ok := disabled["Title"]; !ok {
+		t.Errorf("Expected a block for the heading, got %v", disabled)
+	} else if disabled["Some text."] != 0 || disabled["More text."] != 1 {
+		t.Errorf("Expected one rule to be disabled for 'More text.', got %v", disabled)
+	}
+}
+
+func TestCommentLevels(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "levels.md")
+	content := "This is is one.\n\n<!-- vale Vale.Repetition = suggestion -->\n\n" +
+		"This is is two.\n\n<!-- vale Vale = warning -->\n\nThis is is three.\n"
+	if err = os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	linted, err := linter.Lint([]string{path}, "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	levels := map[int]string{}
+	for _, a := range linted[0].Alerts {
+		levels[a.Line] = a.Severity
+		if a.Line > 1 && !strings.Contains(a.Description, "in-text comment") {
+			t.Errorf("Expected line %d's description to note the change: %q", a.Line, a.Description)
+		}
+	}
+
+	// The rule's own comment takes precedence over its style's.
+	expected := map[int]string{1: "error", 5: "suggestion", 9: "suggestion"}
+	for line, level := range expected {
+		if levels[line] != level {
+			t.Errorf("Expected '%s' on line %d, got %v", level, line, levels)
+		}
+	}
+}
+
+func TestRollout(t *testing.T) {
+	included := 0
+	for i := 0; i < 1000; i++ {
+		path := rolloutPath(filepath.Join("docs", strconv.Itoa(i)+".md"), "")
+		if inRollout(path, "Style.Rule", 30) {
+			included++
+		}
+		if inRollout(path, "Style.Rule", 30) != inRollout(path, "Style.Rule", 30) {
+			t.Fatalf("Expected '%s' to be assigned deterministically", path)
+		}
+	}
+
+	if included < 250 || included > 350 {
+		t.Errorf("Expected roughly 30%% of files to be included, got %d/1000", included)
+	}
+
+	root := filepath.Join(t.TempDir(), ".vale.ini")
+	abs := filepath.Join(filepath.Dir(root), "docs", "a.md")
+	if path := rolloutPath(abs, root); path != "docs/a.md" {
+		t.Errorf("Expected a project-relative path, got '%s'", path)
+	}
+
+	for i := 0; i < 100; i++ {
+		path := strconv.Itoa(i)
+		if !inRollout(path, "Style.Rule", 100) || inRollout(path, "Style.Rule", 0) {
+			t.Fatal("Expected 0% and 100% to include none and all files")
+		}
+	}
+}
+
+func TestNotText(t *testing.T) {
+	dir := t.TempDir()
+
+	minified := "var a=1;" + strings.Repeat("function f(){return a+1};", 200)
+	control := strings.Repeat("text\x01\x02\x03\x04", 100)
+
+	cases := map[string]struct {
+		content string
+		reason  string
+	}{
+		"README.md":      {"# Title\n\nSome text.\n", ""},
+		"logo.png":       {"# Not really an image.\n", "binary"},
+		"data.bin":       {"abc\x00def", "binary"},
+		"noise.txt":      {control, "binary"},
+		"bundle.js":      {minified, "minified"},
+		"app.min.css":    {"body{}", "minified"},
+		"script.js":      {"function f() {\n  return 1;\n}\n", ""},
+		"unicode.md":     {strings.Repeat("Ünïcode 😀 text.\n", 100), ""},
+		"long-prose.txt": {strings.Repeat("word ", 2000), ""},
+	}
+
+	for name, tc := range cases {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if reason := notText(path); reason != tc.reason {
+			t.Errorf("%s: expected '%s', got '%s'", name, tc.reason, reason)
+		}
+	}
+}
+
+func TestNotTextExplicit(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "logo.png")
+	if err := os.WriteFile(path, []byte("abc\x00def"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.GBaseStyles = []string{"Vale"}
+
+	linter, err := NewLinter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linted, err := linter.Lint([]string{dir}, "*")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted) != 0 || linter.Skipped[path] != "binary" {
+		t.Errorf("expected a walked binary file to be skipped, got %d files and %v", len(linted), linter.Skipped)
+	}
+
+	linted, err = linter.Lint([]string{path}, "*")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted) != 1 || len(linter.Skipped) != 0 {
+		t.Errorf("expected an explicit binary file to be linted, got %d files and %v", len(linted), linter.Skipped)
+	}
+}
+
+func TestAcceptedPatterns(t *testing.T) {
+	for _, patterns := range [][]string{nil, {`Duran Duran`}} {
+		cfg, err := core.NewConfig(&core.CLIFlags{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.GBaseStyles = []string{"Vale"}
+		cfg.AcceptedPatterns = patterns
+
+		linter, err := NewLinter(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		linted, err := linter.LintString("Play Duran Duran for the the team.")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		matches := []string{}
+		for _, a := range linted[0].Alerts {
+			matches = append(matches, a.Match)
+		}
+
+		expected := "Duran Duran, the the"
+		if patterns != nil {
+			expected = "the the"
+		}
+
+		if actual := strings.Join(matches, ", "); actual != expected {
+			t.Errorf("patterns = %v: expected '%s', got '%s'", patterns, expected, actual)
+		}
+	}
+}
+
+func TestMarkdownFlavors(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "flavors.md")
+	content := "# Install {#install}\n\n| Name | Value |\n| ---- | ----- |\n| a    | b     |\n"
+	if err = os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	scopes := func(flavor string) map[string]string {
+		linter.Manager.Config.MarkdownFlavors["*.md"] = flavor
+
+		_, blocks, scopeErr := linter.Scopes(path)
+		if scopeErr != nil {
+			t.Fatal(scopeErr)
+		}
+
+		found := map[string]string{}
+		for _, b := range blocks {
+			found[b.Block.Text] = b.Block.Scope
+		}
+		return found
+	}
+
+	if found := scopes("gfm"); !strings.HasPrefix(found["Name"], "text.table") {
+		t.Errorf("Expected a table in GFM, got %v", found)
+	}
+
+	if found := scopes("commonmark"); strings.HasPrefix(found["Name"], "text.table") {
+		t.Errorf("Expected no tables in CommonMark, got %v", found)
+	}
+
+	if found := scopes("pandoc"); !strings.HasPrefix(found["Install"], "text.heading") {
+		t.Errorf("Expected Pandoc to strip heading attributes, got %v", found)
+	}
+}
+
+// panicRule panics on any text that contains "boom".
+type panicRule struct{}
+
+func (panicRule) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert, error) {
+	if strings.Contains(blk.Text, "boom") {
+		panic("boom")
+	}
+	return nil, nil
+}
+
+func (panicRule) Fields() check.Definition {
+	return check.Definition{Name: "Vale.Panic", Level: "error", Scope: []string{"text"}}
+}
+
+func (panicRule) Pattern() string { return "" }
+
+func TestCrashRecovery(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	} else if err = linter.Manager.AddRule("Vale.Panic", panicRule{}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.txt": "This is is fine.\n",
+		"b.txt": "This goes boom.\n",
+	} {
+		if err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	linted, err := linter.Lint([]string{dir}, "*")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted) != 1 || filepath.Base(linted[0].Path) != "a.txt" {
+		t.Fatalf("Expected the other file to be linted, got %v", linted)
+	} else if len(linted[0].Alerts) == 0 {
+		t.Error("Expected the other file's alerts")
+	}
+
+	if len(linter.Crashes) != 1 {
+		t.Fatalf("Expected one crash, got %v", linter.Crashes)
+	}
+
+	crash := linter.Crashes[0]
+	if filepath.Base(crash.Path) != "b.txt" || crash.Rule != "Vale.Panic" {
+		t.Errorf("Expected the crash to be attributed to b.txt and Vale.Panic, got %+v", crash)
+	} else if !strings.Contains(crash.Snippet, "boom") || crash.Stack == "" {
+		t.Errorf("Expected the offending text and a stack trace, got %+v", crash)
+	}
+}
+
+func TestScopeStats(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	linter.stats = newRuleStats()
+
+	content := "# Intro\n\nThis is is a test.\n\n## Setup\n\n- A list is is here.\n\n| A | B |\n|---|---|\n| is is | x |\n"
+	if _, err = linter.LintContent("a.md", content); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := linter.Stats()
+	for _, kind := range []string{"body", "list", "table"} {
+		if stats.Scopes[kind].Alerts != 1 {
+			t.Errorf("Expected one alert in '%s', got %+v", kind, stats.Scopes[kind])
+		}
+	}
+
+	if heading := stats.Scopes["heading"]; heading.Words != 2 || heading.Alerts != 0 {
+		t.Errorf("Unexpected heading stats: %+v", heading)
+	}
+
+	intro, setup := stats.Sections["Intro"], stats.Sections["Setup"]
+	if intro.Alerts != 1 || intro.Words != 6 || intro.Density != 166.67 {
+		t.Errorf("Unexpected stats for 'Intro': %+v", intro)
+	} else if setup.Alerts != 2 {
+		t.Errorf("Unexpected stats for 'Setup': %+v", setup)
+	}
+}
+
+func TestSuppressReasons(t *testing.T) {
+	content := `# Title
+
+<!-- vale off: quoting vendor text -->
+
+Teh text.
+
+<!-- vale on -->
+
+<!-- vale Vale.Spelling = NO -->
+
+Teh text.
+`
+	for _, strict := range []bool{false, true} {
+		cfg, err := core.NewConfig(&core.CLIFlags{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.GBaseStyles = []string{"Vale"}
+		cfg.RequireSuppressReason = strict
+
+		linter, err := NewLinter(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := linter.LintContent("test.md", content)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []core.Suppression{
+			{Comment: "vale off", Reason: "quoting vendor text", Line: 3},
+			{Comment: "vale Vale.Spelling = NO", Line: 9},
+		}
+		if !reflect.DeepEqual(f.Suppressed, expected) {
+			t.Errorf("strict = %v: expected %v, got %v", strict, expected, f.Suppressed)
+		}
+
+		checks := []string{}
+		for _, a := range f.SortedAlerts() {
+			checks = append(checks, fmt.Sprintf("%d:%s", a.Line, a.Check))
+		}
+
+		want := ""
+		if strict {
+			want = "9:Vale.SuppressReason, 11:Vale.Spelling"
+		}
+		if actual := strings.Join(checks, ", "); actual != want {
+			t.Errorf("strict = %v: expected '%s', got '%s'", strict, want, actual)
+		}
+	}
+}
+
+func TestLintComment(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err := check.NewReferences(linter.Manager.Config, map[string]interface{}{
+		"extends": "references",
+		"message": "'%s' isn't defined.",
+		"level":   "error",
+	}, "Test.References")
+	if err != nil {
+		t.Fatal(err)
+	} else if err = linter.Manager.AddRule("Test.References", refs); err != nil {
+		t.Fatal(err)
+	}
+
+	text := "Fixes teh bug; see [the docs][docs]."
+
+	f, err := linter.LintContent("test.md", text)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(f.Alerts) != 2 {
+		t.Fatalf("Expected the document to have two alerts, got %v", f.Alerts)
+	}
+
+	f, err = linter.LintComment(text)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(f.Alerts) != 1 || f.Alerts[0].Check != "Vale.Spelling" {
+		t.Fatalf("Expected only the spelling alert for a comment, got %v", f.Alerts)
+	}
+}
+
+func TestMarkdownRawHTML(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, scope := range map[string]string{"Test.Text": "text", "Test.Details": "details"} {
+		rule, rerr := check.NewExistence(linter.Manager.Config, map[string]interface{}{
+			"extends": "existence",
+			"message": "'%s'",
+			"level":   "error",
+			"scope":   scope,
+			"tokens":  []string{"foo"},
+		}, name)
+		if rerr != nil {
+			t.Fatal(rerr)
+		} else if err = linter.Manager.AddRule(name, rule); err != nil {
+			t.Fatal(err)
+		}
+		linter.Manager.Config.GChecks[name] = true
+	}
+
+	text := strings.Join([]string{
+		`Use <a href="foo.html" title="foo">foo</a> and &amp; foo.`,
+		``,
+		`<details><summary>A foo summary</summary>`,
+		``,
+		`<abbr title="foo">ABC</abbr> then foo.`,
+		``,
+		`</details>`,
+		``,
+		"Not `<b title=\"foo\">` but foo.",
+	}, "\n")
+
+	f, err := linter.LintContent("test.md", text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := []string{}
+	for _, a := range f.SortedAlerts() {
+		if strings.HasPrefix(a.Check, "Test.") {
+			found = append(found, fmt.Sprintf("%s:%d:%d", a.Check, a.Line, a.Span[0]))
+		}
+	}
+
+	expected := []string{
+		"Test.Text:1:36", "Test.Text:1:54",
+		"Test.Details:3:21", "Test.Text:3:21",
+		"Test.Text:5:35",
+		"Test.Text:9:27",
+	}
+	if strings.Join(found, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+}
+
+func TestMarkdownAttributes(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	linter.Manager.Config.Attributes["product"] = "Acme cloud"
+
+	for name, token := range map[string]string{"Test.Name": "Acme cloud", "Test.Word": "bar"} {
+		rule, rerr := check.NewExistence(linter.Manager.Config, map[string]interface{}{
+			"extends": "existence",
+			"message": "'%s'",
+			"level":   "error",
+			"scope":   "text",
+			"tokens":  []string{token},
+		}, name)
+		if rerr != nil {
+			t.Fatal(rerr)
+		} else if err = linter.Manager.AddRule(name, rule); err != nil {
+			t.Fatal(err)
+		}
+		linter.Manager.Config.GChecks[name] = true
+	}
+
+	text := strings.Join([]string{
+		"Try {{ product }} and {{product}}, then bar.",
+		"",
+		"Not {{ unknown }} bar.",
+	}, "\n")
+
+	f, err := linter.LintContent("test.md", text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := []string{}
+	for _, a := range f.SortedAlerts() {
+		if strings.HasPrefix(a.Check, "Test.") {
+			found = append(found, fmt.Sprintf("%s:%d:%d-%d", a.Check, a.Line, a.Span[0], a.Span[1]))
+		}
+	}
+
+	expected := []string{
+		"Test.Name:1:5-17", "Test.Name:1:23-33", "Test.Word:1:41-43",
+		"Test.Word:3:19-21",
+	}
+	if strings.Join(found, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+}
+
+func TestMarkdownCitations(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := check.NewExistence(linter.Manager.Config, map[string]interface{}{
+		"extends": "existence",
+		"message": "'%s'",
+		"level":   "error",
+		"scope":   "text",
+		"tokens":  []string{"doe"},
+	}, "Test.Doe")
+	if err != nil {
+		t.Fatal(err)
+	} else if err = linter.Manager.AddRule("Test.Doe", rule); err != nil {
+		t.Fatal(err)
+	}
+	linter.Manager.Config.GChecks["Test.Doe"] = true
+
+	text := "As [see @doe, p. 3; -@doe] argues, doe and [@doe](https://example.com) differ."
+
+	f, err := linter.LintContent("test.md", text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := []string{}
+	for _, a := range f.SortedAlerts() {
+		if a.Check == "Test.Doe" {
+			found = append(found, fmt.Sprintf("%d:%d", a.Line, a.Span[0]))
+		}
+	}
+
+	expected := []string{"1:36", "1:46"}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+}
+
+func TestMaxDuration(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		if err = os.WriteFile(filepath.Join(dir, name), []byte("Some text.\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A budget that has already run out when the walk starts.
+	linter.Manager.Config.Flags.MaxDuration = time.Nanosecond
+
+	linted, err := linter.Lint([]string{dir}, "*")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted) != 0 || len(linter.Unlinted) != 3 {
+		t.Fatalf("Expected 3 unlinted files, got %d linted and %v", len(linted), linter.Unlinted)
+	}
+
+	linter.Manager.Config.Flags.MaxDuration = time.Hour
+
+	linted, err = linter.Lint([]string{dir}, "*")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted) != 3 || len(linter.Unlinted) != 0 {
+		t.Fatalf("Expected 3 linted files, got %d linted and %v", len(linted), linter.Unlinted)
+	}
+}
+
+func TestLintWithContext(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err = os.WriteFile(filepath.Join(dir, "a.md"), []byte("Some text.\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err = linter.LintWithContext(ctx, []string{dir}, "*"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	} else if _, err = linter.LintContentWithContext(ctx, "a.md", "Some text."); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	// A canceled run doesn't affect the next one.
+	linted, err := linter.Lint([]string{dir}, "*")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted) != 1 {
+		t.Fatalf("Expected 1 linted file, got %d", len(linted))
+	}
+}
+
+func TestJobsOrder(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	linter.Manager.Config.Flags.Jobs = 4
+
+	dir := t.TempDir()
+
+	expected := []string{}
+	for i := 0; i < 20; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("f%02d.md", i))
+		if err = os.WriteFile(path, []byte(strings.Repeat("Some text. ", 20*(20-i))), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		expected = append(expected, path)
+	}
+
+	for run := 0; run < 3; run++ {
+		linted, lerr := linter.Lint([]string{dir}, "*")
+		if lerr != nil {
+			t.Fatal(lerr)
+		}
+
+		found := []string{}
+		for _, f := range linted {
+			found = append(found, f.Path)
+		}
+
+		if !reflect.DeepEqual(found, expected) {
+			t.Fatalf("Expected %v, got %v", expected, found)
+		}
+	}
+
+	linter.Manager.Config.Flags.Jobs = -1
+	if _, err = NewLinter(linter.Manager.Config); err == nil {
+		t.Error("Expected an error for '--jobs=-1'")
+	}
+}
+
+// TestConcurrentLint is a stress test for the worker pool: run it with
+// `-race` (see `make race`).
+func TestConcurrentLint(t *testing.T) {
+	styles, err := filepath.Abs("../../testdata/styles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bench, err := os.ReadFile("../../testdata/fixtures/benchmarks/bench.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bench), "\n")
+
+	dir := t.TempDir()
+	ini := fmt.Sprintf(`StylesPath = %s
+MinAlertLevel = suggestion
+IgnoredClasses = ignored
+
+[*]
+BasedOnStyles = Vale, write-good, proselint, Readability, LanguageTool
+`, styles)
+
+	if err = os.WriteFile(filepath.Join(dir, ".vale.ini"), []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 16; i++ {
+		text := strings.Join(lines[i*10:i*10+40], "\n")
+		if err = os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%02d.md", i)), []byte(text), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lintWith := func(jobs int) []string {
+		cfg, cerr := core.ReadPipeline(&core.CLIFlags{
+			Path: filepath.Join(dir, ".vale.ini"), Jobs: jobs}, false)
+		if cerr != nil {
+			t.Fatal(cerr)
+		}
+
+		linter, lerr := NewLinter(cfg)
+		if lerr != nil {
+			t.Fatal(lerr)
+		}
+
+		linted, lerr := linter.Lint([]string{dir}, "*.md")
+		if lerr != nil {
+			t.Fatal(lerr)
+		}
+
+		found := []string{}
+		for _, f := range linted {
+			for _, a := range f.Alerts {
+				found = append(found, fmt.Sprintf("%s:%d:%d:%s", filepath.Base(f.Path), a.Line, a.Span[0], a.Check))
+			}
+		}
+		// NOTE: Rules don't run in a fixed order, so neither are alerts.
+		sort.Strings(found)
+		return found
+	}
+
+	expected := lintWith(1)
+	if len(expected) == 0 {
+		t.Fatal("Expected alerts")
+	}
+
+	for run := 0; run < 3; run++ {
+		if found := lintWith(8); !reflect.DeepEqual(found, expected) {
+			t.Fatalf("Run %d: got %d alerts with 8 jobs, expected %d", run, len(found), len(expected))
+		}
+	}
+}
+
+// TestConcurrentLinters checks that independent linters -- as used by, e.g.,
+// `vale serve` -- don't share any state.
+func TestConcurrentLinters(t *testing.T) {
+	html := "<p>This is is <span class=\"skip\">teh</span> text.</p>\n<pre>teh</pre>\n"
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			linter, err := initLinter()
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			expected := 1
+			if i%2 == 0 {
+				linter.Manager.Config.IgnoredClasses = []string{"skip"}
+			} else {
+				linter.Manager.Config.SkippedScopes = []string{"script"}
+				expected = 3
+			}
+
+			for run := 0; run < 10; run++ {
+				f, lerr := linter.LintContent("test.html", html)
+				if lerr != nil {
+					errs <- lerr
+					return
+				} else if len(f.Alerts) != expected {
+					errs <- fmt.Errorf("linter %d: expected %d alerts, got %d", i, expected, len(f.Alerts))
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
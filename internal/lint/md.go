@@ -3,10 +3,14 @@ package lint
 import (
 	"bytes"
 	"regexp"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
 	grh "github.com/yuin/goldmark/renderer/html"
 
 	"github.com/errata-ai/vale/v3/internal/core"
@@ -24,6 +28,34 @@ var goldMd = goldmark.New(
 	),
 )
 
+// markdownParsers maps each Markdown flavor (see `MarkdownFlavor`) to its
+// parser, so that a file's scopes match how it's actually rendered -- e.g.,
+// a `| table |` is only a table in flavors that support tables.
+var markdownParsers = map[string]goldmark.Markdown{
+	"gfm": goldMd,
+	"commonmark": goldmark.New(
+		goldmark.WithRendererOptions(
+			grh.WithUnsafe(),
+		),
+	),
+	// NOTE: Pandoc also supports heading attributes (`# Intro {#intro}`),
+	// which would otherwise be linted as part of the heading.
+	"pandoc": goldmark.New(
+		goldmark.WithExtensions(
+			extension.Table,
+			extension.Strikethrough,
+			extension.Footnote,
+			extension.DefinitionList,
+		),
+		goldmark.WithParserOptions(
+			parser.WithAttribute(),
+		),
+		goldmark.WithRendererOptions(
+			grh.WithUnsafe(),
+		),
+	),
+}
+
 // Convert extended info strings -- e.g., ```callout{'title': 'NOTE'} -- that
 // might confuse Blackfriday into normal "```".
 var reExInfo = regexp.MustCompile("`{3,}" + `.+`)
@@ -33,6 +65,31 @@ var reLinkDef = regexp.MustCompile(`\[(?:[^]\n]+)\]:`)
 
 var reNumericList = regexp.MustCompile(`(?m)^\d+\.`)
 
+// reRawTag matches an inline or block HTML tag -- e.g., `<td class="x">` or
+// `</details>` -- embedded in Markdown.
+var reRawTag = regexp.MustCompile(`</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>`)
+
+// reLintedAttr matches the value of an attribute that we lint (see
+// `lintTags`).
+var reLintedAttr = regexp.MustCompile(`\salt\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+// reFencedCode matches fenced code blocks, whose HTML is shown as-is rather
+// than rendered.
+var reFencedCode = regexp.MustCompile("(?ms)^ {0,3}(?:`{3,}|~{3,}).*?^ {0,3}(?:`{3,}|~{3,})")
+
+// reBracketCitation matches a bracketed Pandoc citation -- e.g., `[@doe99]`
+// or `[see @doe99, p. 33; -@smith04]`.
+var reBracketCitation = regexp.MustCompile(`\[(?:[^\[\]\n]*?[\s;])?-?@\w[^\[\]\n]*\]`)
+
+// reInTextCitation matches an in-text Pandoc citation -- e.g., `@doe99 says`.
+var reInTextCitation = regexp.MustCompile(`-?@\w(?:[\w:.#$%&+?<>~/-]*\w)?`)
+
+// reCodeSpan matches an inline code span.
+var reCodeSpan = regexp.MustCompile("`[^`\n]+`")
+
+var reLinkDefTitle = regexp.MustCompile(
+	`(?m)^ {0,3}\[[^\]\n]+\]:[ \t]*\S+[ \t]+(?:"([^"\n]+)"|'([^'\n]+)'|\(([^)\n]+)\))[ \t]*$`)
+
 func (l Linter) lintMarkdown(f *core.File) error {
 	var buf bytes.Buffer
 
@@ -41,7 +98,28 @@ func (l Linter) lintMarkdown(f *core.File) error {
 		return err
 	}
 
-	if err = goldMd.Convert([]byte(s), &buf); err != nil {
+	// MDX files mix JSX and JavaScript into their Markdown, so we remove
+	// them before rendering and mask them in the source (see `mdxSpans`).
+	content := f.Content
+	if f.RealExt == ".mdx" {
+		s = mdxToMarkdown(s)
+		content = maskMDX(content)
+	}
+
+	// NOTE: Citations aren't prose, so we mark them as such (see
+	// `skipClasses`). In-text citations (`@doe99 says`) are only recognized
+	// in the `pandoc` flavor since they look like mentions elsewhere.
+	inText := f.Flavor == "pandoc"
+	s = replaceCitations(s, inText, func(m string) string {
+		return `<cite class="citation">` + m + `</cite>`
+	})
+
+	md, ok := markdownParsers[f.Flavor]
+	if !ok {
+		md = goldMd
+	}
+
+	if err = md.Convert([]byte(s), &buf); err != nil {
 		return core.NewE100(f.Path, err)
 	}
 
@@ -50,7 +128,7 @@ func (l Linter) lintMarkdown(f *core.File) error {
 	// location as being in an infostring like '```json'.
 	//
 	// See https://github.com/errata-ai/vale/v2/issues/248.
-	body := reExInfo.ReplaceAllStringFunc(f.Content, func(m string) string {
+	body := reExInfo.ReplaceAllStringFunc(content, func(m string) string {
 		parts := strings.Split(m, "`")
 
 		// This ensures that we respect the number of opening backticks, which
@@ -76,6 +154,138 @@ func (l Linter) lintMarkdown(f *core.File) error {
 		return strings.Repeat("*", nlp.StrLen(m))
 	})
 
+	body = replaceCitations(body, inText, func(m string) string {
+		return strings.Repeat("*", nlp.StrLen(m))
+	})
+
+	// NOTE: This is required to avoid finding matches inside the attributes
+	// of embedded HTML -- e.g., `<a title="foo">foo</a>`.
+	body = maskRawHTML(body)
+
 	f.Content = body
+	if err = l.lintLinkTitles(f); err != nil {
+		return err
+	}
+
 	return l.lintHTMLTokens(f, buf.Bytes(), 0)
 }
+
+// lintLinkTitles lints the titles of reference-link definitions -- e.g.,
+// `[foo]: https://example.com "Title"` -- which aren't part of the rendered
+// HTML.
+func (l Linter) lintLinkTitles(f *core.File) error {
+	for _, m := range reLinkDefTitle.FindAllStringSubmatch(f.Content, -1) {
+		title := m[1] + m[2] + m[3]
+
+		b := nlp.NewBlock(f.Content, title, "text.reference"+f.RealExt)
+		if err := l.lintBlock(f, b, len(f.Lines), 0, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maskRawHTML replaces the HTML tags embedded in `src` with asterisks, so
+// that their text content is located correctly.
+//
+// The values of linted attributes (such as `alt`) are left as-is, as is any
+// HTML inside of fenced code blocks. HTML inside of code spans is masked too,
+// since its text is never linted.
+func maskRawHTML(src string) string {
+	code := reFencedCode.FindAllStringIndex(src, -1)
+	return replaceOutside(src, reRawTag, code, func(tag string) string {
+		kept := reLintedAttr.FindAllStringSubmatchIndex(tag, -1)
+
+		var b strings.Builder
+		for i, r := range tag {
+			if r == '\n' || inSubmatch(kept, i) {
+				b.WriteRune(r)
+			} else {
+				b.WriteRune('*')
+			}
+		}
+		return b.String()
+	})
+}
+
+// replaceOutside replaces every match of `re` in `src` that doesn't overlap
+// one of the given `skip` ranges.
+// replaceCitations replaces each Pandoc citation in `src` that's outside of
+// code using `repl`.
+func replaceCitations(src string, inText bool, repl func(string) string) string {
+	skip := append(reFencedCode.FindAllStringIndex(src, -1), reCodeSpan.FindAllStringIndex(src, -1)...)
+
+	found := [][]int{}
+	for _, loc := range reBracketCitation.FindAllStringIndex(src, -1) {
+		if loc[1] < len(src) && strings.ContainsRune("([:", rune(src[loc[1]])) {
+			// A link (`[@user](...)`), reference, or definition.
+			continue
+		} else if !overlaps(loc, skip) {
+			found = append(found, loc)
+		}
+	}
+
+	if inText {
+		cited := found
+		for _, loc := range reInTextCitation.FindAllStringIndex(src, -1) {
+			before, _ := utf8.DecodeLastRuneInString(src[:loc[0]])
+			if loc[0] > 0 && (unicode.IsLetter(before) || unicode.IsDigit(before) || strings.ContainsRune(`_@\/.-`, before)) {
+				// An email address, URL, or escaped `@`.
+				continue
+			} else if !overlaps(loc, skip) && !overlaps(loc, cited) {
+				found = append(found, loc)
+			}
+		}
+		sort.Slice(found, func(i, j int) bool { return found[i][0] < found[j][0] })
+	}
+
+	var b strings.Builder
+
+	last := 0
+	for _, loc := range found {
+		b.WriteString(src[last:loc[0]])
+		b.WriteString(repl(src[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(src[last:])
+
+	return b.String()
+}
+
+func replaceOutside(src string, re *regexp.Regexp, skip [][]int, repl func(string) string) string {
+	var b strings.Builder
+
+	last := 0
+	for _, loc := range re.FindAllStringIndex(src, -1) {
+		if overlaps(loc, skip) {
+			continue
+		}
+		b.WriteString(src[last:loc[0]])
+		b.WriteString(repl(src[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(src[last:])
+
+	return b.String()
+}
+
+func overlaps(loc []int, ranges [][]int) bool {
+	for _, r := range ranges {
+		if loc[0] < r[1] && r[0] < loc[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// inSubmatch reports whether byte `i` falls within any (non-whole) submatch.
+func inSubmatch(matches [][]int, i int) bool {
+	for _, m := range matches {
+		for j := 2; j+1 < len(m); j += 2 {
+			if m[j] >= 0 && i >= m[j] && i < m[j+1] {
+				return true
+			}
+		}
+	}
+	return false
+}
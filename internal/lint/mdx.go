@@ -0,0 +1,223 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// reMDXComment matches an MDX comment (e.g., `{/* vale off */}`), which is
+// converted to an HTML comment so that Vale's comment-based controls work.
+var reMDXComment = regexp.MustCompile(`^\{\s*/\*\s*([\s\S]*?)\s*\*/\s*\}$`)
+
+// reMDXModule matches the start of an ESM `import` or `export` statement.
+var reMDXModule = regexp.MustCompile(`^(?:import|export)\b`)
+
+// An mdxSpan is a part of an MDX file that isn't Markdown: a JSX tag, a
+// JavaScript expression, or an ESM statement.
+type mdxSpan struct {
+	start, end int
+	comment    string // the text of an MDX comment, if the span is one
+}
+
+// mdxSpans returns the non-Markdown spans of `src`, in order.
+//
+// JSX elements are split into their tags, so that any prose between them
+// (e.g., `<Note>Some prose.</Note>`) is still linted. Code blocks and code
+// spans are left as is.
+func mdxSpans(src string) []mdxSpan {
+	var spans []mdxSpan
+
+	fence := ""
+	lineStart := true
+
+	for i := 0; i < len(src); {
+		if lineStart {
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src)
+			} else {
+				end += i
+			}
+			line := strings.TrimLeft(src[i:end], " \t")
+
+			marker := fenceMarker(line)
+			if fence != "" {
+				if closesFence(line, marker, fence) {
+					fence = ""
+				}
+				i = end + 1
+				continue
+			} else if marker != "" {
+				fence = marker
+				i = end + 1
+				continue
+			} else if reMDXModule.MatchString(src[i:end]) {
+				// ESM statements span until the next blank line.
+				stop := strings.Index(src[i:], "\n\n")
+				if stop < 0 {
+					stop = len(src) - i
+				}
+				spans = append(spans, mdxSpan{start: i, end: i + stop})
+				i += stop
+				continue
+			}
+		}
+
+		c := src[i]
+		lineStart = c == '\n'
+
+		switch {
+		case c == '`':
+			n := 0
+			for i+n < len(src) && src[i+n] == '`' {
+				n++
+			}
+			if end := strings.Index(src[i+n:], strings.Repeat("`", n)); end >= 0 {
+				i += n + end + n
+				continue
+			}
+			i += n
+		case c == '{':
+			if end := scanJSX(src, i, '{'); end > 0 {
+				span := mdxSpan{start: i, end: end}
+				if m := reMDXComment.FindStringSubmatch(src[i:end]); m != nil {
+					span.comment = m[1]
+				}
+				spans = append(spans, span)
+				i = end
+				continue
+			}
+			i++
+		case c == '<' && isJSXTag(src[i+1:]):
+			if end := scanJSX(src, i, '<'); end > 0 {
+				spans = append(spans, mdxSpan{start: i, end: end})
+				i = end
+				continue
+			}
+			i++
+		default:
+			i++
+		}
+	}
+
+	return spans
+}
+
+// fenceMarker returns the backticks or tildes that open a fenced code block
+// on `line`, if any.
+func fenceMarker(line string) string {
+	for _, c := range []string{"`", "~"} {
+		n := 0
+		for n < len(line) && line[n] == c[0] {
+			n++
+		}
+		if n >= 3 {
+			return line[:n]
+		}
+	}
+	return ""
+}
+
+// closesFence reports whether `line`, which starts with `marker`, closes the
+// code block opened by `fence`.
+func closesFence(line, marker, fence string) bool {
+	return marker != "" && marker[0] == fence[0] && len(marker) >= len(fence) &&
+		strings.TrimSpace(line[len(marker):]) == ""
+}
+
+// isJSXTag reports whether the text following a `<` starts a JSX tag (e.g.,
+// `Note>`, `/Note>`, or `>` for a fragment) rather than, say, an autolink.
+func isJSXTag(s string) bool {
+	s = strings.TrimPrefix(s, "/")
+	if strings.HasPrefix(s, ">") {
+		return true
+	}
+
+	n := 0
+	for n < len(s) && (s[n] == '.' || s[n] == '-' || s[n] == '_' || unicode.IsLetter(rune(s[n])) || (n > 0 && unicode.IsDigit(rune(s[n])))) {
+		n++
+	}
+	if n == 0 || n == len(s) {
+		return false
+	}
+	return s[n] == '>' || s[n] == '/' || unicode.IsSpace(rune(s[n]))
+}
+
+// scanJSX returns the end of the tag or expression that starts at `src[i]`
+// (which is `open`), or -1 if it's never closed.
+//
+// Strings and nested expressions are skipped, so `<Tab title="a > b">` and
+// `{a({b: 1})}` are each a single span.
+func scanJSX(src string, i int, open byte) int {
+	depth := 0
+	quote := byte(0)
+
+	for j := i; j < len(src); j++ {
+		c := src[j]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				j++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			if open == '{' || depth > 0 || c != '`' {
+				quote = c
+			}
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if open == '{' && depth == 0 {
+				return j + 1
+			}
+		case c == '>' && open == '<' && depth == 0:
+			return j + 1
+		}
+	}
+
+	return -1
+}
+
+// mdxToMarkdown removes the JSX and ESM spans of `src`, keeping their line
+// breaks, so that what remains can be rendered as Markdown.
+func mdxToMarkdown(src string) string {
+	var b strings.Builder
+
+	last := 0
+	for _, span := range mdxSpans(src) {
+		b.WriteString(src[last:span.start])
+		if span.comment != "" {
+			b.WriteString("<!-- " + span.comment + " -->")
+		}
+		b.WriteString(strings.Repeat("\n", strings.Count(src[span.start:span.end], "\n")))
+		last = span.end
+	}
+	b.WriteString(src[last:])
+
+	return b.String()
+}
+
+// maskMDX replaces the JSX and ESM spans of `src` with asterisks, so that
+// alerts aren't located within them.
+func maskMDX(src string) string {
+	var b strings.Builder
+
+	last := 0
+	for _, span := range mdxSpans(src) {
+		b.WriteString(src[last:span.start])
+		for _, r := range src[span.start:span.end] {
+			if r == '\n' {
+				b.WriteRune(r)
+			} else {
+				b.WriteRune('*')
+			}
+		}
+		last = span.end
+	}
+	b.WriteString(src[last:])
+
+	return b.String()
+}
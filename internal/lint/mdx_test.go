@@ -0,0 +1,56 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMDXToMarkdown(t *testing.T) {
+	cases := []struct {
+		src, expected string
+	}{
+		{"import A from 'a'\nexport const b = {c: 1}\n\nText.", "\n\n\nText."},
+		{"<Note type=\"x\">\nProse.\n</Note>", "\nProse.\n"},
+		{"A <Badge text=\"a > b\" /> and {props.x} end.", "A  and  end."},
+		{"{/* vale off */}", "<!-- vale off -->"},
+		{"```jsx\n<A b={1} />\n```\n", "```jsx\n<A b={1} />\n```\n"},
+		{"`<A/>` and <https://example.com> and 1 < 2.", "`<A/>` and <https://example.com> and 1 < 2."},
+	}
+
+	for _, tc := range cases {
+		if out := mdxToMarkdown(tc.src); out != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.src, tc.expected, out)
+		}
+	}
+
+	src := "<Tab\n  title=\"a\">\nText.\n"
+	if masked := maskMDX(src); len(masked) != len(src) || strings.Count(masked, "\n") != 3 {
+		t.Errorf("Expected masking to keep offsets, got %q", masked)
+	}
+}
+
+func TestLintMDX(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "import { Note } from './is is'\n\n<Note title=\"is is\">\n  This is is prose.\n</Note>\n\nText {is is} and <A b=\"is is\" /> that is is wrong.\n"
+
+	f, err := linter.LintContent("a.mdx", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := []string{}
+	for _, a := range f.Alerts {
+		if a.Check == "Vale.Repetition" {
+			found = append(found, fmt.Sprintf("%d:%d", a.Line, a.Span[0]))
+		}
+	}
+
+	if strings.Join(found, ",") != "4:8,7:39" {
+		t.Errorf("Expected alerts at 4:8 and 7:39, got %v", found)
+	}
+}
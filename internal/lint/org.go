@@ -1,6 +1,7 @@
 package lint
 
 import (
+	"html"
 	"regexp"
 	"strings"
 
@@ -16,9 +17,18 @@ var orgWriter = org.NewHTMLWriter()
 var orgExample = "\n#+BEGIN_EXAMPLE\n$1\n#+END_EXAMPLE\n"
 
 var reOrgAttribute = regexp.MustCompile(`(#(?:\+| )[^\s]+:.+)`)
-var reOrgProps = regexp.MustCompile(`(:PROPERTIES:\n.+\n:END:)`)
 var reOrgSrc = regexp.MustCompile(`(?i)#\+BEGIN_SRC .+`)
 
+// reOrgDrawer matches a drawer (e.g., `:PROPERTIES:` or `:LOGBOOK:`), which
+// holds metadata rather than prose.
+var reOrgDrawer = regexp.MustCompile(`(?m)^([ \t]*:[\w-]+:[ \t]*\n(?:.*\n)*?[ \t]*:END:)[ \t]*$`)
+
+// reOrgPlanning matches a heading's planning line (e.g., `SCHEDULED: <...>`).
+var reOrgPlanning = regexp.MustCompile(`(?m)^[ \t]*((?:SCHEDULED|DEADLINE|CLOSED):.*?)[ \t]*$`)
+
+// reOrgTags matches a heading's tags (e.g., `:work:urgent:`).
+var reOrgTags = regexp.MustCompile(`(?m)^(\*+ .*?)[ \t]+:[\w@#%:]+:[ \t]*$`)
+
 type ExtendedHTMLWriter struct {
 	*org.HTMLWriter
 }
@@ -29,6 +39,17 @@ func (w *ExtendedHTMLWriter) WriteComment(n org.Comment) {
 	w.HTMLWriter.WriteString(" -->\n")
 }
 
+// WriteRegularLink renders a link without a description (e.g.,
+// `[[file:notes.org]]`) as code, since its text is the link's target rather
+// than prose.
+func (w *ExtendedHTMLWriter) WriteRegularLink(n org.RegularLink) {
+	if n.Description == nil && n.Kind() == "regular" {
+		w.HTMLWriter.WriteString("<code>" + html.EscapeString(n.URL) + "</code>")
+		return
+	}
+	w.HTMLWriter.WriteRegularLink(n)
+}
+
 func (l Linter) lintOrg(f *core.File) error {
 	extendedWriter := &ExtendedHTMLWriter{orgWriter}
 	orgWriter.ExtendingWriter = extendedWriter
@@ -36,7 +57,9 @@ func (l Linter) lintOrg(f *core.File) error {
 	old := f.Content
 
 	s := reOrgAttribute.ReplaceAllString(f.Content, "\n=$1=\n")
-	s = reOrgProps.ReplaceAllString(s, orgExample)
+	s = reOrgDrawer.ReplaceAllString(s, orgExample)
+	s = reOrgPlanning.ReplaceAllString(s, "=$1=")
+	s = reOrgTags.ReplaceAllString(s, "$1")
 
 	f.Content = s
 	s, err := l.Transform(f)
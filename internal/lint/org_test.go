@@ -0,0 +1,43 @@
+package lint
+
+import "testing"
+
+func TestOrgMetadata(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := `* A heading                                                   :is:is:
+  SCHEDULED: <2024-01-01 Mon> is is
+  :PROPERTIES:
+  :CUSTOM_ID: is is
+  :OWNER: is is
+  :END:
+  :LOGBOOK:
+  - Note taken on [2024-01-01 Mon] is is
+  :END:
+
+This is is linted, but [[file:is is.org]] isn't.
+
+#+BEGIN_SRC sh :results is is
+echo is is
+#+END_SRC
+`
+
+	f, err := linter.LintContent("a.org", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []int{}
+	for _, a := range f.Alerts {
+		if a.Check == "Vale.Repetition" {
+			lines = append(lines, a.Line)
+		}
+	}
+
+	if len(lines) != 1 || lines[0] != 11 {
+		t.Errorf("Expected a single alert on line 11, got %v", lines)
+	}
+}
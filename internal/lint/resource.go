@@ -0,0 +1,195 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// resource is a single translatable message in a resource bundle.
+type resource struct {
+	key   string
+	value string
+	line  int   // the 1-based line on which `value` starts
+	cols  []int // the (rune) column at which each line of `value` starts
+}
+
+var reStringsEntry = regexp.MustCompile(`^(\s*)"((?:[^"\\]|\\.)*)"\s*=\s*"((?:[^"\\]|\\.)*)"\s*;`)
+var reAndroidString = regexp.MustCompile(`(?s)<string\s+([^>]*?)name="([^"]+)"([^>]*)>(.*?)</string>`)
+
+// lintResource lints the message values in a Java `.properties`, iOS
+// `.strings`, or Android `strings.xml` file.
+//
+// Keys are never linted; instead, they're attached to the alerts reported
+// for their values.
+func (l *Linter) lintResource(f *core.File) error {
+	var entries []resource
+
+	switch f.NormedExt {
+	case ".properties":
+		entries = parseProperties(f.Content)
+	case ".strings":
+		entries = parseStrings(f.Content)
+	case ".xml":
+		entries = parseAndroidStrings(f.Content)
+	}
+	wholeFile := f.Content
+
+	// NOTE: Values are typically single-line strings, so we can't rely on
+	// the block's position within the file to locate alerts.
+	f.Lookup = true
+
+	last := 0
+	for _, entry := range entries {
+		f.SetText(entry.value)
+
+		block := nlp.NewBlock("", entry.value, "text"+f.RealExt)
+		if err := l.lintProse(f, block, len(f.Lines)); err != nil {
+			return err
+		}
+
+		for i := last; i < len(f.Alerts); i++ {
+			idx := f.Alerts[i].Line - 1
+			if idx >= 0 && idx < len(entry.cols) {
+				f.Alerts[i].Span = []int{
+					f.Alerts[i].Span[0] + entry.cols[idx],
+					f.Alerts[i].Span[1] + entry.cols[idx],
+				}
+			}
+			f.Alerts[i].Line += entry.line - 1
+			f.Alerts[i].Key = entry.key
+		}
+		last = len(f.Alerts)
+	}
+
+	f.SetText(wholeFile)
+	return nil
+}
+
+// parseProperties extracts the key-value pairs from a Java `.properties`
+// file, including values that span multiple lines.
+func parseProperties(src string) []resource {
+	var entries []resource
+	var curr *resource
+
+	for idx, line := range strings.Split(src, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		trimmed := strings.TrimLeft(line, " \t\f")
+
+		if curr != nil {
+			// A continuation of the previous line.
+			curr.cols = append(curr.cols, nlp.StrLen(line)-nlp.StrLen(trimmed))
+			curr.value += "\n" + strings.TrimSuffix(trimmed, `\`)
+		} else if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+			continue
+		} else {
+			key, value, col := splitProperty(trimmed)
+			curr = &resource{
+				key:   key,
+				value: strings.TrimSuffix(value, `\`),
+				line:  idx + 1,
+				cols:  []int{nlp.StrLen(line) - nlp.StrLen(trimmed) + col},
+			}
+		}
+
+		if !continues(line) {
+			entries = append(entries, *curr)
+			curr = nil
+		}
+	}
+
+	if curr != nil {
+		entries = append(entries, *curr)
+	}
+
+	return entries
+}
+
+// splitProperty splits a property line into its key and value, also returning
+// the (rune) column at which the value starts.
+func splitProperty(line string) (string, string, int) {
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '=' || r == ':' || r == ' ' || r == '\t':
+			key := line[:i]
+
+			rest := strings.TrimLeft(line[i:], " \t")
+			if r == ' ' || r == '\t' {
+				// The separator may be whitespace followed by `=` or `:`.
+				rest = strings.TrimPrefix(rest, "=")
+				rest = strings.TrimPrefix(rest, ":")
+			} else {
+				rest = rest[1:]
+			}
+			value := strings.TrimLeft(rest, " \t")
+
+			return key, value, nlp.StrLen(line) - nlp.StrLen(value)
+		}
+	}
+	return line, "", nlp.StrLen(line)
+}
+
+// continues reports whether the given line ends in an odd number of
+// backslashes.
+func continues(line string) bool {
+	count := len(line) - len(strings.TrimRight(line, `\`))
+	return count%2 == 1
+}
+
+// parseStrings extracts the key-value pairs from an iOS `.strings` file.
+func parseStrings(src string) []resource {
+	var entries []resource
+
+	for idx, line := range strings.Split(src, "\n") {
+		m := reStringsEntry.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		entries = append(entries, resource{
+			key:   line[m[4]:m[5]],
+			value: line[m[6]:m[7]],
+			line:  idx + 1,
+			cols:  []int{nlp.StrLen(line[:m[6]])},
+		})
+	}
+
+	return entries
+}
+
+// parseAndroidStrings extracts the `<string>` elements from an Android
+// `strings.xml` file, skipping any marked as `translatable="false"`.
+func parseAndroidStrings(src string) []resource {
+	var entries []resource
+
+	for _, m := range reAndroidString.FindAllStringSubmatchIndex(src, -1) {
+		attrs := src[m[2]:m[3]] + src[m[6]:m[7]]
+		if strings.Contains(attrs, `translatable="false"`) {
+			continue
+		}
+
+		value := src[m[8]:m[9]]
+		before := src[:m[8]]
+
+		line := strings.Count(before, "\n") + 1
+		cols := []int{nlp.StrLen(before[strings.LastIndex(before, "\n")+1:])}
+		for i := 1; i <= strings.Count(value, "\n"); i++ {
+			cols = append(cols, 0)
+		}
+
+		entries = append(entries, resource{
+			key:   src[m[4]:m[5]],
+			value: value,
+			line:  line,
+			cols:  cols,
+		})
+	}
+
+	return entries
+}
@@ -0,0 +1,41 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProperties(t *testing.T) {
+	src := "# A comment\n" +
+		"greeting = Hello, world\n" +
+		"long.message: This spans \\\n" +
+		"    two lines\n" +
+		"path\\=key Value\n"
+
+	expected := []resource{
+		{key: "greeting", value: "Hello, world", line: 2, cols: []int{11}},
+		{key: "long.message", value: "This spans \ntwo lines", line: 3, cols: []int{14, 4}},
+		{key: `path\=key`, value: "Value", line: 5, cols: []int{10}},
+	}
+
+	entries := parseProperties(src)
+	if !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("Expected %v, got %v", expected, entries)
+	}
+}
+
+func TestParseAndroidStrings(t *testing.T) {
+	src := "<resources>\n" +
+		"  <string name=\"app_name\">My App</string>\n" +
+		"  <string name=\"id\" translatable=\"false\">abc</string>\n" +
+		"</resources>\n"
+
+	expected := []resource{
+		{key: "app_name", value: "My App", line: 2, cols: []int{26}},
+	}
+
+	entries := parseAndroidStrings(src)
+	if !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("Expected %v, got %v", expected, entries)
+	}
+}
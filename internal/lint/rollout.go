@@ -0,0 +1,54 @@
+package lint
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// rolloutReason explains why the rule `name` isn't active for `f` due to the
+// config's `[rollout]` section, returning an empty string if it is.
+//
+// Whether a file is included in a rollout depends only on its path (relative
+// to the project) and the rule, so the same files are selected on every run
+// and machine -- while each rule selects a different subset.
+func (l *Linter) rolloutReason(name string, f *core.File) string {
+	cfg := l.Manager.Config
+
+	pct, ok := cfg.Rollout[name]
+	if !ok {
+		style, _, _ := strings.Cut(name, ".")
+		if pct, ok = cfg.Rollout[style]; !ok {
+			return ""
+		}
+	}
+
+	if inRollout(rolloutPath(f.Path, cfg.RootINI), name, pct) {
+		return ""
+	}
+	return fmt.Sprintf("this file isn't among the %d%% it's rolled out to", pct)
+}
+
+// inRollout deterministically assigns `path` to a bucket between 0 and 99 for
+// the given rule.
+func inRollout(path, name string, pct int) bool {
+	h := fnv.New32a()
+	h.Write([]byte(name + "\x00" + path))
+	return int(h.Sum32()%100) < pct
+}
+
+// rolloutPath returns `path` relative to the project's `.vale.ini` file, with
+// slashes as separators.
+func rolloutPath(path, root string) string {
+	if root != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			if rel, relErr := filepath.Rel(filepath.Dir(root), abs); relErr == nil {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(path)
+}
@@ -2,12 +2,17 @@ package lint
 
 import (
 	"bytes"
-	"errors"
+	"context"
+	"fmt"
+	"html"
 	"os/exec"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
 )
 
 // reStructuredText configuration.
@@ -31,38 +36,46 @@ var rstArgs = []string{
 	"--no-section-numbering",
 }
 
+// lintRST lints a reStructuredText document by converting it to HTML using
+// docutils (`rst2html`) or, if it isn't installed, our own converter.
 func (l *Linter) lintRST(f *core.File) error {
-	var html string
-
 	rst2html := core.Which([]string{
 		"rst2html", "rst2html.py", "rst2html-3", "rst2html-3.py"})
 	python := core.Which([]string{
 		"python", "py", "python.exe", "python3", "python3.exe", "py3"})
 
-	if rst2html == "" || python == "" {
-		return core.NewE100("lintRST", errors.New("rst2html not found"))
-	}
-
 	s, err := l.Transform(f)
 	if err != nil {
 		return err
 	}
 
+	if rst2html == "" || python == "" {
+		conv := &rstConverter{}
+		out := conv.convert(s)
+
+		f.Content = reRSTMarkup.ReplaceAllStringFunc(f.Content, func(m string) string {
+			idx := strings.Index(m, "..")
+			return m[:idx] + strings.Repeat("*", nlp.StrLen(m[idx:]))
+		})
+
+		return l.lintHTMLTokens(f, []byte(out), 0)
+	}
+
 	s = reSphinx.ReplaceAllString(s, ".. code::")
 	s = reCodeBlock.ReplaceAllString(s, "::")
 
-	html, err = callRst(s, rst2html, python)
+	out, err := callRst(l.context(), s, rst2html, python)
 	if err != nil {
 		return core.NewE100(f.Path, err)
 	}
 
-	return l.lintHTMLTokens(f, []byte(html), 0)
+	return l.lintHTMLTokens(f, []byte(out), 0)
 }
 
-func callRst(text, lib, _ string) (string, error) {
+func callRst(ctx context.Context, text, lib, _ string) (string, error) {
 	var out bytes.Buffer
 
-	cmd := exec.Command(lib, rstArgs...)
+	cmd := exec.CommandContext(ctx, lib, rstArgs...)
 	cmd.Stdin = strings.NewReader(text)
 	cmd.Stdout = &out
 
@@ -87,3 +100,644 @@ func callRst(text, lib, _ string) (string, error) {
 
 	return html[bodyStart+7 : bodyEnd], nil
 }
+
+// rstPunctuation are the characters that may be used as section adornments.
+const rstPunctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// rstAdmonitions are directives whose content (including their argument) is
+// prose.
+var rstAdmonitions = []string{
+	"attention", "caution", "danger", "error", "hint", "important", "note",
+	"tip", "warning", "seealso", "todo",
+}
+
+// rstContainers are directives whose content is prose but whose arguments
+// (if any) aren't.
+var rstContainers = []string{
+	"container", "compound", "only", "hlist", "glossary", "tabs", "tab",
+	"group-tab", "class", "cssclass", "line-block", "parsed-literal",
+}
+
+// rstQuotes are directives rendered as block quotes.
+var rstQuotes = []string{"epigraph", "highlights", "pull-quote"}
+
+// rstVersions are directives whose first argument is a version number,
+// optionally followed by prose.
+var rstVersions = []string{"versionadded", "versionchanged", "deprecated", "versionremoved"}
+
+// rstInlineRoles maps roles whose content is prose to the HTML tag they're
+// rendered as; all other roles are rendered as code.
+var rstInlineRoles = map[string]string{
+	"emphasis": "em", "strong": "strong", "sub": "sub", "subscript": "sub",
+	"sup": "sup", "superscript": "sup", "title-reference": "cite",
+	"title": "cite", "t": "cite", "abbr": "abbr", "dfn": "dfn", "term": "a",
+	"guilabel": "span", "menuselection": "span", "kbd": "kbd",
+}
+
+// rstLinkRoles are roles that link to another part of the documentation;
+// their content is prose if it has an explicit title (`title <target>`).
+var rstLinkRoles = []string{"ref", "doc", "numref", "any", "download", "keyword", "option"}
+
+var reRSTDirective = regexp.MustCompile(`^\.\.\s+([\w:+.-]+)::(?:\s+(.*))?$`)
+var reRSTTarget = regexp.MustCompile(`^_`)
+var reRSTSubstitution = regexp.MustCompile(`^\|[^|]+\|\s`)
+var reRSTFootnote = regexp.MustCompile(`^\[([^\]\s]+)\](?:\s+(.*))?$`)
+var reRSTOption = regexp.MustCompile(`^:[\w-]+:(?:\s|$)`)
+var reRSTField = regexp.MustCompile(`^:([^:\s][^:]*):(?:\s+(.*))?$`)
+var reRSTBullet = regexp.MustCompile(`^[-*+•‣⁃]( +|$)`)
+var reRSTEnum = regexp.MustCompile(`^(?:(?:\d+|#|[A-Za-z]|[ivxlcdm]+|[IVXLCDM]+)[.)]|\((?:\d+|#|[A-Za-z]|[ivxlcdm]+|[IVXLCDM]+)\))( +|$)`)
+var reRSTGridBorder = regexp.MustCompile(`^\+(?:[-=]+\+)+\s*$`)
+var reRSTSimpleBorder = regexp.MustCompile(`^=+(?: +=+)+\s*$`)
+var reRSTTitleTarget = regexp.MustCompile(`(?s)^(.*?)\s*<([^<>]+)>$`)
+
+// reRSTInline matches inline markup: literals, roles, interpreted text and
+// references, strong and emphasized text, footnote and citation references,
+// substitutions, and simple references (`name_`).
+var reRSTInline = regexp.MustCompile("(?s)``(.+?)``" +
+	"|:([\\w:+.-]+):`((?:\\\\`|[^`])+?)`" +
+	"|`((?:\\\\`|[^`])+?)`(__?|:[\\w:+.-]+:)?" +
+	"|\\*\\*(\\S(?:.*?\\S)?)\\*\\*" +
+	"|\\*(\\S(?:.*?\\S)?)\\*" +
+	"|\\[(?:\\d+|#[\\w-]*|\\*|[\\w.-]+)\\]_" +
+	"|\\|[^|\\s](?:[^|]*[^|\\s])?\\|(?:__?)?" +
+	"|\\b([A-Za-z0-9](?:[\\w-]*[A-Za-z0-9])?)__?\\b")
+
+// reRSTMarkup matches explicit markup that isn't rendered -- directive
+// names, targets, and substitution definitions -- which we mask in the source
+// so that the walker doesn't find prose inside of it.
+var reRSTMarkup = regexp.MustCompile(`(?m)^[ \t]*\.\. (?:[\w:+.-]+::|_[^\n]*|\|[^|\n]+\|[^\n]*)`)
+
+// rstConverter is a minimal reStructuredText-to-HTML converter, which we use
+// when docutils isn't installed.
+//
+// Like `texConverter`, it only needs to preserve a document's prose and
+// structure (sections, lists, admonitions, tables, footnotes, etc.) so that
+// we can assign scopes; since it never introduces text that isn't in the
+// source (such as an admonition's "Note" title), every block can be located.
+type rstConverter struct {
+	out strings.Builder
+
+	// styles holds the section adornment styles in the order in which
+	// they're first used, which determines each section's level.
+	styles []string
+}
+
+func (c *rstConverter) convert(src string) string {
+	src = strings.ReplaceAll(src, "\t", "        ")
+	c.blocks(strings.Split(src, "\n"))
+	return c.out.String()
+}
+
+// blocks converts a sequence of body elements, all of which start at the
+// same (zero) indentation.
+func (c *rstConverter) blocks(lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			i++
+			continue
+		} else if rstIndent(line) > 0 {
+			end := rstBlockEnd(lines, i, 1)
+			c.wrapBlocks("blockquote", rstDedent(lines[i:end]))
+			i = end
+			continue
+		} else if next := c.section(lines, i); next > i {
+			i = next
+			continue
+		}
+
+		switch {
+		case isRSTAdornment(line):
+			// A transition.
+			i++
+		case line == ".." || strings.HasPrefix(line, ".. "):
+			i = c.explicit(lines, i)
+		case reRSTGridBorder.MatchString(line):
+			i = c.gridTable(lines, i)
+		case reRSTSimpleBorder.MatchString(line):
+			i = c.simpleTable(lines, i)
+		case reRSTBullet.MatchString(line):
+			i = c.list(lines, i, reRSTBullet, "ul")
+		case reRSTEnum.MatchString(line):
+			i = c.list(lines, i, reRSTEnum, "ol")
+		case reRSTField.MatchString(line):
+			// We only keep a field's body: its name is usually metadata
+			// (e.g., `:author:` or `:param x:`).
+			m := reRSTField.FindStringSubmatch(line)
+			end := rstBlockEnd(lines, i+1, 1)
+			c.blocks(append([]string{m[2]}, rstDedent(lines[i+1:end])...))
+			i = end
+		case line == "|" || strings.HasPrefix(line, "| "):
+			i = c.lineBlock(lines, i)
+		case strings.HasPrefix(line, ">>> "):
+			end := i
+			for end < len(lines) && !isBlank(lines[end]) {
+				end++
+			}
+			c.pre(lines[i:end])
+			i = end
+		case i+1 < len(lines) && !isBlank(lines[i+1]) && rstIndent(lines[i+1]) > 0:
+			i = c.definitionList(lines, i)
+		default:
+			i = c.paragraph(lines, i)
+		}
+	}
+}
+
+// section converts the section title (with an optional overline) at
+// `lines[i]`, returning the index of the line that follows it -- or `i`, if
+// there isn't one.
+func (c *rstConverter) section(lines []string, i int) int {
+	line := strings.TrimRight(lines[i], " ")
+
+	var title, style string
+	var next int
+	switch {
+	case isRSTAdornment(line) && i+2 < len(lines) && !isBlank(lines[i+1]) &&
+		strings.TrimRight(lines[i+2], " ") == line:
+		title, style, next = strings.TrimSpace(lines[i+1]), "over"+line[:1], i+3
+	case !isRSTAdornment(line) && i+1 < len(lines) && isRSTUnderline(lines[i+1], line):
+		title, style, next = strings.TrimSpace(line), lines[i+1][:1], i+2
+	default:
+		return i
+	}
+
+	level := 0
+	for level < len(c.styles) && c.styles[level] != style {
+		level++
+	}
+	if level == len(c.styles) {
+		c.styles = append(c.styles, style)
+	}
+
+	tag := fmt.Sprintf("h%d", min(level+1, 6))
+	c.out.WriteString("<" + tag + ">" + rstInline(title) + "</" + tag + ">\n")
+
+	return next
+}
+
+// explicit converts the explicit markup block -- a comment, directive,
+// footnote, target, or substitution definition -- starting at `lines[i]`.
+func (c *rstConverter) explicit(lines []string, i int) int {
+	first := strings.TrimSpace(strings.TrimPrefix(lines[i], ".."))
+	if first == "" && (i+1 >= len(lines) || isBlank(lines[i+1])) {
+		// An empty comment.
+		return i + 1
+	}
+
+	end := rstBlockEnd(lines, i+1, 1)
+	body := rstDedent(lines[i+1 : end])
+
+	if m := reRSTDirective.FindStringSubmatch(lines[i]); m != nil {
+		c.directive(strings.ToLower(m[1]), strings.TrimSpace(m[2]), body)
+	} else if m = reRSTFootnote.FindStringSubmatch(first); m != nil {
+		c.out.WriteString(fmt.Sprintf("<ol><li id=\"fn:%s\">", html.EscapeString(m[1])))
+		c.blocks(append([]string{m[2]}, body...))
+		c.out.WriteString("</li></ol>\n")
+	} else if !reRSTTarget.MatchString(first) && !reRSTSubstitution.MatchString(first) {
+		comment := []string{first}
+		for _, line := range body {
+			comment = append(comment, strings.TrimSpace(line))
+		}
+		text := strings.TrimSpace(strings.Join(comment, "\n"))
+		c.out.WriteString("<!-- " + strings.ReplaceAll(text, "--", "- -") + " -->\n")
+	}
+
+	return end
+}
+
+// directive converts the directive `name`; its content is only converted if
+// it's prose (e.g., an admonition), while anything else (e.g., a code block)
+// is skipped.
+func (c *rstConverter) directive(name, arg string, body []string) {
+	// Options (e.g., `:caption:`) come before the content.
+	start := 0
+	for start < len(body) && reRSTOption.MatchString(body[start]) {
+		start = rstBlockEnd(body, start+1, 1)
+	}
+	content := body[start:]
+
+	switch {
+	case core.StringInSlice(name, rstAdmonitions):
+		c.wrapBlocks(`div class="admonition `+name+`"`, append([]string{arg}, content...))
+	case name == "admonition" || name == "topic" || name == "sidebar" || name == "table":
+		c.out.WriteString(`<div class="` + name + `">`)
+		if arg != "" {
+			c.out.WriteString(`<p class="` + name + `-title">` + rstInline(arg) + "</p>\n")
+		}
+		c.blocks(content)
+		c.out.WriteString("</div>\n")
+	case name == "rubric" || name == "centered":
+		c.out.WriteString("<p>" + rstInline(arg) + "</p>\n")
+	case core.StringInSlice(name, rstVersions):
+		_, text, _ := strings.Cut(arg, " ")
+		c.blocks(append([]string{strings.TrimSpace(text)}, content...))
+	case core.StringInSlice(name, rstQuotes):
+		c.wrapBlocks("blockquote", content)
+	case core.StringInSlice(name, rstContainers):
+		c.blocks(content)
+	case name == "figure":
+		// The first paragraph is the figure's caption and the rest is its
+		// legend.
+		end := 0
+		for end < len(content) && isBlank(content[end]) {
+			end++
+		}
+		for end < len(content) && !isBlank(content[end]) {
+			end++
+		}
+		if caption := strings.TrimSpace(strings.Join(content[:end], "\n")); caption != "" {
+			c.out.WriteString("<figcaption>" + rstInline(caption) + "</figcaption>\n")
+		}
+		c.blocks(content[end:])
+	case name == "image" || name == "include" || name == "highlight":
+	default:
+		c.pre(content)
+	}
+}
+
+// list converts a bullet (`ul`) or enumerated (`ol`) list.
+func (c *rstConverter) list(lines []string, i int, marker *regexp.Regexp, tag string) int {
+	c.out.WriteString("<" + tag + ">\n")
+	for i < len(lines) && marker.MatchString(lines[i]) {
+		prefix := marker.FindString(lines[i])
+		first := lines[i][len(prefix):]
+
+		// An item's body is indented to its first line's text; if the item
+		// is empty, it starts on the next line.
+		width := utf8.RuneCountInString(prefix)
+		if first == "" {
+			width = 1
+		}
+
+		end := rstBlockEnd(lines, i+1, width)
+		item := append([]string{first}, rstDedent(lines[i+1:end])...)
+		c.wrapBlocks("li", item)
+
+		i = end
+		for i < len(lines) && isBlank(lines[i]) {
+			i++
+		}
+	}
+	c.out.WriteString("</" + tag + ">\n")
+
+	return i
+}
+
+// definitionList converts a definition list, keeping each term's classifiers
+// (`term : classifier`) out of its text.
+func (c *rstConverter) definitionList(lines []string, i int) int {
+	c.out.WriteString("<dl>\n")
+	for i+1 < len(lines) && rstIndent(lines[i]) == 0 && !isBlank(lines[i]) &&
+		!isBlank(lines[i+1]) && rstIndent(lines[i+1]) > 0 {
+		term, _, _ := strings.Cut(lines[i], " : ")
+		c.out.WriteString("<dt>" + rstInline(strings.TrimSpace(term)) + "</dt>\n")
+
+		end := rstBlockEnd(lines, i+1, 1)
+		c.wrapBlocks("dd", rstDedent(lines[i+1:end]))
+
+		i = end
+		for i < len(lines) && isBlank(lines[i]) {
+			i++
+		}
+	}
+	c.out.WriteString("</dl>\n")
+
+	return i
+}
+
+// lineBlock converts a line block (`| line`) to a paragraph.
+func (c *rstConverter) lineBlock(lines []string, i int) int {
+	var text []string
+	for i < len(lines) && !isBlank(lines[i]) {
+		text = append(text, strings.TrimSpace(strings.TrimPrefix(lines[i], "|")))
+		i++
+	}
+	c.out.WriteString("<p>" + rstInline(strings.Join(text, "\n")) + "</p>\n")
+	return i
+}
+
+// paragraph converts a paragraph, along with the literal block that follows
+// it if it ends with `::`.
+func (c *rstConverter) paragraph(lines []string, i int) int {
+	var text []string
+	for i < len(lines) && !isBlank(lines[i]) && rstIndent(lines[i]) == 0 {
+		text = append(text, strings.TrimRight(lines[i], " "))
+		i++
+	}
+
+	para := strings.Join(text, "\n")
+	literal := strings.HasSuffix(para, "::")
+	if literal {
+		switch {
+		case para == "::":
+			para = ""
+		case strings.HasSuffix(para, " ::") || strings.HasSuffix(para, "\n::"):
+			para = strings.TrimRight(strings.TrimSuffix(para, "::"), " \n")
+		default:
+			para = strings.TrimSuffix(para, ":")
+		}
+	}
+
+	if para != "" {
+		c.out.WriteString("<p>" + rstInline(para) + "</p>\n")
+	}
+
+	if literal {
+		next := i
+		for next < len(lines) && isBlank(lines[next]) {
+			next++
+		}
+		if next < len(lines) && rstIndent(lines[next]) > 0 {
+			end := rstBlockEnd(lines, next, 1)
+			c.pre(rstDedent(lines[next:end]))
+			i = end
+		}
+	}
+
+	return i
+}
+
+// gridTable converts a grid table. Cells that span multiple columns or rows
+// aren't supported, but their text is still linted.
+func (c *rstConverter) gridTable(lines []string, i int) int {
+	border := []rune(strings.TrimRight(lines[i], " "))
+
+	var cols []int
+	for j, r := range border {
+		if r == '+' {
+			cols = append(cols, j)
+		}
+	}
+
+	var rows [][]string
+	var header int
+
+	cells := make([]string, len(cols)-1)
+	i++
+	for ; i < len(lines) && (strings.HasPrefix(lines[i], "+") || strings.HasPrefix(lines[i], "|")); i++ {
+		line := []rune(strings.TrimRight(lines[i], " "))
+		if line[0] == '+' {
+			rows = append(rows, cells)
+			cells = make([]string, len(cols)-1)
+			if strings.ContainsRune(string(line), '=') {
+				header = len(rows)
+			}
+			continue
+		}
+
+		for k := 0; k+1 < len(cols); k++ {
+			if cols[k]+1 >= len(line) {
+				break
+			}
+			cell := strings.TrimSpace(string(line[cols[k]+1 : min(cols[k+1], len(line))]))
+			cells[k] = strings.TrimSpace(cells[k] + "\n" + cell)
+		}
+	}
+
+	c.table(rows, header)
+	return i
+}
+
+// simpleTable converts a simple table, whose columns are marked by its
+// borders (e.g., `=====  =====`).
+func (c *rstConverter) simpleTable(lines []string, i int) int {
+	border := []rune(strings.TrimRight(lines[i], " "))
+
+	var starts []int
+	for j, r := range border {
+		if r == '=' && (j == 0 || border[j-1] == ' ') {
+			starts = append(starts, j)
+		}
+	}
+
+	var rows [][]string
+	var header int
+
+	i++
+	for ; i < len(lines); i++ {
+		line := []rune(strings.TrimRight(lines[i], " "))
+		if reRSTSimpleBorder.MatchString(string(line)) {
+			if i+1 >= len(lines) || isBlank(lines[i+1]) {
+				i++
+				break
+			}
+			header = len(rows)
+			continue
+		} else if len(line) == 0 {
+			continue
+		}
+
+		continuation := len(rows) > 0 && strings.TrimSpace(string(line[:min(len(line), starts[1])])) == ""
+		if !continuation {
+			rows = append(rows, make([]string, len(starts)))
+		}
+
+		row := rows[len(rows)-1]
+		for k, start := range starts {
+			if start >= len(line) {
+				break
+			}
+			end := len(line)
+			if k+1 < len(starts) {
+				end = min(starts[k+1], len(line))
+			}
+			row[k] = strings.TrimSpace(row[k] + "\n" + strings.TrimSpace(string(line[start:end])))
+		}
+	}
+
+	c.table(rows, header)
+	return i
+}
+
+func (c *rstConverter) table(rows [][]string, header int) {
+	c.out.WriteString("<table>\n")
+	for r, row := range rows {
+		tag := "td"
+		if r < header {
+			tag = "th"
+		}
+
+		c.out.WriteString("<tr>")
+		for _, cell := range row {
+			c.out.WriteString("<" + tag + ">" + rstInline(cell) + "</" + tag + ">")
+		}
+		c.out.WriteString("</tr>\n")
+	}
+	c.out.WriteString("</table>\n")
+}
+
+func (c *rstConverter) wrapBlocks(tag string, lines []string) {
+	name, _, _ := strings.Cut(tag, " ")
+	c.out.WriteString("<" + tag + ">\n")
+	c.blocks(lines)
+	c.out.WriteString("</" + name + ">\n")
+}
+
+func (c *rstConverter) pre(lines []string) {
+	c.out.WriteString("<pre>" + html.EscapeString(strings.Join(lines, "\n")) + "</pre>\n")
+}
+
+// rstInline converts inline markup to HTML.
+func rstInline(text string) string {
+	var out strings.Builder
+
+	last := 0
+	for _, m := range reRSTInline.FindAllStringSubmatchIndex(text, -1) {
+		if !rstBoundary(text, m[0], m[1]) {
+			continue
+		}
+		out.WriteString(html.EscapeString(text[last:m[0]]))
+		last = m[1]
+
+		group := func(n int) string {
+			if m[2*n] < 0 {
+				return ""
+			}
+			return text[m[2*n]:m[2*n+1]]
+		}
+
+		switch {
+		case m[2] >= 0:
+			out.WriteString("<code>" + html.EscapeString(group(1)) + "</code>")
+		case m[4] >= 0:
+			out.WriteString(rstRole(group(2), group(3)))
+		case m[8] >= 0:
+			suffix := group(5)
+			switch {
+			case strings.HasPrefix(suffix, ":"):
+				out.WriteString(rstRole(strings.Trim(suffix, ":"), group(4)))
+			case suffix != "":
+				title, _, hasTarget := rstTitle(group(4))
+				if title == "" && hasTarget {
+					out.WriteString("<code>" + html.EscapeString(group(4)) + "</code>")
+				} else {
+					out.WriteString("<a>" + html.EscapeString(title) + "</a>")
+				}
+			default:
+				out.WriteString("<cite>" + html.EscapeString(group(4)) + "</cite>")
+			}
+		case m[12] >= 0:
+			out.WriteString("<strong>" + html.EscapeString(group(6)) + "</strong>")
+		case m[14] >= 0:
+			out.WriteString("<em>" + html.EscapeString(group(7)) + "</em>")
+		case m[16] >= 0:
+			out.WriteString("<a>" + html.EscapeString(group(8)) + "</a>")
+		}
+		// Footnote references and substitutions are dropped.
+	}
+	out.WriteString(html.EscapeString(text[last:]))
+
+	return out.String()
+}
+
+// rstRole renders the content of the role `name`.
+func rstRole(name, content string) string {
+	if name == "cite" || strings.HasPrefix(name, "cite:") {
+		// A `sphinxcontrib-bibtex` citation -- e.g., :cite:p:`doe99`.
+		return `<cite class="citation">` + html.EscapeString(content) + "</cite>"
+	} else if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		// A domain-specific role, such as `py:func`.
+		name = name[idx+1:]
+	}
+	title, _, hasTarget := rstTitle(content)
+
+	tag, prose := rstInlineRoles[name]
+	switch {
+	case name == "abbr":
+		// E.g., :abbr:`LIFO (last-in, first-out)`.
+		abbr, _, _ := strings.Cut(content, " (")
+		return "<abbr>" + html.EscapeString(abbr) + "</abbr>"
+	case prose:
+		return "<" + tag + ">" + html.EscapeString(title) + "</" + tag + ">"
+	case core.StringInSlice(name, rstLinkRoles) && hasTarget && title != "":
+		return "<a>" + html.EscapeString(title) + "</a>"
+	default:
+		return "<code>" + html.EscapeString(content) + "</code>"
+	}
+}
+
+// rstTitle splits `title <target>` into its parts.
+func rstTitle(content string) (string, string, bool) {
+	if m := reRSTTitleTarget.FindStringSubmatch(content); m != nil {
+		return m[1], m[2], true
+	}
+	return content, "", false
+}
+
+// rstBoundary reports whether the inline markup at `text[start:end]` is
+// delimited by whitespace or punctuation, as reStructuredText requires (so
+// that, e.g., `2*3*4` isn't emphasized).
+func rstBoundary(text string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+
+	okBefore := start == 0 || unicode.IsSpace(before) || strings.ContainsRune(`-:/'"<([{`, before)
+	okAfter := end == len(text) || unicode.IsSpace(after) || strings.ContainsRune(`-.,:;!?\/'")]}>`, after)
+
+	return okBefore && okAfter
+}
+
+// isRSTAdornment reports whether `line` is a section adornment (or a
+// transition): a line of at least two of the same punctuation character.
+func isRSTAdornment(line string) bool {
+	line = strings.TrimRight(line, " ")
+	if len(line) < 2 || !strings.ContainsRune(rstPunctuation, rune(line[0])) {
+		return false
+	}
+	return strings.Count(line, line[:1]) == len(line)
+}
+
+// isRSTUnderline reports whether `line` underlines `title`; like docutils, we
+// allow short underlines as long as they have at least four characters.
+func isRSTUnderline(line, title string) bool {
+	line = strings.TrimRight(line, " ")
+	return isRSTAdornment(line) && (len(line) >= 4 || len(line) >= utf8.RuneCountInString(strings.TrimSpace(title)))
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func rstIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// rstBlockEnd returns the end of the block starting at `lines[i]` whose
+// lines are indented by at least `indent` spaces (ignoring trailing blank
+// lines).
+func rstBlockEnd(lines []string, i, indent int) int {
+	end := i
+	for j := i; j < len(lines); j++ {
+		if isBlank(lines[j]) {
+			continue
+		} else if rstIndent(lines[j]) < indent {
+			break
+		}
+		end = j + 1
+	}
+	return end
+}
+
+// rstDedent removes the common indentation of `lines`.
+func rstDedent(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if !isBlank(line) && (indent < 0 || rstIndent(line) < indent) {
+			indent = rstIndent(line)
+		}
+	}
+
+	dedented := make([]string, len(lines))
+	for i, line := range lines {
+		dedented[i] = safeSlice(line, max(indent, 0))
+	}
+	return dedented
+}
+
+func safeSlice(line string, start int) string {
+	if start >= len(line) {
+		return ""
+	}
+	return line[start:]
+}
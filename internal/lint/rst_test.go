@@ -0,0 +1,73 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRSTConverter(t *testing.T) {
+	cases := []struct {
+		description string
+		src         string
+		contains    []string
+		excludes    []string
+	}{
+		{
+			description: "sections",
+			src:         "=====\nTitle\n=====\n\nIntro\n-----\n\nMore\n====\n\nNext\n----\n",
+			contains:    []string{"<h1>Title</h1>", "<h2>Intro</h2>", "<h3>More</h3>", "<h2>Next</h2>"},
+		},
+		{
+			description: "inline markup",
+			src:         "Use *this*, **that**, ``code``, :func:`os.path`, :ref:`the guide <guide>`, `Go <https://go.dev>`_, and 2*3*4.",
+			contains: []string{
+				"<em>this</em>", "<strong>that</strong>", "<code>code</code>", "<code>os.path</code>",
+				"<a>the guide</a>", "<a>Go</a>", "2*3*4"},
+			excludes: []string{"https://go.dev"},
+		},
+		{
+			description: "admonitions and code",
+			src:         ".. note:: Read\n   this.\n\n.. code-block:: python\n   :caption: Example\n\n   x = 1\n",
+			contains:    []string{"<div class=\"admonition note\">\n<p>Read\nthis.</p>", "<pre>\nx = 1</pre>"},
+			excludes:    []string{"Example", "python"},
+		},
+		{
+			description: "literal blocks",
+			src:         "For example::\n\n   code here\n\nDone.",
+			contains:    []string{"<p>For example:</p>\n<pre>code here</pre>", "<p>Done.</p>"},
+		},
+		{
+			description: "lists",
+			src:         "- One.\n- Two.\n\n  More.\n\n#. First.\n",
+			contains: []string{
+				"<ul>\n<li>\n<p>One.</p>\n</li>\n<li>\n<p>Two.</p>\n<p>More.</p>\n</li>\n</ul>",
+				"<ol>\n<li>\n<p>First.</p>"},
+		},
+		{
+			description: "tables",
+			src:         "=====  =====\nName   Value\n=====  =====\na      b\n=====  =====\n",
+			contains:    []string{"<tr><th>Name</th><th>Value</th></tr>", "<tr><td>a</td><td>b</td></tr>"},
+		},
+		{
+			description: "comments, targets, and footnotes",
+			src:         ".. vale off\n\n.. _target: https://example.com\n\n.. [1] A note.\n\n.. A comment.\n",
+			contains:    []string{"<!-- vale off -->", "<li id=\"fn:1\"><p>A note.</p>", "<!-- A comment. -->"},
+			excludes:    []string{"example.com"},
+		},
+	}
+
+	for _, tc := range cases {
+		c := &rstConverter{}
+		out := c.convert(tc.src)
+		for _, s := range tc.contains {
+			if !strings.Contains(out, s) {
+				t.Errorf("%s: expected %q in %q", tc.description, s, out)
+			}
+		}
+		for _, s := range tc.excludes {
+			if strings.Contains(out, s) {
+				t.Errorf("%s: unexpected %q in %q", tc.description, s, out)
+			}
+		}
+	}
+}
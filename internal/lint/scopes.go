@@ -0,0 +1,51 @@
+package lint
+
+import (
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// A ScopedBlock is a single block of text, along with a summary of which
+// rules would see it.
+type ScopedBlock struct {
+	Block   nlp.Block
+	Rules   int            // the number of rules that run on the block
+	Skipped map[string]int // why other rules don't -> how many
+}
+
+// Scopes lints the file at `src`, returning every block of text (and its
+// scope) that was given to the rules.
+//
+// This is used by `--print-scopes` to debug why rules skip a region.
+func (l *Linter) Scopes(src string) (*core.File, []ScopedBlock, error) {
+	store := l.store
+	defer func() {
+		l.scopes = nil
+		l.store = store
+	}()
+
+	l.store = nil
+	l.scopes = &[]ScopedBlock{}
+
+	linted, err := l.Lint([]string{src}, "*")
+	if err != nil || len(linted) == 0 {
+		return nil, nil, err
+	}
+
+	return linted[0], *l.scopes, nil
+}
+
+func (l *Linter) recordScope(f *core.File, blk nlp.Block) {
+	scoped := ScopedBlock{Block: blk, Skipped: map[string]int{}}
+
+	rules := l.Manager.Rules()
+	for _, name := range l.Manager.Order() {
+		if reason := l.skipReason(name, f, rules[name], blk); reason != "" {
+			scoped.Skipped[reason]++
+		} else {
+			scoped.Rules++
+		}
+	}
+
+	*l.scopes = append(*l.scopes, scoped)
+}
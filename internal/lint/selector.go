@@ -0,0 +1,198 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// reCompound matches the parts of a compound selector: a tag name, an ID, a
+// class, or an attribute (e.g., `[role=main]`).
+var reCompound = regexp.MustCompile(
+	`^(?:([a-zA-Z][\w-]*|\*)|#([\w-]+)|\.([\w-]+)|\[([\w-]+)(?:=["']?([^"'\]]*)["']?)?\])`)
+
+// A compoundSelector matches a single element, such as `main.content`.
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   [][2]string // name, value (an empty value matches any)
+	child   bool        // whether it must be a child of the previous selector
+}
+
+// A cssSelector is a (small) subset of CSS: comma-separated lists of
+// compound selectors (tag names, IDs, classes, and attributes) joined by
+// descendant or child (`>`) combinators -- e.g., `main, article > .body`.
+type cssSelector [][]compoundSelector
+
+// parseSelector parses the CSS selector `s`.
+func parseSelector(s string) (cssSelector, error) {
+	var sel cssSelector
+
+	for _, group := range strings.Split(s, ",") {
+		var chain []compoundSelector
+
+		child := false
+		for _, part := range strings.Fields(strings.ReplaceAll(group, ">", " > ")) {
+			if part == ">" {
+				if len(chain) == 0 || child {
+					return nil, fmt.Errorf("unexpected '>' in '%s'", s)
+				}
+				child = true
+				continue
+			}
+
+			compound, err := parseCompound(part)
+			if err != nil {
+				return nil, err
+			}
+			compound.child = child
+			chain = append(chain, compound)
+			child = false
+		}
+
+		if len(chain) == 0 || child {
+			return nil, fmt.Errorf("'%s' is not a valid selector", s)
+		}
+		sel = append(sel, chain)
+	}
+
+	return sel, nil
+}
+
+func parseCompound(s string) (compoundSelector, error) {
+	var c compoundSelector
+
+	for rest := s; rest != ""; {
+		m := reCompound.FindStringSubmatch(rest)
+		if m == nil || (m[1] != "" && rest != s) {
+			return c, fmt.Errorf("unsupported selector '%s'", s)
+		}
+
+		switch {
+		case m[1] != "":
+			c.tag = strings.ToLower(m[1])
+		case m[2] != "":
+			c.id = m[2]
+		case m[3] != "":
+			c.classes = append(c.classes, m[3])
+		default:
+			c.attrs = append(c.attrs, [2]string{strings.ToLower(m[4]), m[5]})
+		}
+		rest = rest[len(m[0]):]
+	}
+
+	return c, nil
+}
+
+// matches reports whether `n` matches the compound selector.
+func (c compoundSelector) matches(n *html.Node) bool {
+	if n.Type != html.ElementNode || (c.tag != "" && c.tag != "*" && c.tag != n.Data) {
+		return false
+	} else if c.id != "" && attr(n, "id") != c.id {
+		return false
+	}
+
+	classes := strings.Fields(attr(n, "class"))
+	for _, class := range c.classes {
+		found := false
+		for _, have := range classes {
+			found = found || have == class
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, a := range c.attrs {
+		value, found := lookupAttr(n, a[0])
+		if !found || (a[1] != "" && value != a[1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches reports whether `n` matches any of the selector's chains.
+func (sel cssSelector) matches(n *html.Node) bool {
+	for _, chain := range sel {
+		if matchChain(chain, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchChain matches `chain` right to left, starting at `n`.
+func matchChain(chain []compoundSelector, n *html.Node) bool {
+	last := chain[len(chain)-1]
+	if !last.matches(n) {
+		return false
+	} else if len(chain) == 1 {
+		return true
+	}
+
+	for p := n.Parent; p != nil; p = p.Parent {
+		if matchChain(chain[:len(chain)-1], p) {
+			return true
+		} else if last.child {
+			return false
+		}
+	}
+
+	return false
+}
+
+// selectHTML returns the outermost elements of `src` that match `sel`,
+// rendered as HTML.
+func selectHTML(src string, sel cssSelector) (string, error) {
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	var walk func(n *html.Node) error
+
+	walk = func(n *html.Node) error {
+		if sel.matches(n) {
+			if err := html.Render(&out, n); err != nil {
+				return err
+			}
+			out.WriteString("\n")
+			return nil
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if err := walk(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err = walk(doc); err != nil {
+		return "", err
+	} else if out.Len() == 0 {
+		return "", errors.New("no elements matched the selector")
+	}
+
+	return out.String(), nil
+}
+
+func attr(n *html.Node, name string) string {
+	value, _ := lookupAttr(n, name)
+	return value
+}
+
+func lookupAttr(n *html.Node, name string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,49 @@
+package lint
+
+import (
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// shard is the subset of files linted by a run given `--shard=i/n`: the
+// i-th (1-based) of n.
+type shard struct {
+	index int
+	total int
+}
+
+// parseShard parses a `--shard` value, such as "2/8".
+func parseShard(s string) (*shard, error) {
+	i, n, found := strings.Cut(s, "/")
+	if !found {
+		return nil, errors.New("expected a value such as '2/8'")
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(i))
+	if err != nil {
+		return nil, errors.New("expected a value such as '2/8'")
+	}
+
+	total, err := strconv.Atoi(strings.TrimSpace(n))
+	if err != nil {
+		return nil, errors.New("expected a value such as '2/8'")
+	} else if total < 1 || index < 1 || index > total {
+		return nil, errors.New("the shard must be between 1 and the number of shards")
+	}
+
+	return &shard{index: index, total: total}, nil
+}
+
+// includes deterministically assigns `path` (relative to the project's
+// `.vale.ini` file; see `rolloutPath`) to one of the shards, reporting
+// whether it's this one.
+//
+// Every file is assigned to exactly one shard, regardless of which files
+// exist, so parallel jobs don't need to coordinate.
+func (s *shard) includes(path string) bool {
+	h := fnv.New32a()
+	h.Write([]byte(path))
+	return int(h.Sum32()%uint32(s.total)) == s.index-1
+}
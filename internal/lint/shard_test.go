@@ -0,0 +1,48 @@
+package lint
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestParseShard(t *testing.T) {
+	for _, bad := range []string{"2", "a/8", "0/8", "9/8", "1/0", "1/b"} {
+		if _, err := parseShard(bad); err == nil {
+			t.Errorf("Expected '%s' to be invalid", bad)
+		}
+	}
+
+	s, err := parseShard("2/8")
+	if err != nil {
+		t.Fatal(err)
+	} else if s.index != 2 || s.total != 8 {
+		t.Errorf("Expected 2/8, got %d/%d", s.index, s.total)
+	}
+}
+
+func TestShardIncludes(t *testing.T) {
+	shards := []*shard{{1, 3}, {2, 3}, {3, 3}}
+
+	counts := make([]int, len(shards))
+	for i := 0; i < 300; i++ {
+		path := "docs/page-" + strconv.Itoa(i) + ".md"
+
+		owners := 0
+		for j, s := range shards {
+			if s.includes(path) {
+				owners++
+				counts[j]++
+			}
+		}
+
+		if owners != 1 {
+			t.Fatalf("Expected '%s' to be in exactly one shard, got %d", path, owners)
+		}
+	}
+
+	for j, n := range counts {
+		if n == 0 {
+			t.Errorf("Expected shard %d to have some files", j+1)
+		}
+	}
+}
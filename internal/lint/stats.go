@@ -0,0 +1,172 @@
+package lint
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// RuleStats summarizes the work done by a single rule during a run.
+//
+// A rule that's loaded but never invoked (or that never matches) across a
+// large set of files is often a sign that it has been broken by a change to
+// the content it's meant to check.
+type RuleStats struct {
+	Invocations  int     // the number of blocks the rule was run on
+	Matches      int     // the number of (visible) alerts it returned
+	Milliseconds float64 // the total time spent running it
+}
+
+// ScopeStats summarizes the alerts found in one kind of content (e.g.,
+// tables) or in one section of a document, showing where alerts concentrate.
+type ScopeStats struct {
+	Words   int     // the number of words linted
+	Alerts  int     // the number of (visible) alerts
+	Density float64 // the number of alerts per 1,000 words
+}
+
+// RunStats are the statistics collected by a run with `--stats`.
+//
+// `Scopes` is keyed by the kind of content (e.g., `heading`, `list`,
+// `table`, or `body`) and `Sections` by the text of the innermost heading
+// (or "(none)", for content that precedes any heading).
+type RunStats struct {
+	Rules    map[string]RuleStats
+	Scopes   map[string]ScopeStats
+	Sections map[string]ScopeStats
+}
+
+// ruleStats records per-rule statistics; it's safe for concurrent use since
+// files are linted in parallel.
+type ruleStats struct {
+	sync.Mutex
+	rules    map[string]*RuleStats
+	scopes   map[string]*ScopeStats
+	sections map[string]*ScopeStats
+}
+
+func newRuleStats() *ruleStats {
+	return &ruleStats{
+		rules:    map[string]*RuleStats{},
+		scopes:   map[string]*ScopeStats{},
+		sections: map[string]*ScopeStats{},
+	}
+}
+
+func (s *ruleStats) record(name string, elapsed time.Duration, matches int) {
+	s.Lock()
+	defer s.Unlock()
+
+	stats, ok := s.rules[name]
+	if !ok {
+		stats = &RuleStats{}
+		s.rules[name] = stats
+	}
+
+	stats.Invocations++
+	stats.Matches += matches
+	stats.Milliseconds += float64(elapsed.Microseconds()) / 1000
+}
+
+// recordBlock adds the words in `blk` and the number of alerts found in it
+// to the totals for its kind of content and its section.
+func (s *ruleStats) recordBlock(f *core.File, blk nlp.Block, alerts int) {
+	kind := scopeKind(blk.Scope, f.RealExt)
+
+	words := 0
+	if kind != "body" || strings.HasPrefix(blk.Scope, "text") {
+		// NOTE: Prose is linted as a whole and then by paragraph and
+		// sentence, so we only count its words once.
+		words = len(strings.Fields(blk.Text))
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	addScopeStats(s.scopes, kind, words, alerts)
+	if kind != "raw" && kind != "summary" {
+		// These blocks span the entire file, so they're not in any one
+		// section.
+		section := "(none)"
+		if enclosing := f.Section(); len(enclosing) > 0 {
+			section = enclosing[len(enclosing)-1].Text
+		}
+		addScopeStats(s.sections, section, words, alerts)
+	}
+}
+
+func addScopeStats(m map[string]*ScopeStats, key string, words, alerts int) {
+	stats, ok := m[key]
+	if !ok {
+		stats = &ScopeStats{}
+		m[key] = stats
+	}
+	stats.Words += words
+	stats.Alerts += alerts
+}
+
+// scopeKind returns the kind of content in a block with the given scope
+// (e.g., `text.heading.h2.md` is a `heading`); prose is `body`.
+func scopeKind(scope, ext string) string {
+	parts := strings.Split(strings.TrimSuffix(scope, ext), ".")
+	switch {
+	case parts[0] == "paragraph" || parts[0] == "sentence":
+		return "body"
+	case parts[0] == "text" && len(parts) == 1:
+		return "body"
+	case parts[0] == "text":
+		return parts[1]
+	}
+	return parts[0]
+}
+
+// Stats returns the statistics collected during a run, including those for
+// rules that were never invoked. It returns nil unless `--stats` was given.
+func (l *Linter) Stats() *RunStats {
+	if l.stats == nil {
+		return nil
+	}
+
+	l.stats.Lock()
+	defer l.stats.Unlock()
+
+	stats := &RunStats{
+		Rules:    map[string]RuleStats{},
+		Scopes:   densities(l.stats.scopes),
+		Sections: densities(l.stats.sections),
+	}
+
+	for name := range l.Manager.Rules() {
+		stats.Rules[name] = RuleStats{}
+		if recorded, ok := l.stats.rules[name]; ok {
+			stats.Rules[name] = *recorded
+		}
+	}
+
+	return stats
+}
+
+func densities(recorded map[string]*ScopeStats) map[string]ScopeStats {
+	stats := map[string]ScopeStats{}
+	for key, s := range recorded {
+		if s.Words > 0 {
+			s.Density = math.Round(float64(s.Alerts)*1000/float64(s.Words)*100) / 100
+		}
+		stats[key] = *s
+	}
+	return stats
+}
+
+func countVisible(alerts []core.Alert) int {
+	count := 0
+	for _, a := range alerts {
+		if !a.Hide {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,264 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// storeInterval is the minimum amount of time between writes of a store to
+// disk while a run is in progress.
+const storeInterval = 2 * time.Second
+
+// storeEntry is the result of linting a single file.
+//
+// `Depends` maps each of the other files that the result depends on (see
+// `File.AddDependency`) to the hash of its contents when the file was linted.
+type storeEntry struct {
+	Hash    string
+	Alerts  []core.Alert
+	Depends map[string]string `json:",omitempty"`
+}
+
+// storeData is the on-disk representation of a `ResultStore`.
+type storeData struct {
+	Fingerprint string
+	Files       map[string]storeEntry
+}
+
+// A ResultStore records the alerts for each linted file, keyed by the file's
+// contents and the configuration used to lint it.
+//
+// This allows a run to skip any files that haven't changed since they were
+// last linted -- e.g., when resuming an interrupted run with `--checkpoint`.
+//
+// A store may be shared by concurrent runs (e.g., parallel CI shards), so
+// it's locked while being read or written and each write merges in the
+// results recorded by other runs.
+//
+// When writes are disabled (see `core.ReadOnly`), a store is only ever read:
+// new results are kept in memory for the rest of the run.
+type ResultStore struct {
+	path     string
+	data     storeData
+	recorded map[string]bool
+	saved    time.Time
+	mu       sync.Mutex
+}
+
+// OpenStore loads the store at `path` (if it exists) for the given
+// configuration.
+//
+// If the store was created using a different configuration (or set of
+// styles), its results are discarded.
+func OpenStore(path string, cfg *core.Config) (*ResultStore, error) {
+	id, err := fingerprint(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &ResultStore{
+		path:     path,
+		saved:    time.Now(),
+		recorded: map[string]bool{},
+		data:     storeData{Fingerprint: id, Files: map[string]storeEntry{}},
+	}
+
+	lock, err := core.LockState(path)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	files, err := readStore(path, id)
+	if err != nil {
+		return nil, err
+	}
+	store.data.Files = files
+
+	return store, nil
+}
+
+// readStore returns the results stored at `path` for the configuration
+// identified by `id`.
+func readStore(path, id string) (map[string]storeEntry, error) {
+	files := map[string]storeEntry{}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var data storeData
+	if err = json.Unmarshal(b, &data); err != nil {
+		return nil, core.NewE100("OpenStore", err)
+	} else if data.Fingerprint == id && data.Files != nil {
+		files = data.Files
+	}
+
+	return files, nil
+}
+
+// Lookup returns the stored alerts for the file at `path`, if neither its
+// contents nor those of its dependencies have changed.
+func (s *ResultStore) Lookup(path string, content []byte) ([]core.Alert, bool) {
+	s.mu.Lock()
+	entry, found := s.data.Files[path]
+	s.mu.Unlock()
+
+	if !found || entry.Hash != hashBytes(content) {
+		return nil, false
+	}
+
+	for dep, hash := range entry.Depends {
+		if hashFile(dep) != hash {
+			return nil, false
+		}
+	}
+
+	return entry.Alerts, true
+}
+
+// Record stores the alerts for the file at `path`, along with the files that
+// they depend on, periodically writing the store to disk.
+func (s *ResultStore) Record(path string, content []byte, alerts []core.Alert, depends ...string) error {
+	entry := storeEntry{Hash: hashBytes(content), Alerts: alerts}
+	if len(depends) > 0 {
+		entry.Depends = map[string]string{}
+		for _, dep := range depends {
+			entry.Depends[dep] = hashFile(dep)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Files[path] = entry
+	s.recorded[path] = true
+	if time.Since(s.saved) < storeInterval {
+		return nil
+	}
+	return s.save()
+}
+
+// Save writes the store to disk.
+func (s *ResultStore) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.save()
+}
+
+func (s *ResultStore) save() error {
+	if core.ReadOnly {
+		// The store is kept in memory for the rest of the run.
+		return nil
+	}
+
+	lock, err := core.LockState(s.path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// Another run may have written to the store since we read it, so we
+	// start from its current contents and only overwrite the files that
+	// we've linted ourselves.
+	files, err := readStore(s.path, s.data.Fingerprint)
+	if err != nil {
+		return err
+	}
+	for path := range s.recorded {
+		files[path] = s.data.Files[path]
+	}
+	s.data.Files = files
+
+	b, err := json.Marshal(s.data)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.path), os.ModePerm); err != nil {
+		return err
+	}
+
+	// NOTE: We write to a temporary file first so that an interruption never
+	// leaves behind a partially-written store.
+	tmp := s.path + ".tmp"
+	if err = os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	s.saved = time.Now()
+
+	return os.Rename(tmp, s.path)
+}
+
+// hashFile returns the hash of the file at `path`'s contents, or an empty
+// string if it can't be read (e.g., it doesn't exist).
+func hashFile(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return hashBytes(b)
+}
+
+func hashBytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprint identifies everything -- other than a file's contents -- that
+// can affect its alerts: the version of Vale, the configuration itself, the
+// files it was loaded from, and the files in each of its style paths.
+func fingerprint(cfg *core.Config) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(core.ValeVersion))
+
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	h.Write(b)
+
+	b, err = json.Marshal([]string{
+		cfg.Flags.Filter, cfg.Flags.InExt, cfg.Flags.AlertLevel, cfg.Flags.Profile})
+	if err != nil {
+		return "", err
+	}
+	h.Write(b)
+
+	if cfg.Flags.Simple {
+		h.Write([]byte("simple"))
+	}
+
+	stamp := func(path string) {
+		if info, statErr := os.Stat(path); statErr == nil && !info.IsDir() {
+			h.Write([]byte(path + info.ModTime().String()))
+		}
+	}
+
+	for _, file := range cfg.ConfigFiles {
+		stamp(file)
+	}
+
+	for _, dir := range cfg.Paths {
+		_ = filepath.WalkDir(dir, func(path string, _ fs.DirEntry, err error) error {
+			if err == nil {
+				stamp(path)
+			}
+			return nil
+		})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,209 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestResultStore(t *testing.T) {
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	store, err := OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts := []core.Alert{{Check: "Vale.Spelling", Match: "teh", Line: 1}}
+	if err = store.Record("a.md", []byte("teh"), alerts); err != nil {
+		t.Fatal(err)
+	} else if err = store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found, ok := store.Lookup("a.md", []byte("teh")); !ok || len(found) != 1 {
+		t.Errorf("Expected a stored result, got %v", found)
+	}
+
+	if _, ok := store.Lookup("a.md", []byte("the")); ok {
+		t.Error("Expected a changed file to be re-linted")
+	}
+
+	cfg.MinAlertLevel = 2
+	store, err = OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := store.Lookup("a.md", []byte("teh")); ok {
+		t.Error("Expected a changed configuration to discard stored results")
+	}
+
+	if err = store.Record("a.md", []byte("teh"), alerts); err != nil {
+		t.Fatal(err)
+	} else if err = store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	version := core.ValeVersion
+	core.ValeVersion = version + "-next"
+	defer func() { core.ValeVersion = version }()
+
+	store, err = OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := store.Lookup("a.md", []byte("teh")); ok {
+		t.Error("Expected a new version of Vale to discard stored results")
+	}
+}
+
+func TestResultStoreShared(t *testing.T) {
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	first, err := OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, store := range map[string]*ResultStore{"a.md": first, "b.md": second} {
+		if err = store.Record(name, []byte(name), nil); err != nil {
+			t.Fatal(err)
+		} else if err = store.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	merged, err := OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.md", "b.md"} {
+		if _, ok := merged.Lookup(name, []byte(name)); !ok {
+			t.Errorf("Expected a stored result for '%s'", name)
+		}
+	}
+}
+
+func TestResultStoreReadOnly(t *testing.T) {
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	store, err := OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if err = store.Record("a.md", []byte("teh"), nil); err != nil {
+		t.Fatal(err)
+	} else if err = store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	core.ReadOnly = true
+	defer func() { core.ReadOnly = false }()
+
+	store, err = OpenStore(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	} else if _, ok := store.Lookup("a.md", []byte("teh")); !ok {
+		t.Error("Expected a read-only store to load existing results")
+	}
+
+	if err = store.Record("b.md", []byte("the"), nil); err != nil {
+		t.Fatal(err)
+	} else if err = store.Save(); err != nil {
+		t.Fatal(err)
+	} else if _, ok := store.Lookup("b.md", []byte("the")); !ok {
+		t.Error("Expected a read-only store to keep new results in memory")
+	}
+
+	core.ReadOnly = false
+	if store, err = OpenStore(path, cfg); err != nil {
+		t.Fatal(err)
+	} else if _, ok := store.Lookup("b.md", []byte("the")); ok {
+		t.Error("Expected a read-only store not to be written to disk")
+	}
+}
+
+func TestResultStoreDependencies(t *testing.T) {
+	t.Setenv("VALE_STATE_PATH", t.TempDir())
+
+	dir := t.TempDir()
+
+	doc := filepath.Join(dir, "a.md")
+	linked := filepath.Join(dir, "b.md")
+
+	if err := os.WriteFile(doc, []byte("See [setup](b.md#install).\n"), 0o600); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(linked, []byte("# Install\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{Checkpoint: filepath.Join(dir, "state.db"), InExt: ".txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.GBaseStyles = []string{"Vale"}
+
+	anchors := func() int {
+		linter, lerr := NewLinter(cfg)
+		if lerr != nil {
+			t.Fatal(lerr)
+		}
+
+		linted, lerr := linter.Lint([]string{doc}, "*")
+		if lerr != nil {
+			t.Fatal(lerr)
+		}
+
+		n := 0
+		for _, a := range linted[0].Alerts {
+			if a.Check == "Vale.Anchors" {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := anchors(); n != 0 {
+		t.Fatalf("Expected no broken anchors, got %d", n)
+	}
+
+	if err = os.WriteFile(linked, []byte("# Installation\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := anchors(); n != 1 {
+		t.Errorf("Expected a renamed heading to invalidate the stored result, got %d alerts", n)
+	}
+}
@@ -0,0 +1,609 @@
+package lint
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// texHeadings maps sectioning commands to HTML heading levels.
+var texHeadings = map[string]int{
+	"title": 1, "part": 1, "chapter": 1, "section": 2, "subsection": 3,
+	"subsubsection": 4, "paragraph": 5, "subparagraph": 6,
+}
+
+// texInline maps formatting commands to inline HTML tags.
+var texInline = map[string]string{
+	"emph": "em", "textit": "em", "textsl": "em", "textbf": "strong",
+	"underline": "em", "texttt": "code", "url": "code", "path": "code",
+	"nolinkurl": "code",
+}
+
+// texReferences are commands whose arguments are keys or paths rather than
+// prose; they're rendered as code so that sentences stay intact.
+var texReferences = []string{
+	"cite", "citep", "citet", "citeauthor", "citeyear", "parencite",
+	"textcite", "autocite", "footcite", "ref", "eqref", "pageref", "cref",
+	"Cref", "autoref", "nameref", "vref",
+}
+
+// texDropped are commands that we ignore entirely, including their arguments.
+var texDropped = []string{
+	"label", "input", "include", "includeonly", "includegraphics",
+	"usepackage", "documentclass", "bibliography", "bibliographystyle",
+	"newcommand", "renewcommand", "providecommand", "newenvironment",
+	"renewenvironment", "setlength", "addtolength", "setcounter", "vspace",
+	"hspace", "maketitle", "tableofcontents", "listoffigures", "listoftables",
+	"author", "date", "thanks", "index", "hypersetup", "graphicspath",
+	"addbibresource", "printbibliography", "pagestyle", "thispagestyle",
+	"newpage", "clearpage", "centering", "noindent", "hline",
+}
+
+// texSkippedEnvs are environments that don't contain prose.
+var texSkippedEnvs = []string{
+	"equation", "align", "alignat", "gather", "multline", "flalign",
+	"eqnarray", "math", "displaymath", "verbatim", "Verbatim", "lstlisting",
+	"minted", "comment", "tikzpicture", "tabular", "tabularx", "longtable",
+	"array", "thebibliography", "filecontents",
+}
+
+// texBlockEnvs are environments rendered as HTML block elements.
+var texBlockEnvs = map[string]string{
+	"itemize": "ul", "enumerate": "ol", "description": "ul",
+	"quote": "blockquote", "quotation": "blockquote", "verse": "blockquote",
+}
+
+var reTeXInclude = regexp.MustCompile(`\\(?:input|include|subfile)\{([^}]+)\}`)
+var reTeXComment = regexp.MustCompile(`(?m)(^|[^\\])%(.*)$`)
+
+// reTeXCode matches inline spans that we convert to code: math, `\verb`,
+// links, and references.
+var reTeXCode = regexp.MustCompile(`\$[^$\n]+\$|\\\(.+?\\\)|\\verb\*?(?:\|[^|\n]*\||\+[^+\n]*\+|![^!\n]*!)|\\href\{[^}\n]*\}|\\(?:` +
+	strings.Join(texReferences, "|") + `)\*?(?:\[[^]\n]*\])*\{[^}\n]*\}`)
+
+// lintTeX lints a LaTeX document by converting it to HTML.
+//
+// We only lint the document's body (i.e., what's inside of
+// `\begin{document}`), skipping math, code listings, tables, and any commands
+// that don't contain prose.
+func (l *Linter) lintTeX(f *core.File) error {
+	s, err := l.Transform(f)
+	if err != nil {
+		return err
+	}
+
+	conv := &texConverter{src: s}
+	out := conv.convert()
+
+	// We don't want to find matches in comments or code, so we mask them in
+	// the source (preserving offsets).
+	f.Content = reTeXComment.ReplaceAllStringFunc(f.Content, func(m string) string {
+		idx := strings.Index(m, "%")
+		return m[:idx] + strings.Repeat("*", nlp.StrLen(m[idx:]))
+	})
+	f.Content = reTeXCode.ReplaceAllStringFunc(f.Content, func(m string) string {
+		return strings.Repeat("*", nlp.StrLen(m))
+	})
+
+	return l.lintHTMLTokens(f, []byte(out), 0)
+}
+
+// texIncludes returns the (existing) files that the LaTeX document at `path`
+// includes via `\input`, `\include`, or `\subfile`.
+func texIncludes(path, content string) []string {
+	var found []string
+
+	dir := filepath.Dir(path)
+	for _, line := range strings.Split(content, "\n") {
+		if idx := strings.Index(line, "%"); idx >= 0 && (idx == 0 || line[idx-1] != '\\') {
+			line = line[:idx]
+		}
+		for _, m := range reTeXInclude.FindAllStringSubmatch(line, -1) {
+			target := filepath.Join(dir, strings.TrimSpace(m[1]))
+			if filepath.Ext(target) == "" {
+				target += ".tex"
+			}
+			if core.FileExists(target) {
+				found = append(found, target)
+			}
+		}
+	}
+
+	return found
+}
+
+// lintIncludes lints any LaTeX files included by those in `linted` that
+// haven't already been linted, so that their alerts are attributed to the
+// included file (rather than the one including it).
+func (l *Linter) lintIncludes(linted []*core.File) ([]*core.File, error) {
+	seen := map[string]bool{}
+	for _, f := range linted {
+		if abs, err := filepath.Abs(f.Path); err == nil {
+			seen[abs] = true
+		}
+	}
+
+	for i := 0; i < len(linted); i++ {
+		f := linted[i]
+		if f.NormedExt != ".tex" {
+			continue
+		}
+
+		content, err := os.ReadFile(f.Path)
+		if err != nil {
+			continue
+		}
+
+		for _, included := range texIncludes(f.Path, string(content)) {
+			abs, _ := filepath.Abs(included)
+			if seen[abs] || l.skip(included) {
+				continue
+			}
+			seen[abs] = true
+
+			result := l.lintStored(included)
+			if result.err != nil {
+				return linted, result.err
+			}
+			linted = append(linted, result.file)
+			if l.OnLinted != nil {
+				l.OnLinted(result.file)
+			}
+		}
+	}
+
+	return linted, nil
+}
+
+// texConverter is a minimal LaTeX-to-HTML converter.
+//
+// It's not meant to render documents; it only needs to preserve their prose
+// and structure (headings, lists, captions, footnotes, etc.) so that we can
+// assign scopes.
+type texConverter struct {
+	src string
+	pos int
+
+	out       strings.Builder
+	footnotes []string
+	inPara    bool
+
+	// lists records, for each open list, whether or not it has an open item.
+	lists []bool
+}
+
+func (c *texConverter) convert() string {
+	start, end := 0, len(c.src)
+	if idx := strings.Index(c.src, `\begin{document}`); idx >= 0 {
+		start = idx + len(`\begin{document}`)
+	}
+	if idx := strings.LastIndex(c.src, `\end{document}`); idx >= start {
+		end = idx
+	}
+
+	c.src = c.src[:end]
+	c.pos = start
+
+	c.body("")
+	c.closePara()
+
+	return c.out.String()
+}
+
+// body converts text until it reaches `\end{env}` (or the end of the
+// document, if `env` is empty).
+func (c *texConverter) body(env string) {
+	for c.pos < len(c.src) {
+		ch := c.src[c.pos]
+		switch {
+		case ch == '%':
+			c.comment()
+		case ch == '\\':
+			if c.command(env) {
+				return
+			}
+		case ch == '$':
+			c.math()
+		case ch == '{' || ch == '}':
+			c.pos++
+		case ch == '~':
+			c.text(" ")
+			c.pos++
+		case ch == '\n' && c.blankLine():
+			c.closePara()
+			c.pos++
+		default:
+			next := strings.IndexAny(c.src[c.pos:], "%\\${}~\n")
+			if next < 0 {
+				next = len(c.src) - c.pos
+			} else if next == 0 {
+				next = 1 // a single newline
+			}
+			c.text(c.src[c.pos : c.pos+next])
+			c.pos += next
+		}
+	}
+}
+
+// comment converts `% vale ...` comments and skips all others.
+func (c *texConverter) comment() {
+	end := strings.IndexByte(c.src[c.pos:], '\n')
+	if end < 0 {
+		end = len(c.src) - c.pos
+	}
+
+	comment := strings.TrimSpace(strings.TrimLeft(c.src[c.pos:c.pos+end], "%"))
+	if strings.HasPrefix(comment, "vale ") {
+		c.closePara()
+		c.out.WriteString("<!-- " + comment + " -->")
+	}
+
+	c.pos += end
+}
+
+// blankLine reports if the newline at the current position starts a blank
+// line (i.e., a paragraph break).
+func (c *texConverter) blankLine() bool {
+	rest := c.src[c.pos+1:]
+	line, _, found := strings.Cut(rest, "\n")
+	return found && strings.TrimSpace(line) == "" || !found && strings.TrimSpace(rest) == ""
+}
+
+func (c *texConverter) text(s string) {
+	if strings.TrimSpace(s) != "" && !c.inPara {
+		c.out.WriteString("<p>")
+		c.inPara = true
+	}
+	c.out.WriteString(html.EscapeString(s))
+}
+
+func (c *texConverter) openBlock(tag string) {
+	c.closePara()
+	c.out.WriteString("<" + tag + ">")
+}
+
+func (c *texConverter) closePara() {
+	if c.inPara {
+		c.out.WriteString("</p>\n")
+		c.inPara = false
+	}
+
+	for i, note := range c.footnotes {
+		c.out.WriteString(fmt.Sprintf("<ol><li id=\"fn:%d\">%s</li></ol>\n", i+1, note))
+	}
+	c.footnotes = nil
+}
+
+// math converts inline math to code and skips display math.
+func (c *texConverter) math() {
+	delim := "$"
+	if strings.HasPrefix(c.src[c.pos:], "$$") {
+		delim = "$$"
+	}
+
+	start := c.pos + len(delim)
+	end := strings.Index(c.src[start:], delim)
+	if end < 0 {
+		c.pos = len(c.src)
+		return
+	}
+
+	c.mathSpan(c.src[start:start+end], delim == "$$")
+	c.pos = start + end + len(delim)
+}
+
+func (c *texConverter) mathSpan(s string, display bool) {
+	if display {
+		c.closePara()
+		c.out.WriteString("<pre>" + html.EscapeString(s) + "</pre>\n")
+		return
+	}
+
+	if !c.inPara {
+		c.out.WriteString("<p>")
+		c.inPara = true
+	}
+
+	// Inline spans are masked in the source (see `reTeXCode`), so we mask
+	// them here too; otherwise, the walker would consume matching words
+	// elsewhere in the document.
+	c.out.WriteString("<code>" + strings.Repeat("*", nlp.StrLen(s)) + "</code>")
+}
+
+// command converts the command at the current position, returning `true` if
+// it ends the environment `env`.
+func (c *texConverter) command(env string) bool {
+	c.pos++ // skip the backslash
+	if c.pos >= len(c.src) {
+		return false
+	}
+
+	ch := c.src[c.pos]
+	if !isTeXLetter(ch) {
+		c.pos++
+		switch ch {
+		case '\\':
+			c.text(" ")
+		case '(':
+			c.delimitedMath(`\)`, false)
+		case '[':
+			c.delimitedMath(`\]`, true)
+		case '%', '&', '$', '#', '_', '{', '}':
+			c.text(string(ch))
+		}
+		return false
+	}
+
+	start := c.pos
+	for c.pos < len(c.src) && isTeXLetter(c.src[c.pos]) {
+		c.pos++
+	}
+	name := c.src[start:c.pos]
+
+	starred := c.pos < len(c.src) && c.src[c.pos] == '*'
+	if starred {
+		c.pos++
+	}
+
+	switch {
+	case name == "begin":
+		c.begin(c.arg())
+	case name == "end":
+		closing := c.arg()
+		if closing == env {
+			return true
+		}
+	case name == "verb":
+		c.verb()
+	case name == "item":
+		c.item()
+	case texHeadings[name] > 0:
+		c.optArg()
+		c.wrap(fmt.Sprintf("h%d", texHeadings[name]), true)
+	case name == "caption":
+		c.optArg()
+		c.wrap("figcaption", true)
+	case name == "footnote":
+		c.optArg()
+		c.footnote()
+	case texInline[name] != "":
+		c.wrap(texInline[name], false)
+	case name == "href":
+		c.mathSpan(c.arg(), false)
+		c.wrap("a", false)
+	case core.StringInSlice(name, texReferences):
+		c.optArg()
+		c.optArg()
+		c.mathSpan(c.arg(), false)
+	case core.StringInSlice(name, texDropped):
+		c.optArg()
+		for c.peekArg() {
+			c.arg()
+		}
+	default:
+		// Unknown commands are dropped, but their arguments (which are
+		// usually prose -- e.g., `\textsc{...}`) are kept.
+		c.optArg()
+	}
+
+	return false
+}
+
+func (c *texConverter) begin(env string) {
+	name := strings.TrimSuffix(env, "*")
+	switch {
+	case core.StringInSlice(name, texSkippedEnvs):
+		end := strings.Index(c.src[c.pos:], `\end{`+env+`}`)
+		if end < 0 {
+			end = len(c.src) - c.pos
+		}
+		c.closePara()
+		c.out.WriteString("<pre>" + html.EscapeString(c.src[c.pos:c.pos+end]) + "</pre>\n")
+		c.pos += end + len(`\end{`+env+`}`)
+		c.pos = min(c.pos, len(c.src))
+	case texBlockEnvs[name] != "":
+		tag := texBlockEnvs[name]
+		list := tag != "blockquote"
+
+		c.openBlock(tag)
+		if list {
+			c.lists = append(c.lists, false)
+		}
+
+		c.body(env)
+		c.closePara()
+
+		if list {
+			if c.lists[len(c.lists)-1] {
+				c.out.WriteString("</li>")
+			}
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		c.out.WriteString("</" + tag + ">\n")
+	default:
+		c.optArg()
+		c.body(env)
+	}
+}
+
+// item starts a new list item, closing the previous one (if any).
+func (c *texConverter) item() {
+	c.closePara()
+
+	if n := len(c.lists); n > 0 {
+		if c.lists[n-1] {
+			c.out.WriteString("</li>")
+		}
+		c.out.WriteString("<li>")
+		c.lists[n-1] = true
+	}
+
+	if label := c.optArg(); label != "" {
+		c.out.WriteString("<p><strong>" + html.EscapeString(label) + "</strong> ")
+		c.inPara = true
+	}
+}
+
+// wrap converts the next argument, wrapping it in the given tag.
+func (c *texConverter) wrap(tag string, block bool) {
+	if block {
+		c.closePara()
+	} else if !c.inPara {
+		c.out.WriteString("<p>")
+		c.inPara = true
+	}
+
+	if !c.peekArg() {
+		return
+	}
+
+	c.out.WriteString("<" + tag + ">")
+	c.group()
+	c.out.WriteString("</" + tag + ">")
+
+	if block {
+		c.out.WriteString("\n")
+	}
+}
+
+// footnote converts the next argument into a footnote, which is emitted after
+// the current paragraph.
+func (c *texConverter) footnote() {
+	if !c.peekArg() {
+		return
+	}
+
+	outer, inPara := c.out.String(), c.inPara
+
+	c.out.Reset()
+	c.inPara = true
+	c.group()
+	note := c.out.String()
+
+	c.out.Reset()
+	c.out.WriteString(outer)
+	c.inPara = inPara
+
+	c.footnotes = append(c.footnotes, note)
+}
+
+// group converts the contents of a `{...}` group at the current position.
+func (c *texConverter) group() {
+	c.skipSpace()
+	end := c.matching('{', '}')
+	if end < 0 {
+		return
+	}
+
+	outer := c.src
+	c.src = c.src[:end]
+	c.pos++ // skip the opening brace
+
+	inPara := c.inPara
+	c.inPara = true
+	c.body("")
+	c.inPara = inPara
+
+	c.src = outer
+	c.pos = end + 1
+}
+
+func (c *texConverter) delimitedMath(closing string, display bool) {
+	end := strings.Index(c.src[c.pos:], closing)
+	if end < 0 {
+		end = len(c.src) - c.pos
+	}
+	c.mathSpan(c.src[c.pos:c.pos+end], display)
+	c.pos = min(c.pos+end+len(closing), len(c.src))
+}
+
+func (c *texConverter) verb() {
+	if c.pos >= len(c.src) {
+		return
+	}
+
+	delim := c.src[c.pos]
+	end := strings.IndexByte(c.src[c.pos+1:], delim)
+	if end < 0 {
+		end = len(c.src) - c.pos - 1
+	}
+
+	c.mathSpan(c.src[c.pos+1:c.pos+1+end], false)
+	c.pos = min(c.pos+end+2, len(c.src))
+}
+
+// arg returns (and skips) the raw contents of the next `{...}` argument.
+func (c *texConverter) arg() string {
+	if !c.peekArg() {
+		return ""
+	}
+
+	c.skipSpace()
+	end := c.matching('{', '}')
+	if end < 0 {
+		return ""
+	}
+
+	s := c.src[c.pos+1 : end]
+	c.pos = end + 1
+	return s
+}
+
+// optArg returns (and skips) the raw contents of an optional `[...]`
+// argument, if there is one.
+func (c *texConverter) optArg() string {
+	if c.pos >= len(c.src) || c.src[c.pos] != '[' {
+		return ""
+	}
+
+	end := c.matching('[', ']')
+	if end < 0 {
+		return ""
+	}
+
+	s := c.src[c.pos+1 : end]
+	c.pos = end + 1
+	return s
+}
+
+// peekArg reports if a `{...}` argument follows the current position.
+func (c *texConverter) peekArg() bool {
+	rest := strings.TrimLeft(c.src[c.pos:], " \t")
+	return strings.HasPrefix(rest, "{")
+}
+
+func (c *texConverter) skipSpace() {
+	for c.pos < len(c.src) && (c.src[c.pos] == ' ' || c.src[c.pos] == '\t') {
+		c.pos++
+	}
+}
+
+// matching returns the index of the delimiter that closes the one at the
+// current position.
+func (c *texConverter) matching(open, closing byte) int {
+	depth := 0
+	for i := c.pos; i < len(c.src); i++ {
+		switch c.src[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isTeXLetter(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch == '@'
+}
@@ -0,0 +1,92 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTeXConverter(t *testing.T) {
+	cases := []struct {
+		description string
+		src         string
+		contains    []string
+		excludes    []string
+	}{
+		{
+			description: "preamble",
+			src:         "\\title{Preamble}\n\\begin{document}\nBody.\n\\end{document}\n",
+			contains:    []string{"<p>Body.</p>"},
+			excludes:    []string{"Preamble"},
+		},
+		{
+			description: "sections and labels",
+			src:         "\\section{Intro}\\label{sec:intro}\n\\subsection*{More}\n",
+			contains:    []string{"<h2>Intro</h2>", "<h3>More</h3>"},
+			excludes:    []string{"sec:intro"},
+		},
+		{
+			description: "footnotes",
+			src:         "Some text.\\footnote{A \\emph{note}.} More text.\n\nNext.",
+			contains: []string{
+				"<p>Some text. More text.</p>\n<ol><li id=\"fn:1\">A <em>note</em>.</li></ol>"},
+		},
+		{
+			description: "captions",
+			src:         "\\begin{figure}[h]\n\\includegraphics{a.png}\n\\caption{A figure.}\n\\end{figure}",
+			contains:    []string{"<figcaption>A figure.</figcaption>"},
+			excludes:    []string{"a.png"},
+		},
+		{
+			description: "math and references",
+			src:         "Let $x = y$ hold \\cite[p.~2]{key}.\n\\begin{equation}\na + b\n\\end{equation}",
+			contains:    []string{"Let <code>*****</code> hold <code>***</code>.", "<pre>\na + b\n</pre>"},
+		},
+		{
+			description: "lists",
+			src:         "\\begin{itemize}\n\\item One.\n\\item Two.\n\\end{itemize}",
+			contains:    []string{"<ul>\n<li><p> One.\n</p>\n</li><li><p> Two.\n</p>\n</li></ul>"},
+		},
+		{
+			description: "comments",
+			src:         "% vale off\nSkipped. % A comment.\n% vale on\n",
+			contains:    []string{"<!-- vale off -->\n<p>Skipped. \n</p>\n<!-- vale on -->"},
+			excludes:    []string{"A comment"},
+		},
+	}
+
+	for _, tc := range cases {
+		c := &texConverter{src: tc.src}
+		out := c.convert()
+		for _, s := range tc.contains {
+			if !strings.Contains(out, s) {
+				t.Errorf("%s: expected %q in %q", tc.description, s, out)
+			}
+		}
+		for _, s := range tc.excludes {
+			if strings.Contains(out, s) {
+				t.Errorf("%s: unexpected %q in %q", tc.description, s, out)
+			}
+		}
+	}
+}
+
+func TestTeXIncludes(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"intro.tex", "data.dat"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(""), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	main := filepath.Join(dir, "main.tex")
+	content := "\\input{intro}\n\\include{data.dat}\n% \\input{commented}\n\\input{missing}\n"
+
+	found := texIncludes(main, content)
+	expected := []string{filepath.Join(dir, "intro.tex"), filepath.Join(dir, "data.dat")}
+
+	if strings.Join(found, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, found)
+	}
+}
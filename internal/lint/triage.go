@@ -0,0 +1,94 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/glob"
+)
+
+// Triage describes what Vale would do with a single file.
+type Triage struct {
+	Path   string
+	Format string // e.g., "markup" or "unknown"
+	Ext    string // the extension the file is parsed as (e.g., ".md")
+
+	// Sections are the config sections whose patterns match the file.
+	Sections []string
+	Styles   []string
+
+	// Reason explains why the file would be skipped; it's empty if the file
+	// would be linted.
+	Reason string
+}
+
+// Triage explains whether -- and, if not, why -- `path` would be linted
+// when it matches the glob `pat`, as well as which config sections apply to
+// it.
+//
+// The checks mirror the order in which a run applies them, so the first
+// reason reported is the one that applies.
+func (l *Linter) Triage(path, pat string) (Triage, error) {
+	cfg := l.Manager.Config
+
+	ext, format := core.FormatFromExt(path, cfg.Formats)
+	result := Triage{
+		Path:     path,
+		Format:   format,
+		Ext:      ext,
+		Sections: matchingSections(path, cfg),
+	}
+
+	gp, err := glob.NewGlob(pat)
+	if err != nil {
+		return result, err
+	}
+	l.glob = &gp
+
+	ref := filepath.ToSlash(core.ReplaceExt(path, cfg.Formats))
+	if !l.match(path) && !l.match(ref) {
+		result.Reason = fmt.Sprintf("it doesn't match the glob '%s'", pat)
+		return result, nil
+	} else if l.skip(path) {
+		result.Reason = "it isn't matched by any config section, and there are no global styles"
+		return result, nil
+	} else if l.shard != nil && !l.shard.includes(rolloutPath(path, cfg.RootINI)) {
+		result.Reason = fmt.Sprintf("it isn't part of shard '%s'", cfg.Flags.Shard)
+		return result, nil
+	} else if reason := notText(path); reason != "" {
+		result.Reason = fmt.Sprintf("it doesn't appear to be text (%s)", reason)
+		return result, nil
+	}
+
+	file, err := core.NewFile(path, cfg)
+	if err != nil {
+		return result, err
+	}
+	result.Styles = file.BaseStyles
+
+	if len(file.Checks) == 0 && len(file.BaseStyles) == 0 {
+		if len(cfg.GBaseStyles) == 0 && len(cfg.GChecks) == 0 {
+			result.Reason = "no styles or rules apply to it"
+		}
+	}
+
+	return result, nil
+}
+
+// matchingSections returns the (sorted) config sections that apply to
+// `path`, either as is or with its extension mapped by `[formats]`.
+func matchingSections(path string, cfg *core.Config) []string {
+	ref := filepath.ToSlash(core.ReplaceExt(path, cfg.Formats))
+
+	sections := []string{}
+	for sec, pat := range cfg.SecToPat {
+		if pat.Match(path) || pat.Match(ref) {
+			sections = append(sections, sec)
+		}
+	}
+	sort.Strings(sections)
+
+	return sections
+}
@@ -0,0 +1,55 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTriage(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":     "Some text.",
+		"b.png":    "Some text.",
+		"c.dat":    "x\x00\x01\x02\x03\x04\x05\x06y",
+		"d.min.js": "var x = 1;",
+	}
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		name   string
+		glob   string
+		reason string
+	}{
+		{"a.md", "*", ""},
+		{"a.md", "*.txt", "it doesn't match the glob '*.txt'"},
+		{"b.png", "*", "it doesn't appear to be text (binary)"},
+		{"c.dat", "*", "it doesn't appear to be text (binary)"},
+		{"d.min.js", "*", "it doesn't appear to be text (minified)"},
+	}
+
+	for _, tc := range cases {
+		result, terr := linter.Triage(filepath.Join(dir, tc.name), tc.glob)
+		if terr != nil {
+			t.Fatal(terr)
+		} else if result.Reason != tc.reason {
+			t.Errorf("%s (%s): expected '%s', got '%s'", tc.name, tc.glob, tc.reason, result.Reason)
+		}
+	}
+
+	result, err := linter.Triage(filepath.Join(dir, "a.md"), "*")
+	if err != nil {
+		t.Fatal(err)
+	} else if result.Format != "markup" || len(result.Styles) != 1 || result.Styles[0] != "Vale" {
+		t.Errorf("Unexpected triage: %+v", result)
+	}
+}
@@ -0,0 +1,108 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// urlTimeout is how long we wait for a page (see `LintURL`) to be fetched.
+const urlTimeout = 30 * time.Second
+
+// UserAgent identifies Vale to the sites whose pages it fetches.
+func UserAgent() string {
+	return "Vale/" + core.ValeVersion
+}
+
+// IsURL reports whether `s` is an HTTP(S) URL to be fetched and linted (see
+// `LintURL`) rather than a path.
+func IsURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// LintURL fetches the page at `rawURL` and lints it as HTML.
+//
+// If `selector` isn't empty, only the page's content area -- the elements
+// matching the CSS selector (e.g., `main` or `article.docs`) -- is linted,
+// which avoids linting its navigation, header, and footer. Either way, alerts
+// are reported against the URL, with lines counted from the start of the
+// (rendered) HTML.
+func (l *Linter) LintURL(rawURL, selector string) (*core.File, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil) //nolint:noctx
+	if err != nil {
+		return nil, core.NewE100("LintURL", err)
+	}
+	req.Header.Set("User-Agent", UserAgent())
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, core.NewE100("LintURL", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, core.NewE100("LintURL", fmt.Errorf("'%s' returned %s", rawURL, resp.Status))
+	} else if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "html") {
+		return nil, core.NewE100("LintURL", fmt.Errorf("'%s' isn't HTML (%s)", rawURL, ct))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize))
+	if err != nil {
+		return nil, core.NewE100("LintURL", err)
+	}
+
+	return l.LintPage(rawURL, string(body), selector)
+}
+
+// LintPage lints `content`, the HTML of the page at `rawURL`, in the same way
+// as `LintURL` -- for callers (such as `vale crawl`) that fetch pages
+// themselves.
+func (l *Linter) LintPage(rawURL, content, selector string) (*core.File, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, core.NewE100("LintURL", err)
+	}
+
+	if selector != "" {
+		sel, selErr := parseSelector(selector)
+		if selErr != nil {
+			return nil, core.NewE100("--selector", selErr)
+		} else if content, selErr = selectHTML(content, sel); selErr != nil {
+			return nil, core.NewE100("LintURL", fmt.Errorf("%s: %w", rawURL, selErr))
+		}
+	}
+
+	file, err := core.NewFileFromContent(pagePath(u), content, l.Manager.Config)
+	if err != nil {
+		return nil, err
+	}
+	file.Path = rawURL
+
+	linted := l.lintFormat(file)
+	if linted.err == nil && l.OnLinted != nil {
+		l.OnLinted(linted.file)
+	}
+	return linted.file, linted.err
+}
+
+// pagePath returns the path that a page is treated as being stored at when
+// matching it against the config's sections: its host and path, with an
+// `.html` extension -- e.g., `docs.example.com/guide/intro.html`.
+func pagePath(u *url.URL) string {
+	p := strings.TrimSuffix(u.Host+u.Path, "/")
+	if p == u.Host {
+		p += "/index"
+	}
+
+	switch path.Ext(p) {
+	case ".html", ".htm":
+		return p
+	}
+	return p + ".html"
+}
@@ -0,0 +1,107 @@
+package lint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	page := `<html><body>
+<nav class="menu">Nav</nav>
+<main id="content" role="main"><article class="doc body"><p>One</p></article></main>
+<div><article class="doc"><p>Two</p></article></div>
+</body></html>`
+
+	cases := map[string][]string{
+		"main":                 {"One"},
+		"#content":             {"One"},
+		"article.doc":          {"One", "Two"},
+		"article.doc.body":     {"One"},
+		"main > article":       {"One"},
+		"body > article":       {},
+		"[role=main] p, .menu": {"Nav", "One"},
+	}
+
+	for selector, expected := range cases {
+		sel, err := parseSelector(selector)
+		if err != nil {
+			t.Fatalf("%s: %v", selector, err)
+		}
+
+		out, err := selectHTML(page, sel)
+		if len(expected) == 0 {
+			if err == nil {
+				t.Errorf("%s: expected no matches, got %q", selector, out)
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("%s: %v", selector, err)
+		}
+
+		for _, s := range []string{"Nav", "One", "Two"} {
+			want := false
+			for _, e := range expected {
+				want = want || e == s
+			}
+			if strings.Contains(out, s) != want {
+				t.Errorf("%s: unexpected output %q", selector, out)
+			}
+		}
+	}
+
+	for _, bad := range []string{"", "main >", "> main", "main:first-child"} {
+		if _, err := parseSelector(bad); err == nil {
+			t.Errorf("Expected '%s' to be rejected", bad)
+		}
+	}
+}
+
+func TestLintURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guide" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html><body>\n<nav>This is is navigation.</nav>\n<main>\n<p>This is is a test.</p>\n</main>\n</body></html>\n"))
+	}))
+	defer srv.Close()
+
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := linter.LintURL(srv.URL+"/guide", "main")
+	if err != nil {
+		t.Fatal(err)
+	} else if f.Path != srv.URL+"/guide" {
+		t.Errorf("Expected alerts to be reported against the URL, got '%s'", f.Path)
+	}
+
+	if len(f.Alerts) != 1 || f.Alerts[0].Check != "Vale.Repetition" || f.Alerts[0].Line != 2 {
+		t.Errorf("Expected one alert in the content area, got %+v", f.Alerts)
+	}
+
+	if _, err = linter.LintURL(srv.URL+"/missing", ""); err == nil {
+		t.Error("Expected an error for a missing page")
+	}
+}
+
+func TestPagePath(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"https://docs.example.com":            "docs.example.com/index.html",
+		"https://docs.example.com/guide/":     "docs.example.com/guide.html",
+		"https://docs.example.com/guide.html": "docs.example.com/guide.html",
+	} {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		} else if p := pagePath(u); p != expected {
+			t.Errorf("%s: expected '%s', got '%s'", raw, expected, p)
+		}
+	}
+}
@@ -129,6 +129,11 @@ func (w *walker) block(text, scope string) nlp.Block {
 	return nlp.NewLinedBlock(w.getCtx(), text, scope, line, nil)
 }
 
+// text returns the unmodified text of the current block.
+func (w *walker) text() string {
+	return strings.Join(strings.Fields(strings.Join(w.queue, " ")), " ")
+}
+
 func (w *walker) walk() (html.TokenType, html.Token, string) {
 	tokt := w.z.Next()
 	tok := w.z.Token()
@@ -34,7 +34,7 @@ func (l Linter) lintXML(file *core.File) error {
 
 	xsltArgs = append(xsltArgs, []string{file.Transform, "-"}...)
 
-	cmd := exec.Command(xsltproc, xsltArgs...)
+	cmd := exec.CommandContext(l.context(), xsltproc, xsltArgs...)
 	cmd.Stdin = strings.NewReader(file.Content)
 	cmd.Stdout = &out
 	cmd.Stderr = &eut
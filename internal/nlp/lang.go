@@ -0,0 +1,157 @@
+package nlp
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minLangHits is the number of function words that we need to see before we
+// guess the language of text written in the Latin script.
+const minLangHits = 3
+
+// langWords lists common function words for each of the Latin-script
+// languages that we can detect. The lists overlap (e.g., "de" or "la"), but
+// each one has enough distinctive entries to tell them apart in practice.
+var langWords = map[string][]string{
+	"en": {
+		"the", "and", "of", "to", "is", "that", "it", "with", "for", "this",
+		"are", "you", "be", "on", "not", "or", "have", "was", "by", "from",
+		"which", "can", "an", "as", "your", "will", "if", "when",
+	},
+	"de": {
+		"der", "die", "das", "und", "ist", "nicht", "mit", "ein", "eine", "zu",
+		"den", "von", "sie", "auf", "für", "sich", "dem", "des", "auch", "es",
+		"wird", "werden", "oder", "kann", "wenn", "wir", "ich", "sind",
+	},
+	"fr": {
+		"le", "la", "les", "et", "est", "des", "une", "un", "du", "dans",
+		"pour", "pas", "que", "qui", "sur", "avec", "ce", "il", "sont", "au",
+		"vous", "nous", "par", "plus", "cette", "ou", "être", "peut",
+	},
+	"es": {
+		"el", "los", "las", "y", "es", "en", "una", "un", "del", "que",
+		"por", "con", "para", "se", "no", "su", "al", "lo", "como", "más",
+		"pero", "está", "son", "este", "esta", "puede", "sus", "cuando",
+	},
+	"it": {
+		"il", "di", "che", "è", "e", "la", "gli", "per", "non", "una",
+		"un", "del", "della", "sono", "con", "si", "da", "nel", "anche",
+		"questo", "come", "più", "ma", "essere", "può", "delle", "alla",
+	},
+	"pt": {
+		"o", "os", "as", "e", "é", "de", "do", "da", "em", "um", "uma",
+		"não", "que", "para", "com", "se", "por", "mais", "no", "na", "são",
+		"dos", "das", "está", "você", "pode", "seu", "sua",
+	},
+	"nl": {
+		"de", "het", "een", "en", "van", "is", "dat", "niet", "op", "te",
+		"zijn", "voor", "met", "die", "ook", "er", "maar", "aan", "worden",
+		"wordt", "kan", "bij", "je", "naar", "dit", "u", "deze", "wat",
+	},
+}
+
+// wordToLangs is the inverse of `langWords`.
+var wordToLangs = func() map[string][]string {
+	index := map[string][]string{}
+	for lang, words := range langWords {
+		for _, w := range words {
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage guesses the language of `text`, returning its ISO 639-1 code
+// (e.g., "de") -- or an empty string if there isn't enough text to tell.
+//
+// Text in a script other than Latin is identified by its script; otherwise,
+// we count the function words of each language that we know.
+func DetectLanguage(text string) string {
+	if lang := detectScript(text); lang != "" {
+		return lang
+	}
+
+	hits := map[string]int{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), notWordRune) {
+		for _, lang := range wordToLangs[word] {
+			hits[lang]++
+		}
+	}
+
+	best, bestN, second := "", 0, 0
+	for lang, n := range hits {
+		if n > bestN || (n == bestN && lang < best) {
+			best, bestN, second = lang, n, bestN
+		} else if n > second {
+			second = n
+		}
+	}
+
+	// NOTE: We require a clear winner: closely related languages (e.g.,
+	// Spanish and Portuguese) share many function words, so a short or
+	// mixed passage could easily go either way.
+	if bestN < minLangHits || 2*bestN < 3*second {
+		return ""
+	}
+	return best
+}
+
+// detectScript identifies the language of text that's mostly written in a
+// non-Latin script, returning an empty string otherwise.
+func detectScript(text string) string {
+	counts := map[string]int{}
+
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["kana"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["cyrillic"]++
+			if strings.ContainsRune("іїєґІЇЄҐ", r) {
+				counts["uk"]++
+			}
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		}
+	}
+
+	mostly := func(n int) bool { return letters > 0 && 2*n > letters }
+	switch {
+	case mostly(counts["kana"] + counts["han"]):
+		if counts["kana"] > 0 {
+			return "ja"
+		}
+		return "zh"
+	case mostly(counts["cyrillic"]):
+		if counts["uk"] > 0 {
+			return "uk"
+		}
+		return "ru"
+	}
+
+	for _, lang := range []string{"ko", "el", "ar", "he"} {
+		if mostly(counts[lang]) {
+			return lang
+		}
+	}
+
+	return ""
+}
+
+func notWordRune(r rune) bool {
+	return !unicode.IsLetter(r)
+}
@@ -0,0 +1,26 @@
+package nlp
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]string{
+		"The configuration file is read from the root of your project, and it can be overridden.":                    "en",
+		"Die Konfigurationsdatei wird aus dem Stammverzeichnis des Projekts gelesen und kann nicht geändert werden.": "de",
+		"Le fichier de configuration est lu à la racine du projet et il peut être remplacé par vous.":                "fr",
+		"El archivo de configuración se lee desde la raíz del proyecto y no se puede cambiar con facilidad.":         "es",
+		"Il file di configurazione viene letto dalla radice del progetto e non può essere modificato.":               "it",
+		"O arquivo de configuração é lido da raiz do projeto e não pode ser alterado por você.":                      "pt",
+		"Het configuratiebestand wordt gelezen vanuit de hoofdmap van het project en kan niet worden gewijzigd.":     "nl",
+		"設定ファイルはプロジェクトのルートから読み込まれます。":                                                                                "ja",
+		"配置文件从项目的根目录读取。":                                                                                             "zh",
+		"Файл конфигурации читается из корня проекта.":                                                               "ru",
+		"Install Vale.": "",
+		"":              "",
+	}
+
+	for text, expected := range cases {
+		if lang := DetectLanguage(text); lang != expected {
+			t.Errorf("%q: expected '%s', got '%s'", text, expected, lang)
+		}
+	}
+}
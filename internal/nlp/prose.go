@@ -2,6 +2,7 @@ package nlp
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/jdkato/twine/nlp/segment"
 	"github.com/jdkato/twine/nlp/tag"
@@ -26,15 +27,50 @@ var SentenceTokenizer = segment.NewPunktSentenceTokenizer()
 // We wait to initialize it until we need it since it's slow (~1s) and we may
 // not need it.
 var tagger *tag.PerceptronTagger
+var taggerOnce sync.Once
 
 // doTag assigns part-of-speech tags to `words`.
 func doTag(words []string) []tag.Token {
-	if tagger == nil {
+	taggerOnce.Do(func() {
 		tagger = tag.NewPerceptronTagger()
-	}
+	})
 	return tagger.Tag(words)
 }
 
+// taggedCacheSize is the number of tagged sentences that we keep.
+const taggedCacheSize = 256
+
+// taggedCache holds recently-tagged text (by model and text), since every
+// `sequence` and `grammar` rule tags the same sentences.
+var taggedCache = struct {
+	sync.Mutex
+	tokens map[string][]tag.Token
+}{tokens: make(map[string][]tag.Token)}
+
+// tagText tags `text` using the given model (see `taggerFor`), reusing a
+// previous result if there is one.
+func tagText(text, model string, tagger Tagger) []tag.Token {
+	key := model + "\x00" + text
+
+	taggedCache.Lock()
+	tokens, ok := taggedCache.tokens[key]
+	taggedCache.Unlock()
+
+	if ok {
+		return tokens
+	}
+	tokens = tagger.Tag(textToWords(text, true))
+
+	taggedCache.Lock()
+	if len(taggedCache.tokens) >= taggedCacheSize {
+		clear(taggedCache.tokens)
+	}
+	taggedCache.tokens[key] = tokens
+	taggedCache.Unlock()
+
+	return tokens
+}
+
 // textToWords convert raw text into a slice of words.
 func textToWords(text string, nlp bool) []string {
 	// TODO: Replace with iterTokenizer?
@@ -56,8 +92,10 @@ func textToWords(text string, nlp bool) []string {
 func TextToTokens(text string, nlp *Info) []tag.Token {
 	// Determine if (and how) we need to do POS tagging.
 	if nlp == nil || nlp.Endpoint == "" {
-		// Fall back to our internal library (English-only).
-		return doTag(textToWords(text, true))
+		// Fall back to our internal library: a model for the text's language
+		// (see `LoadTaggers`), if we have one, or our English model.
+		model, tagger := taggerFor(nlp)
+		return tagText(text, model, tagger)
 	}
 	result, err := pos(text, nlp.Lang, nlp.Endpoint)
 	if err != nil {
@@ -53,12 +53,13 @@ func NewLinedBlock(ctx, txt, sel string, line int, _ *Info) Block {
 // Assigning this on a per-file basis allows us to handle multi-language
 // projects -- one file might be `en` while another is `ja`, for example.
 type Info struct {
-	Lang         string // Language of the file.
-	Endpoint     string // API endpoint (optional); TODO: should this be per-file?
-	Scope        string // The file's ext scope.
-	Tagging      bool   // Does the file need POS tagging?
-	Segmentation bool   // Does the file need sentence segmentation?
-	Splitting    bool   // Does the file need paragraph splitting?
+	Lang         string   // Language of the file.
+	Endpoint     string   // API endpoint (optional); TODO: should this be per-file?
+	Taggers      []string // Directories of tagging models (see `LoadTaggers`).
+	Scope        string   // The file's ext scope.
+	Tagging      bool     // Does the file need POS tagging?
+	Segmentation bool     // Does the file need sentence segmentation?
+	Splitting    bool     // Does the file need paragraph splitting?
 }
 
 // An NLP provider is a library to implements part-of-speech tagging, sentence
@@ -91,7 +92,7 @@ func (n *Info) doNLP(blk *Block, seg segmenter) ([]Block, error) {
 	ext := n.Scope
 
 	if n.Splitting {
-		for _, p := range strings.SplitAfter(blk.Text, "\n\n") {
+		for _, p := range splitParagraphs(blk.Text) {
 			blks = append(
 				blks, NewLinedBlock(ctx, p, "paragraph"+ext, idx, nil))
 		}
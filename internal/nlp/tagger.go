@@ -0,0 +1,235 @@
+package nlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/jdkato/twine/nlp/tag"
+)
+
+// A Tagger assigns a part-of-speech tag to each word of a sentence.
+type Tagger interface {
+	Tag(words []string) []tag.Token
+}
+
+// perceptron is an averaged-perceptron tagging model, as trained by NLTK's
+// `PerceptronTagger` (or TextBlob's `textblob-aptagger`), stored as JSON:
+//
+//	{
+//	  "weights": {"i suffix ung": {"NN": 2.25, ...}, ...},
+//	  "tagdict": {"und": "KON", ...},
+//	  "classes": ["ADJA", "KON", "NN", ...]
+//	}
+//
+// NLTK saves each of these keys to its own file (`<name>.weights.json`, and
+// so on), so a model is their combination.
+//
+// Its tags are those of the corpus it was trained on -- e.g., STTS for
+// German -- which is what rules written for its language should use.
+type perceptron struct {
+	Weights map[string]map[string]float64 `json:"weights"`
+	TagDict map[string]string             `json:"tagdict"`
+	Classes []string                      `json:"classes"`
+}
+
+// loadedTaggers holds the models read by `LoadTaggers`, by path.
+var loadedTaggers = struct {
+	sync.Mutex
+	models map[string]Tagger
+}{models: make(map[string]Tagger)}
+
+// englishTagger is our built-in (English) model.
+type englishTagger struct{}
+
+func (englishTagger) Tag(words []string) []tag.Token {
+	return doTag(words)
+}
+
+// LoadTaggers reads every tagging model (`<lang>.json`; see `perceptron`) in
+// the given directories, which are then used to tag text in their language
+// -- e.g., `de.json` for `de` or `de-AT`.
+//
+// Models are only read once per process, no matter how many times they're
+// loaded.
+func LoadTaggers(dirs []string) error {
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return err
+		}
+
+		for _, path := range files {
+			loadedTaggers.Lock()
+			_, found := loadedTaggers.models[path]
+			loadedTaggers.Unlock()
+
+			if found {
+				continue
+			}
+
+			model, err := loadPerceptron(path)
+			if err != nil {
+				return err
+			}
+
+			loadedTaggers.Lock()
+			loadedTaggers.models[path] = model
+			loadedTaggers.Unlock()
+		}
+	}
+	return nil
+}
+
+func loadPerceptron(path string) (*perceptron, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var model perceptron
+	if err = json.Unmarshal(b, &model); err != nil {
+		return nil, fmt.Errorf("tagger '%s' isn't a valid model: %w", path, err)
+	} else if len(model.Classes) == 0 || len(model.Weights) == 0 {
+		return nil, fmt.Errorf("tagger '%s' must have 'weights' and 'classes'", path)
+	}
+
+	return &model, nil
+}
+
+// taggerFor returns the tagger for the language of `info` along with a key
+// that identifies it, falling back to our built-in (English) model.
+func taggerFor(info *Info) (string, Tagger) {
+	if info == nil || info.Lang == "" {
+		return "", englishTagger{}
+	}
+
+	names := []string{info.Lang}
+	if base, _, found := strings.Cut(strings.ReplaceAll(info.Lang, "_", "-"), "-"); found {
+		names = append(names, base)
+	}
+
+	loadedTaggers.Lock()
+	defer loadedTaggers.Unlock()
+
+	for _, dir := range info.Taggers {
+		for _, name := range names {
+			path := filepath.Join(dir, name+".json")
+			if model, ok := loadedTaggers.models[path]; ok {
+				return path, model
+			}
+		}
+	}
+
+	return "", englishTagger{}
+}
+
+// Tag assigns a tag to each word.
+//
+// This is a port of NLTK's `PerceptronTagger.tag`.
+func (p *perceptron) Tag(words []string) []tag.Token {
+	tokens := make([]tag.Token, 0, len(words))
+
+	context := []string{"-START-", "-START2-"}
+	clean := make([]string, 0, len(words))
+	for _, w := range words {
+		if w != "" {
+			context = append(context, normalizeWord(w))
+			clean = append(clean, w)
+		}
+	}
+	context = append(context, "-END-", "-END2-")
+
+	prev, prev2 := "-START-", "-START2-"
+	for i, word := range clean {
+		t, found := p.TagDict[word]
+		if !found {
+			t = p.predict(features(i, word, context, prev, prev2))
+		}
+		tokens = append(tokens, tag.Token{Text: word, Tag: t})
+		prev2, prev = prev, t
+	}
+
+	return tokens
+}
+
+// predict returns the highest-scoring class for the given features; ties
+// go to the greater class name, as in NLTK.
+func (p *perceptron) predict(feats map[string]float64) string {
+	scores := map[string]float64{}
+	for feat, value := range feats {
+		weights, found := p.Weights[feat]
+		if !found || value == 0 {
+			continue
+		}
+		for label, weight := range weights {
+			scores[label] += value * weight
+		}
+	}
+
+	best := p.Classes[0]
+	for _, label := range p.Classes[1:] {
+		if scores[label] > scores[best] || (scores[label] == scores[best] && label > best) {
+			best = label
+		}
+	}
+	return best
+}
+
+// features returns the features of the i-th word, which must match those
+// that the model was trained with.
+func features(i int, word string, context []string, prev, prev2 string) map[string]float64 {
+	feats := map[string]float64{}
+	add := func(parts ...string) {
+		feats[strings.Join(parts, " ")]++
+	}
+
+	first := ""
+	if word != "" {
+		first = string([]rune(word)[0])
+	}
+
+	i += 2
+	add("bias")
+	add("i suffix", lastRunes(word, 3))
+	add("i pref1", first)
+	add("i-1 tag", prev)
+	add("i-2 tag", prev2)
+	add("i tag+i-2 tag", prev, prev2)
+	add("i word", context[i])
+	add("i-1 tag+i word", prev, context[i])
+	add("i-1 word", context[i-1])
+	add("i-1 suffix", lastRunes(context[i-1], 3))
+	add("i-2 word", context[i-2])
+	add("i+1 word", context[i+1])
+	add("i+1 suffix", lastRunes(context[i+1], 3))
+	add("i+2 word", context[i+2])
+
+	return feats
+}
+
+// normalizeWord is NLTK's `PerceptronTagger.normalize`.
+func normalizeWord(word string) string {
+	runes := []rune(word)
+	switch {
+	case strings.Contains(word, "-") && runes[0] != '-':
+		return "!HYPHEN"
+	case len(runes) == 4 && strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) < 0:
+		return "!YEAR"
+	case unicode.IsDigit(runes[0]):
+		return "!DIGITS"
+	}
+	return strings.ToLower(word)
+}
+
+func lastRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[len(runes)-n:])
+}
@@ -0,0 +1,46 @@
+package nlp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTaggers(t *testing.T) {
+	model := `{
+  "classes": ["ART", "NN", "VVFIN", "$."],
+  "tagdict": {"Die": "ART", ".": "$."},
+  "weights": {
+    "bias": {"VVFIN": 0.5},
+    "i-1 tag ART": {"NN": 2},
+    "i suffix ert": {"VVFIN": 1}
+  }
+}`
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "de.json"), []byte(model), 0o600); err != nil {
+		t.Fatal(err)
+	} else if err = LoadTaggers([]string{dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	tagged := ""
+	for _, tok := range TextToTokens("Die Prüfung dauert.", &Info{Lang: "de-AT", Taggers: []string{dir}}) {
+		tagged += tok.Text + "/" + tok.Tag + " "
+	}
+	if tagged != "Die/ART Prüfung/NN dauert/VVFIN ./$. " {
+		t.Errorf("Unexpected tags: %s", tagged)
+	}
+
+	tokens := TextToTokens("Die Prüfung dauert.", &Info{Lang: "en", Taggers: []string{dir}})
+	if tokens[0].Tag == "ART" {
+		t.Error("Expected English text to use the built-in model")
+	}
+
+	bad := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bad, "fr.json"), []byte(`{"classes": []}`), 0o600); err != nil {
+		t.Fatal(err)
+	} else if err = LoadTaggers([]string{bad}); err == nil {
+		t.Error("Expected an error for an empty model")
+	}
+}
@@ -1,11 +1,31 @@
 package nlp
 
 import (
+	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 )
 
+// reParagraph matches a blank line (possibly containing whitespace).
+var reParagraph = regexp.MustCompile(`\n[ \t]*\n`)
+
+// splitParagraphs splits `s` at each blank line, keeping the delimiters.
+//
+// Hard-wrapped lines (i.e., those separated by a single newline) stay in the
+// same paragraph.
+func splitParagraphs(s string) []string {
+	var parts []string
+
+	last := 0
+	for _, loc := range reParagraph.FindAllStringIndex(s, -1) {
+		parts = append(parts, s[last:loc[1]])
+		last = loc[1]
+	}
+
+	return append(parts, s[last:])
+}
+
 // StrLen returns the number of runes in a string.
 func StrLen(s string) int {
 	return utf8.RuneCountInString(s)
@@ -0,0 +1,199 @@
+package spell
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// compiledMagic identifies a compiled dictionary. Its last byte is the
+// format's version, which must change whenever the format -- or the way that
+// we expand dictionaries -- does.
+var compiledMagic = []byte("VALEDIC\x01")
+
+// loadGoSpell creates a speller from the contents of a Hunspell AFF and DIC
+// file pair.
+//
+// Expanding a large dictionary's affixes can take seconds, so the result is
+// cached (in a compact binary format) in Vale's cache directory, keyed by a
+// hash of both files. Caching is best effort: a missing, stale, or corrupt
+// entry is simply rebuilt, and nothing is written when writes are disabled
+// (see `core.ReadOnly`).
+func loadGoSpell(aff, dic []byte) (*goSpell, error) {
+	path, err := compiledPath(aff, dic)
+	if err == nil {
+		if b, readErr := os.ReadFile(path); readErr == nil {
+			if gs, decodeErr := decodeGoSpell(b); decodeErr == nil {
+				return gs, nil
+			}
+		}
+	}
+
+	gs, err := newGoSpellReader(bytes.NewReader(aff), bytes.NewReader(dic))
+	if err != nil {
+		return nil, err
+	}
+
+	if path != "" && !core.ReadOnly {
+		_ = writeCompiled(path, gs)
+	}
+	return gs, nil
+}
+
+// compiledPath returns the cache path of the given dictionary.
+func compiledPath(aff, dic []byte) (string, error) {
+	h := sha256.New()
+	h.Write(compiledMagic)
+	h.Write(aff)
+	h.Write([]byte{0})
+	h.Write(dic)
+	return core.CachePath(filepath.Join("dictionaries", hex.EncodeToString(h.Sum(nil)[:16])+".bin"))
+}
+
+func writeCompiled(path string, gs *goSpell) error {
+	// NOTE: We write to a temporary file first so that concurrent runs never
+	// read a partial entry.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "dic-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	if err = encodeGoSpell(w, gs); err == nil {
+		err = w.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// encodeGoSpell writes a speller's words, compound rules, ICONV
+// replacements, and word characters as length-prefixed strings.
+func encodeGoSpell(w io.Writer, gs *goSpell) error {
+	compounds := make([]string, len(gs.compounds))
+	for i, pat := range gs.compounds {
+		compounds[i] = pat.String()
+	}
+
+	words := make([]string, 0, len(gs.dict))
+	for word := range gs.dict {
+		words = append(words, word)
+	}
+
+	buf := append([]byte{}, compiledMagic...)
+	for _, list := range [][]string{words, compounds, gs.iconv, {gs.wordChars}} {
+		buf = binary.AppendUvarint(buf, uint64(len(list)))
+		for _, s := range list {
+			buf = binary.AppendUvarint(buf, uint64(len(s)))
+			buf = append(buf, s...)
+		}
+	}
+
+	_, err := w.Write(buf)
+	return err
+}
+
+func decodeGoSpell(b []byte) (*goSpell, error) {
+	if !bytes.HasPrefix(b, compiledMagic) {
+		return nil, errors.New("not a compiled dictionary")
+	}
+	d := newDecoder(b[len(compiledMagic):])
+
+	words := d.strings()
+	compounds := d.strings()
+	iconv := d.strings()
+	wordChars := d.strings()
+	if d.err != nil || len(wordChars) != 1 || !d.done() {
+		return nil, errors.New("corrupt compiled dictionary")
+	}
+
+	gs := goSpell{
+		dict:      make(map[string]struct{}, len(words)),
+		compounds: make([]*regexp.Regexp, 0, len(compounds)),
+		splitter:  newSplitter(wordChars[0]),
+		iconv:     iconv,
+		wordChars: wordChars[0],
+	}
+
+	for _, word := range words {
+		gs.dict[word] = struct{}{}
+	}
+
+	for _, pattern := range compounds {
+		pat, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		gs.compounds = append(gs.compounds, pat)
+	}
+
+	if len(iconv) > 0 {
+		gs.ireplacer = strings.NewReplacer(iconv...)
+	}
+	return &gs, nil
+}
+
+// decoder reads length-prefixed strings, recording the first error.
+//
+// The strings are slices of a single copy of the input, which avoids an
+// allocation per word.
+type decoder struct {
+	b   []byte
+	s   string
+	pos int
+	err error
+}
+
+func newDecoder(b []byte) *decoder {
+	return &decoder{b: b, s: string(b)}
+}
+
+func (d *decoder) uvarint() int {
+	if d.err != nil {
+		return 0
+	}
+	n, size := binary.Uvarint(d.b[d.pos:])
+	if size <= 0 || n > uint64(len(d.b)-d.pos) {
+		d.err = errors.New("invalid length")
+		return 0
+	}
+	d.pos += size
+	return int(n)
+}
+
+func (d *decoder) strings() []string {
+	n := d.uvarint()
+	list := make([]string, 0, n)
+	for i := 0; i < n && d.err == nil; i++ {
+		size := d.uvarint()
+		if d.err == nil && size > len(d.b)-d.pos {
+			d.err = errors.New("invalid string")
+		}
+		if d.err != nil {
+			return nil
+		}
+		list = append(list, d.s[d.pos:d.pos+size])
+		d.pos += size
+	}
+	return list
+}
+
+func (d *decoder) done() bool {
+	return d.pos == len(d.b)
+}
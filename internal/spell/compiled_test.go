@@ -0,0 +1,74 @@
+package spell
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestCompiledRoundTrip(t *testing.T) {
+	gs, err := newGoSpellReader(bytes.NewReader(defaultAff), bytes.NewReader(defaultDic))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = encodeGoSpell(&buf, gs); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := decodeGoSpell(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	} else if len(decoded.dict) != len(gs.dict) || len(decoded.compounds) != len(gs.compounds) {
+		t.Fatalf("Expected %d words and %d compounds, got %d and %d",
+			len(gs.dict), len(gs.compounds), len(decoded.dict), len(decoded.compounds))
+	}
+
+	for _, word := range []string{"documentation", "Documentation", "100GB", "0x1F", "documentatoin"} {
+		if decoded.spell(word) != gs.spell(word) {
+			t.Errorf("'%s': expected %v", word, gs.spell(word))
+		}
+	}
+
+	corrupt := buf.Bytes()[:buf.Len()/2]
+	if _, err = decodeGoSpell(corrupt); err == nil {
+		t.Error("Expected a truncated dictionary to be rejected")
+	}
+}
+
+func TestCompiledCache(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		dir := t.TempDir()
+		t.Setenv("VALE_CACHE_PATH", dir)
+		core.ReadOnly = readOnly
+
+		gs, err := loadGoSpell(defaultAff, defaultDic)
+		core.ReadOnly = false
+		if err != nil {
+			t.Fatal(err)
+		} else if !gs.spell("documentation") {
+			t.Fatal("Expected a working speller")
+		}
+
+		entries, _ := filepath.Glob(filepath.Join(dir, "dictionaries", "*.bin"))
+		if readOnly && len(entries) != 0 {
+			t.Errorf("Expected nothing to be cached with writes disabled, got %v", entries)
+		} else if !readOnly && len(entries) != 1 {
+			t.Fatalf("Expected one cached dictionary, got %v", entries)
+		}
+
+		if !readOnly {
+			// A corrupt entry is rebuilt rather than trusted.
+			if err = os.WriteFile(entries[0], []byte("VALEDIC\x01junk"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if gs, err = loadGoSpell(defaultAff, defaultDic); err != nil || !gs.spell("documentation") {
+				t.Errorf("Expected a corrupt entry to be rebuilt, got %v", err)
+			}
+		}
+	}
+}
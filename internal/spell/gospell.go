@@ -4,13 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"regexp"
 	"sort"
 	"strings"
-
-	"github.com/adrg/strutil"
-	"github.com/adrg/strutil/metrics"
+	"unicode/utf8"
 )
 
 type wordMatch struct {
@@ -24,6 +23,11 @@ type goSpell struct {
 	ireplacer *strings.Replacer
 	compounds []*regexp.Regexp
 	splitter  *splitter
+
+	// The source of `ireplacer` and `splitter`, which we need in order to
+	// cache the speller (see compiled.go).
+	iconv     []string
+	wordChars string
 }
 
 type dictionary struct {
@@ -102,20 +106,54 @@ func (s *goSpell) keys() []string {
 	return keys
 }
 
-func (s *goSpell) suggest(word string) []wordMatch {
-	metric := metrics.NewLevenshtein()
+// suggest returns up to `n` of the dictionary's words that are closest to
+// the given word, taking keyboard layout and word frequency into account.
+func (s *goSpell) suggest(word string, freq frequencies, n int) []wordMatch {
+	size := utf8.RuneCountInString(word)
+
+	// best holds the top `n` scores found so far, from highest to lowest: an
+	// option that can't reach the last of them isn't worth scoring fully.
+	best := make([]float64, 0, n)
 
 	matches := []wordMatch{}
 	for _, option := range s.keys() {
-		sim := strutil.Similarity(option, word, metric)
-		matches = append(matches, wordMatch{option, sim})
+		// Words that differ this much in length are never good suggestions,
+		// so we skip the (relatively) expensive scoring.
+		if diff := utf8.RuneCountInString(option) - size; diff > 2 || diff < -2 {
+			continue
+		}
+
+		floor := math.Inf(-1)
+		if n > 0 && len(best) == n {
+			floor = best[n-1]
+		}
+
+		sc, ok := scoreAbove(word, option, freq, floor)
+		if !ok {
+			continue
+		}
+		matches = append(matches, wordMatch{option, sc})
+
+		if n > 0 {
+			i := sort.Search(len(best), func(i int) bool { return best[i] < sc })
+			if len(best) < n {
+				best = append(best, 0)
+			}
+			if i < len(best) {
+				copy(best[i+1:], best[i:])
+				best[i] = sc
+			}
+		}
 	}
 
 	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score == matches[j].score {
+			return matches[i].word < matches[j].word
+		}
 		return matches[i].score > matches[j].score
 	})
 
-	hits := matches[:5]
+	hits := matches[:min(n, len(matches))]
 	if word == strings.Title(word) { //nolint:staticcheck
 		// Capitalized word, so capitalize the suggestions
 		for i := range hits {
@@ -241,21 +279,21 @@ func newGoSpellReader(aff, dic io.Reader) (*goSpell, error) {
 	if len(affix.IconvReplacements) > 0 {
 		gs.ireplacer = strings.NewReplacer(affix.IconvReplacements...)
 	}
+	gs.iconv = affix.IconvReplacements
+	gs.wordChars = affix.WordChars
+
 	return &gs, nil
 }
 
 // newGoSpell from AFF and DIC Hunspell filenames
 func newGoSpell(affFile, dicFile string) (*goSpell, error) {
-	aff, err := os.Open(affFile)
+	aff, err := os.ReadFile(affFile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to open aff: %s", err.Error())
 	}
-	defer aff.Close()
-	dic, err := os.Open(dicFile)
+	dic, err := os.ReadFile(dicFile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to open dic: %s", err.Error())
 	}
-	defer dic.Close()
-	h, err := newGoSpellReader(aff, dic)
-	return h, err
+	return loadGoSpell(aff, dic)
 }
@@ -17,9 +17,13 @@ var defaultAff []byte
 //go:embed data/en_US-web.dic
 var defaultDic []byte
 
+//go:embed data/en_US-web.freq
+var defaultFreq []byte
+
 var defaultOpts = Options{
 	path: os.Getenv("DICPATH"),
 	load: false,
+	max:  5,
 
 	system: os.Getenv("DICPATH"),
 }
@@ -32,6 +36,8 @@ type Options struct {
 	names       []string
 	dics        []dictionary
 	load        bool
+	freq        string
+	max         int
 }
 
 // A CheckerOption is a setting that changes the checker-creation process.
@@ -74,10 +80,26 @@ func UsingDictionaryByPath(dic, aff string) CheckerOption {
 	}
 }
 
+// WithFrequencies ranks suggestions using the given word frequency list
+// (one word per line, from most to least common) instead of the default one.
+func WithFrequencies(path string) CheckerOption {
+	return func(opts *Options) {
+		opts.freq = path
+	}
+}
+
+// WithMaxSuggestions limits the number of suggestions returned by `Suggest`.
+func WithMaxSuggestions(n int) CheckerOption {
+	return func(opts *Options) {
+		opts.max = n
+	}
+}
+
 // Checker is a spell-checker based on multiple dictionaries.
 type Checker struct {
 	options  Options
 	checkers []*goSpell
+	freq     frequencies
 }
 
 // NewChecker creates a spell checker from multiple
@@ -89,10 +111,12 @@ func NewChecker(options ...CheckerOption) (*Checker, error) {
 	}
 
 	checker := Checker{options: base}
-	for _, name := range base.names {
-		if err := checker.loadDic(name); err != nil {
-			return &checker, err
-		}
+	if err := checker.loadFrequencies(); err != nil {
+		return &checker, err
+	}
+
+	if err := checker.loadDics(base.names); err != nil {
+		return &checker, err
 	}
 
 	for _, entry := range base.dics {
@@ -105,10 +129,7 @@ func NewChecker(options ...CheckerOption) (*Checker, error) {
 
 	if len(checker.checkers) == 0 || base.load {
 		// use default dictionary ...
-		aff := bytes.NewReader(defaultAff)
-		dic := bytes.NewReader(defaultDic)
-
-		c, err := newGoSpellReader(aff, dic)
+		c, err := loadGoSpell(defaultAff, defaultDic)
 		if err != nil {
 			return &checker, err
 		}
@@ -123,12 +144,14 @@ func NewChecker(options ...CheckerOption) (*Checker, error) {
 			return &checker, err
 		}
 
+		names := []string{}
 		for _, f := range files {
 			name := filepath.Base(f)
-			name = name[:len(name)-4]
-			if loadErr := checker.loadDic(name); loadErr != nil {
-				return &checker, loadErr
-			}
+			names = append(names, name[:len(name)-4])
+		}
+
+		if loadErr := checker.loadDics(names); loadErr != nil {
+			return &checker, loadErr
 		}
 	}
 
@@ -145,23 +168,27 @@ func (m *Checker) Spell(word string) bool {
 	return false
 }
 
-// Suggest returns a list of suggestions for a given word.
+// Suggest returns a list of suggestions for a given word, from most to least
+// likely.
 func (m *Checker) Suggest(word string) []string {
 	ranks := []wordMatch{}
 	for _, checker := range m.checkers {
-		ranks = append(ranks, checker.suggest(word)...)
+		ranks = append(ranks, checker.suggest(word, m.freq, m.options.max)...)
 	}
 
-	sort.Slice(ranks, func(i, j int) bool {
+	sort.SliceStable(ranks, func(i, j int) bool {
 		return ranks[i].score > ranks[j].score
 	})
 
+	seen := map[string]bool{}
 	suggestions := []string{}
-	for i, r := range ranks {
-		if i > 5 {
+	for _, r := range ranks {
+		if len(suggestions) >= m.options.max {
 			break
+		} else if !seen[r.word] {
+			seen[r.word] = true
+			suggestions = append(suggestions, r.word)
 		}
-		suggestions = append(suggestions, r.word)
 	}
 
 	return suggestions
@@ -219,32 +246,95 @@ func (m *Checker) readAsset(name string) (string, error) {
 	return "", fmt.Errorf("'%s' not found in %v", name, roots)
 }
 
-func (m *Checker) loadDic(name string) error {
-	dicPath, err := m.readAsset(name + ".dic")
-	if err != nil {
+func (m *Checker) loadFrequencies() error {
+	if m.options.freq == "" {
+		freq, err := readFrequencies(bytes.NewReader(defaultFreq))
+		m.freq = freq
 		return err
 	}
 
-	dic, err := os.Open(dicPath)
+	f, err := os.Open(m.options.freq)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
-	affPath, err := m.readAsset(name + ".aff")
-	if err != nil {
-		return err
+	m.freq, err = readFrequencies(f)
+	return err
+}
+
+// loadDics loads the given dictionaries.
+//
+// A dictionary without its own `.aff` file is merged into the one before it,
+// sharing its affix rules -- e.g., `[en_US, medical, products]`, where the
+// last two only list additional words. This saves users from having to
+// concatenate `.dic` files by hand.
+func (m *Checker) loadDics(names []string) error {
+	var aff, dic []byte
+
+	flush := func() error {
+		if aff == nil {
+			return nil
+		}
+		s, err := loadGoSpell(aff, dic)
+		if err != nil {
+			return err
+		}
+		m.checkers = append(m.checkers, s)
+		return nil
 	}
 
-	aff, err := os.Open(affPath)
-	if err != nil {
-		return err
+	for _, name := range names {
+		dicPath, err := m.readAsset(name + ".dic")
+		if err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(dicPath)
+		if err != nil {
+			return fmt.Errorf("unable to open dic: %s", err.Error())
+		}
+
+		affPath, err := m.readAsset(name + ".aff")
+		if err != nil {
+			if aff == nil {
+				return fmt.Errorf("'%s.aff' not found and there's no previous dictionary to share one with", name)
+			}
+			dic = mergeDic(dic, content)
+			continue
+		}
+
+		if err = flush(); err != nil {
+			return err
+		}
+
+		aff, err = os.ReadFile(affPath)
+		if err != nil {
+			return fmt.Errorf("unable to open aff: %s", err.Error())
+		}
+		dic = content
 	}
 
-	s, err := newGoSpellReader(aff, dic)
-	if err != nil {
-		return err
+	return flush()
+}
+
+// mergeDic appends the entries of the `.dic` file `extra` to `dic`.
+//
+// NOTE: The count on the first line of `dic` is ignored when we read it, so
+// we don't bother updating it.
+func mergeDic(dic, extra []byte) []byte {
+	first, rest, found := bytes.Cut(extra, []byte("\n"))
+	if isNumber(string(bytes.TrimSpace(first))) {
+		extra = rest
+		if !found {
+			extra = nil
+		}
 	}
-	m.checkers = append(m.checkers, s)
 
-	return nil
+	merged := make([]byte, 0, len(dic)+len(extra)+1)
+	merged = append(merged, dic...)
+	if len(merged) > 0 && merged[len(merged)-1] != '\n' {
+		merged = append(merged, '\n')
+	}
+	return append(merged, extra...)
 }
@@ -0,0 +1,46 @@
+package spell
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergedDictionaries(t *testing.T) {
+	t.Setenv("VALE_CACHE_PATH", t.TempDir())
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"base.aff":     "SET UTF-8\nSFX S Y 1\nSFX S 0 s .\n",
+		"base.dic":     "2\nfile/S\nwork",
+		"medical.dic":  "1\nstent/S\n",
+		"products.dic": "Valeflow\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checker, err := NewChecker(
+		WithPath(dir),
+		UsingDictionary("base"),
+		UsingDictionary("medical"),
+		UsingDictionary("products"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(checker.checkers) != 1 {
+		t.Fatalf("Expected 1 merged dictionary, got %d", len(checker.checkers))
+	}
+
+	for _, word := range []string{"files", "work", "stents", "Valeflow"} {
+		if !checker.Spell(word) {
+			t.Errorf("Expected '%s' to be spelled correctly", word)
+		}
+	}
+
+	_, err = NewChecker(WithPath(dir), UsingDictionary("medical"))
+	if err == nil {
+		t.Error("Expected an error for a dictionary without an affix file to share")
+	}
+}
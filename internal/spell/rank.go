@@ -0,0 +1,235 @@
+package spell
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// qwerty is the layout used to weight substitutions: mistyping a key as one
+// of its neighbors is a much more likely error than an arbitrary
+// substitution.
+var qwerty = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// adjacentKeys maps each key to the keys surrounding it.
+var adjacentKeys = buildAdjacency(qwerty)
+
+// adjacentASCII is `adjacentKeys` as a table, since looking up the (nested)
+// map would otherwise dominate the cost of ranking suggestions.
+var adjacentASCII = buildAdjacencyTable(adjacentKeys)
+
+func buildAdjacencyTable(adjacent map[rune]map[rune]bool) *[utf8.RuneSelf][utf8.RuneSelf]bool {
+	var table [utf8.RuneSelf][utf8.RuneSelf]bool
+	for a, keys := range adjacent {
+		for b := range keys {
+			table[a][b] = true
+		}
+	}
+	return &table
+}
+
+func buildAdjacency(rows []string) map[rune]map[rune]bool {
+	adjacent := map[rune]map[rune]bool{}
+
+	link := func(a, b rune) {
+		if adjacent[a] == nil {
+			adjacent[a] = map[rune]bool{}
+		}
+		adjacent[a][b] = true
+	}
+
+	for r, row := range rows {
+		keys := []rune(row)
+		for c, key := range keys {
+			if c > 0 {
+				link(key, keys[c-1])
+			}
+			if c < len(keys)-1 {
+				link(key, keys[c+1])
+			}
+			// Each row is offset by half a key, so a key touches the keys at
+			// `c` and `c+1` in the row above and `c-1` and `c` in the row
+			// below.
+			if r > 0 {
+				above := []rune(rows[r-1])
+				for _, i := range []int{c, c + 1} {
+					if i < len(above) {
+						link(key, above[i])
+						link(above[i], key)
+					}
+				}
+			}
+		}
+	}
+
+	return adjacent
+}
+
+// substitutionCost returns the cost of replacing `a` with `b`.
+func substitutionCost(a, b rune) float64 {
+	switch {
+	case a == b:
+		return 0
+	case a < utf8.RuneSelf && b < utf8.RuneSelf:
+		if adjacentASCII[a][b] {
+			return 0.5
+		}
+		return 1
+	case adjacentKeys[a][b]:
+		return 0.5
+	default:
+		return 1
+	}
+}
+
+// keyboardDistance is an optimal string alignment (restricted
+// Damerau-Levenshtein) distance in which the most common typing errors --
+// hitting an adjacent key or swapping two letters -- are discounted.
+func keyboardDistance(a, b string) float64 {
+	d, _ := boundedDistance(a, b, math.Inf(1))
+	return d
+}
+
+// boundedDistance is `keyboardDistance`, except that it gives up -- returning
+// false -- as soon as the distance is known to exceed `limit`.
+func boundedDistance(a, b string, limit float64) (float64, bool) {
+	// NOTE: This is called for most of the words in a dictionary, so we avoid
+	// allocating for (typical) words of up to `shortWord` letters.
+	var sbuf, tbuf [shortWord]rune
+	var rbuf [3 * (shortWord + 1)]float64
+
+	s, t := lowerRunes(sbuf[:0], a), lowerRunes(tbuf[:0], b)
+
+	// We only need the last three rows of the table: `prev2` (for
+	// transpositions), `prev`, and `cur`.
+	rows := rbuf[:]
+	if n := 3 * (len(t) + 1); n > len(rows) {
+		rows = make([]float64, n)
+	}
+	prev2 := rows[0 : len(t)+1]
+	prev := rows[len(t)+1 : 2*(len(t)+1)]
+	cur := rows[2*(len(t)+1) : 3*(len(t)+1)]
+	for j := range prev {
+		prev[j] = float64(j)
+	}
+
+	// The cheapest way to finish from a cell costs at least the difference
+	// in the lengths of what's left of each word.
+	toFinish := func(i, j int) float64 {
+		return math.Abs(float64((len(s) - i) - (len(t) - j)))
+	}
+
+	prevMin := toFinish(0, 0)
+	for j := range prev {
+		prevMin = min(prevMin, prev[j]+toFinish(0, j))
+	}
+
+	for i := 1; i <= len(s); i++ {
+		cur[0] = float64(i)
+		curMin := cur[0] + toFinish(i, 0)
+
+		for j := 1; j <= len(t); j++ {
+			cur[j] = min(
+				prev[j]+1,
+				cur[j-1]+1,
+				prev[j-1]+substitutionCost(s[i-1], t[j-1]))
+
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+0.5)
+			}
+			curMin = min(curMin, cur[j]+toFinish(i, j))
+		}
+
+		// Every path to the end passes through one of the last two rows, so
+		// if neither can finish within the limit, nothing will.
+		if curMin > limit && prevMin > limit {
+			return 0, false
+		}
+
+		prev2, prev, cur = prev, cur, prev2
+		prevMin = curMin
+	}
+
+	d := prev[len(t)]
+	return d, d <= limit
+}
+
+// shortWord is the length of the longest word that `boundedDistance` handles
+// without allocating.
+const shortWord = 32
+
+// lowerRunes appends the lowercase runes of `s` to `dst`.
+func lowerRunes(dst []rune, s string) []rune {
+	for _, r := range s {
+		dst = append(dst, unicode.ToLower(r))
+	}
+	return dst
+}
+
+// frequencies maps words to their rank (0 being the most common) in a word
+// frequency list.
+type frequencies map[string]int
+
+// readFrequencies reads a word frequency list: one word per line, ordered
+// from most to least common. Lines starting with `#` are ignored.
+func readFrequencies(r io.Reader) (frequencies, error) {
+	freq := frequencies{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		word = strings.ToLower(word)
+		if _, found := freq[word]; !found {
+			freq[word] = len(freq)
+		}
+	}
+
+	return freq, scanner.Err()
+}
+
+// bonus returns a score in [0, 1) for how common `word` is.
+func (f frequencies) bonus(word string) float64 {
+	rank, found := f[strings.ToLower(word)]
+	if !found || len(f) == 0 {
+		return 0
+	}
+	return 1 - float64(rank)/float64(len(f))
+}
+
+// frequencyWeight controls how much a word's frequency can affect its score
+// relative to its similarity to the misspelled word.
+const frequencyWeight = 0.15
+
+// score ranks `option` as a replacement for `word`.
+func score(word, option string, freq frequencies) float64 {
+	sc, _ := scoreAbove(word, option, freq, math.Inf(-1))
+	return sc
+}
+
+// scoreAbove is `score`, except that it gives up -- returning false -- as
+// soon as the score is known to be below `floor`.
+func scoreAbove(word, option string, freq frequencies, floor float64) (float64, bool) {
+	longest := max(utf8.RuneCountInString(word), utf8.RuneCountInString(option))
+	if longest == 0 {
+		return 0, floor <= 0
+	}
+	bonus := frequencyWeight * freq.bonus(option)
+
+	// NOTE: Distances are multiples of 0.5, so the slack only guards
+	// against rounding.
+	limit := (1+bonus-floor)*float64(longest) + 1e-9
+
+	d, ok := boundedDistance(word, option, limit)
+	if !ok {
+		return 0, false
+	}
+
+	sc := 1 - d/float64(longest) + bonus
+	return sc, sc >= floor
+}
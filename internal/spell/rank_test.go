@@ -0,0 +1,62 @@
+package spell
+
+import (
+	"testing"
+)
+
+func TestKeyboardDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		dist float64
+	}{
+		{"the", "the", 0},
+		{"teh", "the", 0.5},
+		{"thr", "the", 0.5},
+		{"thx", "the", 1},
+		{"Hte", "the", 0.5},
+		{"there", "the", 2},
+	}
+
+	for _, c := range cases {
+		if d := keyboardDistance(c.a, c.b); d != c.dist {
+			t.Errorf("distance(%q, %q): expected %v, got %v", c.a, c.b, c.dist, d)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	checker, err := NewChecker(WithMaxSuggestions(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"teh":        "the",
+		"Teh":        "The",
+		"wrod":       "word",
+		"recieve":    "receive",
+		"langauge":   "language",
+		"definately": "definitely",
+	}
+
+	for word, expected := range cases {
+		suggestions := checker.Suggest(word)
+		if len(suggestions) == 0 || len(suggestions) > 3 {
+			t.Fatalf("%s: expected 1-3 suggestions, got %v", word, suggestions)
+		} else if suggestions[0] != expected {
+			t.Errorf("%s: expected '%s' first, got %v", word, expected, suggestions)
+		}
+	}
+}
+
+func TestBoundedDistance(t *testing.T) {
+	for _, pair := range [][2]string{{"teh", "the"}, {"kitten", "sitting"}, {"Langauge", "language"}} {
+		d := keyboardDistance(pair[0], pair[1])
+
+		if got, ok := boundedDistance(pair[0], pair[1], d); !ok || got != d {
+			t.Errorf("%v: expected %v within %v, got %v (%v)", pair, d, d, got, ok)
+		} else if _, ok = boundedDistance(pair[0], pair[1], d-0.5); ok {
+			t.Errorf("%v: expected more than %v", pair, d-0.5)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package vale
+
+import (
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// File is the result of linting a single file (or string).
+type File struct {
+	Path   string
+	Alerts []Alert // sorted by position
+}
+
+// HasErrors reports whether any of the file's alerts is an error.
+func (f *File) HasErrors() bool {
+	for _, a := range f.Alerts {
+		if a.Severity == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// Alert is a single problem found by a rule.
+type Alert struct {
+	Check       string // the rule that reported it (e.g., "Vale.Spelling")
+	Message     string
+	Description string
+	Link        string
+	Severity    string // "suggestion", "warning", or "error"
+	Match       string // the text that was matched
+	Line        int    // the (1-based) line of the match
+	Span        [2]int // the (1-based, inclusive) columns of the match
+	Action      Action
+	Suggestions []string // ranked replacements (e.g., for a misspelling), if any
+}
+
+// Action is a possible fix for an Alert -- e.g., `replace` with `Params`
+// holding the suggested replacements.
+type Action struct {
+	Name   string
+	Params []string
+}
+
+func newFile(f *core.File) *File {
+	file := &File{Path: f.Path, Alerts: []Alert{}}
+	for _, a := range f.SortedAlerts() {
+		file.Alerts = append(file.Alerts, newAlert(a))
+	}
+	return file
+}
+
+func newAlert(a core.Alert) Alert {
+	alert := Alert{
+		Check:       a.Check,
+		Message:     a.Message,
+		Description: a.Description,
+		Link:        a.Link,
+		Severity:    a.Severity,
+		Match:       a.Match,
+		Line:        a.Line,
+		Action:      Action{Name: a.Action.Name, Params: a.Action.Params},
+		Suggestions: a.Suggestions,
+	}
+	if len(a.Span) == 2 {
+		alert.Span = [2]int{a.Span[0], a.Span[1]}
+	}
+	return alert
+}
@@ -0,0 +1,24 @@
+/*
+Package vale is a stable API for embedding Vale in other Go programs, such as
+static site generators or bots, without running the CLI.
+
+A Config is loaded just as the CLI loads it -- from a `.vale.ini` file and
+its StylesPath -- and a Linter compiles its rules once, so it should be
+reused across calls:
+
+	cfg, err := vale.LoadConfig(vale.Options{ConfigPath: ".vale.ini"})
+	if err != nil {
+		return err
+	}
+
+	linter, err := vale.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	file, err := linter.LintString("This is is a test.", ".md")
+
+Everything else in this module lives under `internal/` and may change between
+releases; the types in this package won't.
+*/
+package vale
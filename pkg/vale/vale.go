@@ -0,0 +1,125 @@
+package vale
+
+import (
+	"context"
+	"strings"
+	gosync "sync"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// Options control how a Config is loaded; their zero value loads the same
+// configuration as running `vale` in the current directory.
+type Options struct {
+	// ConfigPath is the `.vale.ini` file to load. If empty, we search for
+	// one starting in the current directory.
+	ConfigPath string
+	// MinAlertLevel overrides the config's `MinAlertLevel` ("suggestion",
+	// "warning", or "error").
+	MinAlertLevel string
+	// Filter is an expression that selects the rules to run (see
+	// `--filter`).
+	Filter string
+	// NoGlobal skips the user's global configuration.
+	NoGlobal bool
+}
+
+// Config is a loaded Vale configuration.
+type Config struct {
+	cfg *core.Config
+}
+
+// LoadConfig loads the configuration described by `opts`.
+func LoadConfig(opts Options) (*Config, error) {
+	flags := core.CLIFlags{
+		Path:         opts.ConfigPath,
+		AlertLevel:   opts.MinAlertLevel,
+		Filter:       opts.Filter,
+		IgnoreGlobal: opts.NoGlobal,
+		InExt:        ".txt",
+		Glob:         "*",
+	}
+
+	cfg, err := core.ReadPipeline(&flags, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{cfg: cfg}, nil
+}
+
+// StylesPath returns the directory from which the config's styles are
+// loaded.
+func (c *Config) StylesPath() string {
+	return c.cfg.StylesPath()
+}
+
+// Linter lints text and files according to a Config.
+//
+// A Linter is safe for concurrent use, although its calls are serialized.
+type Linter struct {
+	linter *lint.Linter
+	mu     gosync.Mutex
+}
+
+// NewLinter compiles the rules of the given config.
+func NewLinter(cfg *Config) (*Linter, error) {
+	l, err := lint.NewLinter(cfg.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Linter{linter: l}, nil
+}
+
+// LintString lints `text` as if it were a file with the given extension
+// (e.g., ".md"); an empty `format` lints it as plain text.
+func (l *Linter) LintString(text, format string) (*File, error) {
+	return l.LintStringWithContext(context.Background(), text, format)
+}
+
+// LintStringWithContext is like LintString, but it gives up -- returning
+// `ctx.Err()` -- as soon as `ctx` is done.
+func (l *Linter) LintStringWithContext(ctx context.Context, text, format string) (*File, error) {
+	if format == "" {
+		format = ".txt"
+	} else if !strings.HasPrefix(format, ".") {
+		format = "." + format
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := l.linter.LintContentWithContext(ctx, "stdin"+format, text)
+	if err != nil {
+		return nil, err
+	}
+	l.linter.Manager.Suggest(f.Alerts)
+
+	return newFile(f), nil
+}
+
+// LintFiles lints the given files and directories, which are searched
+// recursively.
+func (l *Linter) LintFiles(paths ...string) ([]*File, error) {
+	return l.LintFilesWithContext(context.Background(), paths...)
+}
+
+// LintFilesWithContext is like LintFiles, but it gives up -- returning
+// `ctx.Err()` -- as soon as `ctx` is done.
+func (l *Linter) LintFilesWithContext(ctx context.Context, paths ...string) ([]*File, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	linted, err := l.linter.LintWithContext(ctx, paths, "*")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*File, 0, len(linted))
+	for _, f := range linted {
+		l.linter.Manager.Suggest(f.Alerts)
+		files = append(files, newFile(f))
+	}
+	return files, nil
+}
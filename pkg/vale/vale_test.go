@@ -0,0 +1,81 @@
+package vale
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestLinter(t *testing.T, dir string) *Linter {
+	t.Helper()
+
+	ini := filepath.Join(dir, ".vale.ini")
+	if err := os.WriteFile(ini, []byte("MinAlertLevel = suggestion\n\n[*]\nBasedOnStyles = Vale\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(Options{ConfigPath: ini, NoGlobal: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linter, err := NewLinter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return linter
+}
+
+func TestLintString(t *testing.T) {
+	linter := newTestLinter(t, t.TempDir())
+
+	f, err := linter.LintString("# Title\n\nThis is is a test.\n", "md")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(f.Alerts) != 1 {
+		t.Fatalf("Expected one alert, got %v", f.Alerts)
+	}
+
+	a := f.Alerts[0]
+	if a.Check != "Vale.Repetition" || a.Line != 3 || a.Span != [2]int{6, 10} {
+		t.Errorf("Unexpected alert: %+v", a)
+	} else if !f.HasErrors() {
+		t.Error("Expected the file to have errors")
+	}
+
+	f, err = linter.LintString("`is is` code.", ".md")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(f.Alerts) != 0 {
+		t.Errorf("Expected no alerts in code, got %v", f.Alerts)
+	}
+}
+
+func TestLintFiles(t *testing.T) {
+	dir := t.TempDir()
+	linter := newTestLinter(t, dir)
+
+	for name, content := range map[string]string{
+		"a.md":  "This is is a test.\n",
+		"b.txt": "This is fine.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := linter.LintFiles(filepath.Join(dir, "a.md"), filepath.Join(dir, "b.txt"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 2 {
+		t.Fatalf("Expected two files, got %d", len(files))
+	}
+
+	alerts := 0
+	for _, f := range files {
+		alerts += len(f.Alerts)
+	}
+	if alerts != 1 {
+		t.Errorf("Expected one alert, got %d", alerts)
+	}
+}